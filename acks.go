@@ -0,0 +1,360 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// maxAckReceipts is the number of receipts above which finished ones are
+// dropped.
+const maxAckReceipts = 1000
+
+// AckStatus is the state of an alert sent with [RequireAck].
+type AckStatus string
+
+const (
+	// AckPending means the alert is waiting to be acknowledged.
+	AckPending AckStatus = "pending"
+
+	// AckAcked means someone acknowledged the alert in time.
+	AckAcked AckStatus = "acked"
+
+	// AckResolved means the alert's issue was resolved before anyone
+	// acknowledged it or the deadline passed, so it was not escalated.
+	AckResolved AckStatus = "resolved"
+
+	// AckEscalated means nobody acknowledged the alert in time, and it was
+	// passed to the escalation set with [WithAckEscalation].
+	AckEscalated AckStatus = "escalated"
+)
+
+// AckReceipt records the acknowledgment of an alert sent with
+// [RequireAck].
+type AckReceipt struct {
+	Alert         *types.Alert
+	CorrelationID string
+
+	// IssueID is the issue the alert opened, once the client has seen its
+	// [EventAlertCreated] event.
+	IssueID string
+
+	Status AckStatus
+	SentAt time.Time
+
+	// Deadline is zero for alerts tracked only for [WithRepeat].
+	Deadline time.Time
+
+	// AckedBy and AckedAt are set when the alert is acknowledged, which
+	// for an escalated alert is after the escalation. AckedAt is the time
+	// the client saw the acknowledgment if the event has none.
+	AckedBy string
+	AckedAt time.Time
+
+	// EscalatedAt is set when Status is [AckEscalated].
+	EscalatedAt time.Time
+}
+
+// AckEscalation is called with the receipt of an alert that nobody
+// acknowledged within the time given to [RequireAck], for example to page
+// a secondary on-call or send the alert again to another channel.
+type AckEscalation func(ctx context.Context, receipt AckReceipt)
+
+// AckEvent is an issue lifecycle event for [Client.ObserveAckEvent],
+// usually taken from a callback registered with [Client.RegisterCallback].
+// The webhook package's TrackAcks does this for every callback it
+// receives.
+type AckEvent struct {
+	// Type is [EventAlertCreated], [EventAlertAcked] or
+	// [EventAlertResolved]. Other events are ignored.
+	Type CallbackEvent
+
+	IssueID string
+
+	// CorrelationID is the CorrelationID of the alert that opened the
+	// issue, for [EventAlertCreated] events.
+	CorrelationID string
+
+	// By is who acknowledged the issue, for [EventAlertAcked] events.
+	By string
+
+	At time.Time
+}
+
+// RequireAck makes the client track the acknowledgment of every alert in
+// the batch, and pass the receipt of any alert that nobody acknowledged
+// within the given time to the escalation set with [WithAckEscalation].
+// Acknowledgments are matched to alerts by the issues they open, so each
+// alert must have a CorrelationID, and the client must be told about the
+// issue's events with [Client.ObserveAckEvent]. An alert whose issue is
+// already tracked keeps its first deadline. Alerts that are silenced or
+// held back by quiet hours or digests are not tracked. A time of zero or
+// less is silently ignored.
+func RequireAck(within time.Duration) SendOption {
+	return func(so *sendOptions) {
+		if within > 0 {
+			so.ackWithin = within
+		}
+	}
+}
+
+// ObserveAckEvent updates the receipts of alerts sent with [RequireAck]
+// with an issue lifecycle event.
+func (c *Client) ObserveAckEvent(event AckEvent) {
+	if c != nil && c.acks != nil {
+		c.acks.observe(event)
+	}
+}
+
+// AckReceipt returns the receipt of the last alert with correlationID
+// sent with [RequireAck] or repeated with [WithRepeat], and false if there
+// is none. Receipts of alerts
+// that are no longer pending are kept until the client has tracked 1000
+// alerts.
+func (c *Client) AckReceipt(correlationID string) (AckReceipt, bool) {
+	if c == nil || c.acks == nil {
+		return AckReceipt{}, false
+	}
+
+	return c.acks.receipt(correlationID)
+}
+
+// checkAckRequest returns an error if alerts cannot be sent with
+// [RequireAck].
+func (c *Client) checkAckRequest(alerts []*types.Alert) error {
+	if c.options.ackEscalation == nil {
+		return errors.New("RequireAck needs an escalation set with WithAckEscalation")
+	}
+
+	for i, alert := range alerts {
+		if strings.TrimSpace(alert.CorrelationID) == "" {
+			return fmt.Errorf("alert at index %d has no correlation ID, which RequireAck needs to match acknowledgments", i)
+		}
+	}
+
+	return nil
+}
+
+// ackTracker holds the receipts of alerts sent with [RequireAck], and
+// escalates those that are not acknowledged in time.
+type ackTracker struct {
+	escalate AckEscalation
+	ctx      context.Context //nolint:containedctx // escalations run in the background, until Close
+	now      func() time.Time
+
+	mu       sync.Mutex
+	receipts map[string]*ackEntry
+
+	// issues maps the issues seen in [EventAlertCreated] events to their
+	// correlation IDs, and opened maps correlation IDs to their latest
+	// issue. The event can arrive before the send that opened the issue
+	// returns, so they are kept for alerts that are not tracked yet.
+	issues map[string]string
+	opened map[string]string
+	closed bool
+}
+
+type ackEntry struct {
+	receipt AckReceipt
+	timer   *time.Timer
+}
+
+func newAckTracker(ctx context.Context, escalate AckEscalation) *ackTracker {
+	return &ackTracker{
+		escalate: escalate,
+		ctx:      ctx,
+		now:      time.Now,
+		receipts: make(map[string]*ackEntry),
+		issues:   make(map[string]string),
+		opened:   make(map[string]string),
+	}
+}
+
+// track starts the deadlines of alerts, which have been sent. With a
+// within of 0, alerts are tracked without a deadline, for [WithRepeat].
+func (t *ackTracker) track(alerts []*types.Alert, within time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return
+	}
+
+	now := t.now()
+
+	for _, alert := range alerts {
+		correlationID := strings.TrimSpace(alert.CorrelationID)
+
+		if previous, ok := t.receipts[correlationID]; ok && (previous.receipt.Status == AckPending || previous.receipt.Status == AckAcked) {
+			// An alert tracked for [WithRepeat] gets the deadline of its
+			// first send with [RequireAck].
+			if within > 0 && previous.timer == nil && previous.receipt.Status == AckPending {
+				previous.receipt.Deadline = now.Add(within)
+				previous.timer = time.AfterFunc(within, func() { t.expire(previous) })
+			}
+
+			continue
+		}
+
+		if len(t.receipts) >= maxAckReceipts {
+			t.sweep()
+		}
+
+		entry := &ackEntry{receipt: AckReceipt{
+			Alert:         alert,
+			CorrelationID: correlationID,
+			IssueID:       t.opened[correlationID],
+			Status:        AckPending,
+			SentAt:        now,
+		}}
+
+		if within > 0 {
+			entry.receipt.Deadline = now.Add(within)
+			entry.timer = time.AfterFunc(within, func() { t.expire(entry) })
+		}
+
+		t.receipts[correlationID] = entry
+	}
+}
+
+// sweep drops the receipts that are no longer pending, and the issues of
+// alerts that are not pending. The caller must hold t.mu.
+func (t *ackTracker) sweep() {
+	maps.DeleteFunc(t.receipts, func(_ string, entry *ackEntry) bool {
+		return entry.receipt.Status != AckPending
+	})
+
+	maps.DeleteFunc(t.issues, func(_, correlationID string) bool {
+		_, ok := t.receipts[correlationID]
+		return !ok
+	})
+
+	maps.DeleteFunc(t.opened, func(correlationID, _ string) bool {
+		_, ok := t.receipts[correlationID]
+		return !ok
+	})
+}
+
+// expire escalates entry if it is still pending.
+func (t *ackTracker) expire(entry *ackEntry) {
+	t.mu.Lock()
+
+	if t.closed || entry.receipt.Status != AckPending {
+		t.mu.Unlock()
+		return
+	}
+
+	entry.receipt.Status = AckEscalated
+	entry.receipt.EscalatedAt = t.now()
+	receipt := entry.receipt
+
+	t.mu.Unlock()
+
+	if t.escalate == nil {
+		return
+	}
+
+	t.escalate(t.ctx, receipt)
+}
+
+// observe applies an issue lifecycle event to the receipts.
+func (t *ackTracker) observe(event AckEvent) {
+	if event.IssueID == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if event.Type == EventAlertCreated {
+		if len(t.issues) >= maxAckReceipts {
+			t.sweep()
+		}
+
+		correlationID := strings.TrimSpace(event.CorrelationID)
+		t.issues[event.IssueID] = correlationID
+		t.opened[correlationID] = event.IssueID
+
+		if entry, ok := t.receipts[correlationID]; ok && entry.receipt.Status == AckPending {
+			entry.receipt.IssueID = event.IssueID
+		}
+
+		return
+	}
+
+	correlationID, ok := t.issues[event.IssueID]
+	if !ok {
+		return
+	}
+
+	entry, ok := t.receipts[correlationID]
+	if !ok {
+		return
+	}
+
+	// A late acknowledgment is recorded, but does not undo the escalation.
+	if entry.receipt.Status == AckEscalated && event.Type == EventAlertAcked && entry.receipt.AckedAt.IsZero() {
+		entry.receipt.AckedBy = event.By
+		entry.receipt.AckedAt = cmp.Or(event.At, t.now())
+
+		return
+	}
+
+	if entry.receipt.Status != AckPending {
+		return
+	}
+
+	switch event.Type {
+	case EventAlertAcked:
+		entry.receipt.Status = AckAcked
+		entry.receipt.AckedBy = event.By
+		entry.receipt.AckedAt = cmp.Or(event.At, t.now())
+	case EventAlertResolved:
+		entry.receipt.Status = AckResolved
+	case EventAlertCreated, EventSilenceExpired:
+		return
+	default:
+		return
+	}
+
+	entry.receipt.IssueID = event.IssueID
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+}
+
+// receipt returns a copy of the receipt for correlationID.
+func (t *ackTracker) receipt(correlationID string) (AckReceipt, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.receipts[strings.TrimSpace(correlationID)]
+	if !ok {
+		return AckReceipt{}, false
+	}
+
+	return entry.receipt, true
+}
+
+// close stops all deadlines, so no escalation runs after the client is
+// closed.
+func (t *ackTracker) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+
+	for _, entry := range t.receipts {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+	}
+}
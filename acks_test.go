@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newAckClient returns a client connected to an alert recorder, whose
+// escalations are sent to the returned channel.
+func newAckClient(t *testing.T) (*Client, chan AckReceipt) {
+	t.Helper()
+
+	escalations := make(chan AckReceipt, 10)
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithAckEscalation(func(_ context.Context, receipt AckReceipt) {
+		escalations <- receipt
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client, escalations
+}
+
+func ackAlert(correlationID string) *types.Alert {
+	alert := types.NewAlert(types.AlertPanic)
+	alert.Header = "Checkout down"
+	alert.CorrelationID = correlationID
+
+	return alert
+}
+
+func TestRequireAck_Escalates(t *testing.T) {
+	t.Parallel()
+
+	client, escalations := newAckClient(t)
+
+	if _, err := client.SendWithOptions(context.Background(), []*types.Alert{ackAlert("checkout")}, RequireAck(20*time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.ObserveAckEvent(AckEvent{Type: EventAlertCreated, IssueID: "I1", CorrelationID: "checkout"})
+
+	if receipt, ok := client.AckReceipt("checkout"); !ok || receipt.Status != AckPending || receipt.IssueID != "I1" {
+		t.Errorf("expected a pending receipt for issue I1, got %+v", receipt)
+	}
+
+	select {
+	case receipt := <-escalations:
+		if receipt.CorrelationID != "checkout" || receipt.Status != AckEscalated || receipt.Alert.Header != "Checkout down" {
+			t.Errorf("unexpected escalation %+v", receipt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the alert to be escalated")
+	}
+
+	client.ObserveAckEvent(AckEvent{Type: EventAlertAcked, IssueID: "I1", By: "alice"})
+
+	if receipt, _ := client.AckReceipt("checkout"); receipt.Status != AckEscalated || receipt.AckedBy != "alice" || receipt.EscalatedAt.IsZero() {
+		t.Errorf("expected a late acknowledgment to be recorded, got %+v", receipt)
+	}
+}
+
+func TestRequireAck_Acked(t *testing.T) {
+	t.Parallel()
+
+	client, escalations := newAckClient(t)
+	ackedAt := time.Now()
+
+	// The created event can arrive before the send returns.
+	client.ObserveAckEvent(AckEvent{Type: EventAlertCreated, IssueID: "I1", CorrelationID: "checkout"})
+	client.ObserveAckEvent(AckEvent{Type: EventAlertCreated, IssueID: "I2", CorrelationID: "search"})
+
+	alerts := []*types.Alert{ackAlert("checkout"), ackAlert("search")}
+	if _, err := client.SendWithOptions(context.Background(), alerts, RequireAck(50*time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.ObserveAckEvent(AckEvent{Type: EventAlertAcked, IssueID: "I1", By: "alice", At: ackedAt})
+	client.ObserveAckEvent(AckEvent{Type: EventAlertResolved, IssueID: "I2"})
+	client.ObserveAckEvent(AckEvent{Type: EventAlertAcked, IssueID: "unknown"})
+
+	// A repeat of a tracked alert keeps its receipt.
+	if _, err := client.SendWithOptions(context.Background(), alerts[:1], RequireAck(time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case receipt := <-escalations:
+		t.Fatalf("unexpected escalation %+v", receipt)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if receipt, _ := client.AckReceipt("checkout"); receipt.Status != AckAcked || receipt.AckedBy != "alice" || !receipt.AckedAt.Equal(ackedAt) {
+		t.Errorf("expected an acknowledged receipt, got %+v", receipt)
+	}
+
+	if receipt, _ := client.AckReceipt("search"); receipt.Status != AckResolved || receipt.IssueID != "I2" {
+		t.Errorf("expected a resolved receipt, got %+v", receipt)
+	}
+}
+
+func TestRequireAck_Errors(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newAckClient(t)
+
+	if _, err := client.SendWithOptions(context.Background(), []*types.Alert{ackAlert("")}, RequireAck(time.Minute)); err == nil {
+		t.Error("expected an error for an alert without a correlation ID")
+	}
+
+	plain := connectTo(t, newAlertRecorder(t).URL)
+	if _, err := plain.SendWithOptions(context.Background(), []*types.Alert{ackAlert("checkout")}, RequireAck(time.Minute)); err == nil {
+		t.Error("expected an error without WithAckEscalation")
+	}
+
+	if _, err := plain.SendWithOptions(context.Background(), []*types.Alert{ackAlert("checkout")}, RequireAck(0)); err != nil {
+		t.Errorf("expected a zero time to be ignored, got %v", err)
+	}
+
+	if _, ok := plain.AckReceipt("checkout"); ok {
+		t.Error("expected no receipt")
+	}
+
+	if client := New("http://localhost", WithAckEscalation(nil)); client.options.ackEscalation != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil escalation to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+}
+
+func TestRequireAck_Close(t *testing.T) {
+	t.Parallel()
+
+	client, escalations := newAckClient(t)
+
+	if _, err := client.SendWithOptions(context.Background(), []*types.Alert{ackAlert("checkout")}, RequireAck(20*time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.Close()
+
+	select {
+	case receipt := <-escalations:
+		t.Fatalf("unexpected escalation after Close: %+v", receipt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAckTracker_Sweep(t *testing.T) {
+	t.Parallel()
+
+	tracker := newAckTracker(context.Background(), func(context.Context, AckReceipt) {})
+	t.Cleanup(tracker.close)
+
+	for i := range maxAckReceipts {
+		alert := ackAlert(strconv.Itoa(i))
+		tracker.track([]*types.Alert{alert}, time.Hour)
+		tracker.receipts[alert.CorrelationID].receipt.Status = AckAcked
+	}
+
+	tracker.track([]*types.Alert{ackAlert("checkout")}, time.Hour)
+
+	if len(tracker.receipts) != 1 {
+		t.Errorf("expected finished receipts to be dropped, got %d", len(tracker.receipts))
+	}
+}
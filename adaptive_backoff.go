@@ -0,0 +1,138 @@
+package client
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// adaptiveWindow is the number of recent request attempts used to
+	// estimate the failure rate.
+	adaptiveWindow = 20
+
+	// adaptiveFailureThreshold is the failure rate at or above which an
+	// overload response widens the backoff.
+	adaptiveFailureThreshold = 0.5
+
+	// adaptiveIncreaseFactor is the multiplicative increase applied to the
+	// backoff multiplier on an overload response.
+	adaptiveIncreaseFactor = 2.0
+
+	// adaptiveDecreaseStep is the additive decrease applied to the backoff
+	// multiplier on each successful attempt.
+	adaptiveDecreaseStep = 0.25
+
+	maxAdaptiveMultiplier = 16.0
+)
+
+// adaptiveBackoff scales retry wait times AIMD-style: the multiplier
+// doubles on every overload response while the recent failure rate is high,
+// and shrinks by a fixed step on every success until it is back to 1.
+type adaptiveBackoff struct {
+	maxMultiplier float64
+
+	mu         sync.Mutex
+	multiplier float64
+	outcomes   [adaptiveWindow]bool // true means the attempt failed
+	count      int
+	next       int
+}
+
+func newAdaptiveBackoff(maxMultiplier float64) *adaptiveBackoff {
+	return &adaptiveBackoff{maxMultiplier: maxMultiplier, multiplier: 1}
+}
+
+// record registers the outcome of one request attempt.
+func (a *adaptiveBackoff) record(overloaded bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.outcomes[a.next] = overloaded
+	a.next = (a.next + 1) % adaptiveWindow
+
+	if a.count < adaptiveWindow {
+		a.count++
+	}
+
+	if !overloaded {
+		a.multiplier = math.Max(1, a.multiplier-adaptiveDecreaseStep)
+		return
+	}
+
+	if a.failureRateLocked() >= adaptiveFailureThreshold {
+		a.multiplier = math.Min(a.maxMultiplier, a.multiplier*adaptiveIncreaseFactor)
+	}
+}
+
+// state returns the current multiplier and failure rate.
+func (a *adaptiveBackoff) state() (float64, float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.multiplier, a.failureRateLocked()
+}
+
+func (a *adaptiveBackoff) failureRateLocked() float64 {
+	if a.count == 0 {
+		return 0
+	}
+
+	failures := 0
+	for i := range a.count {
+		if a.outcomes[i] {
+			failures++
+		}
+	}
+
+	return float64(failures) / float64(a.count)
+}
+
+// retryAfter returns a resty retry-after function that honours Retry-After
+// headers and otherwise applies capped exponential backoff with jitter
+// between minWait and maxWait, scaled by the current multiplier.
+func (a *adaptiveBackoff) retryAfter(minWait, maxWait time.Duration) resty.RetryAfterFunc {
+	return func(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+		if wait, err := parseRetryAfterHeader(client, resp); err != nil || wait != 0 {
+			return wait, err
+		}
+
+		multiplier, _ := a.state()
+
+		attempt := max(resp.Request.Attempt-1, 0)
+		capped := math.Min(float64(maxWait), float64(minWait)*math.Exp2(float64(attempt)))
+		wait := time.Duration(capped/2 + rand.Float64()*capped/2) //nolint:gosec // jitter does not need a secure source
+
+		return time.Duration(float64(max(wait, minWait)) * multiplier), nil
+	}
+}
+
+// transport wraps next so that the outcome of every attempt is recorded.
+func (a *adaptiveBackoff) transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+
+		switch {
+		case err != nil:
+			// Cancellation says nothing about the server's health.
+			if req.Context().Err() == nil {
+				a.record(true)
+			}
+		default:
+			a.record(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError)
+		}
+
+		return resp, err
+	})
+}
+
+// roundTripperFunc adapts a function to [http.RoundTripper].
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
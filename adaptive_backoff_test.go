@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
+)
+
+func TestAdaptiveBackoff_AIMD(t *testing.T) {
+	t.Parallel()
+
+	a := newAdaptiveBackoff(8)
+
+	// A single failure in an otherwise empty window is a 100% failure rate.
+	a.record(true)
+
+	if m, rate := a.state(); m != 2 || rate != 1 {
+		t.Fatalf("expected multiplier=2 rate=1, got multiplier=%v rate=%v", m, rate)
+	}
+
+	for range 5 {
+		a.record(true)
+	}
+
+	if m, _ := a.state(); m != 8 {
+		t.Errorf("expected multiplier to be capped at 8, got %v", m)
+	}
+
+	a.record(false)
+
+	if m, _ := a.state(); m != 7.75 {
+		t.Errorf("expected additive decrease to 7.75, got %v", m)
+	}
+
+	for range 40 {
+		a.record(false)
+	}
+
+	if m, rate := a.state(); m != 1 || rate != 0 {
+		t.Errorf("expected multiplier=1 rate=0 after recovery, got multiplier=%v rate=%v", m, rate)
+	}
+
+	// Isolated failures below the threshold do not widen the backoff.
+	a.record(true)
+
+	if m, _ := a.state(); m != 1 {
+		t.Errorf("expected isolated failure not to widen backoff, got multiplier=%v", m)
+	}
+}
+
+func TestAdaptiveBackoff_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	a := newAdaptiveBackoff(4)
+	retryAfter := a.retryAfter(100*time.Millisecond, time.Second)
+
+	resp := &resty.Response{Request: &resty.Request{Attempt: 1}}
+
+	wait, err := retryAfter(nil, resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if wait < 100*time.Millisecond || wait > 200*time.Millisecond {
+		t.Errorf("expected unscaled wait between 100ms and 200ms, got %v", wait)
+	}
+
+	for range 3 {
+		a.record(true)
+	}
+
+	wait, _ = retryAfter(nil, &resty.Response{Request: &resty.Request{Attempt: 10}})
+	if wait < 2*time.Second || wait > 4*time.Second {
+		t.Errorf("expected capped wait scaled by 4 to be between 2s and 4s, got %v", wait)
+	}
+
+	withHeader := &resty.Response{
+		Request:     &resty.Request{Attempt: 1},
+		RawResponse: &http.Response{Header: http.Header{"Retry-After": []string{"3"}}},
+	}
+
+	if wait, _ := retryAfter(nil, withHeader); wait != 3*time.Second {
+		t.Errorf("expected Retry-After to be honoured as-is, got %v", wait)
+	}
+}
+
+func TestAdaptiveBackoff_Stats(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAdaptiveBackoff(4), WithRetryCount(0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if stats := client.Stats().AdaptiveBackoff; !stats.Enabled || stats.Multiplier != 1 {
+		t.Fatalf("expected enabled backoff with multiplier 1, got %+v", stats)
+	}
+
+	failing.Store(true)
+
+	for range 3 {
+		_ = client.Send(context.Background(), &types.Alert{Header: "test"})
+	}
+
+	stats := client.Stats().AdaptiveBackoff
+	if stats.Multiplier != 4 {
+		t.Errorf("expected multiplier=4 after sustained failures, got %v", stats.Multiplier)
+	}
+
+	if stats.FailureRate != 0.75 {
+		t.Errorf("expected failure rate 0.75 (3 of 4 attempts), got %v", stats.FailureRate)
+	}
+
+	failing.Store(false)
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m := client.Stats().AdaptiveBackoff.Multiplier; m != 3.75 {
+		t.Errorf("expected multiplier=3.75 after a success, got %v", m)
+	}
+
+	if disabled := New(server.URL).Stats().AdaptiveBackoff; disabled.Enabled {
+		t.Error("expected adaptive backoff to be disabled by default")
+	}
+}
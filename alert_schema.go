@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/slackmgr/types"
+)
+
+// AlertSchemaVersion identifies the wire format used when posting alerts.
+// See [WithAlertSchema].
+type AlertSchemaVersion int
+
+const (
+	// AlertSchemaV1 is the original wire format: {"alerts": [...]}.
+	AlertSchemaV1 AlertSchemaVersion = 1
+
+	// AlertSchemaV2 wraps the alerts in a versioned envelope,
+	// {"schemaVersion": 2, "alerts": [...]}, drops deprecated fields and
+	// sends canonical severities only.
+	AlertSchemaV2 AlertSchemaVersion = 2
+)
+
+type alertsListV2 struct {
+	SchemaVersion AlertSchemaVersion `json:"schemaVersion"`
+	Alerts        []*alertV2         `json:"alerts"`
+}
+
+// alertV2 is the v2 representation of an alert. The shadowing fields are
+// always nil, removing deprecated v1 fields from the encoded output.
+type alertV2 struct {
+	*types.Alert
+
+	FailOnRateLimitError *bool `json:"failOnRateLimitError,omitempty"`
+}
+
+// encodeAlerts marshals alerts using the given schema version.
+func encodeAlerts(alerts []*types.Alert, version AlertSchemaVersion) ([]byte, error) {
+	switch version {
+	case AlertSchemaV1:
+		return json.Marshal(&alertsList{Alerts: alerts})
+	case AlertSchemaV2:
+		list := &alertsListV2{
+			SchemaVersion: AlertSchemaV2,
+			Alerts:        make([]*alertV2, len(alerts)),
+		}
+
+		for i, alert := range alerts {
+			list.Alerts[i] = upconvertAlert(alert)
+		}
+
+		return json.Marshal(list)
+	default:
+		return nil, fmt.Errorf("unsupported alert schema version %d", version)
+	}
+}
+
+// upconvertAlert returns the v2 representation of a v1 alert, without
+// modifying the original.
+func upconvertAlert(alert *types.Alert) *alertV2 {
+	upgraded := *alert
+
+	// v1 accepted "critical" as an alias of "error"; v2 only accepts
+	// canonical severities.
+	if severity := normalizeSeverity(upgraded.Severity); severity == severityCritical {
+		upgraded.Severity = types.AlertError
+	}
+
+	if upgraded.Metadata == nil {
+		upgraded.Metadata = map[string]any{}
+	}
+
+	return &alertV2{Alert: &upgraded}
+}
+
+// UpgradeAlert converts a legacy alert value, such as a
+// github.com/peteraglen/slack-manager-common Alert, to a [types.Alert]. The
+// value is converted through its JSON representation, so any struct or map
+// with the v1 JSON field names is accepted. Deprecated fields are cleared
+// and "critical" severities are rewritten to "error".
+func UpgradeAlert(legacy any) (*types.Alert, error) {
+	if legacy == nil {
+		return nil, errors.New("legacy alert is nil")
+	}
+
+	data, err := json.Marshal(legacy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal legacy alert: %w", err)
+	}
+
+	alert := &types.Alert{}
+	if err := json.Unmarshal(data, alert); err != nil {
+		return nil, fmt.Errorf("failed to convert legacy alert: %w", err)
+	}
+
+	alert.FailOnRateLimitError = false //nolint:staticcheck // clearing the deprecated field is the point
+
+	return upconvertAlert(alert).Alert, nil
+}
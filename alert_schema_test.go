@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestEncodeAlerts_V1(t *testing.T) {
+	t.Parallel()
+
+	body, err := encodeAlerts([]*types.Alert{{Header: "x", Severity: "critical"}}, AlertSchemaV1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := string(body)
+	if strings.Contains(s, "schemaVersion") {
+		t.Errorf("expected no schema version in v1 payload, got %s", s)
+	}
+
+	if !strings.Contains(s, `"failOnRateLimitError":false`) || !strings.Contains(s, `"severity":"critical"`) {
+		t.Errorf("expected v1 payload to be unchanged, got %s", s)
+	}
+}
+
+func TestEncodeAlerts_V2(t *testing.T) {
+	t.Parallel()
+
+	alert := &types.Alert{Header: "x", Severity: " Critical "}
+
+	body, err := encodeAlerts([]*types.Alert{alert}, AlertSchemaV2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		SchemaVersion int              `json:"schemaVersion"`
+		Alerts        []map[string]any `json:"alerts"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded.SchemaVersion != 2 || len(decoded.Alerts) != 1 {
+		t.Fatalf("expected schemaVersion=2 with one alert, got %+v", decoded)
+	}
+
+	encoded := decoded.Alerts[0]
+
+	if _, ok := encoded["failOnRateLimitError"]; ok {
+		t.Error("expected deprecated field to be dropped")
+	}
+
+	if encoded["severity"] != "error" || encoded["header"] != "x" {
+		t.Errorf("unexpected v2 alert: %v", encoded)
+	}
+
+	if alert.Severity != " Critical " {
+		t.Error("expected original alert not to be modified")
+	}
+}
+
+func TestEncodeAlerts_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	if _, err := encodeAlerts([]*types.Alert{{}}, 9); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestUpgradeAlert(t *testing.T) {
+	t.Parallel()
+
+	legacy := struct {
+		Header               string `json:"header"`
+		Severity             string `json:"severity"`
+		SlackChannelID       string `json:"slackChannelId"`
+		FailOnRateLimitError bool   `json:"failOnRateLimitError"`
+	}{"Disk full", "critical", "C123", true}
+
+	alert, err := UpgradeAlert(legacy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if alert.Header != "Disk full" || alert.SlackChannelID != "C123" {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+
+	if alert.Severity != types.AlertError {
+		t.Errorf("expected severity error, got %s", alert.Severity)
+	}
+
+	if alert.FailOnRateLimitError {
+		t.Error("expected deprecated field to be cleared")
+	}
+
+	if _, err := UpgradeAlert(nil); err == nil {
+		t.Error("expected error for nil legacy alert")
+	}
+
+	if _, err := UpgradeAlert(make(chan int)); err == nil {
+		t.Error("expected error for unmarshalable value")
+	}
+}
+
+func TestSend_AlertSchemaV2(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			body, _ = io.ReadAll(r.Body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAlertSchema(AlertSchemaV2))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(body), `"schemaVersion":2`) {
+		t.Errorf("expected v2 payload, got %s", body)
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const statsEndpoint = "stats"
+
+// StatsGroupBy is the dimension that [Client.AlertStats] breaks counts
+// down by.
+type StatsGroupBy string
+
+const (
+	// StatsByTeam groups statistics by the team that owns the route.
+	StatsByTeam StatsGroupBy = "team"
+
+	// StatsBySeverity groups statistics by alert severity.
+	StatsBySeverity StatsGroupBy = "severity"
+
+	// StatsByChannel groups statistics by Slack channel.
+	StatsByChannel StatsGroupBy = "channel"
+
+	// StatsByRouteKey groups statistics by route key.
+	StatsByRouteKey StatsGroupBy = "routeKey"
+)
+
+// AlertStatsReport holds alert counts and response times for a period, as
+// returned by [Client.AlertStats].
+type AlertStatsReport struct {
+	Period  UsagePeriod       `json:"period"`
+	GroupBy StatsGroupBy      `json:"groupBy"`
+	Groups  []AlertStatsGroup `json:"groups"`
+	Total   AlertStatsCounts  `json:"total"`
+}
+
+// AlertStatsGroup holds the statistics of one team, severity, channel or
+// route key.
+type AlertStatsGroup struct {
+	AlertStatsCounts
+
+	// Key is the team, severity, channel or route key.
+	Key string `json:"key"`
+}
+
+// AlertStatsCounts are the statistics reported per group and in total.
+type AlertStatsCounts struct {
+	// Alerts is the number of alerts received.
+	Alerts int64 `json:"alerts"`
+
+	// Issues is the number of issues opened.
+	Issues int64 `json:"issues"`
+
+	// Acked and Resolved are the number of those issues that were
+	// acknowledged and resolved.
+	Acked    int64 `json:"acked"`
+	Resolved int64 `json:"resolved"`
+
+	// MTTASeconds and MTTRSeconds are the mean times from opening an issue
+	// to acknowledging and resolving it, over the issues that were. See
+	// [AlertStatsCounts.MTTA] and [AlertStatsCounts.MTTR].
+	MTTASeconds float64 `json:"mttaSeconds"`
+	MTTRSeconds float64 `json:"mttrSeconds"`
+}
+
+// MTTA returns the mean time to acknowledge.
+func (c AlertStatsCounts) MTTA() time.Duration {
+	return secondsDuration(c.MTTASeconds)
+}
+
+// MTTR returns the mean time to resolve.
+func (c AlertStatsCounts) MTTR() time.Duration {
+	return secondsDuration(c.MTTRSeconds)
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// AlertStats returns alert and issue counts with MTTA and MTTR for period,
+// broken down by groupBy, from GET stats. It gives reporting dashboards
+// the aggregates without exporting every alert. [Client.Connect] must be
+// called first.
+func (c *Client) AlertStats(ctx context.Context, groupBy StatsGroupBy, period UsagePeriod) (*AlertStatsReport, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	switch groupBy {
+	case StatsByTeam, StatsBySeverity, StatsByChannel, StatsByRouteKey:
+	default:
+		return nil, fmt.Errorf("stats must be grouped by %s, %s, %s or %s, got %q", StatsByTeam, StatsBySeverity, StatsByChannel, StatsByRouteKey, groupBy)
+	}
+
+	if err := period.validate("stats"); err != nil {
+		return nil, err
+	}
+
+	query := period.query()
+	query.Set("groupBy", string(groupBy))
+
+	report := &AlertStatsReport{}
+	if err := c.doJSON(ctx, http.MethodGet, statsEndpoint, query, nil, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAlertStats(t *testing.T) {
+	t.Parallel()
+
+	queries := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		queries <- r.URL.RawQuery
+		_, _ = w.Write([]byte(`{
+			"period": {"start": "2026-09-01T00:00:00Z", "end": "2026-10-01T00:00:00Z"},
+			"groupBy": "team",
+			"groups": [{"key": "payments", "alerts": 900, "issues": 40, "acked": 38, "resolved": 40, "mttaSeconds": 90.5, "mttrSeconds": 1800}],
+			"total": {"alerts": 900, "issues": 40, "acked": 38, "resolved": 40, "mttaSeconds": 90.5, "mttrSeconds": 1800}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := connectTo(t, server.URL)
+
+	report, err := client.AlertStats(context.Background(), StatsByTeam, MonthPeriod(2026, time.September))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q := <-queries; q != "end=2026-10-01T00%3A00%3A00Z&groupBy=team&start=2026-09-01T00%3A00%3A00Z" {
+		t.Errorf("unexpected query %q", q)
+	}
+
+	if len(report.Groups) != 1 || report.Groups[0].Key != "payments" || report.Groups[0].Acked != 38 || report.GroupBy != StatsByTeam {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	if mtta, mttr := report.Total.MTTA(), report.Total.MTTR(); mtta != 90500*time.Millisecond || mttr != 30*time.Minute {
+		t.Errorf("unexpected MTTA %v and MTTR %v", mtta, mttr)
+	}
+}
+
+func TestAlertStats_Invalid(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stats" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := connectTo(t, server.URL)
+	period := MonthPeriod(2026, time.September)
+
+	if _, err := client.AlertStats(context.Background(), "service", period); err == nil {
+		t.Error("expected an error for an unknown grouping")
+	}
+
+	if _, err := client.AlertStats(context.Background(), StatsBySeverity, UsagePeriod{Start: period.End, End: period.Start}); err == nil {
+		t.Error("expected an error for an inverted period")
+	}
+
+	if _, err := New(server.URL).AlertStats(context.Background(), StatsBySeverity, period); err == nil {
+		t.Error("expected an error before Connect")
+	}
+}
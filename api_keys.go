@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const apiKeysEndpoint = "admin/keys"
+
+// APIKeyScope is a permission granted to an API key.
+type APIKeyScope string
+
+const (
+	// ScopeAlertsWrite allows sending alerts.
+	ScopeAlertsWrite APIKeyScope = "alerts:write"
+
+	// ScopeAlertsRead allows listing and exporting alerts.
+	ScopeAlertsRead APIKeyScope = "alerts:read"
+
+	// ScopeAuditRead allows reading the audit log.
+	ScopeAuditRead APIKeyScope = "audit:read"
+
+	// ScopeAdmin allows all admin operations, including key management.
+	ScopeAdmin APIKeyScope = "admin"
+)
+
+// ValidAPIKeyScopes returns all scopes accepted by [Client.CreateAPIKey].
+func ValidAPIKeyScopes() []APIKeyScope {
+	return []APIKeyScope{ScopeAlertsWrite, ScopeAlertsRead, ScopeAuditRead, ScopeAdmin}
+}
+
+// APIKey describes an API key. Secret is only populated in the responses of
+// [Client.CreateAPIKey] and [Client.RotateAPIKey]; it cannot be retrieved
+// again later.
+type APIKey struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	Secret    string        `json:"secret,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"`
+}
+
+// CreateAPIKeyRequest holds the parameters of a new API key.
+type CreateAPIKeyRequest struct {
+	// Name is a human-readable label for the key. Required.
+	Name string `json:"name"`
+
+	// Scopes are the permissions granted to the key. At least one is
+	// required; see [ValidAPIKeyScopes].
+	Scopes []APIKeyScope `json:"scopes"`
+
+	// ExpiresAt, if set, is when the key stops working.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// validate checks the request before it is sent.
+func (r *CreateAPIKeyRequest) validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("API key name must not be empty")
+	}
+
+	if len(r.Scopes) == 0 {
+		return errors.New("API key must have at least one scope")
+	}
+
+	for _, scope := range r.Scopes {
+		if !slices.Contains(ValidAPIKeyScopes(), scope) {
+			return fmt.Errorf("invalid API key scope %q", scope)
+		}
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("API key expiry must be in the future")
+	}
+
+	return nil
+}
+
+// CreateAPIKey creates an API key with the given name and scopes. The
+// returned key's Secret must be stored by the caller; it is not shown
+// again. [Client.Connect] must be called first, with credentials that have
+// the [ScopeAdmin] scope.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	key := &APIKey{}
+	if err := c.doJSON(ctx, http.MethodPost, apiKeysEndpoint, nil, &req, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// RotateAPIKey issues a new secret for the key with the given ID, keeping
+// its name and scopes. The previous secret stops working. The returned
+// key's Secret must be stored by the caller.
+func (c *Client) RotateAPIKey(ctx context.Context, id string) (*APIKey, error) {
+	path, err := apiKeyPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	key := &APIKey{}
+	if err := c.doJSON(ctx, http.MethodPost, path+"/rotate", nil, nil, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey permanently revokes the key with the given ID.
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	path, err := apiKeyPath(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func apiKeyPath(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", errors.New("API key ID must not be empty")
+	}
+
+	return apiKeysEndpoint + "/" + url.PathEscape(id), nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method, path string
+		body         map[string]any
+	}
+
+	calls := make(chan call, 3)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls <- call{r.Method, r.URL.EscapedPath(), body}
+
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":"k1","name":"ci","scopes":["alerts:write"],"secret":"s3cr3t","createdAt":"2024-01-01T00:00:00Z"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, err := client.CreateAPIKey(context.Background(), CreateAPIKeyRequest{Name: "ci", Scopes: []APIKeyScope{ScopeAlertsWrite}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if key.ID != "k1" || key.Secret != "s3cr3t" {
+		t.Errorf("unexpected key %+v", key)
+	}
+
+	got := <-calls
+	if got.method != http.MethodPost || got.path != "/admin/keys" || got.body["name"] != "ci" {
+		t.Errorf("unexpected create request %+v", got)
+	}
+
+	if _, err := client.RotateAPIKey(context.Background(), "k/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodPost || got.path != "/admin/keys/k%2F1/rotate" {
+		t.Errorf("unexpected rotate request %+v", got)
+	}
+
+	if err := client.RevokeAPIKey(context.Background(), "k1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodDelete || got.path != "/admin/keys/k1" {
+		t.Errorf("unexpected revoke request %+v", got)
+	}
+}
+
+func TestCreateAPIKeyRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		req     CreateAPIKeyRequest
+		wantErr string
+	}{
+		{"empty name", CreateAPIKeyRequest{Scopes: []APIKeyScope{ScopeAdmin}}, "name must not be empty"},
+		{"no scopes", CreateAPIKeyRequest{Name: "ci"}, "at least one scope"},
+		{"invalid scope", CreateAPIKeyRequest{Name: "ci", Scopes: []APIKeyScope{"root"}}, `invalid API key scope "root"`},
+		{"past expiry", CreateAPIKeyRequest{Name: "ci", Scopes: []APIKeyScope{ScopeAdmin}, ExpiresAt: &past}, "must be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.req.validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAPIKeys_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	if _, err := client.CreateAPIKey(context.Background(), CreateAPIKeyRequest{}); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+
+	if err := client.RevokeAPIKey(context.Background(), " "); err == nil || !strings.Contains(err.Error(), "ID must not be empty") {
+		t.Errorf("expected empty ID error, got %v", err)
+	}
+}
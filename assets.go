@@ -0,0 +1,293 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	assetsEndpoint = "assets"
+
+	// maxAssetSize is the largest asset accepted by [Client.UploadAsset].
+	maxAssetSize = 5 << 20
+)
+
+// assetNameRegex matches valid asset names.
+var assetNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-+]{1,100}$`)
+
+// AssetKind is the type of a static asset stored by the API.
+type AssetKind string
+
+const (
+	// AssetEmoji is a custom emoji, referenced from [types.Alert.IconEmoji]
+	// with [Asset.Emoji].
+	AssetEmoji AssetKind = "emoji"
+
+	// AssetIcon is an image, referenced by its URL.
+	AssetIcon AssetKind = "icon"
+
+	// AssetSnippet is a text snippet, referenced by its URL. See
+	// [WithSnippetConversion].
+	AssetSnippet AssetKind = "snippet"
+)
+
+// Asset is a static asset, such as an icon or emoji, that alerts can
+// reference.
+type Asset struct {
+	Kind        AssetKind `json:"kind"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 hash of the content.
+	SHA256 string `json:"sha256"`
+
+	// URL is where the API serves the asset.
+	URL       string    `json:"url,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+}
+
+// Emoji returns the asset name in the ":name:" form used by
+// [types.Alert.IconEmoji].
+func (a *Asset) Emoji() string {
+	return ":" + a.Name + ":"
+}
+
+type assetsList struct {
+	Assets []*Asset `json:"assets"`
+}
+
+// assetCache remembers the hash of every asset uploaded or confirmed by the
+// client, so unchanged assets are not checked with the API again.
+type assetCache struct {
+	mu     sync.Mutex
+	assets map[string]*Asset
+}
+
+func newAssetCache() *assetCache {
+	return &assetCache{assets: make(map[string]*Asset)}
+}
+
+func (a *assetCache) get(kind AssetKind, name, hash string) (*Asset, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	asset, ok := a.assets[string(kind)+"/"+name]
+	if !ok || asset.SHA256 != hash {
+		return nil, false
+	}
+
+	copied := *asset
+
+	return &copied, true
+}
+
+func (a *assetCache) put(asset *Asset) {
+	copied := *asset
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.assets[string(asset.Kind)+"/"+asset.Name] = &copied
+}
+
+func (a *assetCache) remove(kind AssetKind, name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.assets, string(kind)+"/"+name)
+}
+
+// ListAssets returns the assets of the given kind, or of every kind if kind
+// is empty. [Client.Connect] must be called first.
+func (c *Client) ListAssets(ctx context.Context, kind AssetKind) ([]*Asset, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if kind != "" {
+		query.Set("kind", string(kind))
+	}
+
+	var list assetsList
+	if err := c.doJSON(ctx, http.MethodGet, assetsEndpoint, query, nil, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Assets, nil
+}
+
+// UploadAsset stores data as the named asset, replacing any previous
+// content. The content is hashed first, and the upload is skipped if this
+// client has already uploaded the same content under the same name, or if
+// the API reports that it already stores it. Calling UploadAsset at startup
+// for every asset a producer uses is therefore cheap.
+//
+// An empty contentType is detected from data. Assets may be up to 5 MiB.
+func (c *Client) UploadAsset(ctx context.Context, kind AssetKind, name, contentType string, data []byte) (*Asset, error) {
+	path, err := assetPath(kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("asset %s/%s is empty", kind, name)
+	}
+
+	if len(data) > maxAssetSize {
+		return nil, fmt.Errorf("asset %s/%s is %d bytes, which exceeds the %d byte limit", kind, name, len(data), maxAssetSize)
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	return c.uploadAsset(ctx, path, kind, name, contentType, newReplayBodyBytes(data))
+}
+
+// UploadAssetFrom is like [Client.UploadAsset], but reads the content from
+// r, for example an open file. The content is read once and buffered, in
+// memory up to the [WithBodySpoolThreshold] size and in a temporary file
+// above it, so that retried uploads resend the complete content.
+func (c *Client) UploadAssetFrom(ctx context.Context, kind AssetKind, name, contentType string, r io.Reader) (*Asset, error) {
+	path, err := assetPath(kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	body, err := newReplayBody(r, c.options.spoolThreshold, maxAssetSize)
+	if errors.Is(err, errBodyTooLarge) {
+		return nil, fmt.Errorf("asset %s/%s exceeds the %d byte limit", kind, name, maxAssetSize)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("asset %s/%s: %w", kind, name, err)
+	}
+
+	defer func() {
+		if err := body.Close(); err != nil {
+			c.logger(ctx).Errorf("failed to remove spooled asset %s/%s: %s", kind, name, err)
+		}
+	}()
+
+	if body.size == 0 {
+		return nil, fmt.Errorf("asset %s/%s is empty", kind, name)
+	}
+
+	return c.uploadAsset(ctx, path, kind, name, contentType, body)
+}
+
+// uploadAsset implements [Client.UploadAsset] and [Client.UploadAssetFrom]
+// once the content has been validated.
+func (c *Client) uploadAsset(ctx context.Context, path string, kind AssetKind, name, contentType string, body *replayBody) (*Asset, error) {
+	sum := sha256.New()
+	if _, err := io.Copy(sum, body.open()); err != nil {
+		return nil, fmt.Errorf("failed to hash asset %s/%s: %w", kind, name, err)
+	}
+
+	hash := hex.EncodeToString(sum.Sum(nil))
+	if asset, ok := c.assets.get(kind, name, hash); ok {
+		return asset, nil
+	}
+
+	var existing assetsList
+
+	query := url.Values{"kind": {string(kind)}, "name": {name}, "sha256": {hash}}
+	if err := c.doJSON(ctx, http.MethodGet, assetsEndpoint, query, nil, &existing); err != nil {
+		return nil, err
+	}
+
+	for _, asset := range existing.Assets {
+		if asset != nil && asset.Kind == kind && asset.Name == name && asset.SHA256 == hash {
+			c.logger(ctx).Debugf("asset %s/%s is unchanged, skipping upload", kind, name)
+			c.assets.put(asset)
+
+			return asset, nil
+		}
+	}
+
+	if contentType == "" {
+		sniff := make([]byte, 512)
+		n, _ := io.ReadFull(body.open(), sniff)
+		contentType = http.DetectContentType(sniff[:n])
+	}
+
+	response, err := c.client.R().
+		SetContext(withReplayBody(ctx, body)).
+		SetHeader("Content-Type", contentType).
+		SetHeader("X-Content-SHA256", hash).
+		Put(path)
+	if err != nil {
+		return nil, fmt.Errorf("PUT %s failed: %w", path, err)
+	}
+
+	c.observeDeprecation(ctx, "PUT "+path, response)
+
+	if !response.IsSuccess() {
+		return nil, fmt.Errorf("PUT %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+	}
+
+	c.onSuccess(response)
+
+	asset := &Asset{}
+	if err := c.decodeResponse(response.Header().Get("Content-Type"), response.Body(), asset); err != nil {
+		return nil, fmt.Errorf("failed to decode PUT %s response: %w", path, err)
+	}
+
+	if asset.SHA256 != "" && asset.SHA256 != hash {
+		return nil, fmt.Errorf("asset %s/%s: API stored content with hash %s, expected %s", kind, name, asset.SHA256, hash)
+	}
+
+	asset.SHA256 = hash
+	c.assets.put(asset)
+
+	return asset, nil
+}
+
+// DeleteAsset deletes the named asset.
+func (c *Client) DeleteAsset(ctx context.Context, kind AssetKind, name string) error {
+	path, err := assetPath(kind, name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	if err := c.doJSON(ctx, http.MethodDelete, path, nil, nil, nil); err != nil {
+		return err
+	}
+
+	c.assets.remove(kind, name)
+
+	return nil
+}
+
+func assetPath(kind AssetKind, name string) (string, error) {
+	switch kind {
+	case AssetEmoji, AssetIcon, AssetSnippet:
+	default:
+		return "", fmt.Errorf("invalid asset kind %q", kind)
+	}
+
+	if !assetNameRegex.MatchString(name) {
+		return "", errors.New("asset name must be 1-100 letters, digits or '_', '.', '-', '+'")
+	}
+
+	return assetsEndpoint + "/" + string(kind) + "/" + url.PathEscape(name), nil
+}
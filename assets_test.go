@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// assetServer is a fake assets API that stores uploads in memory.
+type assetServer struct {
+	mu      sync.Mutex
+	stored  map[string]*Asset
+	lookups int
+	uploads int
+}
+
+func (s *assetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/ping":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet && r.URL.Path == "/assets":
+		s.lookups++
+
+		list := assetsList{Assets: []*Asset{}}
+		for _, asset := range s.stored {
+			if q := r.URL.Query(); q.Get("sha256") == "" || (asset.Name == q.Get("name") && asset.SHA256 == q.Get("sha256")) {
+				list.Assets = append(list.Assets, asset)
+			}
+		}
+
+		if err := json.NewEncoder(w).Encode(list); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case r.Method == http.MethodPut:
+		s.uploads++
+
+		data, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(data)
+		parts := strings.Split(r.URL.Path, "/")
+
+		asset := &Asset{
+			Kind:        AssetKind(parts[2]),
+			Name:        parts[3],
+			ContentType: r.Header.Get("Content-Type"),
+			Size:        int64(len(data)),
+			SHA256:      hex.EncodeToString(sum[:]),
+			URL:         "https://assets.example.com/" + parts[3],
+		}
+		s.stored[parts[2]+"/"+parts[3]] = asset
+
+		if err := json.NewEncoder(w).Encode(asset); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestUploadAsset_SkipsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	fake := &assetServer{stored: map[string]*Asset{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	png := []byte("\x89PNG\r\n\x1a\n-fake-image")
+
+	asset, err := client.UploadAsset(ctx, AssetEmoji, "db-down", "", png)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if asset.ContentType != "image/png" || asset.Emoji() != ":db-down:" {
+		t.Errorf("unexpected asset %+v", asset)
+	}
+
+	if _, err := client.UploadAsset(ctx, AssetEmoji, "db-down", "", png); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.uploads != 1 || fake.lookups != 1 {
+		t.Errorf("expected cached asset to skip the API, got %d uploads and %d lookups", fake.uploads, fake.lookups)
+	}
+
+	// A fresh client has no local cache, but the API already has the content.
+	other := New(server.URL)
+	if err := other.Connect(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := other.UploadAsset(ctx, AssetEmoji, "db-down", "", png); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.uploads != 1 || fake.lookups != 2 {
+		t.Errorf("expected stored asset to skip the upload, got %d uploads and %d lookups", fake.uploads, fake.lookups)
+	}
+
+	if _, err := client.UploadAsset(ctx, AssetEmoji, "db-down", "image/png", append(png, '!')); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.uploads != 2 {
+		t.Errorf("expected changed content to be uploaded, got %d uploads", fake.uploads)
+	}
+
+	assets, err := client.ListAssets(ctx, AssetEmoji)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(assets) != 1 {
+		t.Errorf("expected 1 asset, got %d", len(assets))
+	}
+
+	if err := client.DeleteAsset(ctx, AssetEmoji, "db-down"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := client.assets.get(AssetEmoji, "db-down", assets[0].SHA256); ok {
+		t.Error("expected deleted asset to be removed from the cache")
+	}
+}
+
+func TestUploadAsset_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		kind  AssetKind
+		asset string
+		data  []byte
+		want  string
+	}{
+		{"invalid kind", "sticker", "a", []byte("x"), "invalid asset kind"},
+		{"invalid name", AssetIcon, "has space", []byte("x"), "asset name must be"},
+		{"empty", AssetIcon, "a", nil, "is empty"},
+		{"too large", AssetIcon, "a", make([]byte, maxAssetSize+1), "exceeds"},
+		{"not connected", AssetIcon, "a", []byte("x"), "not connected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := client.UploadAsset(ctx, tt.kind, tt.asset, "", tt.data); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	minAsyncQueueSize     = 1
+	maxAsyncQueueSize     = 1_000_000
+	minAsyncFlushInterval = 10 * time.Millisecond
+	maxAsyncFlushInterval = time.Minute
+	asyncBatchSize        = 100
+)
+
+// ErrQueueFull is returned by [Client.SendAsync] when the queue of
+// [WithAsyncMode] has no room for the alerts.
+var ErrQueueFull = errors.New("async send queue is full")
+
+// AsyncErrorHandler is called with the alerts of a batch queued with
+// [Client.SendAsync] that could not be sent, and the error. See
+// [WithAsyncMode].
+type AsyncErrorHandler func(alerts []*types.Alert, err error)
+
+// ResultCallback is called with the result of every batch queued with
+// [Client.SendAsync], and the error if it could not all be sent, as
+// reported to the [AsyncResult] of its alerts. See
+// [WithResultCallback].
+type ResultCallback func(result SendResult, err error)
+
+// SendResult describes a batch of alerts queued with [Client.SendAsync]
+// that was sent.
+type SendResult struct {
+	// Alerts are the alerts of the batch, as queued, or those that were
+	// not delivered if it failed. With [WithQuarantine], the alerts
+	// delivered on their own after the batch was rejected are left out.
+	Alerts []*types.Alert
+
+	// Response is the metadata of the response, or nil if none arrived.
+	Response *ResponseMetadata
+}
+
+// AsyncResult is the handle of the alerts queued by one call of
+// [Client.SendAsync]. It is done once they have all been sent, or could not
+// be.
+type AsyncResult struct {
+	mu      sync.Mutex
+	pending int
+	errs    []error
+	done    chan struct{}
+}
+
+func newAsyncResult(pending int) *AsyncResult {
+	return &AsyncResult{pending: pending, done: make(chan struct{})}
+}
+
+// Done returns a channel that is closed when the result is done.
+func (r *AsyncResult) Done() <-chan struct{} {
+	return r.done
+}
+
+// Await waits until the alerts have been sent, or ctx is done. It returns
+// nil if every alert was sent, and otherwise the errors of the batches that
+// failed, or the error of ctx.
+func (r *AsyncResult) Await(ctx context.Context) error {
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return fmt.Errorf("alerts not sent yet: %w", ctx.Err())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return errors.Join(r.errs...)
+}
+
+// complete records the outcome of one of the alerts.
+func (r *AsyncResult) complete(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil && !slices.Contains(r.errs, err) {
+		r.errs = append(r.errs, err)
+	}
+
+	if r.pending--; r.pending == 0 {
+		close(r.done)
+	}
+}
+
+// AsyncStats reports the state of [WithAsyncMode].
+type AsyncStats struct {
+	// Enabled reports whether [WithAsyncMode] is in effect.
+	Enabled bool
+
+	// Queued is the number of alerts waiting to be sent.
+	Queued int
+
+	// Dropped counts the alerts rejected with [ErrQueueFull].
+	Dropped int64
+
+	// Failed counts the alerts of batches that could not be sent.
+	Failed int64
+}
+
+// asyncItem is a queued alert and the result of the call that queued it.
+type asyncItem struct {
+	alert  *types.Alert
+	result *AsyncResult
+}
+
+// asyncSender queues alerts for [Client.SendAsync] and sends them in
+// batches from a background goroutine.
+type asyncSender struct {
+	c        *Client
+	ctx      context.Context //nolint:containedctx // batches are sent in the background, until Close
+	size     int
+	onError  AsyncErrorHandler
+	onResult ResultCallback
+
+	mu      sync.Mutex
+	queue   []asyncItem
+	dropped int64
+	failed  int64
+	closed  bool
+
+	// sending serializes the sends of the loop and of Shutdown.
+	sending sync.Mutex
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newAsyncSender(ctx context.Context, c *Client, size int, interval time.Duration) *asyncSender {
+	s := &asyncSender{
+		c:        c,
+		ctx:      ctx,
+		size:     size,
+		onError:  c.options.asyncOnError,
+		onResult: c.options.asyncOnResult,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go s.run(interval)
+
+	return s
+}
+
+// run sends the queue every interval, and as soon as a batch is full,
+// until close is called.
+func (s *asyncSender) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		_ = s.send(s.ctx)
+	}
+}
+
+// close stops the background sends, waiting for one in flight, and
+// rejects further alerts. Queued alerts are kept for [Client.Shutdown].
+func (s *asyncSender) close() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+
+		close(s.stop)
+		<-s.done
+	})
+}
+
+// discard empties the queue, failing the results of the alerts in it.
+func (s *asyncSender) discard() {
+	s.mu.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+
+	err := errors.New("client closed before the alerts were sent")
+	for _, item := range queue {
+		item.result.complete(err)
+	}
+}
+
+// enqueue adds alerts to the queue, or none of them if they do not fit.
+func (s *asyncSender) enqueue(alerts []*types.Alert) (*AsyncResult, error) {
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+		return nil, errors.New("async sender is closed")
+	}
+
+	if len(s.queue)+len(alerts) > s.size {
+		s.dropped += int64(len(alerts))
+		s.mu.Unlock()
+
+		return nil, fmt.Errorf("%w: %d of %d queued", ErrQueueFull, len(s.queue), s.size)
+	}
+
+	result := newAsyncResult(len(alerts))
+	for _, alert := range alerts {
+		s.queue = append(s.queue, asyncItem{alert: alert, result: result})
+	}
+
+	full := len(s.queue) >= asyncBatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return result, nil
+}
+
+// next removes and returns the next batch of queued alerts.
+func (s *asyncSender) next() []asyncItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := min(len(s.queue), asyncBatchSize)
+	batch := slices.Clone(s.queue[:n])
+
+	s.queue = slices.Delete(s.queue, 0, n)
+
+	return batch
+}
+
+// send sends the queued alerts in batches until the queue is empty or ctx
+// is done, and returns the errors of the batches that failed.
+func (s *asyncSender) send(ctx context.Context) error {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+
+	var (
+		errs     []error
+		rejected []asyncItem
+	)
+
+	for ctx.Err() == nil {
+		batch := s.next()
+		if len(batch) == 0 {
+			break
+		}
+
+		alerts := make([]*types.Alert, len(batch))
+		for i, item := range batch {
+			alerts[i] = item.alert
+		}
+
+		meta, err := s.c.SendWithOptions(ctx, alerts, alreadyPrepared())
+
+		// A batch cut short by Close stays queued, and is counted among
+		// the alerts it drops.
+		if err != nil && ctx.Err() != nil {
+			s.requeue(batch)
+			break
+		}
+
+		sifted := s.sift(ctx, alerts, meta, err)
+		if failed := slices.Concat(sifted.failed, sifted.quarantined); len(failed) > 0 {
+			s.fail(failed, sifted.err)
+			errs = append(errs, sifted.err)
+		}
+
+		if s.onResult != nil {
+			result := SendResult{Alerts: alerts, Response: meta}
+			if sifted.err != nil {
+				result.Alerts = slices.DeleteFunc(slices.Clone(alerts), func(alert *types.Alert) bool { return !sifted.undelivered(alert) })
+			}
+
+			s.onResult(result, sifted.err)
+		}
+
+		for _, item := range batch {
+			switch {
+			case slices.Contains(sifted.rejected, item.alert):
+				rejected = append(rejected, item)
+			case sifted.undelivered(item.alert):
+				item.result.complete(sifted.err)
+			default:
+				item.result.complete(nil)
+			}
+		}
+	}
+
+	// Rejected alerts go to the back of the queue once the flush is over,
+	// so they neither hold up the others nor are sent again until the next
+	// flush.
+	if len(rejected) > 0 {
+		s.mu.Lock()
+		s.queue = append(s.queue, rejected...)
+		s.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// sift sorts the alerts of a batch sent with err. Without [WithQuarantine],
+// a failed batch failed as a whole.
+func (s *asyncSender) sift(ctx context.Context, alerts []*types.Alert, meta *ResponseMetadata, err error) siftResult {
+	q := s.c.quarantine
+
+	switch {
+	case err != nil && q != nil:
+		return q.sift(ctx, QueueAsync, alerts, meta, err, func(ctx context.Context, alerts []*types.Alert) (*ResponseMetadata, error) {
+			return s.c.SendWithOptions(ctx, alerts, alreadyPrepared())
+		})
+	case err != nil:
+		return siftResult{failed: alerts, err: err}
+	case q != nil:
+		q.forget(alerts...)
+	}
+
+	return siftResult{}
+}
+
+// requeue puts batch back at the front of the queue.
+func (s *asyncSender) requeue(batch []asyncItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = append(batch, s.queue...)
+}
+
+// fail reports a batch that could not be sent.
+func (s *asyncSender) fail(alerts []*types.Alert, err error) {
+	s.mu.Lock()
+	s.failed += int64(len(alerts))
+	s.mu.Unlock()
+
+	if s.onError != nil {
+		s.onError(alerts, err)
+		return
+	}
+
+	if s.onResult == nil {
+		s.c.options.requestLogger.Errorf("failed to send %d queued alert(s): %v", len(alerts), err)
+	}
+}
+
+// alerts returns the queued alerts, in order.
+func (s *asyncSender) alerts() []*types.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]*types.Alert, len(s.queue))
+	for i, item := range s.queue {
+		alerts[i] = item.alert
+	}
+
+	return alerts
+}
+
+// extract removes and returns the queued alerts selected by match.
+func (s *asyncSender) extract(match func(*types.Alert) bool) []asyncItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var taken []asyncItem
+
+	s.queue = slices.DeleteFunc(s.queue, func(item asyncItem) bool {
+		if match(item.alert) {
+			taken = append(taken, item)
+			return true
+		}
+
+		return false
+	})
+
+	return taken
+}
+
+// len returns the number of queued alerts.
+func (s *asyncSender) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.queue)
+}
+
+func (s *asyncSender) stats() AsyncStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return AsyncStats{Enabled: true, Queued: len(s.queue), Dropped: s.dropped, Failed: s.failed}
+}
+
+// SendAsync queues alerts to be sent in the background by [WithAsyncMode]
+// and returns without waiting for the API. The alerts are queued all
+// together or, if the queue has no room for them, not at all, returning
+// [ErrQueueFull]. The alerts are prepared as by [Client.Send] before they
+// are queued, applying the incident of ctx (see [WithIncident]), severity
+// mapping, send and channel policies and the metadata size limit, so an
+// alert rejected by these checks fails the call that queued it rather than
+// its batch. The returned [AsyncResult] reports when they have been sent;
+// failures are also reported to the [AsyncErrorHandler] and the
+// [ResultCallback] of every batch. The batches are sent with the context
+// given to [Client.Connect], so ctx being cancelled does not stop them.
+// The caller's alerts must not be modified after the call. Call
+// [Client.Shutdown] to send the queue before the process exits.
+// [Client.Connect] must be called first.
+func (c *Client) SendAsync(ctx context.Context, alerts ...*types.Alert) (*AsyncResult, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if c.async == nil {
+		return nil, errors.New("async mode is not enabled - use WithAsyncMode")
+	}
+
+	if len(alerts) == 0 {
+		return nil, errors.New("alerts list cannot be empty")
+	}
+
+	for i, alert := range alerts {
+		if alert == nil {
+			return nil, fmt.Errorf("alert at index %d is nil", i)
+		}
+	}
+
+	prepared, err := c.prepareAlerts(ctx, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.async.enqueue(prepared)
+}
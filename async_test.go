@@ -0,0 +1,309 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newAsyncClient returns a client in async mode sending to an alert
+// recorder.
+func newAsyncClient(t *testing.T, queueSize int, interval time.Duration, onError AsyncErrorHandler) (*Client, *alertRecorder) {
+	t.Helper()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithAsyncMode(queueSize, interval, onError), WithRetryCount(0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client, server
+}
+
+func countAlerts(batches [][]*types.Alert) int {
+	n := 0
+	for _, batch := range batches {
+		n += len(batch)
+	}
+
+	return n
+}
+
+func TestSendAsync(t *testing.T) {
+	t.Parallel()
+
+	client, server := newAsyncClient(t, 1000, 20*time.Millisecond, nil)
+
+	for range 3 {
+		if _, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for countAlerts(server.received()) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 3 alerts to be sent, got %d", countAlerts(server.received()))
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if stats := client.Stats().Async; !stats.Enabled || stats.Queued != 0 || stats.Dropped != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSendAsync_FullBatch(t *testing.T) {
+	t.Parallel()
+
+	client, server := newAsyncClient(t, 1000, time.Minute, nil)
+
+	alerts := make([]*types.Alert, asyncBatchSize+1)
+	for i := range alerts {
+		alerts[i] = types.NewAlert(types.AlertInfo)
+	}
+
+	if _, err := client.SendAsync(context.Background(), alerts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitForBatches(t, server, 2)
+
+	if batches := server.received(); len(batches[0]) != asyncBatchSize || len(batches[1]) != 1 {
+		t.Errorf("expected a full batch and the rest, got %d and %d alerts", len(batches[0]), len(batches[1]))
+	}
+}
+
+func TestSendAsync_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	client, server := newAsyncClient(t, 2, time.Minute, nil)
+
+	_, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError), types.NewAlert(types.AlertError), types.NewAlert(types.AlertError))
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+
+	if _, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats := client.Stats().Async; stats.Queued != 1 || stats.Dropped != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if n := countAlerts(server.received()); n != 1 {
+		t.Errorf("expected Shutdown to send the queued alert, got %d", n)
+	}
+
+	if _, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError)); err == nil {
+		t.Error("expected an error after Shutdown")
+	}
+}
+
+func TestSendAsync_OnError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		failed []*types.Alert
+		done   = make(chan struct{})
+	)
+
+	client, server := newAsyncClient(t, 10, 10*time.Millisecond, func(alerts []*types.Alert, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err == nil {
+			t.Error("expected an error")
+		}
+
+		failed = append(failed, alerts...)
+		close(done)
+	})
+	server.setStatus(http.StatusBadRequest)
+
+	if _, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError), types.NewAlert(types.AlertWarning)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the error handler to be called")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(failed) != 2 || client.Stats().Async.Failed != 2 {
+		t.Errorf("expected both alerts to be reported, got %d", len(failed))
+	}
+}
+
+func TestSendAsync_Invalid(t *testing.T) {
+	t.Parallel()
+
+	client := connectTo(t, newAlertRecorder(t).URL)
+	if _, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError)); err == nil {
+		t.Error("expected an error without async mode")
+	}
+
+	async, _ := newAsyncClient(t, 10, time.Minute, nil)
+	if _, err := async.SendAsync(context.Background(), nil); err == nil {
+		t.Error("expected an error for a nil alert")
+	}
+
+	for _, opt := range []Option{
+		WithAsyncMode(0, time.Second, nil),
+		WithAsyncMode(10, time.Millisecond, nil),
+		WithAsyncMode(10, time.Hour, nil),
+	} {
+		if client := New("http://localhost", opt); client.options.asyncQueueSize != 0 || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+}
+
+func TestSendAsync_ValidatesEachCall(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithAsyncMode(10, 10*time.Millisecond, nil), WithRetryCount(0), WithChannelAllowlist("C-ops"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	bad := types.NewAlert(types.AlertError)
+	bad.SlackChannelID = "C-exec"
+
+	var policyErr *PolicyError
+	if _, err := client.SendAsync(context.Background(), bad); !errors.As(err, &policyErr) {
+		t.Fatalf("expected the policy error from SendAsync, got %v", err)
+	}
+
+	good := types.NewAlert(types.AlertError)
+	good.SlackChannelID = "C-ops"
+
+	result, err := client.SendAsync(context.Background(), good)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := result.Await(ctx); err != nil {
+		t.Errorf("expected the valid alert to be delivered, got %v", err)
+	}
+
+	if got := countAlerts(server.received()); got != 1 {
+		t.Errorf("expected only the valid alert to be sent, got %d", got)
+	}
+}
+
+func TestSendAsync_Await(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		results []SendResult
+	)
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithAsyncMode(1000, 10*time.Millisecond, nil), WithRetryCount(0), WithResultCallback(func(result SendResult, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		results = append(results, result)
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	result, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError), types.NewAlert(types.AlertWarning))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := result.Await(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(results) != 1 || len(results[0].Alerts) != 2 || results[0].Response == nil {
+		t.Errorf("expected one batch result with its response, got %+v", results)
+	}
+}
+
+func TestSendAsync_AwaitFailure(t *testing.T) {
+	t.Parallel()
+
+	client, server := newAsyncClient(t, 10, 10*time.Millisecond, func([]*types.Alert, error) {})
+	server.setStatus(http.StatusBadRequest)
+
+	result, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-result.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the result to be done")
+	}
+
+	if err := result.Await(context.Background()); err == nil {
+		t.Error("expected the batch error")
+	}
+}
+
+func TestSendAsync_AwaitClosed(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newAsyncClient(t, 10, time.Minute, nil)
+
+	result, err := client.SendAsync(context.Background(), types.NewAlert(types.AlertError))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := result.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error while queued, got %v", err)
+	}
+
+	client.Close()
+
+	if err := result.Await(context.Background()); err == nil || !strings.Contains(err.Error(), "client closed") {
+		t.Errorf("expected Close to fail the result, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// AuditSend is the action of [AuditRecord]s for alert batches and
+	// server template sends.
+	AuditSend = "send"
+
+	// AuditCreate, AuditUpdate and AuditDelete are the actions of
+	// [AuditRecord]s for POST, PUT or PATCH, and DELETE API requests.
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// AuditRecord records an action taken through the client, for
+// [WithAuditSink].
+type AuditRecord struct {
+	Time time.Time
+
+	// Caller is the identity set with [WithCaller] on the context of the
+	// call, or empty.
+	Caller string
+
+	// Action is [AuditSend], [AuditCreate], [AuditUpdate] or [AuditDelete].
+	Action string
+
+	// Path is the API path, such as "alerts" or "routes/r1".
+	Path string
+
+	// Alerts is the number of alerts in a batch send.
+	Alerts int
+
+	// StatusCode is the status code of the response, or 0 if none arrived.
+	StatusCode int
+
+	Duration time.Duration
+
+	// Err is the error the call returned; nil means it succeeded.
+	Err error
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx that carries the identity of the user
+// or service on whose behalf calls made with it act, such as a user name
+// or a SPIFFE ID, for the [AuditRecord]s passed to [WithAuditSink].
+func WithCaller(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, callerKey{}, identity)
+}
+
+// CallerFromContext returns the identity set with [WithCaller], or an
+// empty string.
+func CallerFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(callerKey{}).(string)
+	return identity
+}
+
+// audit passes a record of a call that started at start to the sink set
+// with [WithAuditSink], if any.
+func (c *Client) audit(ctx context.Context, action, path string, alerts, status int, start time.Time, err error) {
+	if c.options.auditSink == nil {
+		return
+	}
+
+	c.options.auditSink(AuditRecord{
+		Time:       start,
+		Caller:     CallerFromContext(ctx),
+		Action:     action,
+		Path:       path,
+		Alerts:     alerts,
+		StatusCode: status,
+		Duration:   time.Since(start),
+		Err:        err,
+	})
+}
+
+// auditAction returns the audit action of a request with the given
+// method, or an empty string for reads.
+func auditAction(method string) string {
+	switch strings.ToUpper(method) {
+	case http.MethodPost:
+		return AuditCreate
+	case http.MethodPut, http.MethodPatch:
+		return AuditUpdate
+	case http.MethodDelete:
+		return AuditDelete
+	default:
+		return ""
+	}
+}
+
+// status returns the status code of m, or 0 if m is nil.
+func (m *ResponseMetadata) status() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.StatusCode
+}
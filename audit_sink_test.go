@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/routes/missing":
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		case "/routes":
+			_, _ = w.Write([]byte(`{"routes":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		records []AuditRecord
+	)
+
+	client := New(server.URL, WithAuditSink(func(record AuditRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		records = append(records, record)
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := WithCaller(context.Background(), "deploy-bot")
+
+	if err := client.Send(ctx, types.NewAlert(types.AlertError), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.ListRoutes(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.Preview(ctx, types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteRoute(context.Background(), "missing"); err == nil {
+		t.Fatal("expected the delete to fail")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(records) != 2 {
+		t.Fatalf("expected the send and the delete to be recorded, got %+v", records)
+	}
+
+	send := records[0]
+	if send.Action != AuditSend || send.Caller != "deploy-bot" || send.Path != "alerts" || send.Alerts != 2 || send.StatusCode != http.StatusOK || send.Err != nil || time.Since(send.Time) > time.Minute {
+		t.Errorf("unexpected send record %+v", send)
+	}
+
+	del := records[1]
+	if del.Action != AuditDelete || del.Caller != "" || del.Path != "routes/missing" || del.StatusCode != http.StatusNotFound || del.Err == nil {
+		t.Errorf("unexpected delete record %+v", del)
+	}
+}
+
+func TestAuditAction(t *testing.T) {
+	t.Parallel()
+
+	for method, want := range map[string]string{
+		http.MethodGet:    "",
+		http.MethodPost:   AuditCreate,
+		http.MethodPut:    AuditUpdate,
+		http.MethodPatch:  AuditUpdate,
+		http.MethodDelete: AuditDelete,
+	} {
+		if got := auditAction(method); got != want {
+			t.Errorf("expected %s to be audited as %q, got %q", method, want, got)
+		}
+	}
+
+	if New("http://localhost", WithAuditSink(nil)).options.auditSink != nil {
+		t.Error("expected a nil sink to be ignored")
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/audit" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		queries = append(queries, r.URL.RawQuery)
+
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"events":[{"id":"1","actor":"alice","action":"issue.acked","resourceType":"issue","resourceId":"I1"}],"nextCursor":"p2"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"events":[{"id":"2","actor":"alice","action":"issue.deleted","details":{"reason":"duplicate"}}]}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAuthToken("secret"), WithAuditEndpoint("v2/audit"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var events []*AuditEvent
+
+	for event, err := range client.AuditEvents(context.Background(), AuditFilter{Actor: "alice", Actions: []string{"issue.acked", "issue.deleted"}}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) != 2 || events[0].Action != "issue.acked" || events[1].Details["reason"] != "duplicate" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 page requests, got %d", len(queries))
+	}
+
+	if !strings.Contains(queries[0], "actor=alice") || !strings.Contains(queries[0], "action=issue.acked&action=issue.deleted") {
+		t.Errorf("unexpected first query %q", queries[0])
+	}
+
+	if !strings.Contains(queries[1], "cursor=p2") {
+		t.Errorf("expected second query to use cursor p2, got %q", queries[1])
+	}
+}
+
+func TestAuditEvents_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	for event, err := range New("http://example.com").AuditEvents(context.Background(), AuditFilter{}) {
+		if event != nil || err == nil {
+			t.Errorf("expected not connected error, got %v, %v", event, err)
+		}
+	}
+}
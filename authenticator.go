@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxAuthFailureBody is the number of bytes of a 401 response body passed
+// to [Authenticator.OnAuthFailure].
+const maxAuthFailureBody = 64 << 10
+
+// Authenticator adds credentials to requests, for authentication schemes
+// that go beyond a static header. Set it with [WithAuthenticator].
+// Implementations must be safe for concurrent use.
+type Authenticator interface {
+	// Apply adds credentials to req. It is called before every attempt,
+	// including retries, after all other headers have been set.
+	Apply(ctx context.Context, req *http.Request) error
+
+	// OnAuthFailure is called when a request is rejected with 401
+	// Unauthorized, for example to refresh expired credentials. If it
+	// returns nil, the request is sent once more, after calling Apply
+	// again. If it returns an error, the 401 response is returned.
+	OnAuthFailure(ctx context.Context, resp *Response) error
+}
+
+// AuthError reports that an [Authenticator] could not add credentials to
+// a request, for example because its token source failed. The request was
+// not sent, so [DefaultRetryCondition] does not retry it, and it neither
+// triggers [WithFallbackWebhook] or [WithEmailFallback] nor counts against
+// [WithDeliveryHealthAlert].
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string { return "failed to authenticate request: " + e.Err.Error() }
+func (e *AuthError) Unwrap() error { return e.Err }
+
+// isAuthError reports whether err is or wraps an [*AuthError].
+func isAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr)
+}
+
+// basicAuthenticator implements HTTP Basic authentication.
+type basicAuthenticator struct {
+	username string
+	password string
+}
+
+// BasicAuthenticator returns an [Authenticator] for HTTP Basic
+// authentication. [WithBasicAuth] is a shorthand for it.
+func BasicAuthenticator(username, password string) Authenticator { //nolint:ireturn // callers only need the interface
+	return &basicAuthenticator{username: username, password: password}
+}
+
+func (a *basicAuthenticator) Apply(_ context.Context, req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+func (a *basicAuthenticator) OnAuthFailure(context.Context, *Response) error {
+	return errors.New("basic auth credentials were rejected")
+}
+
+// tokenAuthenticator sends a static token in the Authorization header.
+type tokenAuthenticator struct {
+	scheme string
+	token  string
+}
+
+// TokenAuthenticator returns an [Authenticator] that sends token in the
+// Authorization header with the given scheme, such as "Bearer".
+// [WithAuthToken] and [WithAuthScheme] are a shorthand for it.
+func TokenAuthenticator(scheme, token string) Authenticator { //nolint:ireturn // callers only need the interface
+	return &tokenAuthenticator{scheme: scheme, token: token}
+}
+
+func (a *tokenAuthenticator) Apply(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", a.scheme+" "+a.token)
+	return nil
+}
+
+func (a *tokenAuthenticator) OnAuthFailure(context.Context, *Response) error {
+	return errors.New("auth token was rejected")
+}
+
+// AuthTokenProvider returns the current token for the Authorization
+// header. See [WithAuthTokenProvider].
+type AuthTokenProvider func(ctx context.Context) (string, error)
+
+// tokenProviderAuthenticator sends the token returned by a provider in the
+// Authorization header, asking for it before every attempt.
+type tokenProviderAuthenticator struct {
+	scheme   string
+	provider AuthTokenProvider
+}
+
+// TokenProviderAuthenticator returns an [Authenticator] that sends the
+// token returned by provider in the Authorization header with the given
+// scheme, calling provider before every attempt. After a 401 the request
+// is sent once more, with the token provider returns then.
+// [WithAuthTokenProvider] and [WithAuthScheme] are a shorthand for it.
+func TokenProviderAuthenticator(scheme string, provider AuthTokenProvider) Authenticator { //nolint:ireturn // callers only need the interface
+	return &tokenProviderAuthenticator{scheme: scheme, provider: provider}
+}
+
+func (a *tokenProviderAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+	if a.provider == nil {
+		return errors.New("auth token provider is nil")
+	}
+
+	token, err := a.provider(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get auth token: %w", err)
+	}
+
+	if token == "" {
+		return errors.New("auth token provider returned an empty token")
+	}
+
+	req.Header.Set("Authorization", a.scheme+" "+token)
+
+	return nil
+}
+
+// OnAuthFailure lets the request be sent again, in case the token was
+// rotated since it was last provided.
+func (a *tokenProviderAuthenticator) OnAuthFailure(context.Context, *Response) error {
+	return nil
+}
+
+// authenticatorOrNil returns the [Authenticator] configured by
+// [WithAuthenticator], [WithBasicAuth], [WithAuthToken] or
+// [WithAuthTokenProvider], or nil.
+func (o *Options) authenticatorOrNil() Authenticator { //nolint:ireturn // authenticators are pluggable
+	switch {
+	case o.authenticator != nil:
+		return o.authenticator
+	case o.basicAuthUsername != "":
+		return BasicAuthenticator(o.basicAuthUsername, o.basicAuthPassword)
+	case o.authToken != "":
+		return TokenAuthenticator(o.authScheme, o.authToken)
+	case o.authTokenProvider != nil:
+		return TokenProviderAuthenticator(o.authScheme, o.authTokenProvider)
+	default:
+		return nil
+	}
+}
+
+// authTransport wraps next so that every request to host is authenticated
+// with auth, and re-sent once after a 401 that auth recovers from.
+// Requests to other hosts, such as redirect targets, are sent without
+// credentials.
+func authTransport(auth Authenticator, host string, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != host {
+			return next.RoundTrip(req)
+		}
+
+		ctx := req.Context()
+
+		resp, err := authRoundTrip(ctx, auth, next, req)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		// The body can only be replayed if the request supports it.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAuthFailureBody))
+		resp.Body.Close()
+
+		failure := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+		if auth.OnAuthFailure(ctx, failure) != nil {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return resp, nil //nolint:nilerr // the 401 itself is the documented result, so retry conditions see the status
+		}
+
+		retry := req.Clone(ctx)
+
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", err)
+			}
+		}
+
+		return authRoundTrip(ctx, auth, next, retry)
+	})
+}
+
+// authRoundTrip sends a copy of req with credentials applied.
+func authRoundTrip(ctx context.Context, auth Authenticator, next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	req = req.Clone(ctx)
+
+	if err := auth.Apply(ctx, req); err != nil {
+		return nil, &AuthError{Err: err}
+	}
+
+	return next.RoundTrip(req)
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithAuthenticator_NotSentToOtherHosts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu         sync.Mutex
+		redirected string
+	)
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		redirected = r.Header.Get("Authorization")
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		http.Redirect(w, r, other.URL+"/alerts", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAuthenticator(TokenAuthenticator("Token", "secret")))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if redirected != "" {
+		t.Errorf("expected no credentials on the redirect to another host, got %q", redirected)
+	}
+
+	if snapshot := client.EffectiveConfig(); snapshot.Auth != "token" || snapshot.AuthScheme != "Token" || snapshot.AuthToken != redacted {
+		t.Errorf("unexpected auth fields: %+v", snapshot)
+	}
+}
+
+func TestBasicAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "right" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := New(server.URL, WithAuthenticator(BasicAuthenticator("admin", "right"))).Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := New(server.URL, WithBasicAuth("admin", "wrong")).Connect(context.Background()); err == nil {
+		t.Fatal("expected rejected credentials to fail")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if attempts != 2 {
+		t.Errorf("expected rejected basic credentials not to be re-sent, got %d attempts", attempts)
+	}
+
+	snapshot := New(server.URL, WithAuthenticator(BasicAuthenticator("admin", "right"))).EffectiveConfig()
+	if snapshot.Auth != "basic" || snapshot.BasicAuthUsername != "admin" || snapshot.BasicAuthPassword != redacted {
+		t.Errorf("unexpected auth fields: %+v", snapshot)
+	}
+}
+
+func TestWithAuthTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	var (
+		current atomic.Value
+		calls   atomic.Int32
+	)
+
+	current.Store("old")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server accepts only the latest token.
+		if r.Header.Get("Authorization") != "Token new" {
+			current.Store("new")
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAuthScheme("Token"), WithAuthTokenProvider(func(context.Context) (string, error) {
+		calls.Add(1)
+		return current.Load().(string), nil //nolint:forcetypeassert // only strings are stored
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("expected the rotated token to be used after a 401, got %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected the provider to be called before every attempt, got %d calls", n)
+	}
+
+	if snapshot := client.EffectiveConfig(); snapshot.Auth != "tokenProvider" || snapshot.AuthScheme != "Token" || snapshot.AuthToken != "" {
+		t.Errorf("unexpected auth fields: %+v", snapshot)
+	}
+}
+
+func TestWithAuthTokenProvider_Error(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	err := New(server.URL, WithRetryCount(0), WithAuthTokenProvider(func(context.Context) (string, error) {
+		return "", errors.New("vault sealed")
+	})).Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to get auth token: vault sealed") {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+
+	if client := New(server.URL, WithAuthTokenProvider(nil)); client.options.authTokenProvider != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil provider to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+
+	provider := WithAuthTokenProvider(func(context.Context) (string, error) { return "t", nil })
+	for _, opt := range []Option{WithAuthToken("t"), WithBasicAuth("admin", "pa55"), WithAuthenticator(BasicAuthenticator("admin", "pa55"))} {
+		if err := New(server.URL, provider, opt).Connect(context.Background()); err == nil {
+			t.Error("expected an error combining a token provider with other auth")
+		}
+	}
+}
+
+func TestWithAuthTokenProvider_ErrorIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	webhook, messages := newFallbackWebhook(t, http.StatusOK)
+
+	var calls atomic.Int32
+
+	client := New(server.URL, WithRetryCount(2), WithFallbackWebhook(webhook.URL+"/hook"), WithAuthTokenProvider(func(context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			return "t", nil
+		}
+
+		return "", errors.New("vault sealed")
+	}))
+	client.webhookClient = webhook.Client()
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertPanic))
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected an AuthError, got %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected one provider call for the send, got %d", got-1)
+	}
+
+	if got := messages(); len(got) != 0 {
+		t.Errorf("expected no fallback for an auth error, got %q", got)
+	}
+}
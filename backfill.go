@@ -0,0 +1,340 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	defaultBackfillBatchSize = 100
+	maxBackfillBatchSize     = 1000
+)
+
+// AlertIterator is a source of alerts for [Client.Backfill].
+type AlertIterator interface {
+	// Next returns the next alert, or [io.EOF] when the source is
+	// exhausted.
+	Next(ctx context.Context) (*types.Alert, error)
+}
+
+// SliceIterator is an [AlertIterator] over an in-memory slice.
+type SliceIterator struct {
+	alerts []*types.Alert
+	pos    int
+}
+
+// NewSliceIterator returns an [AlertIterator] over alerts.
+func NewSliceIterator(alerts []*types.Alert) *SliceIterator {
+	return &SliceIterator{alerts: alerts}
+}
+
+// Next returns the next alert in the slice, or [io.EOF] after the last one.
+func (it *SliceIterator) Next(_ context.Context) (*types.Alert, error) {
+	if it.pos >= len(it.alerts) {
+		return nil, io.EOF
+	}
+
+	alert := it.alerts[it.pos]
+	it.pos++
+
+	return alert, nil
+}
+
+// Checkpointer persists the progress of a [Client.Backfill] so that it can
+// be resumed after a crash. The offset is the number of alerts consumed
+// from the source that have been sent successfully.
+type Checkpointer interface {
+	// Load returns the saved offset, or 0 if there is none.
+	Load() (int64, error)
+
+	// Save persists offset.
+	Save(offset int64) error
+}
+
+// FileCheckpointer is a [Checkpointer] that stores the offset as JSON in a
+// file. Saves are atomic: the file is written to a temporary file in the
+// same directory and renamed into place.
+type FileCheckpointer struct {
+	path string
+}
+
+type fileCheckpoint struct {
+	Offset int64 `json:"offset"`
+}
+
+// NewFileCheckpointer returns a [FileCheckpointer] that stores the offset in
+// the file at path.
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+// Load returns the saved offset, or 0 if the file does not exist.
+func (f *FileCheckpointer) Load() (int64, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint fileCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return 0, fmt.Errorf("failed to parse checkpoint %s: %w", f.path, err)
+	}
+
+	return checkpoint.Offset, nil
+}
+
+// Save atomically writes offset to the file.
+func (f *FileCheckpointer) Save(offset int64) error {
+	data, err := json.Marshal(fileCheckpoint{Offset: offset})
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// BackfillOptions configures [Client.Backfill]. The zero value sends
+// batches of 100 alerts as fast as the API accepts them, without
+// checkpoints.
+type BackfillOptions struct {
+	// BatchSize is the number of alerts per request. The default is 100
+	// and the maximum is 1000.
+	BatchSize int
+
+	// RatePerSecond limits the number of alerts sent per second. Zero
+	// means no limit.
+	RatePerSecond float64
+
+	// Checkpointer, if set, is used to resume from the last saved offset
+	// and to save progress.
+	Checkpointer Checkpointer
+
+	// CheckpointEvery is the number of batches between checkpoints. The
+	// default is 1. A final checkpoint is always saved when the source is
+	// exhausted.
+	CheckpointEvery int
+
+	// Progress, if set, is called after every batch is sent.
+	Progress func(BackfillProgress)
+
+	// SendOptions are applied to every batch request, e.g.
+	// [WithPreservedTimestamps]. A key set with [WithIdempotencyKey] is
+	// suffixed with the offset of each batch, so a resumed backfill sends
+	// its batches with the keys of the first run.
+	SendOptions []SendOption
+}
+
+// BackfillProgress reports the progress of a [Client.Backfill].
+type BackfillProgress struct {
+	// Offset is the number of alerts consumed from the source, including
+	// those skipped when resuming from a checkpoint.
+	Offset int64
+
+	// Sent is the number of alerts sent by this run.
+	Sent int64
+
+	// Resumed is the offset that this run resumed from.
+	Resumed int64
+
+	// Batches is the number of batches sent by this run.
+	Batches int
+
+	// Elapsed is the time since this run started.
+	Elapsed time.Duration
+}
+
+// Backfill streams every alert from source to the API in batches, at the
+// rate set in opts. It is intended for migrating large historical data
+// sets, so alerts bypass [WithDigest] and [WithQuietHours]; severity
+// mapping and metadata limits still apply.
+//
+// When opts.Checkpointer is set, Backfill first skips the alerts before the
+// saved offset, then saves the offset after every opts.CheckpointEvery
+// successful batches. If Backfill fails or the process crashes, calling it
+// again with a fresh source resumes after the last checkpoint. Alerts sent
+// after the last checkpoint are sent again, so the API should deduplicate
+// them, e.g. by CorrelationID.
+//
+// The batches count towards [WithBandwidthLimit], if set.
+//
+// Backfill returns the progress made, together with the first error that
+// stopped it.
+func (c *Client) Backfill(ctx context.Context, source AlertIterator, opts BackfillOptions) (BackfillProgress, error) {
+	var progress BackfillProgress
+
+	if c == nil {
+		return progress, errors.New("alert client is nil")
+	}
+
+	if c.client == nil {
+		return progress, errors.New("client not connected - call Connect() first")
+	}
+
+	if source == nil {
+		return progress, errors.New("backfill source is nil")
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBackfillBatchSize
+	}
+
+	if batchSize > maxBackfillBatchSize {
+		return progress, fmt.Errorf("backfill batch size must not exceed %d", maxBackfillBatchSize)
+	}
+
+	if opts.RatePerSecond < 0 {
+		return progress, errors.New("backfill rate must not be negative")
+	}
+
+	ctx = withBulk(ctx)
+	checkpointEvery := max(opts.CheckpointEvery, 1)
+	sendOpts := newSendOptions(opts.SendOptions)
+	start := time.Now()
+
+	if opts.Checkpointer != nil {
+		offset, err := opts.Checkpointer.Load()
+		if err != nil {
+			return progress, fmt.Errorf("failed to load backfill checkpoint: %w", err)
+		}
+
+		for progress.Offset < offset {
+			if _, err := source.Next(ctx); err != nil {
+				return progress, fmt.Errorf("failed to skip to checkpoint offset %d: %w", offset, err)
+			}
+
+			progress.Offset++
+		}
+
+		progress.Resumed = offset
+	}
+
+	checkpoint := func() error {
+		if opts.Checkpointer == nil {
+			return nil
+		}
+
+		if err := opts.Checkpointer.Save(progress.Offset); err != nil {
+			return fmt.Errorf("failed to save backfill checkpoint: %w", err)
+		}
+
+		return nil
+	}
+
+	for {
+		batch, readErr := nextBatch(ctx, source, batchSize)
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return progress, fmt.Errorf("failed to read backfill source at offset %d: %w", progress.Offset+int64(len(batch)), readErr)
+		}
+
+		if len(batch) > 0 {
+			if opts.RatePerSecond > 0 {
+				due := start.Add(time.Duration(float64(progress.Sent) / opts.RatePerSecond * float64(time.Second)))
+				if err := sleepUntil(ctx, due); err != nil {
+					return progress, err
+				}
+			}
+
+			batch, err := c.prepareAlerts(ctx, batch)
+			if err != nil {
+				return progress, fmt.Errorf("backfill batch at offset %d: %w", progress.Offset, err)
+			}
+
+			if _, err := c.postAlerts(ctx, batch, sendOpts.batchIdempotencyKey(progress.Offset)); err != nil {
+				return progress, fmt.Errorf("backfill batch at offset %d: %w", progress.Offset, err)
+			}
+
+			progress.Offset += int64(len(batch))
+			progress.Sent += int64(len(batch))
+			progress.Batches++
+			progress.Elapsed = time.Since(start)
+
+			if progress.Batches%checkpointEvery == 0 {
+				if err := checkpoint(); err != nil {
+					return progress, err
+				}
+			}
+
+			if opts.Progress != nil {
+				opts.Progress(progress)
+			}
+		}
+
+		if readErr != nil {
+			return progress, checkpoint()
+		}
+	}
+}
+
+// nextBatch reads up to size alerts from source. The returned error is
+// io.EOF if the source was exhausted.
+func nextBatch(ctx context.Context, source AlertIterator, size int) ([]*types.Alert, error) {
+	batch := make([]*types.Alert, 0, size)
+
+	for len(batch) < size {
+		alert, err := source.Next(ctx)
+		if err != nil {
+			return batch, err
+		}
+
+		if alert == nil {
+			return batch, errors.New("backfill source returned a nil alert")
+		}
+
+		batch = append(batch, alert)
+	}
+
+	return batch, nil
+}
+
+// sleepUntil waits until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
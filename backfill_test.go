@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func backfillAlerts(n int) []*types.Alert {
+	alerts := make([]*types.Alert, n)
+	for i := range alerts {
+		alerts[i] = &types.Alert{Header: fmt.Sprintf("alert %d", i)}
+	}
+
+	return alerts
+}
+
+func TestBackfill_SendsInBatches(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var reports []BackfillProgress
+
+	progress, err := client.Backfill(context.Background(), NewSliceIterator(backfillAlerts(25)), BackfillOptions{
+		BatchSize: 10,
+		Progress:  func(p BackfillProgress) { reports = append(reports, p) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if progress.Sent != 25 || progress.Offset != 25 || progress.Batches != 3 {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+
+	batches := rec.received()
+	if len(batches) != 3 || len(batches[0]) != 10 || len(batches[2]) != 5 {
+		t.Fatalf("expected batches of 10, 10 and 5, got %d batches", len(batches))
+	}
+
+	if batches[2][4].Header != "alert 24" {
+		t.Errorf("expected last alert to be 'alert 24', got %q", batches[2][4].Header)
+	}
+
+	if len(reports) != 3 || reports[1].Sent != 20 {
+		t.Errorf("expected 3 progress reports, got %+v", reports)
+	}
+}
+
+func TestBackfill_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+	checkpointer := NewFileCheckpointer(filepath.Join(t.TempDir(), "backfill.json"))
+
+	client := New(rec.URL, WithRetryCount(0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := BackfillOptions{
+		BatchSize:    10,
+		Checkpointer: checkpointer,
+		Progress: func(p BackfillProgress) {
+			// Simulate the API failing after the second batch.
+			if p.Batches == 2 {
+				rec.setStatus(http.StatusInternalServerError)
+			}
+		},
+	}
+
+	progress, err := client.Backfill(context.Background(), NewSliceIterator(backfillAlerts(45)), opts)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if progress.Offset != 20 {
+		t.Errorf("expected offset 20 after failure, got %d", progress.Offset)
+	}
+
+	if saved, _ := checkpointer.Load(); saved != 20 {
+		t.Errorf("expected checkpoint at 20, got %d", saved)
+	}
+
+	rec.setStatus(http.StatusOK)
+	opts.Progress = nil
+
+	progress, err = client.Backfill(context.Background(), NewSliceIterator(backfillAlerts(45)), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if progress.Resumed != 20 || progress.Sent != 25 || progress.Offset != 45 {
+		t.Errorf("unexpected progress after resume: %+v", progress)
+	}
+
+	if saved, _ := checkpointer.Load(); saved != 45 {
+		t.Errorf("expected final checkpoint at 45, got %d", saved)
+	}
+
+	batches := rec.received()
+	if first := batches[2][0].Header; first != "alert 20" {
+		t.Errorf("expected resumed run to start at 'alert 20', got %q", first)
+	}
+}
+
+func TestBackfill_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+
+	// 30 alerts at 300/s in batches of 10: the third batch is due at 66ms.
+	if _, err := client.Backfill(context.Background(), NewSliceIterator(backfillAlerts(30)), BackfillOptions{
+		BatchSize:     10,
+		RatePerSecond: 300,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected rate limit to slow the backfill, took %v", elapsed)
+	}
+}
+
+func TestBackfill_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("http://example.com").Backfill(context.Background(), NewSliceIterator(nil), BackfillOptions{}); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.Backfill(context.Background(), nil, BackfillOptions{}); err == nil {
+		t.Error("expected error for nil source")
+	}
+
+	if _, err := client.Backfill(context.Background(), NewSliceIterator(nil), BackfillOptions{BatchSize: 5000}); err == nil {
+		t.Error("expected error for oversized batch")
+	}
+
+	sourceErr := errors.New("legacy db unavailable")
+
+	_, err := client.Backfill(context.Background(), failingIterator{err: sourceErr}, BackfillOptions{})
+	if !errors.Is(err, sourceErr) {
+		t.Errorf("expected source error, got %v", err)
+	}
+}
+
+func TestFileCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	checkpointer := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	if offset, err := checkpointer.Load(); err != nil || offset != 0 {
+		t.Fatalf("expected offset 0 for missing file, got %d, %v", offset, err)
+	}
+
+	if err := checkpointer.Save(1234); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if offset, err := checkpointer.Load(); err != nil || offset != 1234 {
+		t.Errorf("expected offset 1234, got %d, %v", offset, err)
+	}
+}
+
+type failingIterator struct {
+	err error
+}
+
+func (it failingIterator) Next(context.Context) (*types.Alert, error) {
+	return nil, it.err
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	minBandwidthLimit = 1 << 10
+
+	// minBandwidthChunk is the smallest amount of data a throttled read
+	// transfers at once, however low the limit.
+	minBandwidthChunk = 512
+)
+
+// bandwidthLimiter is a token bucket that caps the combined throughput of
+// bulk requests. Tokens are bytes; the bucket holds a quarter of a second's
+// worth, so transfers are smooth rather than bursty.
+type bandwidthLimiter struct {
+	rate  float64
+	burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newBandwidthLimiter(bytesPerSec int64) *bandwidthLimiter {
+	burst := max(int(bytesPerSec/4), minBandwidthChunk)
+
+	return &bandwidthLimiter{
+		rate:   float64(bytesPerSec),
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleepFor,
+	}
+}
+
+// wait blocks until n bytes may be transferred or ctx is done. The bytes
+// are reserved immediately, so concurrent callers queue up fairly.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := l.now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, float64(l.burst))
+	l.last = now
+	l.tokens -= float64(n)
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	return l.sleep(ctx, delay)
+}
+
+// sleepFor waits for d or until ctx is done.
+func sleepFor(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// transport throttles the request and response bodies of bulk requests,
+// those made with a context returned by withBulk. Other requests, such as
+// regular sends and pings, are not limited.
+func (l *bandwidthLimiter) transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		if !isBulk(ctx) {
+			return next.RoundTrip(req)
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			req = req.Clone(ctx)
+			req.Body = &throttledReader{ctx: ctx, body: req.Body, limiter: l}
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err == nil && resp.Body != nil {
+			resp.Body = &throttledReader{ctx: ctx, body: resp.Body, limiter: l}
+		}
+
+		return resp, err
+	})
+}
+
+// throttledReader reads from body no faster than its limiter allows.
+type throttledReader struct {
+	ctx     context.Context //nolint:containedctx // bounds the waits of a single request body
+	body    io.ReadCloser
+	limiter *bandwidthLimiter
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > t.limiter.burst {
+		p = p[:t.limiter.burst]
+	}
+
+	n, err := t.body.Read(p)
+	if n > 0 {
+		if waitErr := t.limiter.wait(t.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err
+}
+
+func (t *throttledReader) Close() error {
+	return t.body.Close()
+}
+
+type bulkKey struct{}
+
+// withBulk marks requests made with the returned context as part of a bulk
+// operation, subject to [WithBandwidthLimit].
+func withBulk(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bulkKey{}, true)
+}
+
+func isBulk(ctx context.Context) bool {
+	bulk, _ := ctx.Value(bulkKey{}).(bool)
+	return bulk
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBandwidthClock is a clock for a bandwidthLimiter that only moves when
+// the limiter sleeps. It records the waits the limiter asks for.
+type fakeBandwidthClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func newFakeBandwidthClock(l *bandwidthLimiter) *fakeBandwidthClock {
+	clock := &fakeBandwidthClock{now: l.last}
+
+	l.now = func() time.Time {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+
+		return clock.now
+	}
+
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+
+		clock.waits = append(clock.waits, d)
+		clock.now = clock.now.Add(d)
+
+		return nil
+	}
+
+	return clock
+}
+
+// total returns the sum of the recorded waits and empties the record.
+func (c *fakeBandwidthClock) total() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total time.Duration
+	for _, wait := range c.waits {
+		total += wait
+	}
+
+	c.waits = nil
+
+	return total
+}
+
+func TestBandwidthLimiter_Throttles(t *testing.T) {
+	t.Parallel()
+
+	limiter := newBandwidthLimiter(4096)
+	clock := newFakeBandwidthClock(limiter)
+	reader := &throttledReader{ctx: context.Background(), body: io.NopCloser(bytes.NewReader(make([]byte, 2048))), limiter: limiter}
+
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil || n != 2048 {
+		t.Fatalf("expected 2048 bytes, got %d, %v", n, err)
+	}
+
+	// The first 1024 bytes fill the bucket; the rest take 250ms at 4 KiB/s.
+	if len(clock.waits) != 1 || clock.waits[0] != 250*time.Millisecond {
+		t.Errorf("expected a single 250ms wait, got %v", clock.waits)
+	}
+}
+
+func TestBandwidthLimiter_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	limiter := newBandwidthLimiter(minBandwidthLimit)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reader := &throttledReader{ctx: ctx, body: io.NopCloser(bytes.NewReader(make([]byte, 8192))), limiter: limiter}
+
+	if _, err := io.Copy(io.Discard, reader); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+}
+
+func TestBandwidthLimiter_OnlyBulkRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(make([]byte, 2048))
+	}))
+	defer server.Close()
+
+	limiter := newBandwidthLimiter(4096)
+	clock := newFakeBandwidthClock(limiter)
+	transport := limiter.transport(http.DefaultTransport)
+
+	get := func(ctx context.Context) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		response, err := transport.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer response.Body.Close()
+
+		if _, err := io.Copy(io.Discard, response.Body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	get(context.Background())
+
+	if total := clock.total(); total != 0 {
+		t.Errorf("expected regular request not to be throttled, waited %v", total)
+	}
+
+	get(withBulk(context.Background()))
+
+	if total := clock.total(); total < 249*time.Millisecond || total > 251*time.Millisecond {
+		t.Errorf("expected bulk request to wait 250ms in total, waited %v", total)
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// BodyTransformer rewrites an encoded JSON request body before it is sent,
+// for example to encrypt fields or tag the body for legal hold. It must not
+// modify body in place and must be safe for concurrent use. Returning an
+// error fails the request without sending it.
+type BodyTransformer func(ctx context.Context, body []byte) ([]byte, error)
+
+// transformBody runs the transformers registered with [WithBodyTransformer]
+// over body, in the order they were registered.
+func (c *Client) transformBody(ctx context.Context, body []byte) ([]byte, error) {
+	for i, transform := range c.options.bodyTransforms {
+		transformed, err := transform(ctx, body)
+		if err != nil {
+			return nil, fmt.Errorf("body transformer %d failed: %w", i+1, err)
+		}
+
+		body = transformed
+	}
+
+	return body, nil
+}
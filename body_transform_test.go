@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithBodyTransformer(t *testing.T) {
+	t.Parallel()
+
+	bodies := make(chan string, 4)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		bodies <- r.URL.Path + " " + string(body)
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var calls atomic.Int32
+
+	tag := func(prefix string) BodyTransformer {
+		return func(_ context.Context, body []byte) ([]byte, error) {
+			calls.Add(1)
+			return append([]byte(prefix), body...), nil
+		}
+	}
+
+	client := New(server.URL, WithBodyTransformer(tag("a:")), WithBodyTransformer(tag("b:")))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-bodies; !strings.HasPrefix(got, "/alerts b:a:{\"alerts\":[") {
+		t.Errorf("expected the transformers to run in order on the alert body, got %q", got)
+	}
+
+	if _, err := client.RegisterCallback(context.Background(), "https://svc/hooks", []CallbackEvent{EventAlertAcked}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-bodies; !strings.HasPrefix(got, "/callbacks b:a:{") {
+		t.Errorf("expected admin request bodies to be transformed, got %q", got)
+	}
+
+	if _, err := client.SendServerTemplate(context.Background(), "disk-full", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-bodies; got != `/templates/disk-full/send b:a:{"variables":{}}` {
+		t.Errorf("expected template send bodies to be transformed, got %q", got)
+	}
+
+	if got := calls.Load(); got != 6 {
+		t.Errorf("expected each body to be transformed once per transformer, got %d calls", got)
+	}
+}
+
+func TestWithBodyTransformer_Error(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	denied := errors.New("no key available")
+
+	client := connectTo(t, server.URL)
+	client.options.bodyTransforms = []BodyTransformer{
+		func(_ context.Context, body []byte) ([]byte, error) { return bytes.ToUpper(body), nil },
+		func(context.Context, []byte) ([]byte, error) { return nil, denied },
+	}
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertError))
+	if !errors.Is(err, denied) || !strings.Contains(err.Error(), "body transformer 2 failed") {
+		t.Errorf("expected the second transformer's error, got %v", err)
+	}
+
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected nothing to be sent, got %v", got)
+	}
+}
+
+func TestWithBodyTransformer_Nil(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://localhost", WithBodyTransformer(nil))
+
+	if len(client.options.bodyTransforms) != 0 || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil transformer to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+
+	if n := New("http://localhost", WithBodyTransformer(func(_ context.Context, b []byte) ([]byte, error) { return b, nil })).EffectiveConfig().BodyTransformers; n != 1 {
+		t.Errorf("expected the snapshot to count 1 transformer, got %d", n)
+	}
+}
+
+func TestWithBodyTransformer_Derived(t *testing.T) {
+	t.Parallel()
+
+	identity := func(_ context.Context, b []byte) ([]byte, error) { return b, nil }
+
+	parent := New("http://localhost", WithBodyTransformer(identity))
+
+	first, _ := parent.With(context.Background(), WithBodyTransformer(identity))
+	second, _ := parent.With(context.Background(), WithBodyTransformer(identity), WithBodyTransformer(identity))
+
+	if len(parent.options.bodyTransforms) != 1 || len(first.options.bodyTransforms) != 2 || len(second.options.bodyTransforms) != 3 {
+		t.Errorf("expected derived clients to extend their own copy of the transformers")
+	}
+}
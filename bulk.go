@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// bulkEndpoint is appended to the alerts endpoint for bulk state changes.
+const bulkEndpoint = "/bulk"
+
+// BulkOption configures a call to [Client.AckAll] or [Client.ResolveAll].
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	dryRun bool
+}
+
+// DryRun makes [Client.AckAll] and [Client.ResolveAll] count the issues
+// they would change without changing them, to check a filter before a
+// cleanup job runs it for real.
+func DryRun() BulkOption {
+	return func(bo *bulkOptions) {
+		bo.dryRun = true
+	}
+}
+
+// BulkResult is the outcome of [Client.AckAll] or [Client.ResolveAll].
+type BulkResult struct {
+	// Matched is the number of issues the filter selected.
+	Matched int `json:"matched"`
+
+	// Changed is the number of issues whose state changed. Issues that
+	// were already in the new state are not counted. It is 0 in a
+	// [DryRun].
+	Changed int `json:"changed"`
+
+	DryRun bool `json:"dryRun"`
+}
+
+// AckAll acknowledges every open issue with an alert matching filter, for
+// cleanup jobs after an incident storm. The filter must set at least one
+// field other than PageSize; set Since to act on all issues from then on.
+// [Client.Connect] must be called first.
+func (c *Client) AckAll(ctx context.Context, filter AlertFilter, opts ...BulkOption) (*BulkResult, error) {
+	return c.bulkTransition(ctx, "acked", filter, opts)
+}
+
+// ResolveAll resolves every open issue with an alert matching filter. The
+// filter must set at least one field other than PageSize.
+// [Client.Connect] must be called first.
+func (c *Client) ResolveAll(ctx context.Context, filter AlertFilter, opts ...BulkOption) (*BulkResult, error) {
+	return c.bulkTransition(ctx, "resolved", filter, opts)
+}
+
+// bulkTransition moves the issues matching filter to state with PATCH on
+// the bulk endpoint.
+func (c *Client) bulkTransition(ctx context.Context, state string, filter AlertFilter, opts []BulkOption) (*BulkResult, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	filter.PageSize = 0
+
+	query := filter.query()
+	if len(query) == 0 {
+		return nil, errors.New("bulk filter must select something; set Since to act on all issues")
+	}
+
+	var bo bulkOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&bo)
+		}
+	}
+
+	body := struct {
+		State  string `json:"state"`
+		DryRun bool   `json:"dryRun,omitempty"`
+	}{state, bo.dryRun}
+
+	result := &BulkResult{}
+	if err := c.doJSON(ctx, http.MethodPatch, c.options.alertsEndpoint+bulkEndpoint, query, &body, result); err != nil {
+		return nil, err
+	}
+
+	result.DryRun = bo.dryRun
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBulkTransitions(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		var body struct {
+			State  string `json:"state"`
+			DryRun bool   `json:"dryRun"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+" "+body.State)
+
+		result := BulkResult{Matched: 120, Changed: 118}
+		if body.DryRun {
+			result.Changed = 0
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	t.Cleanup(server.Close)
+
+	client := connectTo(t, server.URL)
+	filter := AlertFilter{RouteKey: "payments", Since: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), PageSize: 10}
+
+	result, err := client.AckAll(context.Background(), filter, DryRun())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.DryRun || result.Matched != 120 || result.Changed != 0 {
+		t.Errorf("unexpected dry run result %+v", result)
+	}
+
+	if result, err = client.ResolveAll(context.Background(), filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.DryRun || result.Changed != 118 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	want := []string{
+		"PATCH /alerts/bulk?routeKey=payments&since=2026-10-14T00%3A00%3A00Z acked",
+		"PATCH /alerts/bulk?routeKey=payments&since=2026-10-14T00%3A00%3A00Z resolved",
+	}
+	if len(requests) != 2 || requests[0] != want[0] || requests[1] != want[1] {
+		t.Errorf("expected requests %q, got %q", want, requests)
+	}
+
+	if _, err := client.ResolveAll(context.Background(), AlertFilter{PageSize: 10}); err == nil {
+		t.Error("expected an error for a filter that selects everything")
+	}
+
+	if len(requests) != 2 {
+		t.Errorf("expected no request for an empty filter, got %q", requests[2:])
+	}
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CallbackSignatureHeader is the request header that carries the
+	// signature of a callback, as produced by [SignCallback].
+	CallbackSignatureHeader = "X-Slackmgr-Signature"
+
+	// DefaultCallbackTolerance is how old a signature may be before
+	// [VerifyCallback] rejects it as a replay.
+	DefaultCallbackTolerance = 5 * time.Minute
+
+	// maxCallbackBody is the largest callback body [VerifyCallbacks] reads.
+	maxCallbackBody = 1 << 20
+
+	// minCallbackSecret is the shortest HMAC key, 112 bits, that FIPS 140-3
+	// mode accepts.
+	minCallbackSecret = 14
+)
+
+// ErrInvalidCallbackSignature is returned by [VerifyCallback] when a
+// callback's signature is missing, malformed, too old or does not match.
+var ErrInvalidCallbackSignature = errors.New("invalid callback signature")
+
+// GenerateCallbackSecret returns a random secret for signing callbacks,
+// to be registered with the callback URL and kept by the receiver.
+func GenerateCallbackSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate callback secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// SignCallback returns the [CallbackSignatureHeader] value for a callback
+// with the given body sent at t: "t=<unix seconds>,v1=<signature>", where
+// the signature is the hex-encoded HMAC-SHA256, keyed with secret, of the
+// timestamp, a dot and the body. Binding the timestamp into the signature
+// lets receivers reject replays of old callbacks. The secret must be at
+// least 14 bytes long; when FIPS 140-3 mode is enforced, shorter secrets
+// make SignCallback panic.
+func SignCallback(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + callbackMAC(secret, timestamp, body)
+}
+
+// VerifyCallback checks a [CallbackSignatureHeader] value produced by
+// [SignCallback] for body. It accepts the signature if it was made with any
+// of secrets, so a secret can be rotated without dropping callbacks, and no
+// more than tolerance ago; a non-positive tolerance means
+// [DefaultCallbackTolerance]. Secrets shorter than 14 bytes are skipped.
+// The returned error wraps [ErrInvalidCallbackSignature].
+func VerifyCallback(signature string, body []byte, tolerance time.Duration, secrets ...string) error {
+	if tolerance <= 0 {
+		tolerance = DefaultCallbackTolerance
+	}
+
+	var (
+		timestamp string
+		macs      []string
+	)
+
+	for part := range strings.SplitSeq(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			macs = append(macs, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(macs) == 0 {
+		return fmt.Errorf("%w: expected t=<timestamp>,v1=<signature>", ErrInvalidCallbackSignature)
+	}
+
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp is %s outside the tolerance of %v", ErrInvalidCallbackSignature, age.Round(time.Second), tolerance)
+	}
+
+	checked := 0
+
+	for _, secret := range secrets {
+		if len(secret) < minCallbackSecret {
+			continue
+		}
+
+		checked++
+		want := callbackMAC(secret, timestamp, body)
+
+		for _, mac := range macs {
+			if hmac.Equal([]byte(mac), []byte(want)) {
+				return nil
+			}
+		}
+	}
+
+	if checked == 0 {
+		return fmt.Errorf("%w: secrets must be at least %d bytes", ErrInvalidCallbackSignature, minCallbackSecret)
+	}
+
+	return fmt.Errorf("%w: no signature matches", ErrInvalidCallbackSignature)
+}
+
+// VerifyCallbacks returns a handler that verifies the signature of every
+// request with [VerifyCallback] before passing it to next, with the body
+// restored. Requests with an invalid signature get 401 Unauthorized, and
+// bodies larger than 1 MiB 413 Request Entity Too Large.
+func VerifyCallbacks(next http.Handler, tolerance time.Duration, secrets ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallbackBody))
+		if err != nil {
+			status := http.StatusBadRequest
+
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+
+			http.Error(w, "failed to read callback body", status)
+
+			return
+		}
+
+		if err := VerifyCallback(r.Header.Get(CallbackSignatureHeader), body, tolerance, secrets...); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func callbackMAC(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
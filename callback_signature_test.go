@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCallbackSecret is long enough for FIPS 140-3 mode.
+const testCallbackSecret = "callback-test-secret"
+
+func TestSignCallback_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateCallbackSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body := []byte(`{"type":"alert.created"}`)
+	signature := SignCallback(secret, time.Now(), body)
+
+	if !strings.HasPrefix(signature, "t=") || !strings.Contains(signature, ",v1=") {
+		t.Fatalf("unexpected signature format %q", signature)
+	}
+
+	if err := VerifyCallback(signature, body, 0, "old-callback-secret", secret); err != nil {
+		t.Errorf("expected the signature to verify with the rotated secret, got %v", err)
+	}
+}
+
+func TestVerifyCallback_Rejects(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"type":"alert.created"}`)
+
+	tests := []struct {
+		name      string
+		signature string
+		body      []byte
+	}{
+		{"missing", "", body},
+		{"malformed", "v1=abc", body},
+		{"wrong secret", SignCallback("other-callback-secret", time.Now(), body), body},
+		{"tampered body", SignCallback(testCallbackSecret, time.Now(), body), []byte(`{"type":"alert.acked"}`)},
+		{"too old", SignCallback(testCallbackSecret, time.Now().Add(-10*time.Minute), body), body},
+		{"from the future", SignCallback(testCallbackSecret, time.Now().Add(10*time.Minute), body), body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := VerifyCallback(tt.signature, tt.body, 0, testCallbackSecret); !errors.Is(err, ErrInvalidCallbackSignature) {
+				t.Errorf("expected ErrInvalidCallbackSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyCallbacks(t *testing.T) {
+	t.Parallel()
+
+	handler := VerifyCallbacks(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}), time.Minute, testCallbackSecret)
+
+	body := `{"type":"alert.created"}`
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/callbacks", strings.NewReader(body))
+	req.Header.Set(CallbackSignatureHeader, SignCallback(testCallbackSecret, time.Now(), []byte(body)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("expected the body to reach the handler, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/callbacks", strings.NewReader(body)))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected an unsigned callback to be rejected, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/callbacks", strings.NewReader(strings.Repeat("x", maxCallbackBody+1))))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected an oversized callback to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestVerifyCallback_ShortSecret(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{}`)
+	signature := SignCallback(testCallbackSecret, time.Now(), body)
+
+	if err := VerifyCallback(signature, body, 0, "short", testCallbackSecret); err != nil {
+		t.Errorf("expected the long secret to verify, got %v", err)
+	}
+
+	if err := VerifyCallback(signature, body, 0, "short"); !errors.Is(err, ErrInvalidCallbackSignature) || !strings.Contains(err.Error(), "at least 14 bytes") {
+		t.Errorf("expected short secrets to be rejected, got %v", err)
+	}
+}
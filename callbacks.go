@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const callbacksEndpoint = "callbacks"
+
+// CallbackEvent is a kind of alert lifecycle event that a callback can
+// subscribe to.
+type CallbackEvent string
+
+const (
+	// EventAlertCreated is sent when an alert opens a new issue.
+	EventAlertCreated CallbackEvent = "alert.created"
+
+	// EventAlertAcked is sent when someone acknowledges an alert in Slack.
+	EventAlertAcked CallbackEvent = "alert.acked"
+
+	// EventAlertResolved is sent when an alert's issue is resolved.
+	EventAlertResolved CallbackEvent = "alert.resolved"
+
+	// EventSilenceExpired is sent when a silence ends.
+	EventSilenceExpired CallbackEvent = "silence.expired"
+)
+
+// ValidCallbackEvents returns all events accepted by
+// [Client.RegisterCallback].
+func ValidCallbackEvents() []CallbackEvent {
+	return []CallbackEvent{EventAlertCreated, EventAlertAcked, EventAlertResolved, EventSilenceExpired}
+}
+
+// Callback is a URL registered to receive alert lifecycle events. Secret
+// is only populated in the response of [Client.RegisterCallback]; it cannot
+// be retrieved again later.
+type Callback struct {
+	ID        string          `json:"id"`
+	URL       string          `json:"url"`
+	Events    []CallbackEvent `json:"events"`
+	Secret    string          `json:"secret,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type callbacksList struct {
+	Callbacks []*Callback `json:"callbacks"`
+}
+
+// RegisterCallback subscribes callbackURL, which must be an absolute http
+// or https URL, to the given events. The manager signs every callback with
+// a new secret generated for the registration, returned in the callback's
+// Secret; pass it to [VerifyCallbacks] in the receiving service. It is not
+// shown again. [Client.Connect] must be called first.
+func (c *Client) RegisterCallback(ctx context.Context, callbackURL string, events []CallbackEvent) (*Callback, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if err := validateCallback(callbackURL, events); err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateCallbackSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Callback{URL: callbackURL, Events: events, Secret: secret}
+
+	registered := &Callback{}
+	if err := c.doJSON(ctx, http.MethodPost, callbacksEndpoint, nil, request, registered); err != nil {
+		return nil, err
+	}
+
+	registered.Secret = secret
+
+	return registered, nil
+}
+
+// ListCallbacks returns all registered callbacks, without their secrets.
+func (c *Client) ListCallbacks(ctx context.Context) ([]*Callback, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	var list callbacksList
+	if err := c.doJSON(ctx, http.MethodGet, callbacksEndpoint, nil, nil, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Callbacks, nil
+}
+
+// DeleteCallback unsubscribes the callback with the given ID.
+func (c *Client) DeleteCallback(ctx context.Context, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New("callback ID must not be empty")
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, callbacksEndpoint+"/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// validateCallback checks a registration before it is sent.
+func validateCallback(callbackURL string, events []CallbackEvent) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil || !isHTTPURL(u) {
+		return fmt.Errorf("invalid callback URL %q: must be an absolute http or https URL", sanitizeURL(callbackURL))
+	}
+
+	if len(events) == 0 {
+		return errors.New("callback must subscribe to at least one event")
+	}
+
+	for _, event := range events {
+		if !slices.Contains(ValidCallbackEvents(), event) {
+			return fmt.Errorf("invalid callback event %q", event)
+		}
+	}
+
+	return nil
+}
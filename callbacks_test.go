@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallbacks(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method, path string
+		body         Callback
+	}
+
+	calls := make(chan call, 3)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var body Callback
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls <- call{r.Method, r.URL.EscapedPath(), body}
+
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":"cb1","url":"https://svc.internal/hooks","events":["alert.created"],"createdAt":"2024-01-01T00:00:00Z"}`))
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"callbacks":[{"id":"cb1","url":"https://svc.internal/hooks","events":["alert.created"]}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := connectTo(t, server.URL)
+	ctx := context.Background()
+
+	registered, err := client.RegisterCallback(ctx, "https://svc.internal/hooks", []CallbackEvent{EventAlertCreated})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := <-calls
+	if got.method != http.MethodPost || got.path != "/callbacks" || got.body.URL != "https://svc.internal/hooks" || len(got.body.Secret) != 64 {
+		t.Errorf("unexpected register request %+v", got)
+	}
+
+	if registered.ID != "cb1" || registered.Secret != got.body.Secret {
+		t.Errorf("expected the registration to return the generated secret, got %+v", registered)
+	}
+
+	callbacks, err := client.ListCallbacks(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodGet || len(callbacks) != 1 || callbacks[0].ID != "cb1" {
+		t.Errorf("unexpected list %+v", callbacks)
+	}
+
+	if err := client.DeleteCallback(ctx, "cb/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodDelete || got.path != "/callbacks/cb%2F1" {
+		t.Errorf("unexpected delete request %+v", got)
+	}
+}
+
+func TestRegisterCallback_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := connectTo(t, newAlertRecorder(t).URL)
+
+	tests := []struct {
+		name   string
+		url    string
+		events []CallbackEvent
+		want   string
+	}{
+		{"relative URL", "/hooks", []CallbackEvent{EventAlertAcked}, "absolute http or https URL"},
+		{"other scheme", "ftp://svc/hooks", []CallbackEvent{EventAlertAcked}, "absolute http or https URL"},
+		{"no events", "https://svc/hooks", nil, "at least one event"},
+		{"unknown event", "https://svc/hooks", []CallbackEvent{"alert.exploded"}, `invalid callback event "alert.exploded"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := client.RegisterCallback(context.Background(), tt.url, tt.events); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if err := client.DeleteCallback(context.Background(), " "); err == nil {
+		t.Error("expected an error for an empty ID")
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// PolicyError is returned by [Client.Send] when a client-side policy, such
+// as [WithChannelAllowlist] or [WithSendPolicy], rejects an alert. None of the alerts in the
+// batch are sent. Check for it with errors.As.
+type PolicyError struct {
+	// Policy names the policy that rejected the alert: "channel
+	// allowlist", "channel denylist" or "send policy".
+	Policy string
+
+	// Alert is the index of the rejected alert in the batch.
+	Alert int
+
+	// Channel is the Slack channel ID of the rejected alert.
+	Channel string
+
+	// Reason explains the rejection.
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("alert at index %d rejected by %s: %s", e.Alert, e.Policy, e.Reason)
+}
+
+// channelPolicy restricts the channels alerts may be posted to. Channels
+// are stored normalized; see normalizeChannel.
+type channelPolicy struct {
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+// check returns a [*PolicyError] for the first alert posted to a channel
+// that the policy does not permit.
+func (p *channelPolicy) check(alerts []*types.Alert) error {
+	for i, alert := range alerts {
+		channel := normalizeChannel(alert.SlackChannelID)
+
+		switch {
+		case p.deny[channel] && channel != "":
+			return &PolicyError{Policy: "channel denylist", Alert: i, Channel: alert.SlackChannelID, Reason: fmt.Sprintf("channel %q is denied", alert.SlackChannelID)}
+		case p.allow == nil:
+			continue
+		case channel == "":
+			return &PolicyError{Policy: "channel allowlist", Alert: i, Reason: "alert has no Slack channel ID, so its destination cannot be checked"}
+		case !p.allow[channel]:
+			return &PolicyError{Policy: "channel allowlist", Alert: i, Channel: alert.SlackChannelID, Reason: fmt.Sprintf("channel %q is not allowed", alert.SlackChannelID)}
+		}
+	}
+
+	return nil
+}
+
+// clone returns a deep copy of p, which may be nil.
+func (p *channelPolicy) clone() *channelPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &channelPolicy{allow: maps.Clone(p.allow), deny: maps.Clone(p.deny)}
+}
+
+// allowed returns the sorted allowlist of p, which may be nil.
+func (p *channelPolicy) allowed() []string {
+	if p == nil {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(p.allow))
+}
+
+// denied returns the sorted denylist of p, which may be nil.
+func (p *channelPolicy) denied() []string {
+	if p == nil {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(p.deny))
+}
+
+// addChannels adds channels to *set, creating it if needed. It returns
+// false if no channel is set.
+func addChannels(set *map[string]bool, channels []string) bool {
+	added := false
+
+	for _, channel := range channels {
+		if channel = normalizeChannel(channel); channel != "" {
+			if *set == nil {
+				*set = make(map[string]bool)
+			}
+
+			(*set)[channel] = true
+			added = true
+		}
+	}
+
+	return added
+}
+
+// normalizeChannel trims whitespace and a leading "#" from a channel ID or
+// name and lowercases it, so "#Ops" and "ops" match.
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
+}
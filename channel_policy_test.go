@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func channelAlert(channel string) *types.Alert {
+	alert := types.NewAlert(types.AlertError)
+	alert.SlackChannelID = channel
+	return alert
+}
+
+func TestWithChannelAllowlist(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		alerts  []*types.Alert
+		policy  string
+		index   int
+		allowed bool
+	}{
+		{"allowed", []*types.Alert{channelAlert("C0ALERTS"), channelAlert("#c0oncall")}, "", 0, true},
+		{"not allowed", []*types.Alert{channelAlert("C0ALERTS"), channelAlert("C0CUSTOMERS")}, "channel allowlist", 1, false},
+		{"no channel", []*types.Alert{types.NewAlert(types.AlertError)}, "channel allowlist", 0, false},
+		{"denied", []*types.Alert{channelAlert(" c0exec ")}, "channel denylist", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newAlertRecorder(t)
+
+			client := New(server.URL,
+				WithChannelAllowlist("C0ALERTS", "C0ONCALL", "C0EXEC"),
+				WithChannelDenylist("#C0EXEC"),
+			)
+			if err := client.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+			defer client.Close()
+
+			err := client.Send(context.Background(), tt.alerts...)
+
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if got := server.received(); len(got) != 1 {
+					t.Errorf("expected the batch to be sent, got %v", got)
+				}
+
+				return
+			}
+
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) || policyErr.Policy != tt.policy || policyErr.Alert != tt.index || policyErr.Channel != tt.alerts[tt.index].SlackChannelID {
+				t.Fatalf("expected a %s error for alert %d, got %v", tt.policy, tt.index, err)
+			}
+
+			if got := server.received(); len(got) != 0 {
+				t.Errorf("expected nothing to be sent, got %v", got)
+			}
+		})
+	}
+}
+
+func TestWithChannelDenylist(t *testing.T) {
+	t.Parallel()
+
+	policy := New("http://localhost", WithChannelDenylist("C0EXEC")).options.channels
+
+	if err := policy.check([]*types.Alert{channelAlert("C0ALERTS"), types.NewAlert(types.AlertInfo)}); err != nil {
+		t.Errorf("expected only denied channels to be rejected, got %v", err)
+	}
+
+	if err := policy.check([]*types.Alert{channelAlert("c0exec")}); err == nil || err.Error() != `alert at index 0 rejected by channel denylist: channel "c0exec" is denied` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestWithChannelAllowlist_Options(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://localhost", WithChannelAllowlist("#B", "a"), WithChannelAllowlist(" "), WithChannelDenylist())
+	if len(client.ConfigWarnings()) != 2 {
+		t.Errorf("expected the empty lists to be ignored with warnings, got %v", client.ConfigWarnings())
+	}
+
+	config := client.EffectiveConfig()
+	if !reflect.DeepEqual(config.ChannelAllowlist, []string{"a", "b"}) || config.ChannelDenylist != nil {
+		t.Errorf("unexpected channel lists %v and %v", config.ChannelAllowlist, config.ChannelDenylist)
+	}
+
+	clone := client.options.clone()
+	clone.channels.allow["c"] = true
+
+	if client.options.channels.allow["c"] {
+		t.Error("expected cloned options not to share the allowlist")
+	}
+}
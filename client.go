@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"crypto/fips140"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,35 @@ type Client struct {
 	once       sync.Once
 	connectErr error
 	transport  *http.Transport
+	digest     *digest
+	quietHours *quietHours
+	silences   *silenceCache
+	loops      []*backgroundLoop
+	stopLoops  context.CancelFunc
+	parent     *Client
+	shared     *sharedEntry
+	stats      *clientStats
+	assets     *assetCache
+	sends      *sendTracker
+	reads      flightGroup
+	lookups    *lookupCache
+	zones      *zoneCache
+	acks       *ackTracker
+	repeats    *repeater
+	async      *asyncSender
+	health     *deliveryHealth
+	backend    Transport
+	headers    headerOverrides
+	adaptive   *adaptiveBackoff
+	priority   *prioritySemaphore
+	bandwidth  *bandwidthLimiter
+	rateLimit  *rateLimiter
+	quarantine *quarantine
+	reresolve  *reresolver
+	endpoints  *endpointSet
+
+	// webhookClient overrides the HTTP client used for Slack webhooks.
+	webhookClient *http.Client
 }
 
 type alertsList struct {
@@ -34,7 +64,8 @@ type alertsList struct {
 
 // apiErrorResponse represents the standard error response from the API.
 type apiErrorResponse struct {
-	Error string `json:"error"`
+	Error  string           `json:"error"`
+	Fields []FieldViolation `json:"fields,omitempty"`
 }
 
 // ResponseMetadata contains metadata from the HTTP response returned by [Client.SendWithResponse].
@@ -42,6 +73,15 @@ type ResponseMetadata struct {
 	Duration   time.Duration
 	StatusCode int
 	Headers    map[string]string
+
+	// Attempts holds the timing breakdown of every attempt that received a
+	// response, in order; the last entry belongs to this response.
+	Attempts []RequestTiming
+
+	// Skipped reports that no request was made because every alert was
+	// silenced or held back (see [WithSilences], [WithQuietHours] and
+	// [WithDigest]). The other fields are then zero.
+	Skipped bool
 }
 
 // New creates a new [Client] configured with the given base URL and options.
@@ -56,64 +96,78 @@ func New(baseURL string, opts ...Option) *Client {
 	return &Client{
 		baseURL: baseURL,
 		options: options,
+		stats:   newClientStats(),
+		assets:  newAssetCache(),
+		sends:   &sendTracker{},
 	}
 }
 
-// Connect initializes the HTTP client and validates connectivity by pinging
-// the API. It is safe for concurrent use and only initializes once — if
-// Connect fails, subsequent calls return the same error.
-func (c *Client) Connect(ctx context.Context) error {
-	c.once.Do(func() {
-		if c.baseURL == "" {
-			c.connectErr = errors.New("base URL must be set")
-			return
-		}
+// NewStrict is like [New], but validates the base URL and options
+// immediately instead of at [Client.Connect]. Option values that [New]
+// would silently ignore, such as an out-of-range [WithTimeout], are
+// reported as errors, as are conflicting options such as supplying both
+// [WithBasicAuth] and [WithAuthToken]. No network request is made.
+func NewStrict(baseURL string, opts ...Option) (*Client, error) {
+	c := New(baseURL, opts...)
 
-		if err := c.options.Validate(); err != nil {
-			c.connectErr = fmt.Errorf("invalid options: %w", err)
-			return
-		}
+	if c.baseURL == "" {
+		return nil, errors.New("base URL must be set")
+	}
 
-		// Configure transport with connection pool settings
-		c.transport = &http.Transport{
-			MaxIdleConns:      c.options.maxIdleConns,
-			MaxConnsPerHost:   c.options.maxConnsPerHost,
-			IdleConnTimeout:   c.options.idleConnTimeout,
-			DisableKeepAlives: c.options.disableKeepAlive,
-			TLSClientConfig:   c.options.tlsConfig,
-		}
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
 
-		c.client = resty.New().
-			SetBaseURL(c.baseURL).
-			SetTimeout(c.options.timeout).
-			SetTransport(c.transport).
-			SetRedirectPolicy(resty.FlexibleRedirectPolicy(c.options.maxRedirects)).
-			SetRetryCount(c.options.retryCount).
-			SetRetryWaitTime(c.options.retryWaitTime).
-			SetRetryMaxWaitTime(c.options.retryMaxWaitTime).
-			AddRetryCondition(c.options.retryPolicy).
-			SetRetryAfter(parseRetryAfterHeader).
-			SetLogger(c.options.requestLogger).
-			SetHeader("User-Agent", c.options.userAgent)
+	if !isHTTPURL(u) {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http or https URL", c.baseURL)
+	}
 
-		for key, value := range c.options.requestHeaders {
-			c.client.SetHeader(key, value)
+	if len(c.options.ignored) > 0 {
+		errs := make([]error, len(c.options.ignored))
+		for i, msg := range c.options.ignored {
+			errs[i] = errors.New(msg)
 		}
 
-		if c.options.basicAuthUsername != "" {
-			c.client.SetBasicAuth(c.options.basicAuthUsername, c.options.basicAuthPassword)
-		} else if c.options.authToken != "" {
-			c.client.SetAuthScheme(c.options.authScheme)
-			c.client.SetAuthToken(c.options.authToken)
-		}
+		return nil, fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
 
-		if err := c.ping(ctx); err != nil {
-			c.connectErr = fmt.Errorf("failed to ping alerts API: %w", err)
-			return
-		}
-	})
+	if err := c.options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
 
-	return c.connectErr
+	return c, nil
+}
+
+// ConfigWarnings returns a description of every option value that was
+// ignored when the client was created, such as a retry wait time below the
+// 100ms minimum, in the order the options were applied. The same warnings
+// are logged via the [RequestLogger] when [Client.Connect] is called.
+func (c *Client) ConfigWarnings() []string {
+	if c == nil || c.options == nil || len(c.options.ignored) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), c.options.ignored...)
+}
+
+// Connect initializes the HTTP client and validates connectivity by pinging
+// the API. It is safe for concurrent use and only initializes once — if
+// Connect fails, subsequent calls return the same error. Ignored option
+// values are logged as warnings; see [Client.ConfigWarnings]. Use
+// [Client.ConnectWithRetry] to wait for an API that is not reachable yet.
+func (c *Client) Connect(ctx context.Context) error {
+	return c.connect(ctx, c.ping)
+}
+
+// baseURLHost returns the host of rawURL, or "" if it cannot be parsed.
+func baseURLHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
 }
 
 // Send posts one or more alerts to the API. [Client.Connect] must be called
@@ -127,42 +181,82 @@ func (c *Client) Send(ctx context.Context, alerts ...*types.Alert) error {
 // [Client.Connect] must be called first. Returns an error if the alerts slice is empty or
 // any element is nil. The returned *ResponseMetadata is non-nil whenever an HTTP response
 // was received (even on non-2xx); it is nil only when a network-level error prevents any
-// response from arriving.
+// response from arriving. When no request was made because every alert was held back or
+// silenced, it has [ResponseMetadata.Skipped] set.
 func (c *Client) SendWithResponse(ctx context.Context, alerts ...*types.Alert) (*ResponseMetadata, error) {
+	return c.SendWithOptions(ctx, alerts)
+}
+
+// SendWithOptions is like [Client.SendWithResponse], but applies the given
+// per-call options to the request. Options only affect the request made by
+// this call; alerts held back by [WithDigest] or [WithQuietHours] are sent
+// later without them.
+func (c *Client) SendWithOptions(ctx context.Context, alerts []*types.Alert, opts ...SendOption) (*ResponseMetadata, error) {
 	if c == nil {
 		return nil, errors.New("alert client is nil")
 	}
 
-	if c.client == nil {
-		return nil, errors.New("client not connected - call Connect() first")
+	ctx, span := c.startSpan(ctx, SpanSend, Attribute{Key: attrAlerts, Value: len(alerts)})
+
+	meta, err := c.send(ctx, alerts, opts)
+	span.SetAttributes(sendAttributes(meta)...)
+	span.End(err)
+
+	return meta, err
+}
+
+// Close releases idle connections held by the client. After Close is called
+// the client should not be reused. Close cancels the client's background
+// work, such as periodic digest flushes and silence syncs. It does not wait
+// for sends in flight, and it drops the queue of [WithAsyncMode], pending
+// digests and alerts deferred by quiet hours, logging a warning with their
+// number; call [Client.Shutdown] instead to deliver them before closing.
+// For a client returned by [Shared], Close releases one reference and only
+// closes the client when the last reference is released.
+func (c *Client) Close() {
+	if c.shared != nil && !c.shared.release() {
+		return
 	}
 
-	if len(alerts) == 0 {
-		return nil, errors.New("alerts list cannot be empty")
+	if c.stopLoops != nil {
+		c.stopLoops()
 	}
 
-	for i, alert := range alerts {
-		if alert == nil {
-			return nil, fmt.Errorf("alert at index %d is nil", i)
-		}
+	if c.acks != nil && c.parent == nil {
+		c.acks.close()
 	}
 
-	alertsInput := &alertsList{
-		Alerts: alerts,
+	if c.repeats != nil && c.parent == nil {
+		c.repeats.close()
 	}
 
-	body, err := json.Marshal(alertsInput)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal alerts list: %w", err)
+	if c.async != nil && c.parent == nil {
+		c.async.close()
 	}
 
-	return c.postWithResponse(ctx, c.options.alertsEndpoint, body)
-}
+	for _, loop := range c.loops {
+		loop.shutdown()
+	}
 
-// Close releases idle connections held by the client. After Close is called
-// the client should not be reused.
-func (c *Client) Close() {
-	if c.transport != nil {
+	if c.parent == nil {
+		if n := c.heldBack(); n > 0 {
+			c.options.requestLogger.Warnf("closing alert client drops %d held-back alert(s); call Shutdown to deliver them", n)
+		}
+
+		if c.async != nil {
+			c.async.discard()
+		}
+	}
+
+	if c.backend != nil && c.parent == nil {
+		if err := c.backend.Close(); err != nil {
+			c.options.requestLogger.Errorf("failed to close transport: %v", err)
+		}
+	}
+
+	// The other API methods use the HTTP pool whichever backend sends
+	// the alerts.
+	if c.transport != nil && c.parent == nil {
 		c.transport.CloseIdleConnections()
 	}
 }
@@ -189,8 +283,416 @@ func (c *Client) RestyClient() *resty.Client {
 	return c.client
 }
 
+// send runs [Client.SendWithOptions].
+func (c *Client) send(ctx context.Context, alerts []*types.Alert, opts []SendOption) (*ResponseMetadata, error) {
+	if c.client == nil {
+		return nil, errors.New("client not connected - call Connect() first")
+	}
+
+	if len(alerts) == 0 {
+		return nil, errors.New("alerts list cannot be empty")
+	}
+
+	for i, alert := range alerts {
+		if alert == nil {
+			return nil, fmt.Errorf("alert at index %d is nil", i)
+		}
+	}
+
+	so := newSendOptions(opts)
+
+	if so == nil || !so.prepared {
+		var err error
+		if alerts, err = c.prepareAlerts(ctx, alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	if so != nil && so.ackWithin > 0 {
+		if err := c.checkAckRequest(alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.silences != nil {
+		if alerts = c.silences.filter(alerts, c.logger(ctx)); len(alerts) == 0 {
+			return &ResponseMetadata{Skipped: true}, nil
+		}
+	}
+
+	if c.quietHours != nil {
+		if alerts = c.quietHours.capture(alerts, c.logger(ctx)); len(alerts) == 0 {
+			return &ResponseMetadata{Skipped: true}, nil
+		}
+	}
+
+	if c.digest != nil {
+		if alerts = c.digest.capture(alerts); len(alerts) == 0 {
+			return &ResponseMetadata{Skipped: true}, nil
+		}
+	}
+
+	meta, err := c.postAlerts(ctx, alerts, so)
+	if err == nil && so != nil && so.ackWithin > 0 {
+		c.acks.track(alerts, so.ackWithin)
+	}
+
+	if err == nil && c.repeats != nil {
+		c.repeats.schedule(alerts)
+	}
+
+	return meta, err
+}
+
+// connect runs the initialization of [Client.Connect], checking
+// connectivity with ping.
+func (c *Client) connect(ctx context.Context, ping func(context.Context) error) error {
+	c.once.Do(func() {
+		untraced := ctx
+
+		ctx, span := c.startSpan(ctx, SpanConnect)
+		defer func() { span.End(c.connectErr) }()
+
+		if c.baseURL == "" {
+			c.connectErr = errors.New("base URL must be set")
+			return
+		}
+
+		if err := c.options.Validate(); err != nil {
+			c.connectErr = fmt.Errorf("invalid options: %w", err)
+			return
+		}
+
+		if err := c.options.checkFIPS(fipsBuild, fips140.Enabled()); err != nil {
+			c.connectErr = err
+			return
+		}
+
+		_, sampled := c.options.requestLogger.(*samplingLogger)
+		if c.options.logSampling > 0 && !sampled {
+			sampler := newSamplingLogger(c.options.requestLogger, c.options.logSampling)
+			c.options.requestLogger = sampler
+			c.loops = append(c.loops, startLoop(logSamplingWindow, sampler.state.flush))
+		}
+
+		for _, warning := range c.options.ignored {
+			c.options.requestLogger.Warnf("alert client config: %s", warning)
+		}
+
+		if c.options.lookupTTL > 0 {
+			c.lookups = newLookupCache(c.options.lookupTTL, c.options.lookupStaleTTL, c.options.lookupMaxEntries)
+		}
+
+		if c.options.channelZones {
+			c.zones = newZoneCache()
+		}
+
+		if c.parent != nil {
+			c.connectDerived(ctx)
+			return
+		}
+
+		tlsConfig, err := c.options.transportTLSConfig()
+		if err != nil {
+			c.connectErr = err
+			return
+		}
+
+		// Configure transport with connection pool settings
+		c.transport = &http.Transport{
+			MaxIdleConns:      c.options.maxIdleConns,
+			MaxConnsPerHost:   c.options.maxConnsPerHost,
+			IdleConnTimeout:   c.options.idleConnTimeout,
+			DisableKeepAlives: c.options.disableKeepAlive,
+			TLSClientConfig:   tlsConfig,
+		}
+
+		if c.options.priorityReserve > 0 {
+			c.priority = newPrioritySemaphore(c.options.maxConnsPerHost, c.options.priorityReserve)
+		}
+
+		if c.options.adaptiveBackoff > 0 {
+			c.adaptive = newAdaptiveBackoff(c.options.adaptiveBackoff)
+		}
+
+		if c.options.bandwidthLimit > 0 {
+			c.bandwidth = newBandwidthLimiter(c.options.bandwidthLimit)
+		}
+
+		if c.options.quarantineStore != nil {
+			c.quarantine = newQuarantine(c.options.quarantineStore, c.options.quarantineLimit, c.options.requestLogger)
+		}
+
+		if c.options.rateLimit > 0 {
+			c.rateLimit = newRateLimiter(c.options.rateLimit, c.options.rateLimitBurst)
+		}
+
+		if c.options.reresolve {
+			c.reresolve = newReresolver(c.transport, c.options.requestLogger)
+		}
+
+		if c.options.discovery != nil {
+			c.endpoints = newEndpointSet(c.options.discovery, c.baseURL)
+
+			if err := c.endpoints.refresh(ctx); err != nil {
+				c.connectErr = fmt.Errorf("failed to discover API endpoints: %w", err)
+				return
+			}
+		}
+
+		c.client = c.newRestyClient(c.transport) //nolint:contextcheck // its hooks use the context of each request
+		c.backend = c.newBackend()
+
+		if err := ping(ctx); err != nil {
+			c.connectErr = fmt.Errorf("failed to ping alerts API: %w", err)
+			return
+		}
+
+		// The background loops keep the values of ctx, such as logging
+		// fields, but run until Close rather than until ctx is done, and
+		// outside the connect span.
+		loopCtx, stopLoops := context.WithCancel(context.WithoutCancel(untraced))
+		c.stopLoops = stopLoops
+
+		if c.options.discoveryRefresh > 0 {
+			c.loops = append(c.loops, startLoop(c.options.discoveryRefresh, func() {
+				if err := c.endpoints.refresh(loopCtx); err != nil {
+					c.options.requestLogger.Errorf("failed to refresh API endpoints: %v", err)
+				}
+			}))
+		}
+
+		if c.options.ackEscalation != nil || c.options.repeatInterval > 0 {
+			c.acks = newAckTracker(loopCtx, c.options.ackEscalation)
+		}
+
+		if c.options.repeatInterval > 0 {
+			c.repeats = newRepeater(loopCtx, c, c.options.repeatInterval, c.options.repeatMax, c.options.repeatUntil)
+		}
+
+		if c.options.asyncQueueSize > 0 {
+			c.async = newAsyncSender(loopCtx, c, c.options.asyncQueueSize, c.options.asyncInterval)
+		}
+
+		if c.options.deliveryHealth != nil {
+			c.health = newDeliveryHealth(*c.options.deliveryHealth)
+			c.loops = append(c.loops, startLoop(deliveryHealthCheckInterval, func() {
+				c.checkDeliveryHealth(loopCtx)
+			}))
+		}
+
+		if len(c.options.localSilences) > 0 || c.options.silenceSync > 0 {
+			c.silences = newSilenceCache(c.options.localSilences, c.options.silencePolicy)
+		}
+
+		if c.options.silenceSync > 0 {
+			c.syncSilences(ctx)
+			c.loops = append(c.loops, startLoop(c.options.silenceSync, func() {
+				c.syncSilences(loopCtx)
+			}))
+		}
+
+		if len(c.options.quietHoursRules) > 0 {
+			c.quietHours = newQuietHours(c.options.quietHoursRules)
+			c.loops = append(c.loops, startLoop(quietHoursCheckInterval, func() {
+				if err := c.releaseQuietHours(loopCtx); err != nil {
+					c.options.requestLogger.Errorf("%v", err)
+				}
+			}))
+		}
+
+		if c.options.digestSelector != nil {
+			c.digest = newDigest(c.options.digestSelector)
+			c.loops = append(c.loops, startLoop(c.options.digestInterval, func() {
+				if err := c.Flush(loopCtx); err != nil {
+					c.options.requestLogger.Errorf("failed to post alert digest: %v", err)
+				}
+			}))
+		}
+	})
+
+	return c.connectErr
+}
+
+// newRestyClient returns a resty client configured from the client's
+// options, sending requests through transport.
+func (c *Client) newRestyClient(transport http.RoundTripper) *resty.Client {
+	retryAfter := parseRetryAfterHeader
+
+	if c.options.metrics != nil {
+		transport = metricsTransport(c.options.metrics, transport)
+	}
+
+	if c.rateLimit != nil {
+		transport = c.rateLimit.transport(transport)
+	}
+
+	if c.endpoints != nil {
+		transport = c.endpoints.transport(transport)
+	}
+
+	if c.reresolve != nil {
+		transport = c.reresolve.transport(transport)
+	}
+
+	if c.bandwidth != nil {
+		transport = c.bandwidth.transport(transport)
+	}
+
+	if c.adaptive != nil {
+		transport = c.adaptive.transport(transport)
+		retryAfter = c.adaptive.retryAfter(c.options.retryWaitTime, c.options.retryMaxWaitTime)
+	}
+
+	if auth := c.options.authenticatorOrNil(); auth != nil {
+		transport = authTransport(auth, baseURLHost(c.baseURL), transport)
+	}
+
+	retries := c.newRetrier(retryAfter)
+	bounds := retries.bounds()
+
+	if c.adaptive != nil {
+		bounds.MaxWaitTime = time.Duration(float64(bounds.MaxWaitTime) * c.adaptive.maxMultiplier)
+	}
+
+	delay := c.retryDelayFunc()
+	if delay != nil {
+		bounds.WaitTime = 0
+	}
+
+	client := resty.New().
+		SetBaseURL(c.baseURL).
+		SetTimeout(c.options.timeout).
+		SetTransport(transport).
+		SetRedirectPolicy(resty.FlexibleRedirectPolicy(c.options.maxRedirects)).
+		SetRetryCount(bounds.Count).
+		SetRetryWaitTime(bounds.WaitTime).
+		SetRetryMaxWaitTime(bounds.MaxWaitTime).
+		AddRetryCondition(retries.condition).
+		SetRetryAfter(withRetryDelay(retries.retryAfter, delay)).
+		SetLogger(c.options.requestLogger).
+		SetHeader("User-Agent", c.options.userAgent)
+
+	for key, value := range c.options.requestHeaders {
+		client.SetHeader(key, value)
+	}
+
+	if c.options.cookieJar != nil {
+		client.SetCookieJar(c.options.cookieJar)
+	}
+
+	client.EnableTrace().OnAfterResponse(c.observeResponse)
+
+	if c.options.tracer != nil {
+		client.AddRetryHook(traceRetry)
+	}
+
+	if c.options.metrics != nil {
+		client.AddRetryHook(metricsRetry(c.options.metrics))
+	}
+
+	client.SetPreRequestHook(func(_ *resty.Client, request *http.Request) error {
+		c.headers.apply(request)
+
+		if c.options.tracer != nil {
+			c.options.tracer.Inject(request.Context(), request.Header)
+		}
+
+		attachReplayBody(request)
+
+		if c.options.progress != nil {
+			trackProgress(request, c.options.progress)
+		}
+
+		return nil
+	})
+
+	if c.options.contextFields != nil {
+		client.OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			request.SetLogger(c.logger(request.Context()))
+			return nil
+		})
+	}
+
+	return client
+}
+
+// prepareAlerts applies the incident of ctx, severity mapping, runbooks,
+// the theme, send policies, channel policies, timestamps, plain-text
+// fallbacks, PII masking and metadata size limits to alerts before they
+// are sent. It returns the alerts to send, which differ from alerts if one
+// was linked, styled, modified or given a fallback text.
+func (c *Client) prepareAlerts(ctx context.Context, alerts []*types.Alert) ([]*types.Alert, error) {
+	alerts = linkIncident(ctx, alerts)
+
+	if c.options.severityMapper != nil {
+		if err := c.options.severityMapper.apply(alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.options.runbooks != nil {
+		alerts = c.attachRunbooks(ctx, alerts)
+	}
+
+	if len(c.options.theme) > 0 {
+		alerts = c.applyTheme(alerts)
+	}
+
+	if len(c.options.sendPolicies) > 0 {
+		var err error
+		if alerts, err = c.applySendPolicies(ctx, alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.options.channels != nil {
+		if err := c.options.channels.check(alerts); err != nil {
+			return nil, err
+		}
+	}
+
+	alerts = c.renderTimestamps(ctx, alerts)
+
+	if c.options.plainText {
+		alerts = addPlainTextFallbacks(alerts)
+	}
+
+	if c.options.piiMasker != nil {
+		c.options.piiMasker.apply(alerts)
+	}
+
+	if c.options.maxMetadataSize > 0 {
+		if err := checkMetadataSize(alerts, c.options.maxMetadataSize); err != nil {
+			return nil, err
+		}
+	}
+
+	return alerts, nil
+}
+
 func (c *Client) ping(ctx context.Context) error {
-	return c.get(ctx, c.options.pingEndpoint)
+	return c.backend.Ping(ctx)
+}
+
+// retryStrategy returns the strategy set with [WithRetryStrategy], or one
+// running the retry condition.
+func (c *Client) retryStrategy() RetryStrategy {
+	if c.options.retryStrategy != nil {
+		return c.options.retryStrategy
+	}
+
+	return conditionStrategy(c.options.retryPolicy)
+}
+
+// newBackend returns the [Transport] set with [WithTransport], or an HTTP
+// transport using this client's resty client.
+func (c *Client) newBackend() Transport { //nolint:ireturn // the transport is pluggable
+	if c.options.backend != nil {
+		return c.options.backend
+	}
+
+	return &httpTransport{c: c}
 }
 
 func (c *Client) get(ctx context.Context, path string) error {
@@ -201,34 +703,210 @@ func (c *Client) get(ctx context.Context, path string) error {
 		return fmt.Errorf("GET %s failed: %w", path, err)
 	}
 
+	c.observeDeprecation(ctx, "GET "+path, response)
+
 	if !response.IsSuccess() {
 		return fmt.Errorf("GET %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
 	}
 
+	c.onSuccess(response)
+
 	return nil
 }
 
-func (c *Client) postWithResponse(ctx context.Context, path string, body []byte) (*ResponseMetadata, error) {
+// encodeBatch encodes alerts for sending, encrypting fields and running
+// the body transformers, and returns the headers to send with the body.
+func (c *Client) encodeBatch(ctx context.Context, alerts []*types.Alert) ([]byte, http.Header, error) {
+	body, err := encodeAlerts(alerts, c.options.alertSchema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal alerts list: %w", err)
+	}
+
+	body, header, err := c.encryptFields(ctx, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt alert fields: %w", err)
+	}
+
+	if body, err = c.transformBody(ctx, body); err != nil {
+		return nil, nil, err
+	}
+
+	return body, header, nil
+}
+
+func (c *Client) postAlerts(ctx context.Context, alerts []*types.Alert, so *sendOptions) (*ResponseMetadata, error) {
+	alerts = c.convertSnippets(ctx, alerts)
+
+	body, header, err := c.encodeBatch(ctx, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	high := highPriority(alerts)
+	if high {
+		c.sends.start()
+		defer c.sends.done()
+	}
+
+	if c.priority != nil {
+		if err := c.priority.acquire(ctx, high); err != nil {
+			return nil, fmt.Errorf("failed waiting for a request slot: %w", err)
+		}
+		defer c.priority.release()
+	}
+
+	var key string
+
+	batch := &Batch{Alerts: alerts, Body: body}
+	if so != nil {
+		batch.Params = so.query
+		key = so.idempotencyKey
+	}
+
+	batch.Header = withIdempotencyKey(header, key)
+
+	start := time.Now()
+	meta, err := c.backend.SendBatch(ctx, batch)
+
+	// Neither cancellation nor a local credential failure says anything
+	// about the health of delivery.
+	if c.health != nil && ctx.Err() == nil && !isAuthError(err) {
+		c.health.record(err != nil)
+	}
+
+	// No response at all means the API is unreachable, unless the request
+	// was never sent for lack of credentials.
+	if err != nil && meta == nil && ctx.Err() == nil && !isAuthError(err) && (c.options.fallbackWebhook != "" || c.options.emailFallback != nil) {
+		err = c.deliverFallback(ctx, alerts, err)
+	}
+
+	c.audit(ctx, AuditSend, c.options.alertsEndpoint, len(alerts), meta.status(), start, err)
+
+	if err != nil && c.options.schemaRepair {
+		if repaired, n := repairAlerts(alerts, err); repaired != nil {
+			c.logger(ctx).Warnf("trimmed %d field(s) the API rejected as too long, sending the alerts again: %v", n, err)
+			return c.postAlerts(ctx, repaired, so.repairedIdempotencyKey())
+		}
+	}
+
+	return meta, err
+}
+
+func (c *Client) postWithResponse(ctx context.Context, path string, body []byte, so *sendOptions) (*ResponseMetadata, error) {
+	ctx, timings := withAttemptTimings(ctx)
+
 	request := c.client.R().SetContext(ctx).SetBody(body)
+	so.apply(request)
 
 	response, err := request.Post(path)
 	if err != nil {
 		return nil, fmt.Errorf("POST %s failed: %w", path, err)
 	}
 
+	c.observeDeprecation(ctx, "POST "+path, response)
+
 	meta := &ResponseMetadata{
 		Duration:   response.Time(),
 		StatusCode: response.StatusCode(),
 		Headers:    flattenHeaders(response.Header()),
+		Attempts:   timings.list(),
 	}
 
 	if !response.IsSuccess() {
-		return meta, fmt.Errorf("POST %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+		err := fmt.Errorf("POST %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+		if violations := fieldViolations(response.StatusCode(), response.Body()); len(violations) > 0 {
+			return meta, newFieldValidationError(err.Error(), response.StatusCode(), violations)
+		}
+
+		return meta, err
 	}
 
+	c.onSuccess(response)
+
 	return meta, nil
 }
 
+// onSuccess runs the hooks registered for successful responses.
+func (c *Client) onSuccess(response *resty.Response) {
+	if c.options.headerCallback != nil {
+		c.options.headerCallback(response.Header().Clone())
+	}
+}
+
+// checkConnected returns an error if the client is nil or
+// [Client.Connect] has not been called successfully.
+func (c *Client) checkConnected() error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	if c.client == nil {
+		return errors.New("client not connected - call Connect() first")
+	}
+
+	return nil
+}
+
+// doJSON sends a request with an optional JSON body and query, and decodes
+// the JSON response body into out unless out is nil. Writes, other than
+// previews, are recorded with [WithAuditSink].
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	start := time.Now()
+	status, err := c.doJSONRequest(ctx, method, path, query, body, out)
+
+	if action := auditAction(method); action != "" && path != previewEndpoint {
+		c.audit(ctx, action, path, 0, status, start, err)
+	}
+
+	return err
+}
+
+// doJSONRequest implements doJSON, returning the status code of the
+// response, or 0 if none arrived.
+func (c *Client) doJSONRequest(ctx context.Context, method, path string, query url.Values, body, out any) (int, error) {
+	request := c.client.R().SetContext(ctx)
+
+	if len(query) > 0 {
+		request.SetQueryParamsFromValues(query)
+	}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal %s %s request: %w", method, path, err)
+		}
+
+		if data, err = c.transformBody(ctx, data); err != nil {
+			return 0, fmt.Errorf("%s %s failed: %w", method, path, err)
+		}
+
+		request.SetBody(data)
+	}
+
+	response, err := request.Execute(method, path)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+
+	c.observeDeprecation(ctx, method+" "+path, response)
+
+	if !response.IsSuccess() {
+		return response.StatusCode(), fmt.Errorf("%s %s failed with status code %d: %s", method, sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+	}
+
+	c.onSuccess(response)
+
+	if out == nil || len(response.Body()) == 0 {
+		return response.StatusCode(), nil
+	}
+
+	if err := c.decodeResponse(response.Header().Get("Content-Type"), response.Body(), out); err != nil {
+		return response.StatusCode(), fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+
+	return response.StatusCode(), nil
+}
+
 func flattenHeaders(h http.Header) map[string]string {
 	headers := make(map[string]string, len(h))
 	for key, values := range h {
@@ -253,6 +931,11 @@ func getBodyErrorMessage(response *resty.Response) string {
 	return string(body)
 }
 
+// isHTTPURL reports whether u is an absolute http or https URL.
+func isHTTPURL(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // sanitizeURL removes credentials (user info) from URLs to prevent leaking in logs.
 func sanitizeURL(rawURL string) string {
 	parsed, err := url.Parse(rawURL)
@@ -3,10 +3,14 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,6 +36,157 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewStrict(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewStrict("https://example.com", WithRetryCount(5), WithTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.options.retryCount != 5 {
+		t.Errorf("expected retryCount=5, got %d", client.options.retryCount)
+	}
+
+	if client.client != nil {
+		t.Error("expected NewStrict not to connect")
+	}
+}
+
+func TestNewStrict_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name:    "empty URL",
+			baseURL: "",
+			wantErr: "base URL must be set",
+		},
+		{
+			name:    "relative URL",
+			baseURL: "example.com/api",
+			wantErr: "must be an absolute http or https URL",
+		},
+		{
+			name:    "unsupported scheme",
+			baseURL: "ftp://example.com",
+			wantErr: "must be an absolute http or https URL",
+		},
+		{
+			name:    "out of range timeout",
+			baseURL: "https://example.com",
+			opts:    []Option{WithTimeout(0)},
+			wantErr: "WithTimeout(0s) ignored: must be between 1s and 5m0s",
+		},
+		{
+			name:    "negative retry count",
+			baseURL: "https://example.com",
+			opts:    []Option{WithRetryCount(-1)},
+			wantErr: "WithRetryCount(-1) ignored: must be non-negative",
+		},
+		{
+			name:    "protected header",
+			baseURL: "https://example.com",
+			opts:    []Option{WithRequestHeader("Content-Type", "text/plain")},
+			wantErr: "WithRequestHeader(Content-Type) ignored: protected header cannot be overridden",
+		},
+		{
+			name:    "both auth methods",
+			baseURL: "https://example.com",
+			opts:    []Option{WithBasicAuth("user", "pass"), WithAuthToken("token")},
+			wantErr: "cannot use both basic auth and token auth",
+		},
+		{
+			name:    "retry max wait below wait time",
+			baseURL: "https://example.com",
+			opts:    []Option{WithRetryWaitTime(time.Second), WithRetryMaxWaitTime(500 * time.Millisecond)},
+			wantErr: "invalid options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewStrict(tt.baseURL, tt.opts...)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if client != nil {
+				t.Error("expected nil client on error")
+			}
+
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error to contain %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewStrict_ReportsAllIgnoredOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStrict("https://example.com", WithTimeout(0), WithMaxIdleConns(0))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	for _, want := range []string{"WithTimeout", "WithMaxIdleConns"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got: %v", want, err)
+		}
+	}
+}
+
+func TestConfigWarnings(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com", WithRetryWaitTime(50*time.Millisecond), WithMaxRedirects(-1))
+
+	want := []string{
+		"WithRetryWaitTime(50ms) ignored: must be at least 100ms",
+		"WithMaxRedirects(-1) ignored: must be between 0 and 20",
+	}
+
+	got := client.ConfigWarnings()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected warnings %q, got %q", want, got)
+	}
+
+	got[0] = "modified"
+	if client.ConfigWarnings()[0] != want[0] {
+		t.Error("expected ConfigWarnings to return a copy")
+	}
+
+	if warnings := New("http://example.com").ConfigWarnings(); warnings != nil {
+		t.Errorf("expected no warnings, got %q", warnings)
+	}
+}
+
+func TestConnect_LogsConfigWarnings(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+	logger := &recordingLogger{}
+
+	client := New(rec.URL, WithRequestLogger(logger), WithRetryWaitTime(50*time.Millisecond))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "alert client config: WithRetryWaitTime(50ms) ignored: must be at least 100ms"
+	if warnings := logger.warnings(); !slices.Contains(warnings, want) {
+		t.Errorf("expected warning %q to be logged, got %q", want, warnings)
+	}
+}
+
 func TestConnect_EmptyURL(t *testing.T) {
 	t.Parallel()
 
@@ -76,6 +231,7 @@ func TestConnect_PingFailure(t *testing.T) {
 	defer server.Close()
 
 	client := New(server.URL)
+	setRetryDelay(t, client, noRetryDelay)
 
 	err := client.Connect(context.Background())
 
@@ -328,6 +484,60 @@ func TestSend_Success(t *testing.T) {
 	}
 }
 
+func TestSend_ResponseHeaderCallback(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Server-Version", "2.1.0")
+
+		if r.Header.Get("X-Fail") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var (
+		mu       sync.Mutex
+		versions []string
+	)
+
+	client := New(server.URL, WithResponseHeaderCallback(func(h http.Header) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		versions = append(versions, h.Get("X-Server-Version"))
+	}))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	failing, err := client.With(context.Background(), WithRequestHeader("X-Fail", "1"), WithRetryCount(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := failing.Send(context.Background(), &types.Alert{Header: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// One call for the ping and one for the successful send; failed
+	// requests do not invoke the callback.
+	if len(versions) != 2 || versions[0] != "2.1.0" || versions[1] != "2.1.0" {
+		t.Errorf("expected callback for ping and send, got %v", versions)
+	}
+}
+
 func TestSend_HTTPError_JSONErrorResponse(t *testing.T) {
 	t.Parallel()
 
@@ -1159,3 +1369,83 @@ func makeRestyRequest(t *testing.T, url string) *resty.Response {
 
 	return resp
 }
+
+// alertRecorder is a test server that answers pings and records every batch
+// of alerts posted to the alerts endpoint.
+type alertRecorder struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	batches [][]*types.Alert
+	status  int
+}
+
+// newAlertRecorder starts an alertRecorder that responds with 200 OK until
+// setStatus is called. The server is closed when the test finishes.
+func newAlertRecorder(t *testing.T) *alertRecorder {
+	t.Helper()
+
+	rec := &alertRecorder{status: http.StatusOK}
+
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var input alertsList
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		if rec.status == http.StatusOK {
+			rec.batches = append(rec.batches, input.Alerts)
+		}
+
+		w.WriteHeader(rec.status)
+	}))
+
+	t.Cleanup(rec.Close)
+
+	return rec
+}
+
+func (r *alertRecorder) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status = status
+}
+
+func (r *alertRecorder) received() [][]*types.Alert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([][]*types.Alert(nil), r.batches...)
+}
+
+// recordingLogger is a RequestLogger that records warnings.
+type recordingLogger struct {
+	NoopLogger
+
+	mu   sync.Mutex
+	warn []string
+}
+
+func (l *recordingLogger) Warnf(format string, v ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.warn = append(l.warn, fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) warnings() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.warn...)
+}
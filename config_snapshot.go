@@ -0,0 +1,391 @@
+package client
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret values in a [ConfigSnapshot].
+const redacted = "[REDACTED]"
+
+// ConfigSnapshot is the resolved configuration of a [Client], as returned by
+// [Client.EffectiveConfig]. Secrets such as auth tokens, passwords and
+// sensitive header values are redacted, so a snapshot is safe to log or
+// attach to a bug report. Durations are encoded as strings such as "500ms"
+// when marshaled to JSON.
+type ConfigSnapshot struct {
+	BaseURL             string            `json:"baseUrl"`
+	RetryCount          int               `json:"retryCount"`
+	RetryWaitTime       time.Duration     `json:"retryWaitTime"`
+	RetryMaxWaitTime    time.Duration     `json:"retryMaxWaitTime"`
+	RetryPolicy         string            `json:"retryPolicy"`
+	EndpointRetryCounts map[string]int    `json:"endpointRetryCounts,omitempty"`
+	RequestLogger       string            `json:"requestLogger"`
+	LogSampling         int               `json:"logSampling"`
+	RequestHeaders      map[string]string `json:"requestHeaders"`
+	Auth                string            `json:"auth"`
+	AuthScheme          string            `json:"authScheme,omitempty"`
+	Authenticator       string            `json:"authenticator,omitempty"`
+	AuthToken           string            `json:"authToken,omitempty"`
+	BasicAuthUsername   string            `json:"basicAuthUsername,omitempty"`
+	BasicAuthPassword   string            `json:"basicAuthPassword,omitempty"`
+	Timeout             time.Duration     `json:"timeout"`
+	UserAgent           string            `json:"userAgent"`
+	MaxIdleConns        int               `json:"maxIdleConns"`
+	MaxConnsPerHost     int               `json:"maxConnsPerHost"`
+	IdleConnTimeout     time.Duration     `json:"idleConnTimeout"`
+	DisableKeepAlive    bool              `json:"disableKeepAlive"`
+	MaxRedirects        int               `json:"maxRedirects"`
+	CustomTLSConfig     bool              `json:"customTlsConfig"`
+	CustomCookieJar     bool              `json:"customCookieJar"`
+	Transport           string            `json:"transport"`
+	AlertsEndpoint      string            `json:"alertsEndpoint"`
+	PingEndpoint        string            `json:"pingEndpoint"`
+	AuditEndpoint       string            `json:"auditEndpoint"`
+	DigestInterval      time.Duration     `json:"digestInterval"`
+	QuietHoursRules     int               `json:"quietHoursRules"`
+	LocalSilences       int               `json:"localSilences"`
+	SilenceSyncInterval time.Duration     `json:"silenceSyncInterval"`
+	SilencePolicy       string            `json:"silenceConflictPolicy"`
+	LookupCacheTTL      time.Duration     `json:"lookupCacheTtl"`
+	LookupCacheStaleTTL time.Duration     `json:"lookupCacheStaleTtl"`
+	LookupCacheEntries  int               `json:"lookupCacheMaxEntries"`
+	SlowThreshold       time.Duration     `json:"slowThreshold"`
+	FallbackWebhook     string            `json:"fallbackWebhook,omitempty"`
+	EmailFallback       string            `json:"emailFallback,omitempty"`
+	HealthFailureRate   float64           `json:"deliveryHealthFailureRate,omitempty"`
+	HealthMinutes       int               `json:"deliveryHealthMinutes,omitempty"`
+	HealthChannel       string            `json:"deliveryHealthChannel,omitempty"`
+	HealthWebhook       string            `json:"deliveryHealthWebhook,omitempty"`
+	AuditSink           bool              `json:"auditSink"`
+	ContextFields       bool              `json:"contextFields"`
+	HeaderCallback      bool              `json:"headerCallback"`
+	UploadProgress      bool              `json:"uploadProgress"`
+	SeverityMapping     string            `json:"severityMapping"`
+	AlertSchema         int               `json:"alertSchema"`
+	MaxMetadataSize     int               `json:"maxMetadataSize"`
+	BodySpoolThreshold  int64             `json:"bodySpoolThreshold"`
+	BandwidthLimit      int64             `json:"bandwidthLimit"`
+	AdaptiveBackoff     float64           `json:"adaptiveBackoff"`
+	PriorityReservation float64           `json:"priorityReservation"`
+	ReresolveOnFailure  bool              `json:"reresolveOnFailure"`
+	EndpointDiscovery   bool              `json:"endpointDiscovery"`
+	DiscoveryRefresh    time.Duration     `json:"discoveryRefreshInterval"`
+	BodyTransformers    int               `json:"bodyTransformers"`
+	EncryptedFields     []string          `json:"encryptedFields,omitempty"`
+	PIIMaskRules        []string          `json:"piiMaskRules,omitempty"`
+	ChannelAllowlist    []string          `json:"channelAllowlist,omitempty"`
+	ChannelDenylist     []string          `json:"channelDenylist,omitempty"`
+	SendPolicies        int               `json:"sendPolicies"`
+	SnippetThreshold    int               `json:"snippetThreshold"`
+	Theme               Theme             `json:"theme,omitempty"`
+	PlainTextFallback   bool              `json:"plainTextFallback"`
+	ChannelTimeZones    bool              `json:"channelTimeZones"`
+	TimeZoneFallback    string            `json:"timeZoneFallback,omitempty"`
+	TimestampLayout     string            `json:"timestampLayout"`
+	RunbookResolver     bool              `json:"runbookResolver"`
+	AckEscalation       bool              `json:"ackEscalation"`
+	RepeatInterval      time.Duration     `json:"repeatInterval"`
+	RepeatMax           int               `json:"repeatMax"`
+	RepeatCondition     bool              `json:"repeatCondition"`
+	Tracing             bool              `json:"tracing"`
+	Metrics             bool              `json:"metrics"`
+	AsyncQueueSize      int               `json:"asyncQueueSize"`
+	AsyncFlushInterval  time.Duration     `json:"asyncFlushInterval"`
+	AsyncErrorHandler   bool              `json:"asyncErrorHandler"`
+	AsyncResultCallback bool              `json:"asyncResultCallback"`
+	RateLimit           float64           `json:"rateLimit"`
+	RateLimitBurst      int               `json:"rateLimitBurst"`
+	QuarantineAttempts  int               `json:"quarantineAttempts"`
+	QuarantineStore     string            `json:"quarantineStore,omitempty"`
+	SchemaRepair        bool              `json:"schemaRepair"`
+	StrictResponses     bool              `json:"strictResponses"`
+	Warnings            []string          `json:"warnings,omitempty"`
+}
+
+// MarshalJSON encodes the snapshot with durations rendered as strings.
+func (s ConfigSnapshot) MarshalJSON() ([]byte, error) {
+	type plain ConfigSnapshot
+
+	return json.Marshal(&struct {
+		plain
+
+		RetryWaitTime    string `json:"retryWaitTime"`
+		RetryMaxWaitTime string `json:"retryMaxWaitTime"`
+		Timeout          string `json:"timeout"`
+		IdleConnTimeout  string `json:"idleConnTimeout"`
+		DigestInterval   string `json:"digestInterval"`
+		SilenceSync      string `json:"silenceSyncInterval"`
+		LookupTTL        string `json:"lookupCacheTtl"`
+		LookupStaleTTL   string `json:"lookupCacheStaleTtl"`
+		SlowRequest      string `json:"slowThreshold"`
+		DiscoveryRefresh string `json:"discoveryRefreshInterval"`
+		RepeatInterval   string `json:"repeatInterval"`
+		AsyncFlush       string `json:"asyncFlushInterval"`
+	}{
+		plain:            plain(s),
+		RetryWaitTime:    s.RetryWaitTime.String(),
+		RetryMaxWaitTime: s.RetryMaxWaitTime.String(),
+		Timeout:          s.Timeout.String(),
+		IdleConnTimeout:  s.IdleConnTimeout.String(),
+		DigestInterval:   s.DigestInterval.String(),
+		SilenceSync:      s.SilenceSyncInterval.String(),
+		LookupTTL:        s.LookupCacheTTL.String(),
+		LookupStaleTTL:   s.LookupCacheStaleTTL.String(),
+		SlowRequest:      s.SlowThreshold.String(),
+		DiscoveryRefresh: s.DiscoveryRefresh.String(),
+		RepeatInterval:   s.RepeatInterval.String(),
+		AsyncFlush:       s.AsyncFlushInterval.String(),
+	})
+}
+
+// EffectiveConfig returns the resolved value of every option, after
+// defaults have been applied and invalid values ignored. Secrets are
+// redacted. It may be called before [Client.Connect].
+func (c *Client) EffectiveConfig() ConfigSnapshot {
+	if c == nil || c.options == nil {
+		return ConfigSnapshot{}
+	}
+
+	o := c.options
+
+	snapshot := ConfigSnapshot{
+		BaseURL:             redactURL(c.baseURL),
+		RetryCount:          o.retryCount,
+		RetryWaitTime:       o.retryWaitTime,
+		RetryMaxWaitTime:    o.retryMaxWaitTime,
+		RetryPolicy:         "custom",
+		RequestLogger:       loggerType(o.requestLogger),
+		LogSampling:         o.logSampling,
+		RequestHeaders:      make(map[string]string, len(o.requestHeaders)),
+		Auth:                "none",
+		Timeout:             o.timeout,
+		UserAgent:           o.userAgent,
+		MaxIdleConns:        o.maxIdleConns,
+		MaxConnsPerHost:     o.maxConnsPerHost,
+		IdleConnTimeout:     o.idleConnTimeout,
+		DisableKeepAlive:    o.disableKeepAlive,
+		MaxRedirects:        o.maxRedirects,
+		CustomTLSConfig:     o.tlsConfig != nil || o.clientCert != nil,
+		CustomCookieJar:     o.cookieJar != nil,
+		Transport:           "http",
+		AlertsEndpoint:      o.alertsEndpoint,
+		PingEndpoint:        o.pingEndpoint,
+		AuditEndpoint:       o.auditEndpoint,
+		QuietHoursRules:     len(o.quietHoursRules),
+		LocalSilences:       len(o.localSilences),
+		SilenceSyncInterval: o.silenceSync,
+		SilencePolicy:       silencePolicyName(o.silencePolicy),
+		LookupCacheTTL:      o.lookupTTL,
+		LookupCacheStaleTTL: o.lookupStaleTTL,
+		LookupCacheEntries:  o.lookupMaxEntries,
+		SlowThreshold:       o.slowThreshold,
+		SeverityMapping:     "none",
+		AlertSchema:         int(o.alertSchema),
+		MaxMetadataSize:     o.maxMetadataSize,
+		BodySpoolThreshold:  o.spoolThreshold,
+		BandwidthLimit:      o.bandwidthLimit,
+		AdaptiveBackoff:     o.adaptiveBackoff,
+		PriorityReservation: o.priorityReserve,
+		ReresolveOnFailure:  o.reresolve,
+		EndpointDiscovery:   o.discovery != nil,
+		DiscoveryRefresh:    o.discoveryRefresh,
+		BodyTransformers:    len(o.bodyTransforms),
+		EncryptedFields:     o.encryptFields,
+		PIIMaskRules:        o.piiMasker.ruleNames(),
+		ChannelAllowlist:    o.channels.allowed(),
+		ChannelDenylist:     o.channels.denied(),
+		SendPolicies:        len(o.sendPolicies),
+		SnippetThreshold:    o.snippetThreshold,
+		Theme:               maps.Clone(o.theme),
+		PlainTextFallback:   o.plainText,
+		ChannelTimeZones:    o.channelZones,
+		TimestampLayout:     cmp.Or(o.timestampLayout, DefaultTimestampLayout),
+		RunbookResolver:     o.runbooks != nil,
+		AckEscalation:       o.ackEscalation != nil,
+		RepeatInterval:      o.repeatInterval,
+		RepeatMax:           o.repeatMax,
+		RepeatCondition:     o.repeatUntil != nil,
+		Tracing:             o.tracer != nil,
+		Metrics:             o.metrics != nil,
+		AsyncQueueSize:      o.asyncQueueSize,
+		AsyncFlushInterval:  o.asyncInterval,
+		AsyncErrorHandler:   o.asyncOnError != nil,
+		AsyncResultCallback: o.asyncOnResult != nil,
+		RateLimit:           o.rateLimit,
+		RateLimitBurst:      o.rateLimitBurst,
+		QuarantineAttempts:  o.quarantineLimit,
+		SchemaRepair:        o.schemaRepair,
+		StrictResponses:     o.strictResponses,
+		AuditSink:           o.auditSink != nil,
+		ContextFields:       o.contextFields != nil,
+		HeaderCallback:      o.headerCallback != nil,
+		UploadProgress:      o.progress != nil,
+		Warnings:            c.ConfigWarnings(),
+	}
+
+	if o.retryStrategy == nil && !o.customRetry {
+		snapshot.RetryPolicy = "default"
+	}
+
+	for endpoint, cfg := range o.endpointRetry {
+		if snapshot.EndpointRetryCounts == nil {
+			snapshot.EndpointRetryCounts = make(map[string]int, len(o.endpointRetry))
+		}
+
+		snapshot.EndpointRetryCounts[endpoint.String()] = cfg.Count
+	}
+
+	maps.Copy(snapshot.RequestHeaders, o.requestHeaders)
+
+	for key, override := range c.headers.load() {
+		for name := range snapshot.RequestHeaders {
+			if strings.EqualFold(name, key) {
+				delete(snapshot.RequestHeaders, name)
+			}
+		}
+
+		if !override.deleted {
+			snapshot.RequestHeaders[key] = override.value
+		}
+	}
+
+	for name := range snapshot.RequestHeaders {
+		if sensitiveName(name) {
+			snapshot.RequestHeaders[name] = redacted
+		}
+	}
+
+	if o.authenticator != nil {
+		snapshot.Authenticator = fmt.Sprintf("%T", o.authenticator)
+	}
+
+	switch auth := o.authenticatorOrNil().(type) {
+	case nil:
+	case *basicAuthenticator:
+		snapshot.Auth = "basic"
+		snapshot.BasicAuthUsername = auth.username
+		snapshot.BasicAuthPassword = redacted
+	case *tokenAuthenticator:
+		snapshot.Auth = "token"
+		snapshot.AuthScheme = auth.scheme
+		snapshot.AuthToken = redacted
+	case *tokenProviderAuthenticator:
+		snapshot.Auth = "tokenProvider"
+		snapshot.AuthScheme = auth.scheme
+	default:
+		snapshot.Auth = "authenticator"
+	}
+
+	if o.backend != nil {
+		snapshot.Transport = fmt.Sprintf("%T", o.backend)
+	}
+
+	if o.fallbackWebhook != "" {
+		snapshot.FallbackWebhook = redactWebhookURL(o.fallbackWebhook)
+	}
+
+	if o.emailFallback != nil {
+		snapshot.EmailFallback = o.emailFallback.addr()
+	}
+
+	if o.deliveryHealth != nil {
+		snapshot.HealthFailureRate = o.deliveryHealth.FailureRate
+		snapshot.HealthMinutes = o.deliveryHealth.Minutes
+		snapshot.HealthChannel = o.deliveryHealth.SlackChannelID
+
+		if o.deliveryHealth.WebhookURL != "" {
+			snapshot.HealthWebhook = redactWebhookURL(o.deliveryHealth.WebhookURL)
+		}
+	}
+
+	if o.quarantineStore != nil {
+		snapshot.QuarantineStore = fmt.Sprintf("%T", o.quarantineStore)
+	}
+
+	if o.zoneFallback != nil {
+		snapshot.TimeZoneFallback = o.zoneFallback.String()
+	}
+
+	if o.digestSelector != nil {
+		snapshot.DigestInterval = o.digestInterval
+	}
+
+	if o.severityMapper != nil {
+		snapshot.SeverityMapping = "lenient"
+		if o.severityMapper.mode == SeverityMappingStrict {
+			snapshot.SeverityMapping = "strict"
+		}
+	}
+
+	return snapshot
+}
+
+// sensitiveName reports whether the value of the named header or query
+// parameter should be redacted in a [ConfigSnapshot], which is when its
+// lower-cased name contains any of a set of words such as "auth" or
+// "token".
+func sensitiveName(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, word := range []string{"auth", "token", "secret", "key", "cookie", "password", "signature"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactURL masks any password embedded in rawURL and the values of its
+// sensitive query parameters. A URL that cannot be parsed is masked as a
+// whole, since it may still hold credentials.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			key, _, _ := strings.Cut(param, "=")
+			if name, err := url.QueryUnescape(key); err != nil || sensitiveName(name) {
+				params[i] = key + "=" + redacted
+			}
+		}
+
+		u.RawQuery = strings.Join(params, "&")
+	}
+
+	return u.Redacted()
+}
+
+// silencePolicyName returns the name of policy in a [ConfigSnapshot].
+func silencePolicyName(policy SilenceConflictPolicy) string {
+	switch policy {
+	case SilencePreferLocal:
+		return "preferLocal"
+	case SilencePreferNewest:
+		return "preferNewest"
+	case SilencePreferServer:
+		return "preferServer"
+	default:
+		return fmt.Sprintf("unknown(%d)", policy)
+	}
+}
+
+// redactWebhookURL masks the path of a webhook URL, which holds its secret.
+func redactWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return redacted
+	}
+
+	return u.Scheme + "://" + u.Host + "/" + redacted
+}
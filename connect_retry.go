@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultConnectWaitTime    = 500 * time.Millisecond
+	defaultConnectMaxWaitTime = 30 * time.Second
+)
+
+// ConnectRetryPolicy controls how [Client.ConnectWithRetry] retries the
+// initial ping. The zero value retries until the context is done, waiting
+// 500ms after the first failure and doubling the wait up to 30s.
+type ConnectRetryPolicy struct {
+	// WaitTime is the wait after the first failed ping. Defaults to 500ms.
+	WaitTime time.Duration
+
+	// MaxWaitTime caps the wait, which doubles after every failed ping.
+	// Defaults to 30s, or WaitTime if that is longer.
+	MaxWaitTime time.Duration
+
+	// MaxAttempts, if positive, is the number of pings after which
+	// ConnectWithRetry gives up, even if the context is not done.
+	MaxAttempts int
+}
+
+func (p ConnectRetryPolicy) validate() error {
+	if p.WaitTime < 0 || p.MaxWaitTime < 0 {
+		return errors.New("wait times must not be negative")
+	}
+
+	if p.MaxAttempts < 0 {
+		return errors.New("max attempts must not be negative")
+	}
+
+	return nil
+}
+
+// withDefaults fills the zero wait times of p.
+func (p ConnectRetryPolicy) withDefaults() ConnectRetryPolicy {
+	if p.WaitTime == 0 {
+		p.WaitTime = defaultConnectWaitTime
+	}
+
+	if p.MaxWaitTime == 0 {
+		p.MaxWaitTime = max(defaultConnectMaxWaitTime, p.WaitTime)
+	}
+
+	return p
+}
+
+// ConnectWithRetry is like [Client.Connect], but when the API cannot be
+// reached it keeps pinging with exponential backoff, until a ping succeeds,
+// ctx is done or policy gives up. This suits services that start before the
+// alerts API is reachable, such as pods starting alongside it. Each failed
+// ping is logged as a warning. Invalid options are not retried.
+//
+// Connect and ConnectWithRetry share their initialization: whichever is
+// called first connects the client, and later calls of either return its
+// result.
+func (c *Client) ConnectWithRetry(ctx context.Context, policy ConnectRetryPolicy) error {
+	if err := policy.validate(); err != nil {
+		return fmt.Errorf("invalid connect retry policy: %w", err)
+	}
+
+	policy = policy.withDefaults()
+
+	return c.connect(ctx, func(ctx context.Context) error {
+		return c.pingWithRetry(ctx, policy)
+	})
+}
+
+// pingWithRetry pings the API until it succeeds, following policy.
+func (c *Client) pingWithRetry(ctx context.Context, policy ConnectRetryPolicy) error {
+	wait := policy.WaitTime
+
+	for attempt := 1; ; attempt++ {
+		err := c.ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return fmt.Errorf("gave up after %d attempt(s): %w", attempt, err)
+		}
+
+		delay := wait/2 + time.Duration(rand.Int64N(int64(wait/2)+1)) //nolint:gosec // jitter does not need a secure source
+		if retryDelay := c.retryDelayFunc(); retryDelay != nil {
+			delay = retryDelay(delay)
+		}
+
+		c.logger(ctx).Warnf("alerts API not reachable (attempt %d), retrying in %s: %v", attempt, delay.Round(time.Millisecond), err)
+
+		if sleepErr := sleepFor(ctx, delay); sleepErr != nil {
+			return fmt.Errorf("gave up after %d attempt(s), %w: %w", attempt, sleepErr, err)
+		}
+
+		wait = min(wait*2, policy.MaxWaitTime)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newStartingServer returns a server whose pings fail with 503 until the
+// given number of them have been made.
+func newStartingServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var pings atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if pings.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &pings
+}
+
+func TestConnectWithRetry_WaitsForAPI(t *testing.T) {
+	t.Parallel()
+
+	server, pings := newStartingServer(t, 3)
+	logger := &recordingLogger{}
+
+	client := New(server.URL, WithRetryCount(0), WithRequestLogger(logger))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.ConnectWithRetry(context.Background(), ConnectRetryPolicy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if got := pings.Load(); got != 4 {
+		t.Errorf("expected 4 pings, got %d", got)
+	}
+
+	if warnings := logger.warnings(); len(warnings) != 3 || !strings.Contains(warnings[0], "attempt 1") {
+		t.Errorf("expected a warning per failed ping, got %q", warnings)
+	}
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Errorf("expected Connect to return the shared result, got %v", err)
+	}
+}
+
+func TestConnectWithRetry_MaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	server, pings := newStartingServer(t, 10)
+
+	client := New(server.URL, WithRetryCount(0))
+	setRetryDelay(t, client, noRetryDelay)
+
+	err := client.ConnectWithRetry(context.Background(), ConnectRetryPolicy{MaxAttempts: 2})
+	if err == nil || !strings.Contains(err.Error(), "gave up after 2 attempt(s)") || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected the policy to give up, got %v", err)
+	}
+
+	if got := pings.Load(); got != 2 {
+		t.Errorf("expected 2 pings, got %d", got)
+	}
+}
+
+func TestConnectWithRetry_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newStartingServer(t, 1000)
+
+	client := New(server.URL, WithRetryCount(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.ConnectWithRetry(ctx, ConnectRetryPolicy{WaitTime: 10 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "failed to ping alerts API") {
+		t.Fatalf("expected the deadline to end the retries, got %v", err)
+	}
+}
+
+func TestConnectWithRetry_InvalidPolicy(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://127.0.0.1:0")
+
+	err := client.ConnectWithRetry(context.Background(), ConnectRetryPolicy{MaxAttempts: -1})
+	if err == nil || !strings.Contains(err.Error(), "invalid connect retry policy") {
+		t.Fatalf("expected an invalid policy error, got %v", err)
+	}
+}
+
+func TestConnectRetryPolicy_Defaults(t *testing.T) {
+	t.Parallel()
+
+	got := ConnectRetryPolicy{WaitTime: time.Minute}.withDefaults()
+	if got.WaitTime != time.Minute || got.MaxWaitTime != time.Minute {
+		t.Errorf("expected the max wait to cover the wait, got %+v", got)
+	}
+
+	got = ConnectRetryPolicy{}.withDefaults()
+	if got.WaitTime != defaultConnectWaitTime || got.MaxWaitTime != defaultConnectMaxWaitTime {
+		t.Errorf("unexpected defaults: %+v", got)
+	}
+}
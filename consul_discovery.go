@@ -0,0 +1,126 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultConsulAddress = "http://127.0.0.1:8500"
+
+// ConsulDiscoveryConfig configures [ConsulDiscovery].
+type ConsulDiscoveryConfig struct {
+	// Address is the URL of the Consul HTTP API. Defaults to the local
+	// agent, http://127.0.0.1:8500.
+	Address string
+
+	// Service is the name of the service the API is registered as.
+	Service string
+
+	// Tag, if set, only finds instances registered with this tag.
+	Tag string
+
+	// Datacenter, if set, queries this datacenter instead of the agent's.
+	Datacenter string
+
+	// Token is sent as the X-Consul-Token header, if set.
+	Token string
+
+	// HTTPClient is used for the requests to Consul, for example to
+	// configure TLS. Defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+}
+
+// consulServiceEntry is the part of a Consul health service entry used to
+// find an instance's address.
+type consulServiceEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+// ConsulDiscovery returns an [EndpointDiscovery] that finds the healthy
+// instances of a service through the Consul health API. Only instances
+// whose health checks are all passing are returned. An instance registered
+// without a service address is reached at the address of its node.
+// Without a Service, discovery fails.
+func ConsulDiscovery(cfg ConsulDiscoveryConfig) EndpointDiscovery {
+	address := strings.TrimSuffix(cmp.Or(cfg.Address, defaultConsulAddress), "/")
+	httpClient := cfg.HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	query := url.Values{"passing": {"true"}}
+
+	if cfg.Tag != "" {
+		query.Set("tag", cfg.Tag)
+	}
+
+	if cfg.Datacenter != "" {
+		query.Set("dc", cfg.Datacenter)
+	}
+
+	endpoint := address + "/v1/health/service/" + url.PathEscape(cfg.Service) + "?" + query.Encode()
+
+	return func(ctx context.Context) ([]ServiceEndpoint, error) {
+		if cfg.Service == "" {
+			return nil, errors.New("consul discovery requires a service name")
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create consul request: %w", err)
+		}
+
+		if cfg.Token != "" {
+			req.Header.Set("X-Consul-Token", cfg.Token)
+		}
+
+		var entries []consulServiceEntry
+		if err := doDiscoveryRequest(httpClient, req, &entries); err != nil {
+			return nil, fmt.Errorf("consul: %w", err)
+		}
+
+		endpoints := make([]ServiceEndpoint, 0, len(entries))
+		for _, entry := range entries {
+			endpoints = append(endpoints, ServiceEndpoint{
+				Host: cmp.Or(entry.Service.Address, entry.Node.Address),
+				Port: entry.Service.Port,
+			})
+		}
+
+		return endpoints, nil
+	}
+}
+
+// doDiscoveryRequest sends req and decodes the JSON response into out.
+func doDiscoveryRequest(httpClient *http.Client, req *http.Request, out any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", req.Method, req.URL.Path, unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s failed with status code %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", req.URL.Path, err)
+	}
+
+	return nil
+}
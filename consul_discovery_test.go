@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestConsulDiscovery(t *testing.T) {
+	t.Parallel()
+
+	alerts := newAlertRecorder(t)
+	live := serverEndpoint(t, alerts.URL)
+
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v1/health/service/slack manager" || query.Get("passing") != "true" || query.Get("tag") != "primary" || query.Get("dc") != "eu1" || r.Header.Get("X-Consul-Token") != "secret" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(w, `[{"Node": {"Address": %q}, "Service": {"Address": "", "Port": %d}}]`, live.Host, live.Port)
+	}))
+	defer consul.Close()
+
+	discover := ConsulDiscovery(ConsulDiscoveryConfig{
+		Address:    consul.URL + "/",
+		Service:    "slack manager",
+		Tag:        "primary",
+		Datacenter: "eu1",
+		Token:      "secret",
+	})
+
+	client := New("http://alerts.invalid/", WithEndpointDiscovery(discover, 0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(alerts.received()) != 1 {
+		t.Errorf("expected the alert to reach the instance found in consul, got %d batches", len(alerts.received()))
+	}
+}
+
+func TestConsulDiscovery_Errors(t *testing.T) {
+	t.Parallel()
+
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+	}))
+	defer consul.Close()
+
+	_, err := ConsulDiscovery(ConsulDiscoveryConfig{Address: consul.URL, Service: "slackmgr"})(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "status code 403: ACL not found") {
+		t.Errorf("expected the consul error, got %v", err)
+	}
+
+	_, err = ConsulDiscovery(ConsulDiscoveryConfig{Address: consul.URL})(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "requires a service name") {
+		t.Errorf("expected a missing service error, got %v", err)
+	}
+}
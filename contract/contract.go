@@ -0,0 +1,329 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/slackmgr/go-client"
+	"github.com/slackmgr/types"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	// pollInterval is the wait between lookups of a sent alert, which the
+	// API may list only after a while.
+	pollInterval = 500 * time.Millisecond
+
+	// clockSkew is how far the clocks of the client and the API may
+	// differ.
+	clockSkew = time.Minute
+)
+
+// errSkipped reports a check that the [Config] does not enable.
+var errSkipped = errors.New("skipped")
+
+// Config is the deployment the checks run against.
+type Config struct {
+	// BaseURL is the base URL of the API, as passed to [client.New].
+	BaseURL string
+
+	// Channel is the ID of the Slack channel the checks send alerts to.
+	// Every issue in it is acknowledged and resolved.
+	Channel string
+
+	// Options are the client options, such as credentials. They are
+	// applied after [client.WithStrictResponses].
+	Options []client.Option
+
+	// RateLimitRequests is the number of requests, in a row, after which
+	// the API must have answered 429 Too Many Requests. Zero skips the
+	// rate limit check.
+	RateLimitRequests int
+
+	// Timeout bounds each check. The default is 30 seconds.
+	Timeout time.Duration
+}
+
+// check is one of the checks of the suite.
+type check struct {
+	name string
+	run  func(ctx context.Context, s *suite) error
+}
+
+var checks = []check{ //nolint:gochecknoglobals // the fixed list of checks
+	{"Ping", checkPing},
+	{"Alerts", checkAlerts},
+	{"ErrorShapes", checkErrorShapes},
+	{"RateLimit", checkRateLimit},
+}
+
+// suite is the state shared by the checks.
+type suite struct {
+	cfg    Config
+	client *client.Client
+}
+
+// Run runs the checks against the API described by cfg, each as a subtest
+// of t.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+
+	s, err := connect(t.Context(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.client.Close)
+
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(t.Context(), s.timeout())
+			defer cancel()
+
+			switch err := c.run(ctx, s); {
+			case errors.Is(err, errSkipped):
+				t.Skip(err)
+			case err != nil:
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Verify runs the checks against the API described by cfg, and returns the
+// failures of all of them, each prefixed with the name of its check.
+func Verify(ctx context.Context, cfg Config) error {
+	s, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer s.client.Close()
+
+	var errs []error
+
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, s.timeout())
+		err := c.run(checkCtx, s)
+		cancel()
+
+		if err != nil && !errors.Is(err, errSkipped) {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// connect validates cfg and connects a client to the API.
+func connect(ctx context.Context, cfg Config) (*suite, error) {
+	if cfg.BaseURL == "" || cfg.Channel == "" {
+		return nil, errors.New("contract config must set BaseURL and Channel")
+	}
+
+	c := client.New(cfg.BaseURL, append([]client.Option{client.WithStrictResponses()}, cfg.Options...)...)
+	if err := c.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	return &suite{cfg: cfg, client: c}, nil
+}
+
+func (s *suite) timeout() time.Duration {
+	if s.cfg.Timeout > 0 {
+		return s.cfg.Timeout
+	}
+
+	return defaultTimeout
+}
+
+// checkPing checks that the ping endpoint answers.
+func checkPing(ctx context.Context, s *suite) error {
+	return s.client.Ping(ctx)
+}
+
+// checkAlerts sends an alert, waits for it to be listed, and acknowledges
+// and resolves the issues of the channel.
+func checkAlerts(ctx context.Context, s *suite) error {
+	since := time.Now().Add(-clockSkew)
+
+	alert := types.NewAlert(types.AlertInfo)
+	alert.CorrelationID = "contract-" + rand.Text()
+	alert.Header = "Client contract check"
+	alert.Text = "Sent by the go-client contract checks. It is resolved when they finish."
+	alert.SlackChannelID = s.cfg.Channel
+
+	meta, err := s.client.SendWithResponse(ctx, alert)
+	if err != nil {
+		return fmt.Errorf("failed to send an alert: %w", err)
+	}
+
+	if meta.StatusCode < 200 || meta.StatusCode > 299 {
+		return fmt.Errorf("expected a 2xx status for an accepted alert, got %d", meta.StatusCode)
+	}
+
+	filter := client.AlertFilter{SlackChannelID: s.cfg.Channel, Since: since}
+
+	if err := s.awaitListed(ctx, filter, alert.CorrelationID); err != nil {
+		return err
+	}
+
+	dryRun, err := s.client.AckAll(ctx, filter, client.DryRun())
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge in a dry run: %w", err)
+	}
+
+	if dryRun.Matched < 1 || dryRun.Changed != 0 {
+		return fmt.Errorf("expected a dry run to match the alert and change nothing, got %+v", *dryRun)
+	}
+
+	transitions := []struct {
+		name string
+		run  func(context.Context, client.AlertFilter, ...client.BulkOption) (*client.BulkResult, error)
+	}{
+		{"acknowledge", s.client.AckAll},
+		{"resolve", s.client.ResolveAll},
+	}
+
+	for _, transition := range transitions {
+		result, err := transition.run(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to %s the alert: %w", transition.name, err)
+		}
+
+		if result.Matched < 1 || result.Changed > result.Matched {
+			return fmt.Errorf("expected to %s the alert, got %+v", transition.name, *result)
+		}
+	}
+
+	return nil
+}
+
+// awaitListed lists the alerts matching filter until one has the
+// correlation ID.
+func (s *suite) awaitListed(ctx context.Context, filter client.AlertFilter, correlationID string) error {
+	for {
+		for listed, err := range s.client.ListAlerts(ctx, filter) {
+			if err != nil {
+				return fmt.Errorf("failed to list alerts: %w", err)
+			}
+
+			if listed.CorrelationID == correlationID {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("sent alert %s was not listed: %w", correlationID, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// errorBody is the shape of API error responses.
+type errorBody struct {
+	Error  string                  `json:"error"`
+	Fields []client.FieldViolation `json:"fields"`
+}
+
+// checkErrorShapes checks the status and body of the responses to a
+// malformed request and to an invalid alert.
+func checkErrorShapes(ctx context.Context, s *suite) error {
+	path := s.client.EffectiveConfig().AlertsEndpoint
+
+	requests := []struct {
+		name string
+		body string
+	}{
+		{"malformed request", `{"alerts": [`},
+		{"invalid alert", `{"alerts": [{"header": "", "text": "", "slackChannelId": ""}]}`},
+	}
+
+	for _, request := range requests {
+		name := request.name
+
+		response, err := s.client.RestyClient().R().SetContext(ctx).SetBody([]byte(request.body)).Post(path)
+		if err != nil {
+			return fmt.Errorf("%s: request failed: %w", name, err)
+		}
+
+		if status := response.StatusCode(); status != http.StatusBadRequest && status != http.StatusUnprocessableEntity {
+			return fmt.Errorf("%s: expected status 400 or 422, got %d", name, status)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(response.Body()))
+		decoder.DisallowUnknownFields()
+
+		var shape errorBody
+		if err := decoder.Decode(&shape); err != nil || shape.Error == "" {
+			return fmt.Errorf(`%s: expected a body of the form {"error": "..."}, got %q`, name, response.Body())
+		}
+
+		for _, v := range shape.Fields {
+			if !strings.HasPrefix(v.Path, "/") || v.Code == "" {
+				return fmt.Errorf("%s: expected field violations with a JSON Pointer path and a code, got %+v", name, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRateLimit pings the API until it answers 429, which must say when
+// to retry.
+func checkRateLimit(ctx context.Context, s *suite) error {
+	if s.cfg.RateLimitRequests <= 0 {
+		return fmt.Errorf("rate limit check %w: set RateLimitRequests to enable it", errSkipped)
+	}
+
+	// Retries would hide the 429 responses.
+	c := client.New(s.cfg.BaseURL, append(slices.Clip(s.cfg.Options), client.WithRetryCount(0))...)
+	if err := c.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.Close()
+
+	path := c.EffectiveConfig().PingEndpoint
+
+	for range s.cfg.RateLimitRequests {
+		response, err := c.RestyClient().R().SetContext(ctx).Get(path)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+
+		if response.StatusCode() != http.StatusTooManyRequests {
+			continue
+		}
+
+		retryAfter := response.Header().Get("Retry-After")
+		if !validRetryAfter(retryAfter) {
+			return fmt.Errorf("expected a 429 response with a Retry-After header, got %q", retryAfter)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("expected a 429 response within %d requests", s.cfg.RateLimitRequests)
+}
+
+// validRetryAfter reports whether value is a Retry-After header, a number
+// of seconds or an HTTP date.
+func validRetryAfter(value string) bool {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return seconds >= 0
+	}
+
+	_, err := http.ParseTime(value)
+
+	return err == nil
+}
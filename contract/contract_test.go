@@ -0,0 +1,180 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	client "github.com/slackmgr/go-client"
+	"github.com/slackmgr/types"
+)
+
+// fakeAPI implements the API contract in memory. Broken APIs drift from it
+// in ways the checks must catch.
+type fakeAPI struct {
+	t         *testing.T
+	broken    bool
+	rateLimit int
+
+	mu     sync.Mutex
+	alerts []*types.Alert
+	pings  int
+}
+
+func newFakeAPI(t *testing.T, api *fakeAPI) string {
+	t.Helper()
+
+	api.t = t
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/ping":
+		if a.pings++; a.rateLimit > 0 && a.pings > a.rateLimit {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	case r.URL.Path == "/alerts" && r.Method == http.MethodPost:
+		a.post(w, r)
+	case r.URL.Path == "/alerts" && r.Method == http.MethodGet:
+		if a.broken {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"alerts": [], "nextCursor": "", "total": 0}`))
+
+			return
+		}
+
+		a.writeJSON(w, http.StatusOK, map[string]any{"alerts": a.alerts, "nextCursor": ""})
+	case r.URL.Path == "/alerts/bulk" && r.Method == http.MethodPatch:
+		var body struct {
+			DryRun bool `json:"dryRun"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		changed := len(a.alerts)
+		if body.DryRun {
+			changed = 0
+		}
+
+		a.writeJSON(w, http.StatusOK, client.BulkResult{Matched: len(a.alerts), Changed: changed, DryRun: body.DryRun})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (a *fakeAPI) post(w http.ResponseWriter, r *http.Request) {
+	// A broken API accepts anything.
+	if a.broken {
+		return
+	}
+
+	var body struct {
+		Alerts []*types.Alert `json:"alerts"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid JSON"}`))
+
+		return
+	}
+
+	for i, alert := range body.Alerts {
+		if alert.Header == "" && alert.Text == "" {
+			a.writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+				"error":  "invalid alerts",
+				"fields": []client.FieldViolation{{Alert: i, Path: "/header", Code: client.ViolationRequired, Message: "header or text is required"}},
+			})
+
+			return
+		}
+	}
+
+	a.alerts = append(a.alerts, body.Alerts...)
+}
+
+// writeJSON writes v as a JSON response with status.
+func (a *fakeAPI) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func TestRun(t *testing.T) { //nolint:tparallel // the checks run in order, against shared state
+	t.Parallel()
+
+	api := &fakeAPI{rateLimit: 10}
+
+	Run(t, Config{BaseURL: newFakeAPI(t, api), Channel: "C123", RateLimitRequests: 20})
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if len(api.alerts) != 1 || api.alerts[0].SlackChannelID != "C123" || !strings.HasPrefix(api.alerts[0].CorrelationID, "contract-") {
+		t.Errorf("expected one contract alert, got %+v", api.alerts)
+	}
+}
+
+func TestVerify_Drift(t *testing.T) {
+	t.Parallel()
+
+	url := newFakeAPI(t, &fakeAPI{broken: true})
+
+	err := Verify(context.Background(), Config{BaseURL: url, Channel: "C123", RateLimitRequests: 5, Options: []client.Option{client.WithRetryCount(0)}})
+	if err == nil {
+		t.Fatal("expected the checks to fail")
+	}
+
+	for _, want := range []string{
+		`Alerts: failed to list alerts: failed to decode GET alerts response: response does not match the expected schema: json: unknown field "total"`,
+		"ErrorShapes: malformed request: expected status 400 or 422, got 200",
+		"RateLimit: expected a 429 response within 5 requests",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "Ping:") {
+		t.Errorf("expected the ping check to pass, got %v", err)
+	}
+}
+
+func TestVerify_Config(t *testing.T) {
+	t.Parallel()
+
+	if err := Verify(context.Background(), Config{BaseURL: "http://localhost"}); err == nil || !strings.Contains(err.Error(), "Channel") {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
+
+func TestValidRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]bool{
+		"120":                           true,
+		"Wed, 21 Oct 2026 07:28:00 GMT": true,
+		"":                              false,
+		"-1":                            false,
+		"soon":                          false,
+	} {
+		if got := validRetryAfter(value); got != want {
+			t.Errorf("validRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package contract checks that a Slack Manager API behaves the way this
+// client expects, so that server releases can be gated on client
+// compatibility.
+//
+// [Run] runs the checks as subtests against any deployment:
+//
+//	func TestClientContract(t *testing.T) {
+//	    contract.Run(t, contract.Config{
+//	        BaseURL: os.Getenv("SLACK_MANAGER_URL"),
+//	        Channel: "C0123456789",
+//	        Options: []client.Option{client.WithAuthToken(os.Getenv("SLACK_MANAGER_TOKEN"))},
+//	        RateLimitRequests: 500,
+//	    })
+//	}
+//
+// The checks cover the ping endpoint, sending, listing, acknowledging and
+// resolving alerts, the shape of error responses and, if enabled, rate
+// limiting. Responses are decoded with [client.WithStrictResponses], so
+// fields the client does not know fail the checks too. [Verify] runs the
+// same checks outside of go test.
+//
+// The checks send real alerts to the channel and then acknowledge and
+// resolve every issue in it, so use a channel reserved for them.
+package contract
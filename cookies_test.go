@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// newSessionGateway returns a server that issues a session cookie with
+// its first response and rejects the first send with a 503.
+func newSessionGateway(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		sessions []string
+		sends    atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := ""
+		if cookie, err := r.Cookie("session"); err == nil {
+			session = cookie.Value
+		}
+
+		mu.Lock()
+		sessions = append(sessions, r.URL.Path+"="+session)
+		mu.Unlock()
+
+		if session == "" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+		}
+
+		if r.URL.Path == "/alerts" && sends.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), sessions...)
+	}
+}
+
+func TestWithCookieJar(t *testing.T) {
+	t.Parallel()
+
+	server, sessions := newSessionGateway(t)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := New(server.URL, WithCookieJar(jar), WithRetryCount(1))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"/ping=", "/alerts=s1", "/alerts=s1"}
+	if got := sessions(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("expected sessions %v, got %v", want, got)
+	}
+
+	u, _ := url.Parse(server.URL)
+	if cookies := jar.Cookies(u); len(cookies) != 1 || cookies[0].Value != "s1" {
+		t.Errorf("expected the session cookie in the supplied jar, got %v", cookies)
+	}
+
+	if !client.EffectiveConfig().CustomCookieJar {
+		t.Error("expected CustomCookieJar in the config snapshot")
+	}
+}
+
+func TestDefaultCookieJar_SharedWithDerived(t *testing.T) {
+	t.Parallel()
+
+	server, sessions := newSessionGateway(t)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	derived, err := client.With(context.Background(), WithRequestHeader("X-Team", "payments"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer derived.Close()
+
+	if _, err := derived.ListRoutes(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := sessions(); len(got) != 2 || got[1] != "/routes=s1" {
+		t.Errorf("expected the derived client to send the parent's session cookie, got %v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// IncidentIDKey is the Metadata key under which an alert's incident ID is
+// stored. Alerts with the same incident ID are related, for example the
+// database, API and checkout alerts of one outage, even though each is its
+// own issue with its own [types.Alert.CorrelationID].
+const IncidentIDKey = "incidentId"
+
+type incidentKey struct{}
+
+// WithIncident returns a copy of ctx that carries an incident ID. Alerts
+// sent with it, and server templates rendered with it, are linked to the
+// incident unless they already have an incident ID of their own.
+func WithIncident(ctx context.Context, incidentID string) context.Context {
+	return context.WithValue(ctx, incidentKey{}, strings.TrimSpace(incidentID))
+}
+
+// IncidentFromContext returns the incident ID set with [WithIncident], or
+// an empty string.
+func IncidentFromContext(ctx context.Context) string {
+	incidentID, _ := ctx.Value(incidentKey{}).(string)
+	return incidentID
+}
+
+// SetIncidentID links alert to an incident by storing incidentID in its
+// Metadata under [IncidentIDKey]. An empty incidentID removes the link.
+func SetIncidentID(alert *types.Alert, incidentID string) {
+	if alert == nil {
+		return
+	}
+
+	incidentID = strings.TrimSpace(incidentID)
+	if incidentID == "" {
+		delete(alert.Metadata, IncidentIDKey)
+		return
+	}
+
+	if alert.Metadata == nil {
+		alert.Metadata = make(map[string]any, 1)
+	}
+
+	alert.Metadata[IncidentIDKey] = incidentID
+}
+
+// IncidentID returns the incident ID of alert, or an empty string if it is
+// not linked to an incident.
+func IncidentID(alert *types.Alert) string {
+	if alert == nil {
+		return ""
+	}
+
+	incidentID, _ := alert.Metadata[IncidentIDKey].(string)
+
+	return incidentID
+}
+
+// AlertsForCorrelation returns every alert linked to the incident
+// incidentID, oldest first, to build a timeline of the incident. The
+// alerts are fetched with [Client.ListAlerts], filtered on
+// [AlertFilter.IncidentID].
+func (c *Client) AlertsForCorrelation(ctx context.Context, incidentID string) ([]*types.Alert, error) {
+	incidentID = strings.TrimSpace(incidentID)
+	if incidentID == "" {
+		return nil, errors.New("incident ID must not be empty")
+	}
+
+	var alerts []*types.Alert
+
+	for alert, err := range c.ListAlerts(ctx, AlertFilter{IncidentID: incidentID}) {
+		if err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	slices.SortStableFunc(alerts, func(a, b *types.Alert) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return alerts, nil
+}
+
+// linkIncident returns alerts linked to the incident set with
+// [WithIncident] on ctx. Alerts that already have an incident ID are kept
+// as they are. The caller's alerts are not changed; linked alerts are
+// copied.
+func linkIncident(ctx context.Context, alerts []*types.Alert) []*types.Alert {
+	incidentID := IncidentFromContext(ctx)
+	if incidentID == "" {
+		return alerts
+	}
+
+	result := make([]*types.Alert, len(alerts))
+
+	for i, alert := range alerts {
+		result[i] = alert
+
+		if alert == nil || IncidentID(alert) != "" {
+			continue
+		}
+
+		linked := *alert
+		linked.Metadata = maps.Clone(alert.Metadata)
+		SetIncidentID(&linked, incidentID)
+		result[i] = &linked
+	}
+
+	return result
+}
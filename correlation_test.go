@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestIncidentID(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewAlert(types.AlertError)
+	if IncidentID(alert) != "" || IncidentID(nil) != "" {
+		t.Error("expected no incident ID")
+	}
+
+	SetIncidentID(alert, " INC-42 ")
+	if got := IncidentID(alert); got != "INC-42" {
+		t.Errorf("expected INC-42, got %q", got)
+	}
+
+	SetIncidentID(alert, "")
+	if _, ok := alert.Metadata[IncidentIDKey]; ok {
+		t.Error("expected an empty ID to remove the link")
+	}
+
+	SetIncidentID(&types.Alert{}, "INC-1")
+	SetIncidentID(nil, "INC-1")
+}
+
+func TestWithIncident(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client := connectTo(t, server.URL)
+
+	own := types.NewAlert(types.AlertError)
+	SetIncidentID(own, "INC-1")
+
+	plain := types.NewAlert(types.AlertWarning)
+	plain.Metadata = nil
+
+	ctx := WithIncident(context.Background(), "INC-2")
+	if IncidentFromContext(ctx) != "INC-2" || IncidentFromContext(context.Background()) != "" {
+		t.Fatal("expected the incident to be carried by the context")
+	}
+
+	if err := client.Send(ctx, own, plain); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := server.received()[0]
+	if IncidentID(batch[0]) != "INC-1" || IncidentID(batch[1]) != "INC-2" {
+		t.Errorf("expected incidents INC-1 and INC-2, got %v and %v", batch[0].Metadata, batch[1].Metadata)
+	}
+
+	if plain.Metadata != nil {
+		t.Error("expected the caller's alert to be left unchanged")
+	}
+}
+
+func TestSendServerTemplate_Incident(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&request)
+	}))
+	t.Cleanup(server.Close)
+
+	client := connectTo(t, server.URL)
+
+	if _, err := client.SendServerTemplate(WithIncident(context.Background(), "INC-7"), "disk-full", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if request[IncidentIDKey] != "INC-7" {
+		t.Errorf("expected the incident in the request, got %v", request)
+	}
+}
+
+func TestAlertsForCorrelation(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	var query string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		query = r.URL.RawQuery
+
+		alerts := []*types.Alert{
+			{Header: "checkout", Timestamp: start.Add(2 * time.Minute)},
+			{Header: "database", Timestamp: start},
+			{Header: "api", Timestamp: start.Add(time.Minute)},
+		}
+
+		_ = json.NewEncoder(w).Encode(alertsPage{Alerts: alerts})
+	}))
+	t.Cleanup(server.Close)
+
+	client := connectTo(t, server.URL)
+
+	if _, err := client.AlertsForCorrelation(context.Background(), " "); err == nil {
+		t.Error("expected an error for an empty incident ID")
+	}
+
+	alerts, err := client.AlertsForCorrelation(context.Background(), "INC-42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query != "incidentId=INC-42" {
+		t.Errorf("unexpected query %q", query)
+	}
+
+	if len(alerts) != 3 || alerts[0].Header != "database" || alerts[1].Header != "api" || alerts[2].Header != "checkout" {
+		t.Errorf("expected the alerts oldest first, got %v", alerts)
+	}
+}
+
+func TestRenderFallbackText_Incident(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = "Checkout down"
+	alert.CorrelationID = "checkout"
+	SetIncidentID(alert, "INC-42")
+
+	want := "*[ERROR] Checkout down*\n_correlation ID checkout, incident INC-42_"
+	if got := renderFallbackText([]*types.Alert{alert}); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
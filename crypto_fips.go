@@ -0,0 +1,6 @@
+//go:build fips
+
+package client
+
+// fipsBuild is set by the fips build tag; see [CryptoProfile].
+const fipsBuild = true
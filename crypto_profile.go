@@ -0,0 +1,67 @@
+package client
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+// CryptoReport describes the cryptography used by the client, for
+// compliance attestations. See [CryptoProfile].
+type CryptoReport struct {
+	// FIPSBuild reports whether the client was built with the fips build
+	// tag, which makes [Client.Connect] fail unless FIPS 140-3 mode is on.
+	FIPSBuild bool `json:"fipsBuild"`
+
+	// FIPS140 reports whether the Go Cryptographic Module, or BoringCrypto
+	// in GOEXPERIMENT=boringcrypto builds, runs in FIPS 140-3 mode.
+	FIPS140 bool `json:"fips140"`
+
+	// Algorithms lists the algorithms the client uses and what for.
+	Algorithms []CryptoAlgorithm `json:"algorithms"`
+}
+
+// CryptoAlgorithm is an algorithm listed in a [CryptoReport].
+type CryptoAlgorithm struct {
+	Name string `json:"name"`
+	Use  string `json:"use"`
+}
+
+// CryptoProfile reports the cryptography used by the client. Every
+// algorithm is FIPS 140-3 approved and comes from the standard library, so
+// it runs in the validated module when FIPS 140-3 mode is on: enable it
+// with GODEBUG=fips140=on, or at build time with GOFIPS140. Build with
+// -tags fips to refuse to connect when it is off.
+func CryptoProfile() CryptoReport {
+	return CryptoReport{
+		FIPSBuild: fipsBuild,
+		FIPS140:   fips140.Enabled(),
+		Algorithms: []CryptoAlgorithm{
+			{Name: "TLS 1.2+", Use: "connections to the API, webhooks and SMTP servers"},
+			{Name: "HMAC-SHA256", Use: "callback signatures"},
+			{Name: "AES-256-GCM", Use: "field encryption, with random 96-bit nonces"},
+			{Name: "SHA-256", Use: "alert fingerprints and asset hashes"},
+			{Name: "CTR_DRBG (crypto/rand)", Use: "callback secrets, nonces, lock owners and idempotency keys"},
+		},
+	}
+}
+
+// checkFIPS returns an error if the client must run in FIPS 140-3 mode
+// (required is set by the fips build tag) but crypto is not enabled in
+// that mode, or the TLS config allows versions older than TLS 1.2.
+func (o *Options) checkFIPS(required, enabled bool) error {
+	if !required {
+		return nil
+	}
+
+	if !enabled {
+		return errors.New("client was built with the fips tag but FIPS 140-3 mode is off; run with GODEBUG=fips140=on")
+	}
+
+	if o.tlsConfig != nil && o.tlsConfig.MinVersion != 0 && o.tlsConfig.MinVersion < tls.VersionTLS12 {
+		return fmt.Errorf("TLS config allows TLS versions older than 1.2 (min version %#x), which FIPS 140-3 mode does not permit", o.tlsConfig.MinVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"strings"
+	"testing"
+)
+
+func TestCryptoProfile(t *testing.T) {
+	t.Parallel()
+
+	profile := CryptoProfile()
+
+	if profile.FIPSBuild != fipsBuild || profile.FIPS140 != fips140.Enabled() {
+		t.Errorf("unexpected FIPS status %+v", profile)
+	}
+
+	names := make([]string, 0, len(profile.Algorithms))
+	for _, algorithm := range profile.Algorithms {
+		names = append(names, algorithm.Name)
+	}
+
+	if got := strings.Join(names, ", "); !strings.Contains(got, "HMAC-SHA256") || !strings.Contains(got, "AES-256-GCM") {
+		t.Errorf("expected the signing and encryption algorithms to be listed, got %s", got)
+	}
+}
+
+func TestCheckFIPS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		tls               *tls.Config
+		required, enabled bool
+		want              string
+	}{
+		{name: "not required", required: false, enabled: false},
+		{name: "enabled", required: true, enabled: true, tls: &tls.Config{MinVersion: tls.VersionTLS13}},
+		{name: "mode off", required: true, enabled: false, want: "FIPS 140-3 mode is off"},
+		{name: "old TLS", required: true, enabled: true, tls: &tls.Config{MinVersion: tls.VersionTLS10}, want: "older than 1.2"}, //nolint:gosec // the version is rejected
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &Options{tlsConfig: tt.tls}
+
+			err := o.checkFIPS(tt.required, tt.enabled)
+			if (tt.want == "") != (err == nil) || err != nil && !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/slackmgr/types"
+)
+
+var defaultClient atomic.Pointer[Client] //nolint:gochecknoglobals // the package-level Send needs a process-wide default
+
+// SetDefault sets the client used by the package-level [Send] and
+// [SendWithResponse] functions. It is safe for concurrent use; passing nil
+// clears the default. The client must still be connected with
+// [Client.Connect], before or after it is set.
+func SetDefault(c *Client) {
+	defaultClient.Store(c)
+}
+
+// Default returns the client set by [SetDefault], or nil if none is set.
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// Send posts one or more alerts using the default client set by
+// [SetDefault]. See [Client.Send].
+func Send(ctx context.Context, alerts ...*types.Alert) error {
+	_, err := SendWithResponse(ctx, alerts...)
+	return err
+}
+
+// SendWithResponse posts one or more alerts using the default client set by
+// [SetDefault] and returns HTTP response metadata. See
+// [Client.SendWithResponse].
+func SendWithResponse(ctx context.Context, alerts ...*types.Alert) (*ResponseMetadata, error) {
+	c := Default()
+	if c == nil {
+		return nil, errors.New("no default client set - call SetDefault() first")
+	}
+
+	return c.SendWithResponse(ctx, alerts...)
+}
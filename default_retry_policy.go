@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"net/http"
 	"syscall"
 
 	"github.com/go-resty/resty/v2"
@@ -23,20 +24,34 @@ var permanentConnErrors = []syscall.Errno{ //nolint:gochecknoglobals
 	syscall.EACCES,
 }
 
-// DefaultRetryPolicy is the default retry condition used by [Client]. It
-// retries on HTTP 429 (rate limit) and 5xx server errors, and on transient
-// connection errors. It does not retry on context cancellation, deadline
-// exceeded, DNS resolution failures, or permanent connection failures
-// (connection refused, network/host unreachable, permission denied).
+// DefaultRetryPolicy is the resty-typed form of [DefaultRetryCondition].
 //
-// Supply a custom function via [WithRetryPolicy] to override this behaviour.
+// Deprecated: Use [DefaultRetryCondition] with [WithRetryCondition].
 func DefaultRetryPolicy(r *resty.Response, err error) bool {
+	return DefaultRetryCondition(newResponse(r), err)
+}
+
+// DefaultRetryCondition is the default retry condition used by [Client].
+// It retries on HTTP 429 (rate limit) and 5xx server errors, and on
+// transient connection errors. It does not retry on context cancellation,
+// deadline exceeded, DNS resolution failures, permanent connection
+// failures (connection refused, network/host unreachable, permission
+// denied), or an [*AuthError] from the [Authenticator].
+//
+// Supply a custom function via [WithRetryCondition] to override this
+// behaviour.
+func DefaultRetryCondition(r *Response, err error) bool {
 	if err != nil {
 		// Don't retry on context cancellation or deadline exceeded
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return false
 		}
 
+		// Don't retry requests that were never sent for lack of credentials
+		if isAuthError(err) {
+			return false
+		}
+
 		// Don't retry on DNS resolution errors
 		var dnsErr *net.DNSError
 		if errors.As(err, &dnsErr) {
@@ -58,6 +73,10 @@ func DefaultRetryPolicy(r *resty.Response, err error) bool {
 		return true
 	}
 
+	if r == nil {
+		return false
+	}
+
 	// Retry on 429 (rate limit) and 5xx (server errors)
-	return r.StatusCode() == 429 || r.StatusCode() >= 500
+	return r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= 500
 }
@@ -5,10 +5,13 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync/atomic"
 	"syscall"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
 )
 
 func TestDefaultRetryPolicy_ContextCanceled(t *testing.T) {
@@ -199,3 +202,85 @@ func createRestyResponse(t *testing.T, statusCode int) *resty.Response {
 
 	return resp
 }
+
+func TestDefaultRetryCondition_NilResponse(t *testing.T) {
+	t.Parallel()
+
+	if DefaultRetryCondition(nil, nil) {
+		t.Error("expected false without a response or error")
+	}
+}
+
+func TestWithRetryCondition(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-Reason", "maintenance")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"try again"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var seen []*Response
+
+	client := New(server.URL,
+		WithRetryCondition(func(r *Response, err error) bool {
+			seen = append(seen, r)
+			return err == nil && r.StatusCode == http.StatusConflict && r.Header.Get("X-Reason") == "maintenance"
+		}),
+	)
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 send attempts, got %d", calls.Load())
+	}
+
+	if !slices.ContainsFunc(seen, func(r *Response) bool { return r != nil && string(r.Body) == `{"error":"try again"}` }) {
+		t.Errorf("expected the condition to see the response body, got %+v", seen)
+	}
+}
+
+func TestWithRetryPolicy_Deprecated(t *testing.T) {
+	t.Parallel()
+
+	var got *resty.Response
+
+	opts := newClientOptions()
+	WithRetryPolicy(func(r *resty.Response, _ error) bool {
+		got = r
+		return false
+	})(opts)
+
+	resp := createRestyResponse(t, 503)
+	if opts.retryPolicy(newResponse(resp), nil) || got != resp {
+		t.Error("expected the deprecated policy to receive the resty response")
+	}
+
+	WithRetryPolicy(DefaultRetryPolicy)(opts)
+
+	if !opts.retryPolicy(newResponse(resp), nil) {
+		t.Error("expected DefaultRetryPolicy to retry a 503")
+	}
+}
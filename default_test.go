@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// TestDefaultClient is the only test that touches the package-level default
+// client, so it can run in parallel with the rest.
+func TestDefaultClient(t *testing.T) {
+	t.Parallel()
+
+	t.Cleanup(func() { SetDefault(nil) })
+
+	SetDefault(nil)
+
+	if err := Send(context.Background(), &types.Alert{Header: "test"}); err == nil || err.Error() != "no default client set - call SetDefault() first" {
+		t.Errorf("expected no default client error, got %v", err)
+	}
+
+	rec := newAlertRecorder(t)
+
+	c := New(rec.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() { SetDefault(c) })
+	}
+	wg.Wait()
+
+	if Default() != c {
+		t.Fatal("expected Default to return the client set by SetDefault")
+	}
+
+	meta, err := SendWithResponse(context.Background(), &types.Alert{Header: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", meta.StatusCode)
+	}
+
+	if err := Send(context.Background(), &types.Alert{Header: "again"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := len(rec.received()); got != 2 {
+		t.Errorf("expected 2 batches, got %d", got)
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	maxDeliveryHealthMinutes      = 60
+	deliveryHealthCheckInterval   = 15 * time.Second
+	deliveryHealthCorrelationBase = "slack-manager-go-client/delivery-health"
+)
+
+// DeliveryHealthAlert configures [WithDeliveryHealthAlert].
+type DeliveryHealthAlert struct {
+	// FailureRate is the fraction of failed send requests, in (0, 1], at or
+	// above which a minute counts as degraded.
+	FailureRate float64
+
+	// Minutes is the number of consecutive degraded minutes, 1–60, after
+	// which the degraded alert is emitted.
+	Minutes int
+
+	// SlackChannelID is the channel to post the alert to through the API.
+	// This works when delivery is only partially failing, e.g. because of
+	// rate limiting.
+	SlackChannelID string
+
+	// WebhookURL is a Slack incoming webhook to post the alert to,
+	// bypassing the API entirely. At least one of SlackChannelID and
+	// WebhookURL must be set.
+	WebhookURL string
+}
+
+func (d *DeliveryHealthAlert) validate() error {
+	if d.FailureRate <= 0 || d.FailureRate > 1 {
+		return errors.New("delivery health alert: failure rate must be greater than 0 and at most 1")
+	}
+
+	if d.Minutes < 1 || d.Minutes > maxDeliveryHealthMinutes {
+		return fmt.Errorf("delivery health alert: minutes must be between 1 and %d", maxDeliveryHealthMinutes)
+	}
+
+	if d.SlackChannelID == "" && d.WebhookURL == "" {
+		return errors.New("delivery health alert: a Slack channel or webhook URL must be set")
+	}
+
+	if d.SlackChannelID != "" && !types.SlackChannelIDOrNameRegex.MatchString(d.SlackChannelID) {
+		return fmt.Errorf("delivery health alert: invalid Slack channel %q", d.SlackChannelID)
+	}
+
+	if d.WebhookURL != "" {
+		if err := validateWebhookURL(d.WebhookURL); err != nil {
+			return fmt.Errorf("delivery health alert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deliveryHealth tracks the outcome of send requests per minute and
+// decides when delivery is degraded.
+type deliveryHealth struct {
+	cfg DeliveryHealthAlert
+	now func() time.Time
+
+	mu       sync.Mutex
+	buckets  [maxDeliveryHealthMinutes + 1]healthBucket
+	degraded bool
+}
+
+// healthBucket counts the send requests made in one minute.
+type healthBucket struct {
+	minute int64
+	sent   int
+	failed int
+}
+
+func newDeliveryHealth(cfg DeliveryHealthAlert) *deliveryHealth {
+	return &deliveryHealth{cfg: cfg, now: time.Now}
+}
+
+// record counts one send request.
+func (d *deliveryHealth) record(failed bool) {
+	minute := d.now().Unix() / 60
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b := &d.buckets[minute%int64(len(d.buckets))]
+	if b.minute != minute {
+		*b = healthBucket{minute: minute}
+	}
+
+	b.sent++
+
+	if failed {
+		b.failed++
+	}
+}
+
+// bucketLocked returns the counts for minute, or an empty bucket.
+func (d *deliveryHealth) bucketLocked(minute int64) healthBucket {
+	b := d.buckets[minute%int64(len(d.buckets))]
+	if b.minute != minute {
+		return healthBucket{minute: minute}
+	}
+
+	return b
+}
+
+func (d *deliveryHealth) degradedMinute(b healthBucket) bool {
+	return b.sent > 0 && float64(b.failed)/float64(b.sent) >= d.cfg.FailureRate
+}
+
+// healthChange is a change of the delivery state found by
+// [deliveryHealth.evaluate], with the failed and total sends behind it.
+type healthChange struct {
+	degraded  bool
+	recovered bool
+	failed    int
+	sent      int
+}
+
+// evaluate checks the last complete minutes and reports whether delivery
+// has just become degraded or just recovered. Delivery is degraded when
+// each of the last cfg.Minutes complete minutes was degraded, and it
+// recovers after a complete minute with sends below the failure rate.
+func (d *deliveryHealth) evaluate() healthChange {
+	current := d.now().Unix() / 60
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.degraded {
+		last := d.bucketLocked(current - 1)
+		if last.sent > 0 && !d.degradedMinute(last) {
+			d.degraded = false
+			return healthChange{recovered: true, failed: last.failed, sent: last.sent}
+		}
+
+		return healthChange{}
+	}
+
+	change := healthChange{degraded: true}
+
+	for m := current - int64(d.cfg.Minutes); m < current; m++ {
+		b := d.bucketLocked(m)
+		if !d.degradedMinute(b) {
+			return healthChange{}
+		}
+
+		change.failed += b.failed
+		change.sent += b.sent
+	}
+
+	d.degraded = true
+
+	return change
+}
+
+// checkDeliveryHealth emits the degraded or recovered alert configured with
+// [WithDeliveryHealthAlert] when the delivery state changes.
+func (c *Client) checkDeliveryHealth(ctx context.Context) {
+	change := c.health.evaluate()
+	if !change.degraded && !change.recovered {
+		return
+	}
+
+	cfg := c.health.cfg
+	host, _ := os.Hostname()
+
+	alert := types.NewAlert(types.AlertError)
+	alert.CorrelationID = deliveryHealthCorrelationBase + "/" + host
+	alert.Host = host
+	alert.Header = "Alert delivery degraded"
+	alert.Text = fmt.Sprintf("%d of %d alert send requests from %s (%s) failed over the last %d minute(s). Alerts may not be reaching Slack.", change.failed, change.sent, host, c.options.userAgent, cfg.Minutes)
+
+	if change.recovered {
+		alert.Severity = types.AlertResolved
+		alert.Header = "Alert delivery recovered"
+		alert.Text = fmt.Sprintf("%d of %d alert send requests from %s (%s) failed in the last minute.", change.failed, change.sent, host, c.options.userAgent)
+	}
+
+	alert.FallbackText = alert.Header
+	c.logger(ctx).Warnf("%s: %s", alert.Header, alert.Text)
+
+	if cfg.SlackChannelID != "" {
+		alert.SlackChannelID = cfg.SlackChannelID
+
+		body, header, err := c.encodeBatch(ctx, []*types.Alert{alert})
+		if err == nil {
+			_, err = c.backend.SendBatch(ctx, &Batch{Alerts: []*types.Alert{alert}, Body: body, Header: withIdempotencyKey(header, "")})
+		}
+
+		if err != nil {
+			c.logger(ctx).Errorf("failed to post delivery health alert: %v", err)
+		}
+	}
+
+	if cfg.WebhookURL != "" {
+		if err := c.postSlackWebhook(ctx, cfg.WebhookURL, "*"+alert.Header+"*\n"+alert.Text); err != nil {
+			c.logger(ctx).Errorf("failed to post delivery health alert: %v", err)
+		}
+	}
+}
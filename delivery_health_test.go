@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestDeliveryHealth_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+
+	health := newDeliveryHealth(DeliveryHealthAlert{FailureRate: 0.5, Minutes: 2, SlackChannelID: "C1"})
+	health.now = func() time.Time { return now }
+
+	send := func(ok, failed int) {
+		for range ok {
+			health.record(false)
+		}
+
+		for range failed {
+			health.record(true)
+		}
+	}
+
+	send(1, 1) // 12:00 degraded
+	now = start.Add(time.Minute)
+
+	if health.evaluate().degraded {
+		t.Fatal("expected one degraded minute not to be enough")
+	}
+
+	send(0, 3) // 12:01 degraded
+	now = start.Add(2 * time.Minute)
+
+	if change := health.evaluate(); change != (healthChange{degraded: true, failed: 4, sent: 5}) {
+		t.Fatalf("expected degraded with 4/5 failures, got %+v", change)
+	}
+
+	if health.evaluate().degraded {
+		t.Error("expected the degraded transition to be reported once")
+	}
+
+	send(3, 1) // 12:02 healthy
+	now = start.Add(3 * time.Minute)
+
+	if change := health.evaluate(); change != (healthChange{recovered: true, failed: 1, sent: 4}) {
+		t.Errorf("expected recovery with 1/4 failures, got %+v", change)
+	}
+}
+
+func TestWithDeliveryHealthAlert_EmitsAlerts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		posted   []*types.Alert
+		webhooks []string
+	)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var list alertsList
+		_ = json.NewDecoder(r.Body).Decode(&list)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if list.Alerts[0].SlackChannelID == "C-meta" {
+			posted = append(posted, list.Alerts[0])
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer api.Close()
+
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+
+		mu.Lock()
+		webhooks = append(webhooks, msg["text"])
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	client := New(api.URL, WithRetryCount(0), WithDeliveryHealthAlert(DeliveryHealthAlert{
+		FailureRate:    1,
+		Minutes:        1,
+		SlackChannelID: "C-meta",
+		WebhookURL:     webhook.URL + "/services/T/B/secret",
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	client.webhookClient = webhook.Client()
+
+	now := time.Now()
+	client.health.now = func() time.Time { return now }
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	now = now.Add(time.Minute)
+	client.checkDeliveryHealth(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(posted) != 1 || posted[0].Header != "Alert delivery degraded" || !strings.HasPrefix(posted[0].CorrelationID, deliveryHealthCorrelationBase) {
+		t.Errorf("expected a degraded alert to be posted through the API, got %+v", posted)
+	}
+
+	if len(webhooks) != 1 || !strings.Contains(webhooks[0], "*Alert delivery degraded*") || !strings.Contains(webhooks[0], "1 of 1 alert send requests") {
+		t.Errorf("expected a degraded message on the webhook, got %q", webhooks)
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import "context"
+
+// With returns a derived client that applies opts on top of this client's
+// options and shares its connection pool, so per-team customisations such as
+// extra headers, a different timeout or logger do not open additional
+// connections. Options that configure the pool itself ([WithMaxIdleConns],
+// [WithMaxConnsPerHost], [WithIdleConnTimeout], [WithDisableKeepAlive],
+// [WithTLSConfig] and [WithClientCertificate]) or the send path
+// ([WithTransport]), as well as [WithDigest], [WithQuietHours],
+// [WithSilences], [WithSilenceSync], [WithDeliveryHealthAlert],
+// [WithBandwidthLimit], [WithAsyncMode], [WithRateLimit] and
+// [WithQuarantine], have no effect on a derived client: digests, quiet-hours
+// spools, silences, delivery health, a custom [Transport], the bandwidth
+// budget, the async queue, the rate limit, the quarantine and [Client.Stats]
+// are shared with the parent.
+//
+// If this client is already connected, the derived client is connected
+// with ctx and ready to use; if that fails, for example because opts are
+// invalid, the error is returned. Otherwise call [Client.Connect] on the
+// derived client, which connects the parent first. Closing a derived client
+// does not affect the parent.
+//
+// With takes a context and returns an error, rather than returning only the
+// derived client and connecting it on first use, so that invalid opts are
+// reported where the client is derived instead of by whichever call happens
+// to use it first, and so that every method of a derived client behaves
+// like that of a client returned by [New] and connected with
+// [Client.Connect].
+func (c *Client) With(ctx context.Context, opts ...Option) (*Client, error) {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	options := c.options.clone()
+	for _, o := range opts {
+		o(options)
+	}
+
+	derived := &Client{
+		baseURL: c.baseURL,
+		options: options,
+		parent:  root,
+		stats:   root.stats,
+		assets:  root.assets,
+		sends:   root.sends,
+	}
+
+	if overrides := c.headers.load(); len(overrides) > 0 {
+		derived.headers.current.Store(&overrides)
+	}
+
+	if root.client != nil {
+		if err := derived.Connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return derived, nil
+}
+
+// connectDerived completes [Client.Connect] for a client created by
+// [Client.With]. No ping is made; the parent has already verified
+// connectivity.
+func (c *Client) connectDerived(ctx context.Context) {
+	if err := c.parent.Connect(ctx); err != nil {
+		c.connectErr = err
+		return
+	}
+
+	c.adaptive = c.parent.adaptive
+	c.priority = c.parent.priority
+	c.bandwidth = c.parent.bandwidth
+	c.rateLimit = c.parent.rateLimit
+	c.quarantine = c.parent.quarantine
+	c.reresolve = c.parent.reresolve
+	c.endpoints = c.parent.endpoints
+	c.client = c.newRestyClient(c.parent.transport) //nolint:contextcheck // its hooks use the context of each request
+	if c.options.cookieJar == nil {
+		c.client.SetCookieJar(c.parent.client.GetClient().Jar)
+	}
+	c.backend = &httpTransport{c: c}
+	if c.parent.options.backend != nil {
+		c.backend = c.parent.backend
+	}
+	c.digest = c.parent.digest
+	c.quietHours = c.parent.quietHours
+	c.silences = c.parent.silences
+	c.health = c.parent.health
+	c.acks = c.parent.acks
+	c.repeats = c.parent.repeats
+	c.async = c.parent.async
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWith_SharesTransport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		teams []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			mu.Lock()
+			teams = append(teams, r.Header.Get("X-Team"))
+			mu.Unlock()
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	parent := New(server.URL, WithRequestHeader("X-Team", "platform"))
+	if err := parent.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer parent.Close()
+
+	derived, err := parent.With(context.Background(), WithRequestHeader("X-Team", "payments"), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if derived.RestyClient() == nil {
+		t.Fatal("expected derived client of a connected parent to be ready")
+	}
+
+	if derived.RestyClient().GetClient().Transport != parent.transport {
+		t.Error("expected derived client to share the parent's transport")
+	}
+
+	if derived.options.timeout != 5*time.Second || parent.options.timeout != defaultTimeout {
+		t.Errorf("expected override to apply only to derived client, got derived=%v parent=%v", derived.options.timeout, parent.options.timeout)
+	}
+
+	alert := &types.Alert{Header: "test"}
+
+	if err := parent.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := derived.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(teams) != 2 || teams[0] != "platform" || teams[1] != "payments" {
+		t.Errorf("expected headers [platform payments], got %v", teams)
+	}
+
+	derived.Close()
+
+	if err := parent.Ping(context.Background()); err != nil {
+		t.Errorf("expected parent to remain usable after derived Close, got %v", err)
+	}
+}
+
+func TestWith_ConnectsParent(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	parent := New(rec.URL)
+	derived, err := parent.With(context.Background(), WithUserAgent("team-bot"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if derived.RestyClient() != nil {
+		t.Fatal("expected derived client of an unconnected parent to require Connect")
+	}
+
+	if err := derived.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if parent.RestyClient() == nil {
+		t.Error("expected connecting the derived client to connect the parent")
+	}
+
+	grandchild, err := derived.With(context.Background(), WithRetryCount(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if grandchild.parent != parent {
+		t.Error("expected derived clients to share the root parent")
+	}
+
+	if grandchild.options.userAgent != "team-bot" {
+		t.Errorf("expected grandchild to inherit userAgent=team-bot, got %s", grandchild.options.userAgent)
+	}
+}
+
+func TestWith_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	parent := New(rec.URL, WithAuthToken("token"))
+	if err := parent.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer parent.Close()
+
+	derived, err := parent.With(context.Background(), WithBasicAuth("user", "pass"))
+	if derived != nil || err == nil || !strings.Contains(err.Error(), "invalid options") {
+		t.Errorf("expected invalid options error, got %v, %v", derived, err)
+	}
+}
+
+func TestWith_DoesNotModifyParentHeaders(t *testing.T) {
+	t.Parallel()
+
+	parent := New("http://example.com", WithRequestHeader("X-Team", "platform"))
+	if _, err := parent.With(context.Background(), WithRequestHeader("X-Team", "payments"), WithRequestHeader("X-Extra", "1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if parent.options.requestHeaders["X-Team"] != "platform" {
+		t.Errorf("expected parent header X-Team=platform, got %s", parent.options.requestHeaders["X-Team"])
+	}
+
+	if _, ok := parent.options.requestHeaders["X-Extra"]; ok {
+		t.Error("expected parent headers to be unchanged")
+	}
+}
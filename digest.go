@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	minDigestInterval = 1 * time.Second
+	maxDigestInterval = 24 * time.Hour
+	maxDigestLines    = 50
+)
+
+// ChannelSelector returns the Slack channel ID or name in which a digested
+// alert should be summarised. Returning an empty string excludes the alert
+// from the digest, and it is sent immediately instead.
+type ChannelSelector func(alert *types.Alert) string
+
+// digest accumulates low-priority alerts per channel and periodically posts
+// a single summary alert for each channel.
+type digest struct {
+	selector ChannelSelector
+
+	mu      sync.Mutex
+	pending map[string][]*types.Alert
+	order   []string
+}
+
+func newDigest(selector ChannelSelector) *digest {
+	return &digest{
+		selector: selector,
+		pending:  make(map[string][]*types.Alert),
+	}
+}
+
+// capture stores all digest-eligible alerts and returns the remaining alerts,
+// which must be sent immediately.
+func (d *digest) capture(alerts []*types.Alert) []*types.Alert {
+	passthrough := make([]*types.Alert, 0, len(alerts))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, alert := range alerts {
+		if !lowPriority(alert) {
+			passthrough = append(passthrough, alert)
+			continue
+		}
+
+		channel := strings.TrimSpace(d.selector(alert))
+		if channel == "" {
+			passthrough = append(passthrough, alert)
+			continue
+		}
+
+		if _, ok := d.pending[channel]; !ok {
+			d.order = append(d.order, channel)
+		}
+
+		d.pending[channel] = append(d.pending[channel], alert)
+	}
+
+	return passthrough
+}
+
+// digestBatch is the set of pending alerts for a single digest channel.
+type digestBatch struct {
+	channel string
+	alerts  []*types.Alert
+}
+
+// drain removes and returns all pending alerts, grouped per channel.
+func (d *digest) drain() []digestBatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	batches := make([]digestBatch, 0, len(d.order))
+	for _, channel := range d.order {
+		batches = append(batches, digestBatch{channel: channel, alerts: d.pending[channel]})
+	}
+
+	d.pending = make(map[string][]*types.Alert)
+	d.order = nil
+
+	return batches
+}
+
+// batches returns the pending alerts, grouped per channel, without
+// removing them.
+func (d *digest) batches() []digestBatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	batches := make([]digestBatch, 0, len(d.order))
+	for _, channel := range d.order {
+		batches = append(batches, digestBatch{channel: channel, alerts: slices.Clone(d.pending[channel])})
+	}
+
+	return batches
+}
+
+// extract removes and returns the pending alerts selected by match,
+// grouped per channel.
+func (d *digest) extract(match func(*types.Alert) bool) []digestBatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var taken []digestBatch
+
+	for _, channel := range d.order {
+		batch := digestBatch{channel: channel}
+
+		d.pending[channel] = slices.DeleteFunc(d.pending[channel], func(alert *types.Alert) bool {
+			if match(alert) {
+				batch.alerts = append(batch.alerts, alert)
+				return true
+			}
+
+			return false
+		})
+
+		if len(batch.alerts) > 0 {
+			taken = append(taken, batch)
+		}
+
+		if len(d.pending[channel]) == 0 {
+			delete(d.pending, channel)
+		}
+	}
+
+	d.order = slices.DeleteFunc(d.order, func(channel string) bool {
+		_, ok := d.pending[channel]
+		return !ok
+	})
+
+	return taken
+}
+
+// len returns the number of pending alerts.
+func (d *digest) len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n := 0
+	for _, alerts := range d.pending {
+		n += len(alerts)
+	}
+
+	return n
+}
+
+// requeue returns a batch that failed to post to the front of the queue for
+// its channel, so it is included in the next flush.
+func (d *digest) requeue(batch digestBatch) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.pending[batch.channel]; !ok {
+		d.order = append(d.order, batch.channel)
+	}
+
+	d.pending[batch.channel] = append(batch.alerts, d.pending[batch.channel]...)
+}
+
+// lowPriority reports whether the alert may be held back by digest mode or
+// quiet hours. Panic, error and resolved alerts are always sent immediately.
+func lowPriority(alert *types.Alert) bool {
+	switch normalizeSeverity(alert.Severity) {
+	case types.AlertWarning, types.AlertInfo:
+		return true
+	case types.AlertPanic, types.AlertError, types.AlertResolved:
+		return false
+	default:
+		return false
+	}
+}
+
+// severityCritical is the legacy alias of [types.AlertError] that the API
+// still accepts. It is untyped so it can also key severity vocabularies.
+const severityCritical = "critical"
+
+// normalizeSeverity returns the severity in the lower-case, trimmed form
+// used by the API.
+func normalizeSeverity(severity types.AlertSeverity) types.AlertSeverity {
+	return types.AlertSeverity(strings.ToLower(strings.TrimSpace(string(severity))))
+}
+
+func buildDigestAlert(channel string, alerts []*types.Alert) *types.Alert {
+	severity := types.AlertInfo
+
+	var text strings.Builder
+
+	for i, alert := range alerts {
+		if normalizeSeverity(alert.Severity) == types.AlertWarning {
+			severity = types.AlertWarning
+		}
+
+		if i >= maxDigestLines {
+			continue
+		}
+
+		fmt.Fprintf(&text, "• [%s] %s\n", normalizeSeverity(alert.Severity), digestLine(alert))
+	}
+
+	if len(alerts) > maxDigestLines {
+		fmt.Fprintf(&text, "…and %d more\n", len(alerts)-maxDigestLines)
+	}
+
+	summary := types.NewAlert(severity)
+	summary.SlackChannelID = channel
+	summary.Header = fmt.Sprintf("Digest: %d alert(s)", len(alerts))
+	summary.FallbackText = summary.Header
+	summary.Text = strings.TrimSpace(text.String())
+
+	return summary
+}
+
+func digestLine(alert *types.Alert) string {
+	header := strings.TrimSpace(alert.Header)
+	text, _, _ := strings.Cut(strings.TrimSpace(alert.Text), "\n")
+
+	switch {
+	case header != "" && text != "":
+		return header + " — " + text
+	case header != "":
+		return header
+	default:
+		return text
+	}
+}
+
+// Flush immediately posts any pending digests configured via [WithDigest].
+// It is a no-op when digest mode is not enabled. Digests containing warnings
+// are posted before info-only digests. Digests that fail to post are
+// returned to the queue and retried on the next flush. Use
+// [Client.Shutdown] when the process is exiting.
+func (c *Client) Flush(ctx context.Context) error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	if c.client == nil {
+		return errors.New("client not connected - call Connect() first")
+	}
+
+	if c.digest == nil {
+		return nil
+	}
+
+	return c.sendPending(ctx, c.pendingDigests())
+}
+
+// pendingDigests drains the digest and returns one summary per channel.
+func (c *Client) pendingDigests() []pendingSend {
+	batches := c.digest.drain()
+	pending := make([]pendingSend, 0, len(batches))
+
+	for _, batch := range batches {
+		pending = append(pending, pendingSend{
+			alerts:  []*types.Alert{buildDigestAlert(batch.channel, batch.alerts)},
+			failure: "failed to post digest for channel " + batch.channel,
+			requeue: func([]*types.Alert) { c.digest.requeue(batch) },
+		})
+	}
+
+	return pending
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// digestAll is a [ChannelSelector] that digests every alert into C123.
+func digestAll(*types.Alert) string {
+	return "C123"
+}
+
+func TestDigest_Capture(t *testing.T) {
+	t.Parallel()
+
+	d := newDigest(func(a *types.Alert) string {
+		if a.Host == "skip" {
+			return ""
+		}
+		return "C123"
+	})
+
+	alerts := []*types.Alert{
+		{Header: "panic", Severity: types.AlertPanic},
+		{Header: "error", Severity: types.AlertError},
+		{Header: "warning", Severity: types.AlertWarning},
+		{Header: "info", Severity: " INFO "},
+		{Header: "resolved", Severity: types.AlertResolved},
+		{Header: "default"},
+		{Header: "skipped", Severity: types.AlertInfo, Host: "skip"},
+	}
+
+	passthrough := d.capture(alerts)
+
+	got := make([]string, 0, len(passthrough))
+	for _, a := range passthrough {
+		got = append(got, a.Header)
+	}
+
+	if strings.Join(got, ",") != "panic,error,resolved,default,skipped" {
+		t.Errorf("unexpected passthrough alerts: %v", got)
+	}
+
+	batches := d.drain()
+	if len(batches) != 1 || batches[0].channel != "C123" || len(batches[0].alerts) != 2 {
+		t.Fatalf("unexpected batches: %+v", batches)
+	}
+
+	if len(d.drain()) != 0 {
+		t.Error("expected drain to empty the digest")
+	}
+}
+
+func TestBuildDigestAlert(t *testing.T) {
+	t.Parallel()
+
+	alerts := []*types.Alert{
+		{Header: "Disk filling", Text: "80% used\nmore detail", Severity: types.AlertInfo},
+		{Text: "Only text", Severity: types.AlertWarning},
+	}
+
+	summary := buildDigestAlert("C123", alerts)
+
+	if summary.SlackChannelID != "C123" {
+		t.Errorf("expected channel C123, got %s", summary.SlackChannelID)
+	}
+
+	if summary.Severity != types.AlertWarning {
+		t.Errorf("expected severity warning, got %s", summary.Severity)
+	}
+
+	if summary.Header != "Digest: 2 alert(s)" {
+		t.Errorf("unexpected header: %s", summary.Header)
+	}
+
+	want := "• [info] Disk filling — 80% used\n• [warning] Only text"
+	if summary.Text != want {
+		t.Errorf("expected text %q, got %q", want, summary.Text)
+	}
+}
+
+func TestBuildDigestAlert_TruncatesLines(t *testing.T) {
+	t.Parallel()
+
+	alerts := make([]*types.Alert, maxDigestLines+5)
+	for i := range alerts {
+		alerts[i] = &types.Alert{Header: "x", Severity: types.AlertInfo}
+	}
+
+	summary := buildDigestAlert("C123", alerts)
+
+	if summary.Severity != types.AlertInfo {
+		t.Errorf("expected severity info, got %s", summary.Severity)
+	}
+
+	if !strings.HasSuffix(summary.Text, "…and 5 more") {
+		t.Errorf("expected overflow line, got %q", summary.Text)
+	}
+
+	if n := strings.Count(summary.Text, "• "); n != maxDigestLines {
+		t.Errorf("expected %d lines, got %d", maxDigestLines, n)
+	}
+}
+
+func TestSend_Digest(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	err := client.Send(context.Background(),
+		&types.Alert{Header: "low", Severity: types.AlertWarning},
+		&types.Alert{Header: "high", Severity: types.AlertError},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 || len(batches[0]) != 1 || batches[0][0].Header != "high" {
+		t.Fatalf("expected only the high severity alert to be sent, got %+v", batches)
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	batches = server.received()
+	if len(batches) != 2 {
+		t.Fatalf("expected digest to be posted, got %d batches", len(batches))
+	}
+
+	summary := batches[1][0]
+	if summary.SlackChannelID != "C123" || !strings.Contains(summary.Text, "low") {
+		t.Errorf("unexpected digest alert: %+v", summary)
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("second flush failed: %v", err)
+	}
+
+	if len(server.received()) != 2 {
+		t.Error("expected empty flush to post nothing")
+	}
+}
+
+func TestSendWithResponse_AllDigested(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	meta, err := client.SendWithResponse(context.Background(), &types.Alert{Header: "low", Severity: types.AlertInfo})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta == nil || !meta.Skipped || meta.StatusCode != 0 {
+		t.Errorf("expected skipped metadata when all alerts are digested, got %+v", meta)
+	}
+
+	if len(server.received()) != 0 {
+		t.Error("expected no request to be made")
+	}
+}
+
+func TestFlush_RequeuesOnFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithRetryCount(0), WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	_ = client.Send(context.Background(), &types.Alert{Header: "first", Severity: types.AlertInfo})
+
+	server.setStatus(http.StatusInternalServerError)
+
+	err := client.Flush(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to post digest for channel C123") {
+		t.Fatalf("expected flush error, got %v", err)
+	}
+
+	server.setStatus(http.StatusOK)
+
+	_ = client.Send(context.Background(), &types.Alert{Header: "second", Severity: types.AlertInfo})
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 {
+		t.Fatalf("expected one digest, got %d", len(batches))
+	}
+
+	if !strings.Contains(batches[0][0].Text, "first") || !strings.Contains(batches[0][0].Text, "second") {
+		t.Errorf("expected requeued alerts in digest, got %q", batches[0][0].Text)
+	}
+}
+
+func TestFlush_AppliesChannelPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithRetryCount(0), WithDigest(digestAll, time.Hour), WithChannelDenylist("C123"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "disk", Severity: types.AlertInfo, SlackChannelID: "C-ops"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var policyErr *PolicyError
+	if err := client.Flush(context.Background()); !errors.As(err, &policyErr) {
+		t.Fatalf("expected the digest to be rejected by the channel policy, got %v", err)
+	}
+
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected no digest to be posted, got %v", got)
+	}
+
+	if n := client.digest.len(); n != 1 {
+		t.Errorf("expected the digested alert to stay queued, got %d", n)
+	}
+}
+
+func TestFlush_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	err := client.Flush(context.Background())
+	if err == nil || err.Error() != "client not connected - call Connect() first" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFlush_DigestDisabled(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Errorf("expected no-op flush, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	minDiscoveryRefresh = time.Second
+	maxDiscoveryRefresh = 24 * time.Hour
+)
+
+// ServiceEndpoint is an address at which the API can be reached, as
+// returned by an [EndpointDiscovery] function.
+type ServiceEndpoint struct {
+	// Host is a host name or IP address.
+	Host string
+
+	// Port is the TCP port. Zero means the port of the base URL.
+	Port int
+}
+
+// EndpointDiscovery returns the addresses at which the API can currently be
+// reached, most preferred first. See [WithEndpointDiscovery].
+type EndpointDiscovery func(ctx context.Context) ([]ServiceEndpoint, error)
+
+// SRVDiscovery returns an [EndpointDiscovery] that looks up the DNS SRV
+// records of _service._proto.name, as in SRVDiscovery("alerts", "tcp",
+// "slackmgr.service.consul"). Targets are ordered by priority, and
+// randomly by weight within a priority, as described in RFC 2782.
+func SRVDiscovery(service, proto, name string) EndpointDiscovery {
+	return func(ctx context.Context) ([]ServiceEndpoint, error) {
+		_, records, err := net.DefaultResolver.LookupSRV(ctx, service, proto, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up SRV records: %w", err)
+		}
+
+		endpoints := make([]ServiceEndpoint, 0, len(records))
+		for _, record := range records {
+			endpoints = append(endpoints, ServiceEndpoint{Host: strings.TrimSuffix(record.Target, "."), Port: int(record.Port)})
+		}
+
+		return endpoints, nil
+	}
+}
+
+// endpointSet holds the addresses found by [WithEndpointDiscovery] and
+// sends every request to the current one, moving on to the next address
+// when a connection fails.
+type endpointSet struct {
+	discover    EndpointDiscovery
+	defaultPort string
+
+	mu        sync.Mutex
+	addresses []string // host:port
+	current   int
+}
+
+// newEndpointSet returns an endpoint set for discover, filling missing
+// ports from the port of baseURL.
+func newEndpointSet(discover EndpointDiscovery, baseURL string) *endpointSet {
+	s := &endpointSet{discover: discover}
+
+	if u, err := url.Parse(baseURL); err == nil {
+		s.defaultPort = u.Port()
+	}
+
+	return s
+}
+
+// refresh replaces the addresses with newly discovered ones. The current
+// address is kept if it was discovered again. If discovery fails or finds
+// nothing, the previous addresses stay in use.
+func (s *endpointSet) refresh(ctx context.Context) error {
+	endpoints, err := s.discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	addresses := make([]string, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		if endpoint.Host == "" {
+			continue
+		}
+
+		switch {
+		case endpoint.Port > 0:
+			addresses = append(addresses, net.JoinHostPort(endpoint.Host, strconv.Itoa(endpoint.Port)))
+		case s.defaultPort != "":
+			addresses = append(addresses, net.JoinHostPort(endpoint.Host, s.defaultPort))
+		case strings.Contains(endpoint.Host, ":"):
+			addresses = append(addresses, "["+endpoint.Host+"]")
+		default:
+			addresses = append(addresses, endpoint.Host)
+		}
+	}
+
+	if len(addresses) == 0 {
+		return errors.New("no endpoints discovered")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := ""
+	if len(s.addresses) > 0 {
+		current = s.addresses[s.current]
+	}
+
+	s.addresses = addresses
+	s.current = max(slices.Index(addresses, current), 0)
+
+	return nil
+}
+
+// pick returns the current address and the number of addresses.
+func (s *endpointSet) pick() (string, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addresses[s.current], len(s.addresses)
+}
+
+// failed moves on to the next address, unless another request already did.
+func (s *endpointSet) failed(address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.addresses[s.current] == address {
+		s.current = (s.current + 1) % len(s.addresses)
+	}
+}
+
+// transport wraps next so that every request is sent to the current
+// address. A request whose connection cannot be established is sent to the
+// next address straight away, once per address; after other connection
+// errors, the next address is used by the next attempt.
+func (s *endpointSet) transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		address, n := s.pick()
+
+		for attempt := 1; ; attempt++ {
+			out := req.Clone(req.Context())
+			out.URL.Host = address
+			out.Host = ""
+
+			if attempt > 1 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+
+				out.Body = body
+			}
+
+			resp, err := next.RoundTrip(out)
+			if err == nil || req.Context().Err() != nil {
+				return resp, err
+			}
+
+			s.failed(address)
+
+			rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+			if attempt >= n || !isDialError(err) || !rewindable {
+				return resp, err
+			}
+
+			address, _ = s.pick()
+		}
+	})
+}
+
+// isDialError reports whether err means no connection was established, so
+// the request was not sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
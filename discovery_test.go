@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// staticDiscovery returns an EndpointDiscovery that always finds endpoints.
+func staticDiscovery(endpoints ...ServiceEndpoint) EndpointDiscovery {
+	return func(context.Context) ([]ServiceEndpoint, error) {
+		return endpoints, nil
+	}
+}
+
+// serverEndpoint returns the address of the server at rawURL.
+func serverEndpoint(t *testing.T, rawURL string) ServiceEndpoint {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return ServiceEndpoint{Host: u.Hostname(), Port: port}
+}
+
+func TestWithEndpointDiscovery_FailsOverToNextEndpoint(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	listener := listenTCP(t)
+	dead := ServiceEndpoint{Host: "127.0.0.1", Port: tcpPort(t, listener)}
+	_ = listener.Close()
+
+	client := New("http://alerts.invalid/", WithEndpointDiscovery(staticDiscovery(dead, serverEndpoint(t, server.URL)), 0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(server.received()) != 1 {
+		t.Errorf("expected the alert to reach the live endpoint, got %d batches", len(server.received()))
+	}
+
+	if address, _ := client.endpoints.pick(); address != strings.TrimPrefix(server.URL, "http://") {
+		t.Errorf("expected the client to stay on the live endpoint, got %s", address)
+	}
+}
+
+func TestWithEndpointDiscovery_ConnectError(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://alerts.invalid/", WithEndpointDiscovery(func(context.Context) ([]ServiceEndpoint, error) {
+		return nil, errors.New("consul unavailable")
+	}, 0))
+
+	err := client.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to discover API endpoints: consul unavailable") {
+		t.Fatalf("expected the discovery error, got %v", err)
+	}
+}
+
+func TestEndpointSet_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var found []ServiceEndpoint
+
+	set := newEndpointSet(func(context.Context) ([]ServiceEndpoint, error) { return found, nil }, "https://alerts.example.com:8443/api")
+
+	found = []ServiceEndpoint{{Host: "10.0.0.1"}, {Host: "10.0.0.2", Port: 9000}, {Host: "::1"}}
+	if err := set.refresh(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"10.0.0.1:8443", "10.0.0.2:9000", "[::1]:8443"}; strings.Join(set.addresses, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected %q, got %q", want, set.addresses)
+	}
+
+	set.failed("10.0.0.1:8443")
+
+	found = []ServiceEndpoint{{Host: "10.0.0.3"}, {Host: "10.0.0.2", Port: 9000}}
+	if err := set.refresh(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if address, n := set.pick(); address != "10.0.0.2:9000" || n != 2 {
+		t.Errorf("expected the current endpoint to be kept, got %s of %d", address, n)
+	}
+
+	found = nil
+	if err := set.refresh(context.Background()); err == nil {
+		t.Error("expected an error when nothing is discovered")
+	}
+
+	if _, n := set.pick(); n != 2 {
+		t.Errorf("expected the previous endpoints to be kept, got %d", n)
+	}
+}
+
+func TestWithEndpointDiscovery_Rejected(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://localhost", WithEndpointDiscovery(nil, 0), WithEndpointDiscovery(staticDiscovery(), -1))
+
+	if warnings := client.ConfigWarnings(); len(warnings) != 2 {
+		t.Errorf("expected both options to be rejected, got %q", warnings)
+	}
+
+	if client.EffectiveConfig().EndpointDiscovery {
+		t.Error("expected discovery to stay disabled")
+	}
+}
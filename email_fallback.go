@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const defaultSMTPPort = 587
+
+// EmailFallback configures the SMTP server used by [WithEmailFallback].
+type EmailFallback struct {
+	// Host is the SMTP server host name. It is also the name the server's
+	// TLS certificate is verified against.
+	Host string
+
+	// Port is the SMTP server port. Zero selects 587.
+	Port int
+
+	// ImplicitTLS connects with TLS from the start, as on port 465.
+	// Otherwise STARTTLS is used when the server offers it.
+	ImplicitTLS bool
+
+	// Username and Password enable PLAIN authentication. Credentials are
+	// only sent over TLS or to a server on localhost.
+	Username string
+	Password string
+
+	// From is the sender address.
+	From string
+
+	// To lists the recipient addresses. At least one is required.
+	To []string
+}
+
+// addr returns the host:port of the SMTP server.
+func (e EmailFallback) addr() string {
+	port := e.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	return net.JoinHostPort(e.Host, strconv.Itoa(port))
+}
+
+func (e EmailFallback) validate() error {
+	if e.Host == "" {
+		return errors.New("email fallback: host must be set")
+	}
+
+	if e.Port < 0 || e.Port > 65535 {
+		return fmt.Errorf("email fallback: port must be between 0 and 65535, got %d", e.Port)
+	}
+
+	if e.Password != "" && e.Username == "" {
+		return errors.New("email fallback: a password requires a username")
+	}
+
+	if _, err := mail.ParseAddress(e.From); err != nil {
+		return fmt.Errorf("email fallback: invalid sender address %q: %w", e.From, err)
+	}
+
+	if len(e.To) == 0 {
+		return errors.New("email fallback: at least one recipient must be set")
+	}
+
+	for _, to := range e.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("email fallback: invalid recipient address %q: %w", to, err)
+		}
+	}
+
+	return nil
+}
+
+// sendFallbackEmail mails the critical alerts to the recipients configured
+// with [WithEmailFallback]. The whole exchange is bounded by ctx and the
+// client timeout.
+func (c *Client) sendFallbackEmail(ctx context.Context, alerts []*types.Alert) error {
+	cfg := c.options.emailFallback
+	addr := cfg.addr()
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	deadline := time.Now().Add(c.options.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	_ = conn.SetDeadline(deadline)
+
+	tlsConfig := &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+
+	if cfg.ImplicitTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	sc, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP handshake with %s failed: %w", addr, err)
+	}
+	defer sc.Close()
+
+	if !cfg.ImplicitTLS {
+		if ok, _ := sc.Extension("STARTTLS"); ok {
+			if err := sc.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("SMTP STARTTLS with %s failed: %w", addr, err)
+			}
+		}
+	}
+
+	if cfg.Username != "" {
+		if err := sc.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication with %s failed: %w", addr, err)
+		}
+	}
+
+	if err := sc.Mail(emailAddress(cfg.From)); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected by %s: %w", addr, err)
+	}
+
+	for _, to := range cfg.To {
+		if err := sc.Rcpt(emailAddress(to)); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s rejected by %s: %w", to, addr, err)
+		}
+	}
+
+	w, err := sc.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA rejected by %s: %w", addr, err)
+	}
+
+	if _, err := w.Write(renderFallbackEmail(cfg, alerts)); err != nil {
+		return fmt.Errorf("failed to write email to %s: %w", addr, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server %s rejected the email: %w", addr, err)
+	}
+
+	if err := sc.Quit(); err != nil {
+		return fmt.Errorf("SMTP QUIT with %s failed: %w", addr, err)
+	}
+
+	return nil
+}
+
+// emailAddress returns the bare address of a validated address such as
+// "Alerts <alerts@example.com>".
+func emailAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+
+	return parsed.Address
+}
+
+// renderFallbackEmail renders alerts as a plain-text email message.
+func renderFallbackEmail(cfg *EmailFallback, alerts []*types.Alert) []byte {
+	subject := fmt.Sprintf("%d critical alert(s): %s", len(alerts), fallbackHeader(alerts[0]))
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mimeHeaderValue(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+
+	for i, alert := range alerts {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+
+		fmt.Fprintf(&b, "[%s] %s\r\n", fallbackSeverity(alert), fallbackHeader(alert))
+
+		if alert.Text != "" {
+			b.WriteString(strings.ReplaceAll(alert.Text, "\n", "\r\n") + "\r\n")
+		}
+
+		if details := fallbackDetails(alert); details != "" {
+			b.WriteString(details + "\r\n")
+		}
+	}
+
+	return []byte(b.String())
+}
+
+// mimeHeaderValue strips line breaks, which would start a new header, and
+// Q-encodes values that are not plain ASCII.
+func mimeHeaderValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+
+	return mime.QEncoding.Encode("utf-8", value)
+}
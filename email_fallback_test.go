@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// fakeSMTPServer is a minimal SMTP server that records the recipients and
+// data of every message it accepts.
+type fakeSMTPServer struct {
+	listener net.Listener
+	tcpPort  int
+
+	mu         sync.Mutex
+	recipients []string
+	messages   []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener := listenTCP(t)
+	s := &fakeSMTPServer{listener: listener, tcpPort: tcpPort(t, listener)}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.tcpPort
+}
+
+// listenTCP listens on a free local port.
+func listenTCP(t *testing.T) net.Listener {
+	t.Helper()
+
+	listener, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return listener
+}
+
+// tcpPort returns the port listener is bound to.
+func tcpPort(t *testing.T, listener net.Listener) int {
+	t.Helper()
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("expected a TCP address, got %T", listener.Addr())
+	}
+
+	return addr.Port
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM"):
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO"):
+			s.mu.Lock()
+			s.recipients = append(s.recipients, strings.TrimSpace(line[len("RCPT TO:"):]))
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if dataLine == ".\r\n" {
+					break
+				}
+
+				data.WriteString(dataLine)
+			}
+
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received() ([]string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.recipients...), append([]string(nil), s.messages...)
+}
+
+func TestWithEmailFallback_AfterWebhookFailure(t *testing.T) {
+	t.Parallel()
+
+	smtpServer := newFakeSMTPServer(t)
+	webhook, _ := newFallbackWebhook(t, http.StatusInternalServerError)
+
+	client := newUnreachableClient(t,
+		WithFallbackWebhook(webhook.URL+"/hook"),
+		WithEmailFallback(EmailFallback{
+			Host: "127.0.0.1",
+			Port: smtpServer.port(),
+			From: "Alerts <alerts@example.com>",
+			To:   []string{"oncall@example.com"},
+		}),
+	)
+	client.webhookClient = webhook.Client()
+
+	alert := types.NewAlert(types.AlertPanic)
+	alert.Header = "Payments down\r\nBcc: attacker@example.com"
+	alert.Text = "All payment requests are failing"
+	alert.SlackChannelID = "C123"
+
+	err := client.Send(context.Background(), alert)
+
+	var fallbackErr *FallbackError
+	if !errors.As(err, &fallbackErr) || fallbackErr.Transport != "email" || fallbackErr.Delivered != 1 {
+		t.Fatalf("expected an email FallbackError for one alert, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "webhook fallback also failed") {
+		t.Errorf("expected the webhook failure to be reported, got %v", err)
+	}
+
+	recipients, messages := smtpServer.received()
+	if len(recipients) != 1 || recipients[0] != "<oncall@example.com>" {
+		t.Errorf("expected one recipient, got %q", recipients)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("expected one email, got %d", len(messages))
+	}
+
+	for _, want := range []string{
+		"Subject: 1 critical alert(s): Payments down Bcc: attacker@example.com\r\n",
+		"[PANIC] Payments down",
+		"All payment requests are failing\r\n",
+		"channel C123\r\n",
+	} {
+		if !strings.Contains(messages[0], want) {
+			t.Errorf("expected email to contain %q, got %q", want, messages[0])
+		}
+	}
+
+	headers, _, _ := strings.Cut(messages[0], "\r\n\r\n")
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("expected the subject line break to be stripped, got %q", headers)
+	}
+}
+
+func TestWithEmailFallback_WithoutWebhook(t *testing.T) {
+	t.Parallel()
+
+	smtpServer := newFakeSMTPServer(t)
+
+	client := newUnreachableClient(t, WithEmailFallback(EmailFallback{
+		Host: "127.0.0.1",
+		Port: smtpServer.port(),
+		From: "alerts@example.com",
+		To:   []string{"a@example.com", "b@example.com"},
+	}))
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertError), types.NewAlert(types.AlertError))
+
+	var fallbackErr *FallbackError
+	if !errors.As(err, &fallbackErr) || fallbackErr.Transport != "email" || fallbackErr.Delivered != 2 {
+		t.Fatalf("expected an email FallbackError for two alerts, got %v", err)
+	}
+
+	if recipients, messages := smtpServer.received(); len(recipients) != 2 || len(messages) != 1 {
+		t.Errorf("expected one email to two recipients, got %q and %d emails", recipients, len(messages))
+	}
+}
+
+func TestWithEmailFallback_AllTiersFail(t *testing.T) {
+	t.Parallel()
+
+	listener := listenTCP(t)
+	port := tcpPort(t, listener)
+	_ = listener.Close()
+
+	client := newUnreachableClient(t, WithEmailFallback(EmailFallback{
+		Host: "127.0.0.1",
+		Port: port,
+		From: "alerts@example.com",
+		To:   []string{"oncall@example.com"},
+	}))
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertError))
+	if err == nil || !strings.Contains(err.Error(), "email fallback also failed") || !strings.Contains(err.Error(), "127.0.0.1:"+strconv.Itoa(port)) {
+		t.Fatalf("expected the email failure to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Endpoint is a class of API endpoints that can have its own retry
+// settings; see [WithEndpointRetry].
+type Endpoint int
+
+const (
+	// EndpointSend covers alert sends, including digests, quiet-hours
+	// flushes, backfills and delivery health alerts.
+	EndpointSend Endpoint = iota + 1
+
+	// EndpointPing covers the connectivity check made by [Client.Connect]
+	// and [Client.Ping].
+	EndpointPing
+
+	// EndpointRead covers GET requests, such as [Client.ListAlerts] and
+	// the lookups.
+	EndpointRead
+
+	// EndpointWrite covers other requests that create or change server
+	// state, such as [Client.CreateRoute] or [Client.UploadAsset].
+	EndpointWrite
+
+	// EndpointDelete covers DELETE requests.
+	EndpointDelete
+)
+
+// String returns the lower-case name of the endpoint class.
+func (e Endpoint) String() string {
+	switch e {
+	case EndpointSend:
+		return "send"
+	case EndpointPing:
+		return "ping"
+	case EndpointRead:
+		return "read"
+	case EndpointWrite:
+		return "write"
+	case EndpointDelete:
+		return "delete"
+	default:
+		return fmt.Sprintf("Endpoint(%d)", int(e))
+	}
+}
+
+func (e Endpoint) valid() bool {
+	return e >= EndpointSend && e <= EndpointDelete
+}
+
+// RetryConfig holds the retry settings of one endpoint class. Count is the
+// maximum number of retries; zero disables retries. Zero WaitTime and
+// MaxWaitTime use [WithRetryWaitTime] and [WithRetryMaxWaitTime], and a
+// nil Strategy uses [WithRetryStrategy] or [WithRetryCondition].
+type RetryConfig struct {
+	Count       int
+	WaitTime    time.Duration
+	MaxWaitTime time.Duration
+	Strategy    RetryStrategy
+}
+
+// resolve fills the zero fields of r from global.
+func (r RetryConfig) resolve(global RetryConfig) RetryConfig {
+	if r.WaitTime == 0 {
+		r.WaitTime = global.WaitTime
+	}
+
+	if r.MaxWaitTime == 0 {
+		r.MaxWaitTime = max(global.MaxWaitTime, r.WaitTime)
+	}
+
+	if r.Strategy == nil {
+		r.Strategy = global.Strategy
+	}
+
+	return r
+}
+
+func (r RetryConfig) validate(endpoint Endpoint) error {
+	if r.Count < 0 || r.Count > maxRetryCount {
+		return fmt.Errorf("%s endpoint retry count must be between 0 and %d, got %d", endpoint, maxRetryCount, r.Count)
+	}
+
+	if r.WaitTime != 0 && (r.WaitTime < minRetryWaitTime || r.WaitTime > maxRetryWaitTime) {
+		return fmt.Errorf("%s endpoint retry wait time must be between %v and %v, got %v", endpoint, minRetryWaitTime, maxRetryWaitTime, r.WaitTime)
+	}
+
+	if r.MaxWaitTime != 0 && (r.MaxWaitTime < minRetryMaxWaitTime || r.MaxWaitTime > maxRetryMaxWaitTime) {
+		return fmt.Errorf("%s endpoint retry max wait time must be between %v and %v, got %v", endpoint, minRetryMaxWaitTime, maxRetryMaxWaitTime, r.MaxWaitTime)
+	}
+
+	if r.WaitTime != 0 && r.MaxWaitTime != 0 && r.MaxWaitTime < r.WaitTime {
+		return fmt.Errorf("%s endpoint retry max wait time (%v) must be greater than or equal to its wait time (%v)", endpoint, r.MaxWaitTime, r.WaitTime)
+	}
+
+	return nil
+}
+
+type endpointKey struct{}
+
+// withEndpoint marks the requests made with ctx as belonging to endpoint.
+func withEndpoint(ctx context.Context, endpoint Endpoint) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// endpointOf returns the endpoint class of a request: the one set with
+// withEndpoint, or else the class implied by its method.
+func endpointOf(ctx context.Context, method string) Endpoint {
+	if endpoint, ok := ctx.Value(endpointKey{}).(Endpoint); ok {
+		return endpoint
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return EndpointRead
+	case http.MethodDelete:
+		return EndpointDelete
+	default:
+		return EndpointWrite
+	}
+}
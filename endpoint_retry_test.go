@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithEndpointRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts = map[string]int{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(server.URL,
+		WithRetryCount(1),
+		WithEndpointRetry(EndpointSend, RetryConfig{Count: 2}),
+		WithEndpointRetry(EndpointDelete, RetryConfig{}),
+	)
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err == nil {
+		t.Error("expected send to fail")
+	}
+
+	if err := client.DeleteRoute(context.Background(), "r1"); err == nil {
+		t.Error("expected delete to fail")
+	}
+
+	if _, err := client.ListRoutes(context.Background()); err == nil {
+		t.Error("expected list to fail")
+	}
+
+	if got := client.EffectiveConfig().EndpointRetryCounts; got["send"] != 2 || got["delete"] != 0 || len(got) != 2 {
+		t.Errorf("unexpected endpoint retry counts in the config snapshot: %v", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for request, want := range map[string]int{
+		"POST /alerts":      3,
+		"DELETE /routes/r1": 1,
+		"GET /routes":       2,
+	} {
+		if attempts[request] != want {
+			t.Errorf("expected %d attempts for %s, got %d", want, request, attempts[request])
+		}
+	}
+}
+
+func TestWithEndpointRetry_Ping(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		pings int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		pings++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithEndpointRetry(EndpointPing, RetryConfig{}))
+	if err := client.Connect(context.Background()); err == nil {
+		t.Fatal("expected connect to fail")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pings != 1 {
+		t.Errorf("expected a single ping without retries, got %d", pings)
+	}
+}
+
+func TestEndpointOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		send   bool // the request is made with withEndpoint(ctx, EndpointSend)
+		method string
+		want   Endpoint
+	}{
+		{false, http.MethodGet, EndpointRead},
+		{false, http.MethodPut, EndpointWrite},
+		{false, http.MethodPost, EndpointWrite},
+		{false, http.MethodDelete, EndpointDelete},
+		{true, http.MethodPost, EndpointSend},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.send {
+			ctx = withEndpoint(ctx, EndpointSend)
+		}
+
+		if got := endpointOf(ctx, tt.method); got != tt.want {
+			t.Errorf("endpointOf(%s) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
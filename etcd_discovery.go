@@ -0,0 +1,176 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultEtcdEndpoint = "http://127.0.0.1:2379"
+
+// EtcdDiscoveryConfig configures [EtcdDiscovery].
+type EtcdDiscoveryConfig struct {
+	// Endpoint is the URL of the etcd v3 JSON gateway. Defaults to
+	// http://127.0.0.1:2379.
+	Endpoint string
+
+	// Prefix is the key prefix under which the API's instances register,
+	// such as "/services/slackmgr/".
+	Prefix string
+
+	// Username and Password, if set, authenticate with etcd before every
+	// lookup.
+	Username string
+	Password string
+
+	// HTTPClient is used for the requests to etcd, for example to
+	// configure TLS. Defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+}
+
+type etcdRangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end"` //nolint:tagliatelle // etcd gateway schema
+}
+
+type etcdRangeResponse struct {
+	KVs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	} `json:"kvs"`
+}
+
+type etcdAuthRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type etcdAuthResponse struct {
+	Token string `json:"token"`
+}
+
+// EtcdDiscovery returns an [EndpointDiscovery] that reads the instances of
+// the API from the etcd keys under a prefix, through the v3 JSON gateway.
+// Each value is an address, "host" or "host:port", or a URL whose host and
+// port are used; values that are neither are skipped. Instances are
+// returned in key order. Without a Prefix, discovery fails.
+func EtcdDiscovery(cfg EtcdDiscoveryConfig) EndpointDiscovery {
+	endpoint := strings.TrimSuffix(cmp.Or(cfg.Endpoint, defaultEtcdEndpoint), "/")
+	httpClient := cfg.HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return func(ctx context.Context) ([]ServiceEndpoint, error) {
+		if cfg.Prefix == "" {
+			return nil, errors.New("etcd discovery requires a key prefix")
+		}
+
+		token := ""
+
+		if cfg.Username != "" {
+			var auth etcdAuthResponse
+			if err := postEtcd(ctx, httpClient, endpoint+"/v3/auth/authenticate", "", etcdAuthRequest{Name: cfg.Username, Password: cfg.Password}, &auth); err != nil {
+				return nil, err
+			}
+
+			token = auth.Token
+		}
+
+		var kvs etcdRangeResponse
+		if err := postEtcd(ctx, httpClient, endpoint+"/v3/kv/range", token, etcdRangeRequest{Key: []byte(cfg.Prefix), RangeEnd: prefixRangeEnd(cfg.Prefix)}, &kvs); err != nil {
+			return nil, err
+		}
+
+		endpoints := make([]ServiceEndpoint, 0, len(kvs.KVs))
+		for _, kv := range kvs.KVs {
+			if endpoint, ok := parseEndpointValue(string(kv.Value)); ok {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+
+		return endpoints, nil
+	}
+}
+
+// postEtcd posts body as JSON to an etcd gateway endpoint and decodes the
+// response into out.
+func postEtcd(ctx context.Context, httpClient *http.Client, endpoint, token string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal etcd request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create etcd request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	if err := doDiscoveryRequest(httpClient, req, out); err != nil {
+		return fmt.Errorf("etcd: %w", err)
+	}
+
+	return nil
+}
+
+// prefixRangeEnd returns the end of the etcd key range holding every key
+// that starts with prefix.
+func prefixRangeEnd(prefix string) []byte {
+	end := []byte(prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	// Every byte is 0xff: the range runs to the end of the keyspace.
+	return []byte{0}
+}
+
+// parseEndpointValue parses a registered address, "host", "host:port" or a
+// URL.
+func parseEndpointValue(value string) (ServiceEndpoint, bool) {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil {
+			return ServiceEndpoint{}, false
+		}
+
+		value = u.Host
+	}
+
+	if value == "" {
+		return ServiceEndpoint{}, false
+	}
+
+	host, portText, err := net.SplitHostPort(value)
+	if err != nil {
+		return ServiceEndpoint{Host: strings.Trim(value, "[]")}, true
+	}
+
+	port, err := strconv.Atoi(portText)
+	if err != nil || port <= 0 || port > 65535 {
+		return ServiceEndpoint{}, false
+	}
+
+	return ServiceEndpoint{Host: host, Port: port}, true
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newFakeEtcd returns an etcd gateway holding kvs, which requires the
+// token "t0k3n" when a password is set.
+func newFakeEtcd(t *testing.T, password string, kvs map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			var req etcdAuthRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "reader" || req.Password != password {
+				http.Error(w, "authentication failed", http.StatusUnauthorized)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(etcdAuthResponse{Token: "t0k3n"})
+		case "/v3/kv/range":
+			if password != "" && r.Header.Get("Authorization") != "t0k3n" {
+				http.Error(w, "user name is empty", http.StatusUnauthorized)
+				return
+			}
+
+			var req etcdRangeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			var resp etcdRangeResponse
+
+			for _, key := range []string{"/services/slackmgr/a", "/services/slackmgr/b", "/services/slackmgr/c", "/services/slackmgr/d", "/services/slackmgr0"} {
+				if bytes.Compare([]byte(key), req.Key) >= 0 && bytes.Compare([]byte(key), req.RangeEnd) < 0 {
+					resp.KVs = append(resp.KVs, struct {
+						Key   []byte `json:"key"`
+						Value []byte `json:"value"`
+					}{[]byte(key), []byte(kvs[key])})
+				}
+			}
+
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestEtcdDiscovery(t *testing.T) {
+	t.Parallel()
+
+	etcd := newFakeEtcd(t, "pa55", map[string]string{
+		"/services/slackmgr/a": "10.0.0.1:8080",
+		"/services/slackmgr/b": "https://alerts-2.internal:8443",
+		"/services/slackmgr/c": "10.0.0.3:banana",
+		"/services/slackmgr/d": "alerts-4.internal",
+		"/services/slackmgr0":  "10.0.0.9:8080",
+	})
+
+	endpoints, err := EtcdDiscovery(EtcdDiscoveryConfig{
+		Endpoint: etcd.URL,
+		Prefix:   "/services/slackmgr/",
+		Username: "reader",
+		Password: "pa55",
+	})(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ServiceEndpoint{
+		{Host: "10.0.0.1", Port: 8080},
+		{Host: "alerts-2.internal", Port: 8443},
+		{Host: "alerts-4.internal"},
+	}
+	if !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("expected %+v, got %+v", want, endpoints)
+	}
+}
+
+func TestEtcdDiscovery_Errors(t *testing.T) {
+	t.Parallel()
+
+	etcd := newFakeEtcd(t, "pa55", nil)
+
+	if _, err := EtcdDiscovery(EtcdDiscoveryConfig{Endpoint: etcd.URL, Prefix: "/services/", Username: "reader", Password: "wrong"})(context.Background()); err == nil {
+		t.Error("expected an authentication error")
+	}
+
+	if _, err := EtcdDiscovery(EtcdDiscoveryConfig{Endpoint: etcd.URL, Prefix: "/services/"})(context.Background()); err == nil {
+		t.Error("expected an error without credentials")
+	}
+
+	if _, err := EtcdDiscovery(EtcdDiscoveryConfig{Endpoint: etcd.URL})(context.Background()); err == nil {
+		t.Error("expected an error without a prefix")
+	}
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prefix string
+		want   []byte
+	}{
+		{"/a/", []byte("/a0")},
+		{"a\xff", []byte("b")},
+		{"\xff\xff", []byte{0}},
+	}
+
+	for _, tt := range tests {
+		if got := prefixRangeEnd(tt.prefix); !bytes.Equal(got, tt.want) {
+			t.Errorf("prefixRangeEnd(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
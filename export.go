@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// ExportFormat is the file format written by [Client.ExportAlerts].
+type ExportFormat int
+
+const (
+	// ExportNDJSON writes one JSON-encoded alert per line.
+	ExportNDJSON ExportFormat = iota
+
+	// ExportCSV writes a header row followed by one row per alert, with
+	// the columns listed in [ExportCSVColumns].
+	ExportCSV
+)
+
+// ExportCSVColumns returns the columns written by [ExportCSV], in order.
+// Metadata is written as a JSON object. Each call returns a new slice.
+func ExportCSVColumns() []string {
+	return strings.Fields("timestamp correlationId type severity slackChannelId routeKey " +
+		"header text author host link autoResolveSeconds metadata")
+}
+
+// ExportAlerts writes the alerts matching filter to w in the given format,
+// streaming them page by page from [Client.ListAlerts] so that large
+// extracts do not have to fit in memory. Output is written as it arrives;
+// if an error occurs, w holds the alerts exported up to that point. The
+// downloaded pages count towards [WithBandwidthLimit], if set.
+func (c *Client) ExportAlerts(ctx context.Context, filter AlertFilter, w io.Writer, format ExportFormat) error {
+	if w == nil {
+		return errors.New("export writer is nil")
+	}
+
+	var (
+		write func(*types.Alert) error
+		flush = func() error { return nil }
+	)
+
+	switch format {
+	case ExportNDJSON:
+		encoder := json.NewEncoder(w)
+		write = func(alert *types.Alert) error { return encoder.Encode(alert) }
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(ExportCSVColumns()); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+
+		write = func(alert *types.Alert) error {
+			row, err := csvRow(alert)
+			if err != nil {
+				return err
+			}
+
+			return writer.Write(row)
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return fmt.Errorf("unsupported export format %d", format)
+	}
+
+	for alert, err := range c.ListAlerts(withBulk(ctx), filter) {
+		if err != nil {
+			if flushErr := flush(); flushErr != nil {
+				return errors.Join(err, flushErr)
+			}
+
+			return err
+		}
+
+		if err := write(alert); err != nil {
+			return fmt.Errorf("failed to write alert: %w", err)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to write alerts: %w", err)
+	}
+
+	return nil
+}
+
+// csvRow returns the [ExportCSVColumns] values for alert.
+func csvRow(alert *types.Alert) ([]string, error) {
+	metadata := ""
+
+	if len(alert.Metadata) > 0 {
+		data, err := json.Marshal(alert.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+
+		metadata = string(data)
+	}
+
+	timestamp := ""
+	if !alert.Timestamp.IsZero() {
+		timestamp = alert.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	return []string{
+		timestamp,
+		alert.CorrelationID,
+		alert.Type,
+		string(alert.Severity),
+		alert.SlackChannelID,
+		alert.RouteKey,
+		alert.Header,
+		alert.Text,
+		alert.Author,
+		alert.Host,
+		alert.Link,
+		strconv.Itoa(alert.AutoResolveSeconds),
+		metadata,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestExportAlerts_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newListServer(t, 3)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.ExportAlerts(context.Background(), AlertFilter{}, &buf, ExportNDJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	lines := 0
+
+	for scanner.Scan() {
+		var alert types.Alert
+		if err := json.Unmarshal(scanner.Bytes(), &alert); err != nil {
+			t.Fatalf("line %d is not a JSON alert: %v", lines, err)
+		}
+
+		lines++
+	}
+
+	if lines != 3 {
+		t.Errorf("expected 3 lines, got %d", lines)
+	}
+}
+
+func TestExportAlerts_CSV(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newListServer(t, 3)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.ExportAlerts(context.Background(), AlertFilter{}, &buf, ExportCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("expected header and 3 rows, got %d records", len(records))
+	}
+
+	if strings.Join(records[0], ",") != strings.Join(ExportCSVColumns(), ",") {
+		t.Errorf("unexpected header row %v", records[0])
+	}
+
+	row := records[2]
+
+	if row[0] != "2024-01-01T00:01:00Z" || row[3] != "warning" || row[6] != "alert 1" || row[12] != `{"n":1}` {
+		t.Errorf("unexpected row %v", row)
+	}
+}
+
+func TestExportAlerts_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	if err := client.ExportAlerts(context.Background(), AlertFilter{}, nil, ExportNDJSON); err == nil {
+		t.Error("expected error for nil writer")
+	}
+
+	if err := client.ExportAlerts(context.Background(), AlertFilter{}, &bytes.Buffer{}, ExportFormat(9)); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	if err := client.ExportAlerts(context.Background(), AlertFilter{}, &bytes.Buffer{}, ExportCSV); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
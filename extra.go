@@ -0,0 +1,129 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// MaxExtraSize is the maximum JSON-encoded size, in bytes, of an alert's
+// Metadata map accepted by [SetExtra].
+const MaxExtraSize = 64 * 1024
+
+// SetExtra stores a custom field in the alert's Metadata map, which is sent
+// to the API unchanged and passed through to webhook payloads. The key is
+// trimmed of leading and trailing whitespace and must not be empty. The
+// value must be JSON-encodable, and the resulting Metadata map must not
+// exceed [MaxExtraSize] bytes when encoded; otherwise the alert is left
+// unchanged and an error is returned.
+func SetExtra(alert *types.Alert, key string, value any) error {
+	if alert == nil {
+		return errors.New("alert is nil")
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return errors.New("extra field key must not be empty")
+	}
+
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Errorf("extra field %q is not JSON-encodable: %w", key, err)
+	}
+
+	previous, existed := alert.Metadata[key]
+
+	if alert.Metadata == nil {
+		alert.Metadata = make(map[string]any)
+	}
+
+	alert.Metadata[key] = value
+
+	if size := metadataSize(alert); size > MaxExtraSize {
+		if existed {
+			alert.Metadata[key] = previous
+		} else {
+			delete(alert.Metadata, key)
+		}
+
+		return fmt.Errorf("extra field %q would grow metadata to %d bytes, exceeding the limit of %d", key, size, MaxExtraSize)
+	}
+
+	return nil
+}
+
+// GetExtra returns the custom field stored under key in the alert's
+// Metadata map, converted to T. Values that are not already of type T are
+// converted through their JSON representation, so a float64 decoded from
+// JSON can be read as an int, and a map as a struct. The second return
+// value is false if the key is missing or cannot be converted.
+func GetExtra[T any](alert *types.Alert, key string) (T, bool) {
+	var zero T
+
+	if alert == nil {
+		return zero, false
+	}
+
+	raw, ok := alert.Metadata[strings.TrimSpace(key)]
+	if !ok {
+		return zero, false
+	}
+
+	if v, ok := raw.(T); ok {
+		return v, true
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return zero, false
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return zero, false
+	}
+
+	return v, true
+}
+
+// DeleteExtra removes the custom field stored under key, if present.
+func DeleteExtra(alert *types.Alert, key string) {
+	if alert != nil {
+		delete(alert.Metadata, strings.TrimSpace(key))
+	}
+}
+
+// metadataSize returns the JSON-encoded size of the alert's Metadata map,
+// or -1 if it cannot be encoded.
+func metadataSize(alert *types.Alert) int {
+	if len(alert.Metadata) == 0 {
+		return 0
+	}
+
+	data, err := json.Marshal(alert.Metadata)
+	if err != nil {
+		return -1
+	}
+
+	return len(data)
+}
+
+// checkMetadataSize returns an error for the first alert whose Metadata map
+// exceeds limit bytes when encoded.
+func checkMetadataSize(alerts []*types.Alert, limit int) error {
+	for i, alert := range alerts {
+		size := metadataSize(alert)
+
+		if size < 0 {
+			return fmt.Errorf("alert at index %d has metadata that is not JSON-encodable", i)
+		}
+
+		if size > limit {
+			return fmt.Errorf("alert at index %d has %d bytes of metadata, exceeding the limit of %d", i, size, limit)
+		}
+	}
+
+	return nil
+}
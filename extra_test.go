@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSetExtra_GetExtra(t *testing.T) {
+	t.Parallel()
+
+	type owner struct {
+		Team  string `json:"team"`
+		Pager bool   `json:"pager"`
+	}
+
+	alert := &types.Alert{}
+
+	if err := SetExtra(alert, " ticket ", "OPS-1234"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := SetExtra(alert, "retries", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := SetExtra(alert, "owner", owner{Team: "sre", Pager: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Simulate a round trip through the API.
+	data, err := json.Marshal(alert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := &types.Alert{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := GetExtra[string](decoded, "ticket"); !ok || v != "OPS-1234" {
+		t.Errorf("expected ticket=OPS-1234, got %q (%v)", v, ok)
+	}
+
+	if v, ok := GetExtra[int](decoded, "retries"); !ok || v != 3 {
+		t.Errorf("expected retries=3, got %d (%v)", v, ok)
+	}
+
+	if v, ok := GetExtra[owner](decoded, "owner"); !ok || v.Team != "sre" || !v.Pager {
+		t.Errorf("unexpected owner: %+v (%v)", v, ok)
+	}
+
+	if _, ok := GetExtra[int](decoded, "ticket"); ok {
+		t.Error("expected string field not to convert to int")
+	}
+
+	if _, ok := GetExtra[string](decoded, "missing"); ok {
+		t.Error("expected missing key to report false")
+	}
+
+	DeleteExtra(decoded, "ticket")
+
+	if _, ok := GetExtra[string](decoded, "ticket"); ok {
+		t.Error("expected deleted key to be gone")
+	}
+}
+
+func TestSetExtra_Errors(t *testing.T) {
+	t.Parallel()
+
+	if err := SetExtra(nil, "k", 1); err == nil {
+		t.Error("expected error for nil alert")
+	}
+
+	alert := &types.Alert{}
+
+	if err := SetExtra(alert, "  ", 1); err == nil {
+		t.Error("expected error for empty key")
+	}
+
+	if err := SetExtra(alert, "ch", make(chan int)); err == nil {
+		t.Error("expected error for non-encodable value")
+	}
+
+	_ = SetExtra(alert, "big", "original")
+
+	err := SetExtra(alert, "big", strings.Repeat("x", MaxExtraSize))
+	if err == nil || !strings.Contains(err.Error(), "exceeding the limit") {
+		t.Fatalf("expected size limit error, got %v", err)
+	}
+
+	if v, _ := GetExtra[string](alert, "big"); v != "original" {
+		t.Errorf("expected previous value to be restored, got %q", v)
+	}
+}
+
+func TestSend_MaxMetadataSize(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithMaxMetadataSize(32))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	small := &types.Alert{Header: "ok", Metadata: map[string]any{"a": 1}}
+	large := &types.Alert{Header: "big", Metadata: map[string]any{"a": strings.Repeat("x", 64)}}
+
+	err := client.Send(context.Background(), small, large)
+	if err == nil || !strings.Contains(err.Error(), "alert at index 1 has") {
+		t.Fatalf("expected metadata size error, got %v", err)
+	}
+
+	if len(server.received()) != 0 {
+		t.Error("expected no request to be made")
+	}
+
+	if err := client.Send(context.Background(), small); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
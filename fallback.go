@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// FallbackError is returned by [Client.Send] when the manager API could not
+// be reached but the critical alerts in the batch were delivered by a
+// fallback transport, such as [WithFallbackWebhook] or [WithEmailFallback].
+// Alerts that are not critical were not delivered.
+type FallbackError struct {
+	// Transport names the fallback that delivered the alerts: "webhook" or
+	// "email".
+	Transport string
+
+	// Delivered is the number of critical alerts the fallback delivered.
+	Delivered int
+
+	// Err is the error returned by the manager API, together with the
+	// errors of any fallbacks that were tried first.
+	Err error
+}
+
+func (e *FallbackError) Error() string {
+	return fmt.Sprintf("%v (%d critical alert(s) delivered by the %s fallback)", e.Err, e.Delivered, e.Transport)
+}
+
+func (e *FallbackError) Unwrap() error {
+	return e.Err
+}
+
+// criticalAlerts returns the alerts with panic or error severity.
+func criticalAlerts(alerts []*types.Alert) []*types.Alert {
+	var critical []*types.Alert
+
+	for _, alert := range alerts {
+		if highPriority([]*types.Alert{alert}) {
+			critical = append(critical, alert)
+		}
+	}
+
+	return critical
+}
+
+// deliverFallback sends the critical alerts in a batch that the manager API
+// failed to accept, with error err, through the configured fallback
+// transports in turn: the webhook, then email. It returns the error
+// [Client.Send] should report.
+func (c *Client) deliverFallback(ctx context.Context, alerts []*types.Alert, err error) error {
+	critical := criticalAlerts(alerts)
+	if len(critical) == 0 {
+		return err
+	}
+
+	type fallback struct {
+		transport string
+		send      func() error
+	}
+
+	var fallbacks []fallback
+
+	if c.options.fallbackWebhook != "" {
+		fallbacks = append(fallbacks, fallback{"webhook", func() error {
+			return c.postSlackWebhook(ctx, c.options.fallbackWebhook, renderFallbackText(critical))
+		}})
+	}
+
+	if c.options.emailFallback != nil {
+		fallbacks = append(fallbacks, fallback{"email", func() error {
+			return c.sendFallbackEmail(ctx, critical)
+		}})
+	}
+
+	for _, f := range fallbacks {
+		fallbackErr := f.send()
+		if fallbackErr == nil {
+			c.logger(ctx).Warnf("manager API unreachable; delivered %d critical alert(s) by %s fallback", len(critical), f.transport)
+			return &FallbackError{Transport: f.transport, Delivered: len(critical), Err: err}
+		}
+
+		c.logger(ctx).Errorf("%s fallback failed for %d critical alert(s): %v", f.transport, len(critical), fallbackErr)
+		err = fmt.Errorf("%w; %s fallback also failed: %w", err, f.transport, fallbackErr)
+	}
+
+	return err
+}
+
+// renderFallbackText renders alerts as a minimal Slack message: a bold
+// severity and header line, the text, and the channel, correlation ID and
+// incident the alert was meant for, since the webhook posts to its own channel.
+func renderFallbackText(alerts []*types.Alert) string {
+	var b strings.Builder
+
+	for i, alert := range alerts {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+
+		fmt.Fprintf(&b, "*[%s] %s*", fallbackSeverity(alert), fallbackHeader(alert))
+
+		if alert.Text != "" {
+			b.WriteString("\n" + alert.Text)
+		}
+
+		if details := fallbackDetails(alert); details != "" {
+			b.WriteString("\n_" + details + "_")
+		}
+	}
+
+	return b.String()
+}
+
+// fallbackSeverity returns the alert severity in upper case.
+func fallbackSeverity(alert *types.Alert) string {
+	return strings.ToUpper(string(normalizeSeverity(alert.Severity)))
+}
+
+// fallbackHeader returns the alert header, or its fallback text if the
+// header is empty.
+func fallbackHeader(alert *types.Alert) string {
+	if alert.Header == "" {
+		return alert.FallbackText
+	}
+
+	return alert.Header
+}
+
+// fallbackDetails describes where the alert was meant to be posted.
+func fallbackDetails(alert *types.Alert) string {
+	var details []string
+
+	if alert.SlackChannelID != "" {
+		details = append(details, "channel "+alert.SlackChannelID)
+	}
+
+	if alert.RouteKey != "" {
+		details = append(details, "route key "+alert.RouteKey)
+	}
+
+	if alert.CorrelationID != "" {
+		details = append(details, "correlation ID "+alert.CorrelationID)
+	}
+
+	if incidentID := IncidentID(alert); incidentID != "" {
+		details = append(details, "incident "+incidentID)
+	}
+
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// newFallbackWebhook starts a TLS server recording the text of every
+// webhook message it receives.
+func newFallbackWebhook(t *testing.T, status int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+
+		mu.Lock()
+		messages = append(messages, msg["text"])
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), messages...)
+	}
+}
+
+// newUnreachableClient returns a connected client whose API server has
+// been shut down.
+func newUnreachableClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	client := New(api.URL, append([]Option{WithRetryCount(0)}, opts...)...)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	api.Close()
+
+	return client
+}
+
+func TestWithFallbackWebhook_DeliversCriticalAlerts(t *testing.T) {
+	t.Parallel()
+
+	webhook, messages := newFallbackWebhook(t, http.StatusOK)
+	client := newUnreachableClient(t, WithFallbackWebhook(webhook.URL+"/services/T/B/secret"))
+	client.webhookClient = webhook.Client()
+
+	critical := types.NewAlert(types.AlertError)
+	critical.Header = "Disk full"
+	critical.Text = "/var is at 100%"
+	critical.SlackChannelID = "C123"
+	critical.CorrelationID = "disk-var"
+
+	warning := types.NewAlert(types.AlertWarning)
+	warning.Header = "Disk filling"
+
+	err := client.Send(context.Background(), critical, warning)
+
+	var fallbackErr *FallbackError
+	if !errors.As(err, &fallbackErr) || fallbackErr.Transport != "webhook" || fallbackErr.Delivered != 1 {
+		t.Fatalf("expected a webhook FallbackError for one alert, got %v", err)
+	}
+
+	got := messages()
+	if len(got) != 1 {
+		t.Fatalf("expected one webhook message, got %q", got)
+	}
+
+	want := "*[ERROR] Disk full*\n/var is at 100%\n_channel C123, correlation ID disk-var_"
+	if got[0] != want {
+		t.Errorf("expected webhook message %q, got %q", want, got[0])
+	}
+}
+
+func TestWithFallbackWebhook_SkipsNonCriticalAlerts(t *testing.T) {
+	t.Parallel()
+
+	webhook, messages := newFallbackWebhook(t, http.StatusOK)
+	client := newUnreachableClient(t, WithFallbackWebhook(webhook.URL+"/hook"))
+	client.webhookClient = webhook.Client()
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertWarning))
+
+	var fallbackErr *FallbackError
+	if err == nil || errors.As(err, &fallbackErr) {
+		t.Fatalf("expected the API error to be returned unchanged, got %v", err)
+	}
+
+	if got := messages(); len(got) != 0 {
+		t.Errorf("expected no webhook messages, got %q", got)
+	}
+}
+
+func TestWithFallbackWebhook_WebhookFailure(t *testing.T) {
+	t.Parallel()
+
+	webhook, _ := newFallbackWebhook(t, http.StatusForbidden)
+	client := newUnreachableClient(t, WithFallbackWebhook(webhook.URL+"/services/T/B/secret"))
+	client.webhookClient = webhook.Client()
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertPanic))
+	if err == nil || !strings.Contains(err.Error(), "webhook fallback also failed") || !strings.Contains(err.Error(), "status code 403") {
+		t.Fatalf("expected both failures to be reported, got %v", err)
+	}
+
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("expected the webhook path to be omitted from the error, got %v", err)
+	}
+}
+
+func TestWithFallbackWebhook_NotUsedForErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	webhook, messages := newFallbackWebhook(t, http.StatusOK)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+
+	client := New(api.URL, WithRetryCount(0), WithFallbackWebhook(webhook.URL+"/hook"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	client.webhookClient = webhook.Client()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	if got := messages(); len(got) != 0 {
+		t.Errorf("expected no webhook messages while the API responds, got %q", got)
+	}
+}
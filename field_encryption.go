@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+const (
+	// EncryptionKeyIDHeader is the request header that carries the ID of
+	// the master key that wrapped the data key of an alert batch encrypted
+	// with [WithFieldEncryption].
+	EncryptionKeyIDHeader = "X-Slackmgr-Encryption-Key-Id"
+
+	// EncryptedFieldPrefix starts every field value encrypted by
+	// [WithFieldEncryption]. The rest of the value is the base64-encoded
+	// AES-GCM nonce followed by the ciphertext of the field's JSON value.
+	EncryptedFieldPrefix = "enc:v1:"
+
+	severityField       = "severity"
+	channelField        = "slackChannelId"
+	encryptionAlgorithm = "AES-256-GCM"
+	dataKeySize         = 32
+)
+
+// KeyProvider issues data keys for [WithFieldEncryption], backed by a key
+// management service such as AWS KMS, Google Cloud KMS or Vault Transit.
+// Implementations must be safe for concurrent use.
+type KeyProvider interface {
+	// GenerateDataKey returns a new 256-bit data key, both in plaintext and
+	// encrypted under the provider's master key.
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+}
+
+// DataKey is a data key issued by a [KeyProvider].
+type DataKey struct {
+	// KeyID identifies the master key that encrypted the data key.
+	KeyID string
+
+	// Plaintext is the 32-byte key used to encrypt the fields. It is never
+	// sent.
+	Plaintext []byte
+
+	// Encrypted is the key encrypted under the master key, sent with the
+	// batch so the manager can have it decrypted.
+	Encrypted []byte
+}
+
+// encryptionEnvelope describes the encryption of an alert batch. It is
+// added to the body under the "encryption" key.
+type encryptionEnvelope struct {
+	Algorithm    string   `json:"algorithm"`
+	KeyID        string   `json:"keyId"`
+	EncryptedKey []byte   `json:"encryptedKey"`
+	Fields       []string `json:"fields"`
+}
+
+// routingAlertFields are the JSON names of the alert fields the manager
+// needs in plaintext to route and deduplicate alerts, which
+// [WithFieldEncryption] refuses.
+func routingAlertFields() []string {
+	return []string{"timestamp", "correlationId", severityField, channelField, "routeKey"}
+}
+
+// encryptFields encrypts the fields configured with [WithFieldEncryption]
+// in every alert of an encoded alerts list, with a new data key, and adds
+// the encryption envelope. It returns the header to send with the body.
+func (c *Client) encryptFields(ctx context.Context, body []byte) ([]byte, http.Header, error) {
+	if c.options.encryptKeys == nil {
+		return body, nil, nil
+	}
+
+	key, err := c.options.encryptKeys.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	if key == nil || len(key.Plaintext) != dataKeySize {
+		return nil, nil, fmt.Errorf("data key must be %d bytes", dataKeySize)
+	}
+
+	block, err := aes.NewCipher(key.Plaintext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	// Random nonces keep the encryption approved in FIPS 140-3 mode.
+	aead, err := cipher.NewGCMWithRandomNonce(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	var list map[string]json.RawMessage
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode alerts list: %w", err)
+	}
+
+	var alerts []map[string]json.RawMessage
+	if err := json.Unmarshal(list["alerts"], &alerts); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode alerts list: %w", err)
+	}
+
+	for _, alert := range alerts {
+		for _, field := range c.options.encryptFields {
+			value, ok := alert[field]
+			if !ok || string(value) == "null" {
+				continue
+			}
+
+			// Seal prepends the nonce. The field name is authenticated, so
+			// ciphertexts cannot be moved between fields.
+			sealed := aead.Seal(nil, nil, value, []byte(field))
+
+			if alert[field], err = json.Marshal(EncryptedFieldPrefix + base64.StdEncoding.EncodeToString(sealed)); err != nil {
+				return nil, nil, fmt.Errorf("failed to encode field %q: %w", field, err)
+			}
+		}
+	}
+
+	envelope := encryptionEnvelope{Algorithm: encryptionAlgorithm, KeyID: key.KeyID, EncryptedKey: key.Encrypted, Fields: c.options.encryptFields}
+
+	if list["alerts"], err = json.Marshal(alerts); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode alerts list: %w", err)
+	}
+
+	if list["encryption"], err = json.Marshal(envelope); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode encryption envelope: %w", err)
+	}
+
+	if body, err = json.Marshal(list); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode alerts list: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set(EncryptionKeyIDHeader, key.KeyID)
+
+	return body, header, nil
+}
+
+// validateEncryptedFields checks the fields passed to [WithFieldEncryption].
+func validateEncryptedFields(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("at least one field must be set")
+	}
+
+	for _, field := range fields {
+		if field == "" {
+			return errors.New("field names must not be empty")
+		}
+
+		if slices.Contains(routingAlertFields(), field) {
+			return fmt.Errorf("field %q is needed by the manager to route alerts", field)
+		}
+	}
+
+	return nil
+}
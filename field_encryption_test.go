@@ -0,0 +1,181 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// staticKeys issues the same data key every time.
+type staticKeys struct {
+	key []byte
+	err error
+}
+
+func (k staticKeys) GenerateDataKey(context.Context) (*DataKey, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+
+	return &DataKey{KeyID: "kms-key-1", Plaintext: k.key, Encrypted: []byte("wrapped")}, nil
+}
+
+func decryptField(t *testing.T, key []byte, field string, value json.RawMessage) string {
+	t.Helper()
+
+	var encoded string
+	if err := json.Unmarshal(value, &encoded); err != nil || !strings.HasPrefix(encoded, EncryptedFieldPrefix) {
+		t.Fatalf("expected %s to be encrypted, got %s", field, value)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encoded, EncryptedFieldPrefix))
+	if err != nil {
+		t.Fatalf("invalid base64: %v", err)
+	}
+
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCMWithRandomNonce(block)
+
+	plain, err := aead.Open(nil, nil, sealed, []byte(field))
+	if err != nil {
+		t.Fatalf("failed to decrypt %s: %v", field, err)
+	}
+
+	return string(plain)
+}
+
+func TestWithFieldEncryption(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		keyID string
+		body  []byte
+	}
+
+	requests := make(chan request, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{r.Header.Get(EncryptionKeyIDHeader), body}
+	}))
+	defer server.Close()
+
+	key := bytes.Repeat([]byte{7}, 32)
+
+	client := New(server.URL, WithFieldEncryption(staticKeys{key: key}, "text", "header", "fields"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = "Card 4111 declined"
+	alert.Text = "customer jane@example.com"
+	alert.RouteKey = "payments"
+
+	if err := client.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if alert.Text != "customer jane@example.com" {
+		t.Errorf("expected the caller's alert to be left unchanged, got %q", alert.Text)
+	}
+
+	got := <-requests
+	if got.keyID != "kms-key-1" {
+		t.Errorf("expected the key ID header, got %q", got.keyID)
+	}
+
+	var list struct {
+		Alerts     []map[string]json.RawMessage `json:"alerts"`
+		Encryption encryptionEnvelope           `json:"encryption"`
+	}
+	if err := json.Unmarshal(got.body, &list); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+
+	if e := list.Encryption; e.Algorithm != "AES-256-GCM" || e.KeyID != "kms-key-1" || string(e.EncryptedKey) != "wrapped" || len(e.Fields) != 3 {
+		t.Errorf("unexpected envelope %+v", e)
+	}
+
+	sent := list.Alerts[0]
+
+	if text := decryptField(t, key, "text", sent["text"]); text != `"customer jane@example.com"` {
+		t.Errorf("expected the text to round-trip, got %s", text)
+	}
+
+	if header := decryptField(t, key, "header", sent["header"]); header != `"Card 4111 declined"` {
+		t.Errorf("expected the header to round-trip, got %s", header)
+	}
+
+	if string(sent["fields"]) != "null" || string(sent["routeKey"]) != `"payments"` {
+		t.Errorf("expected null and unlisted fields to be sent as is, got %s and %s", sent["fields"], sent["routeKey"])
+	}
+}
+
+func TestWithFieldEncryption_KeyErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, keys := range []staticKeys{{err: errors.New("kms unavailable")}, {key: []byte("short")}} {
+		server := newAlertRecorder(t)
+		client := connectTo(t, server.URL)
+		client.options.encryptKeys = keys
+		client.options.encryptFields = []string{"text"}
+
+		if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err == nil || !strings.Contains(err.Error(), "failed to encrypt alert fields") {
+			t.Errorf("expected an encryption error, got %v", err)
+		}
+
+		if got := server.received(); len(got) != 0 {
+			t.Errorf("expected nothing to be sent, got %v", got)
+		}
+	}
+}
+
+func TestWithFieldEncryption_Invalid(t *testing.T) {
+	t.Parallel()
+
+	keys := staticKeys{key: make([]byte, 32)}
+
+	tests := []struct {
+		name   string
+		kms    KeyProvider
+		fields []string
+	}{
+		{"nil provider", nil, []string{"text"}},
+		{"no fields", keys, nil},
+		{"empty field", keys, []string{""}},
+		{"routing field", keys, []string{"text", "routeKey"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New("http://localhost", WithFieldEncryption(tt.kms, tt.fields...))
+			if client.options.encryptKeys != nil || len(client.ConfigWarnings()) != 1 {
+				t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+			}
+		})
+	}
+
+	if fields := New("http://localhost", WithFieldEncryption(keys, "text")).EffectiveConfig().EncryptedFields; len(fields) != 1 {
+		t.Errorf("expected the snapshot to list the encrypted fields, got %v", fields)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/slackmgr/types"
+)
+
+const fingerprintVersion = "slackmgr-fingerprint-v1"
+
+// Fingerprint returns a stable identifier for the content of an alert,
+// suitable for deduplication and grouping. Two alerts have the same
+// fingerprint when their normalized routing, identity and content fields
+// are equal; the timestamp, metadata and presentation fields (username,
+// icon, footer, fields, webhooks, etc.) are ignored.
+//
+// The fingerprint is the lower-case hex SHA-256 digest of the following
+// values, each followed by a NUL byte:
+//
+//	"slackmgr-fingerprint-v1", slackChannelId, routeKey, type,
+//	correlationId, severity, header, text, author, host
+//
+// The values are normalized by [types.Alert.Clean], so other tools can
+// compute the same value: all are trimmed of leading and trailing
+// whitespace; slackChannelId is upper-cased; routeKey, type and severity
+// are lower-cased; newlines in header are replaced by spaces; an empty or
+// "critical" severity is replaced by "error"; and header, text, author and
+// host are truncated to [types.MaxHeaderLength], [types.MaxTextLength],
+// [types.MaxAuthorLength] and [types.MaxHostLength] as Clean does. A nil
+// alert has an empty fingerprint.
+func Fingerprint(alert *types.Alert) string {
+	if alert == nil {
+		return ""
+	}
+
+	// Clean a copy of only the fingerprinted fields, so that the alert and
+	// the fields and webhooks it points to are left untouched.
+	normalized := &types.Alert{
+		SlackChannelID: alert.SlackChannelID,
+		RouteKey:       alert.RouteKey,
+		Type:           alert.Type,
+		CorrelationID:  alert.CorrelationID,
+		Severity:       alert.Severity,
+		Header:         alert.Header,
+		Text:           alert.Text,
+		Author:         alert.Author,
+		Host:           alert.Host,
+	}
+	normalized.Clean()
+
+	h := sha256.New()
+
+	for _, field := range []string{
+		fingerprintVersion,
+		normalized.SlackChannelID,
+		normalized.RouteKey,
+		normalized.Type,
+		normalized.CorrelationID,
+		string(normalized.Severity),
+		normalized.Header,
+		normalized.Text,
+		normalized.Author,
+		normalized.Host,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
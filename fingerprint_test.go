@@ -0,0 +1,103 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestFingerprint_Stable(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{
+		SlackChannelID: "c123",
+		RouteKey:       " Payments ",
+		Header:         "Disk\nfull",
+		Text:           "  80% used ",
+		Severity:       "critical",
+		Timestamp:      time.Now(),
+		Metadata:       map[string]any{"x": 1},
+	}
+
+	b := &types.Alert{
+		SlackChannelID: "C123",
+		RouteKey:       "payments",
+		Header:         "Disk full",
+		Text:           "80% used",
+		Severity:       types.AlertError,
+		Timestamp:      time.Now().Add(-time.Hour),
+		Username:       "bot",
+	}
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("expected equivalent alerts to have the same fingerprint")
+	}
+
+	if len(Fingerprint(a)) != 64 {
+		t.Errorf("expected 64 hex characters, got %q", Fingerprint(a))
+	}
+}
+
+func TestFingerprint_KnownValue(t *testing.T) {
+	t.Parallel()
+
+	// Pinned so that an accidental change to the algorithm is caught; other
+	// implementations can use this value as a test vector.
+	alert := &types.Alert{SlackChannelID: "C123", Header: "Disk full", Severity: types.AlertWarning}
+
+	const want = "280f9252c0e7305f54c61a2931d65d81b0e19f36c2d61f46ded3354640cde353"
+	if got := Fingerprint(alert); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestFingerprint_Truncates(t *testing.T) {
+	t.Parallel()
+
+	header := strings.Repeat("x", types.MaxHeaderLength+20)
+	long := &types.Alert{SlackChannelID: "C123", Header: header}
+	cut := &types.Alert{SlackChannelID: "C123", Header: header[:types.MaxHeaderLength-3] + "..."}
+
+	if Fingerprint(long) != Fingerprint(cut) {
+		t.Error("expected the header to be truncated as types.Alert.Clean does")
+	}
+
+	if long.Header != header {
+		t.Error("expected the alert to be left untouched")
+	}
+}
+
+func TestFingerprint_Differs(t *testing.T) {
+	t.Parallel()
+
+	base := types.Alert{SlackChannelID: "C123", Header: "Disk full"}
+
+	variants := []func(a *types.Alert){
+		func(a *types.Alert) { a.SlackChannelID = "C456" },
+		func(a *types.Alert) { a.RouteKey = "payments" },
+		func(a *types.Alert) { a.Type = "security" },
+		func(a *types.Alert) { a.CorrelationID = "id" },
+		func(a *types.Alert) { a.Severity = types.AlertWarning },
+		func(a *types.Alert) { a.Header = "Disk empty" },
+		func(a *types.Alert) { a.Text = "text" },
+		func(a *types.Alert) { a.Author = "me" },
+		func(a *types.Alert) { a.Host = "db-1" },
+		// Field boundaries must be unambiguous.
+		func(a *types.Alert) { a.Header, a.Text = "Disk", "full" },
+	}
+
+	for i, modify := range variants {
+		variant := base
+		modify(&variant)
+
+		if Fingerprint(&variant) == Fingerprint(&base) {
+			t.Errorf("variant %d: expected a different fingerprint", i)
+		}
+	}
+
+	if Fingerprint(nil) != "" {
+		t.Error("expected empty fingerprint for nil alert")
+	}
+}
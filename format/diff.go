@@ -0,0 +1,265 @@
+package format
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around changes.
+	diffContext = 3
+
+	// maxDiffEdits bounds the work done to find the smallest diff. Inputs
+	// that differ in more lines than this are diffed as a replacement of
+	// everything between their common start and end.
+	maxDiffEdits = 1000
+)
+
+// diffOp is the kind of a diffLine.
+type diffOp byte
+
+const (
+	diffEqual  diffOp = ' '
+	diffDelete diffOp = '-'
+	diffInsert diffOp = '+'
+)
+
+// diffLine is a line of a diff, with the line numbers it has in the old
+// and new text, counting from 0.
+type diffLine struct {
+	op       diffOp
+	text     string
+	old, new int
+}
+
+// UnifiedDiff returns the line changes from before to after as a unified
+// diff in a code block, for alerts about configuration changes. Changes
+// are grouped into hunks with 3 lines of context, each starting with an
+// "@@ -l,s +l,s @@" header. It returns an empty string if before and
+// after are equal.
+//
+// If maxLines is greater than 0, the diff is cut after maxLines lines, or
+// sooner if it would exceed [MaxLength], and a last line says how many
+// lines were left out. If maxLines is 0 or less, the whole diff is
+// returned however long it is; send it with the client's snippet
+// conversion enabled to have the API store it in full while the alert
+// shows its start.
+func UnifiedDiff(before, after string, maxLines int) string {
+	if before == after {
+		return ""
+	}
+
+	diff := diffLines(splitLines(before), splitLines(after))
+	lines := make([]string, 0, len(diff))
+
+	for _, hunk := range hunks(diff) {
+		first := hunk[0]
+		oldCount, newCount := 0, 0
+
+		for _, line := range hunk {
+			switch line.op {
+			case diffEqual:
+				oldCount++
+				newCount++
+			case diffDelete:
+				oldCount++
+			case diffInsert:
+				newCount++
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("@@ -%s +%s @@", hunkRange(first.old, oldCount), hunkRange(first.new, newCount)))
+
+		for _, line := range hunk {
+			lines = append(lines, string(line.op)+breakFences(line.text))
+		}
+	}
+
+	if maxLines <= 0 {
+		return codeBlock(lines, "lines", 0, 0)
+	}
+
+	return codeBlock(lines, "lines", maxLines, MaxLength)
+}
+
+// splitLines splits s into lines, ignoring a final newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n")
+}
+
+// hunkRange formats the start and length of a hunk, counting lines from 1.
+// An empty range starts at the line before it, as in GNU diff.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// hunks groups the changes in diff with up to diffContext equal lines
+// around them. Changes separated by more than twice that many equal lines
+// go into separate hunks.
+func hunks(diff []diffLine) [][]diffLine {
+	var (
+		groups [][]diffLine
+		start  = -1
+		end    int
+	)
+
+	for i, line := range diff {
+		if line.op == diffEqual {
+			continue
+		}
+
+		from := max(i-diffContext, 0)
+
+		if start >= 0 && from > end {
+			groups = append(groups, diff[start:end])
+			start = -1
+		}
+
+		if start < 0 {
+			start = from
+		}
+
+		end = min(i+diffContext+1, len(diff))
+	}
+
+	if start >= 0 {
+		groups = append(groups, diff[start:end])
+	}
+
+	return groups
+}
+
+// diffLines returns a shortest edit script from a to b, using Myers'
+// algorithm on the lines between their common start and end.
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]diffLine, 0, len(a)+len(b)-prefix-suffix)
+
+	for i := range prefix {
+		diff = append(diff, diffLine{op: diffEqual, text: a[i], old: i, new: i})
+	}
+
+	diff = append(diff, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix)...)
+
+	for i := range suffix {
+		oldLine, newLine := len(a)-suffix+i, len(b)-suffix+i
+		diff = append(diff, diffLine{op: diffEqual, text: a[oldLine], old: oldLine, new: newLine})
+	}
+
+	return diff
+}
+
+// myers returns a shortest edit script from a to b, whose lines are
+// numbered from offset. If more than maxDiffEdits edits are needed, it
+// deletes all of a and inserts all of b instead.
+func myers(a, b []string, offset int) []diffLine {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxDiffEdits)
+
+	// v[k+limit+1] is the furthest x reached on diagonal k = x - y. trace
+	// holds v before each round d, for the diagonals round d reads.
+	v := make([]int, 2*limit+3)
+	trace := make([][]int, 0, limit+1)
+
+	for d := 0; d <= limit; d++ {
+		low, high := limit-d, limit+d+3
+		trace = append(trace, slices.Clone(v[low:high]))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[limit+k] < v[limit+k+2] {
+				x = v[limit+k+2]
+			} else {
+				x = v[limit+k] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[limit+k+1] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+
+	diff := make([]diffLine, 0, n+m)
+	for i, line := range a {
+		diff = append(diff, diffLine{op: diffDelete, text: line, old: offset + i, new: offset})
+	}
+
+	for i, line := range b {
+		diff = append(diff, diffLine{op: diffInsert, text: line, old: offset + n, new: offset + i})
+	}
+
+	return diff
+}
+
+// backtrack follows the rounds recorded by myers back from the end of a
+// and b to build the edit script.
+func backtrack(trace [][]int, a, b []string, offset int) []diffLine {
+	var diff []diffLine
+
+	x, y := len(a), len(b)
+
+	for d, v := range slices.Backward(trace) {
+		// v[k+d+1] is the furthest x on diagonal k before round d.
+		k := x - y
+
+		prevK := k - 1
+		if k == -d || k != d && v[k-1+d+1] < v[k+1+d+1] {
+			prevK = k + 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = v[prevK+d+1]
+		}
+
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY && x > 0 && y > 0 {
+			x--
+			y--
+			diff = append(diff, diffLine{op: diffEqual, text: a[x], old: offset + x, new: offset + y})
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			y--
+			diff = append(diff, diffLine{op: diffInsert, text: b[y], old: offset + x, new: offset + y})
+		} else {
+			x--
+			diff = append(diff, diffLine{op: diffDelete, text: a[x], old: offset + x, new: offset + y})
+		}
+	}
+
+	slices.Reverse(diff)
+
+	return diff
+}
@@ -0,0 +1,140 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n"
+
+	want := "```\n" +
+		"@@ -1,5 +1,5 @@\n" +
+		" a\n" +
+		"-b\n" +
+		"+B\n" +
+		" c\n" +
+		" d\n" +
+		" e\n" +
+		"@@ -11,3 +11,4 @@\n" +
+		" k\n" +
+		" l\n" +
+		" m\n" +
+		"+n\n" +
+		"```"
+	if got := UnifiedDiff(before, after, 0); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if got := UnifiedDiff("x\ny\n", "x\ny\n", 10); got != "" {
+		t.Errorf("expected no diff for equal inputs, got %q", got)
+	}
+}
+
+func TestUnifiedDiff_Edges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{"from empty", "", "a\nb", "```\n@@ -0,0 +1,2 @@\n+a\n+b\n```"},
+		{"to empty", "a\n", "", "```\n@@ -1,1 +0,0 @@\n-a\n```"},
+		{"merged hunks", "1\n2\n3\n4\n5\n6\n7\n8", "0\n2\n3\n4\n5\n6\n7\n9", "```\n@@ -1,8 +1,8 @@\n-1\n+0\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+9\n```"},
+		{"fences", "```\n", "~~~\n", "```\n@@ -1,1 +1,1 @@\n-'''\n+~~~\n```"},
+		{"crlf", "a\r\nb\r\n", "a\nc\n", "```\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := UnifiedDiff(tt.before, tt.after, 0); got != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_MaxLines(t *testing.T) {
+	t.Parallel()
+
+	var before, after strings.Builder
+	for i := range 100 {
+		fmt.Fprintf(&before, "line %d\n", i)
+		fmt.Fprintf(&after, "line %d changed\n", i)
+	}
+
+	got := UnifiedDiff(before.String(), after.String(), 10)
+	lines := strings.Split(got, "\n")
+
+	if len(lines) != 13 || lines[11] != "… 191 more lines" {
+		t.Errorf("expected 10 lines and a note, got\n%s", got)
+	}
+
+	full := UnifiedDiff(before.String(), after.String(), 0)
+	if strings.Count(full, "\n") != 202 {
+		t.Errorf("expected the whole diff without a limit, got %d lines", strings.Count(full, "\n"))
+	}
+
+	huge := strings.Repeat(strings.Repeat("x", 100)+"\n", 200)
+	if got := UnifiedDiff("", huge, 1000); utf8.RuneCountInString(got) > MaxLength {
+		t.Errorf("expected the diff to be kept within %d characters, got %d", MaxLength, utf8.RuneCountInString(got))
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	// The example from Myers' paper.
+	a := []string{"a", "b", "c", "a", "b", "b", "a"}
+	b := []string{"c", "b", "a", "b", "a", "c"}
+
+	diff := diffLines(a, b)
+
+	edits := 0
+	var gotA, gotB []string
+
+	for _, line := range diff {
+		switch line.op {
+		case diffEqual:
+			gotA = append(gotA, line.text)
+			gotB = append(gotB, line.text)
+
+			if a[line.old] != line.text || b[line.new] != line.text {
+				t.Errorf("wrong line numbers in %+v", line)
+			}
+		case diffDelete:
+			gotA = append(gotA, line.text)
+			edits++
+		case diffInsert:
+			gotB = append(gotB, line.text)
+			edits++
+		}
+	}
+
+	if strings.Join(gotA, " ") != strings.Join(a, " ") || strings.Join(gotB, " ") != strings.Join(b, " ") {
+		t.Errorf("diff does not rebuild its inputs: %+v", diff)
+	}
+
+	if edits != 5 {
+		t.Errorf("expected the shortest edit script of 5 edits, got %d", edits)
+	}
+
+	many := make([]string, 1200)
+	other := make([]string, 1200)
+	for i := range many {
+		many[i] = fmt.Sprint("old ", i)
+		other[i] = fmt.Sprint("new ", i)
+	}
+
+	if got := diffLines(many, other); len(got) != 2400 || got[0].op != diffDelete || got[1200].op != diffInsert {
+		t.Errorf("expected a full replacement past the edit limit, got %d lines", len(got))
+	}
+}
@@ -0,0 +1,28 @@
+// Package format builds alert text that renders well in Slack.
+//
+// [Table] and [KV] lay out rows and key-value pairs as aligned columns in
+// a monospace code block:
+//
+//	alert.Text = format.Table([][]string{
+//	    {"Host", "CPU", "Memory"},
+//	    {"db-1", "97%", "12.1 GiB"},
+//	    {"db-2", "41%", "8.0 GiB"},
+//	})
+//
+// renders as
+//
+//	Host  CPU  Memory
+//	----  ---  --------
+//	db-1  97%  12.1 GiB
+//	db-2  41%  8.0 GiB
+//
+// The output always fits in [MaxLength] characters, the limit of
+// [types.Alert.Text]. Long cells are cut short and rows that do not fit
+// are left out, with a last line saying how many.
+//
+// [UnifiedDiff] renders the changes between two texts, such as two
+// versions of a configuration file, as a unified diff.
+//
+// [Sparkline] and [Chart] show the shape of a metric series, as a line of
+// block characters or as a PNG image to upload as an asset.
+package format
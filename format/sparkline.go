@@ -0,0 +1,248 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// sparkBars are the levels of a sparkline, from lowest to highest.
+	sparkBars = "▁▂▃▄▅▆▇█"
+
+	defaultChartWidth  = 400
+	defaultChartHeight = 100
+
+	// chartMargin keeps the line clear of the edges of a chart.
+	chartMargin = 4
+
+	// maxChartSize bounds the width and height of a chart, in pixels.
+	maxChartSize = 4000
+)
+
+// ChartOptions configures [Chart].
+type ChartOptions struct {
+	// Width and Height are the size of the image in pixels. They default
+	// to 400 and 100.
+	Width  int
+	Height int
+
+	// Color is the colour of the line. The area under it is filled with
+	// a lighter shade. Defaults to Slack blue.
+	Color color.Color
+
+	// Thresholds are drawn as dashed red lines, for example for the limit
+	// an alert fired on. They are included in the range of the y axis.
+	Thresholds []float64
+}
+
+// Sparkline renders series as a line of block characters, one per value,
+// scaled from the lowest value to the highest, such as "▁▂▄▇█▅▂". NaN and
+// infinite values are shown as spaces. A series whose values are all the
+// same is drawn at half height. Sparkline returns an empty string if
+// series is empty.
+func Sparkline(series []float64) string {
+	low, high, ok := seriesRange(series)
+	if !ok {
+		return strings.Repeat(" ", len(series))
+	}
+
+	bars := []rune(sparkBars)
+
+	var b strings.Builder
+
+	for _, value := range series {
+		switch {
+		case !isFinite(value):
+			b.WriteRune(' ')
+		case high == low:
+			b.WriteRune(bars[len(bars)/2-1])
+		default:
+			b.WriteRune(bars[int((value-low)/(high-low)*float64(len(bars)-1))])
+		}
+	}
+
+	return b.String()
+}
+
+// Chart renders series as a PNG line chart, to upload as an asset and link
+// from an alert when a sparkline is too coarse. Values are spaced evenly
+// from left to right; NaN and infinite values leave gaps in the line. It
+// returns an error if series has no finite values or the size is invalid.
+func Chart(series []float64, opts ChartOptions) ([]byte, error) {
+	width, height := opts.Width, opts.Height
+	if width == 0 {
+		width = defaultChartWidth
+	}
+
+	if height == 0 {
+		height = defaultChartHeight
+	}
+
+	if width <= 2*chartMargin || height <= 2*chartMargin || width > maxChartSize || height > maxChartSize {
+		return nil, fmt.Errorf("chart size must be between %d and %d pixels, got %dx%d", 2*chartMargin+1, maxChartSize, width, height)
+	}
+
+	low, high, ok := seriesRange(slices.Concat(series, opts.Thresholds))
+	if !ok {
+		return nil, errors.New("series has no finite values")
+	}
+
+	if high == low {
+		low, high = low-1, high+1
+	}
+
+	line := opts.Color
+	if line == nil {
+		line = color.RGBA{R: 0x12, G: 0x64, B: 0xa3, A: 0xff}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, img.Bounds(), color.White)
+
+	fill := lighten(line)
+	plotX := func(i int) int {
+		if len(series) == 1 {
+			return width / 2
+		}
+
+		return chartMargin + i*(width-1-2*chartMargin)/(len(series)-1)
+	}
+	plotY := func(value float64) int {
+		return chartMargin + int(math.Round((high-value)/(high-low)*float64(height-1-2*chartMargin)))
+	}
+
+	for _, threshold := range opts.Thresholds {
+		if !isFinite(threshold) {
+			continue
+		}
+
+		y := plotY(threshold)
+		for x := range width {
+			if x/4%2 == 0 {
+				img.Set(x, y, color.RGBA{R: 0xe0, G: 0x1e, B: 0x5a, A: 0xff})
+			}
+		}
+	}
+
+	for i, value := range series {
+		if !isFinite(value) {
+			continue
+		}
+
+		x, y := plotX(i), plotY(value)
+
+		if i+1 < len(series) && isFinite(series[i+1]) {
+			nextX, nextY := plotX(i+1), plotY(series[i+1])
+
+			for column := x; column <= nextX; column++ {
+				top := y
+				if nextX > x {
+					top = y + (nextY-y)*(column-x)/(nextX-x)
+				}
+
+				fillRect(img, image.Rect(column, top+1, column+1, height-chartMargin), fill)
+			}
+
+			drawLine(img, x, y, nextX, nextY, line)
+		} else {
+			fillRect(img, image.Rect(x-1, y-1, x+2, y+2), line)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// seriesRange returns the lowest and highest finite values of series, and
+// false if it has none.
+func seriesRange(series []float64) (float64, float64, bool) {
+	low, high := math.Inf(1), math.Inf(-1)
+
+	for _, value := range series {
+		if isFinite(value) {
+			low, high = min(low, value), max(high, value)
+		}
+	}
+
+	return low, high, low <= high
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// lighten mixes c with four parts of white.
+func lighten(c color.Color) color.Color {
+	r, g, b, _ := c.RGBA()
+	mix := func(v uint32) uint8 { return uint8((v>>8 + 4*0xff) / 5) }
+
+	return color.RGBA{R: mix(r), G: mix(g), B: mix(b), A: 0xff}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	rect = rect.Intersect(img.Bounds())
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// drawLine draws a line two pixels thick from (x0, y0) to (x1, y1), with
+// Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+
+	for {
+		fillRect(img, image.Rect(x0, y0, x0+2, y0+2), c)
+
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * err
+
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		series []float64
+		want   string
+	}{
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+		{[]float64{10, 80, math.NaN(), 45, 10}, "▁█ ▄▁"},
+		{[]float64{3, 3, 3}, "▄▄▄"},
+		{[]float64{math.Inf(1), math.NaN()}, "  "},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Sparkline(tt.series); got != tt.want {
+			t.Errorf("Sparkline(%v): expected %q, got %q", tt.series, tt.want, got)
+		}
+	}
+}
+
+func TestChart(t *testing.T) {
+	t.Parallel()
+
+	series := []float64{1, 3, 2, math.NaN(), 8, 5, 9}
+
+	data, err := Chart(series, ChartOptions{Thresholds: []float64{7}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != defaultChartWidth || b.Dy() != defaultChartHeight {
+		t.Errorf("expected the default size, got %v", b)
+	}
+
+	blue := color.RGBAModel.Convert(color.RGBA{R: 0x12, G: 0x64, B: 0xa3, A: 0xff})
+
+	// The last value is the highest, so the line ends in the top right.
+	if got := color.RGBAModel.Convert(img.At(defaultChartWidth-1-chartMargin, chartMargin)); got != blue {
+		t.Errorf("expected the line at the last value, got %v", got)
+	}
+
+	if got := color.RGBAModel.Convert(img.At(0, 0)); got != color.RGBAModel.Convert(color.White) {
+		t.Errorf("expected a white background, got %v", got)
+	}
+
+	if _, err := Chart(series, ChartOptions{Width: 4000, Height: 20, Color: color.Black}); err != nil {
+		t.Errorf("unexpected error for a custom size: %v", err)
+	}
+
+	if _, err := Chart([]float64{5}, ChartOptions{}); err != nil {
+		t.Errorf("unexpected error for a single value: %v", err)
+	}
+
+	for _, opts := range []ChartOptions{{Width: 8}, {Height: 5000}, {Width: -1}} {
+		if _, err := Chart(series, opts); err == nil {
+			t.Errorf("expected an error for size %dx%d", opts.Width, opts.Height)
+		}
+	}
+
+	if _, err := Chart([]float64{math.NaN()}, ChartOptions{}); err == nil {
+		t.Error("expected an error for a series without values")
+	}
+}
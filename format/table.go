@@ -0,0 +1,190 @@
+package format
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// MaxLength is the most characters returned by [Table] and [KV], the
+	// length limit of [types.Alert.Text].
+	MaxLength = types.MaxTextLength
+
+	// MaxCellWidth is the most characters shown of a [Table] cell or a
+	// [KV] key. Longer ones are cut short and end with "…".
+	MaxCellWidth = 60
+)
+
+const (
+	codeFence     = "```"
+	columnPadding = "  "
+)
+
+// Table lays out rows as aligned columns in a code block, with the first
+// row as the header, underlined. Rows may have different lengths; missing
+// cells are left empty. Newlines and tabs in cells are replaced with
+// spaces. Rows that would take the block past [MaxLength] are left out,
+// and a last line says how many. Table returns an empty string if rows is
+// empty.
+//
+// Columns are aligned by counting characters, so cells with wide
+// characters, such as CJK text or emoji, can push later columns out of
+// line.
+func Table(rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cells := make([][]string, len(rows))
+	columns := 0
+
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, cell := range row {
+			cells[i][j] = cut(clean(cell), MaxCellWidth)
+		}
+
+		columns = max(columns, len(row))
+	}
+
+	widths := make([]int, columns)
+	for _, row := range cells {
+		for j, cell := range row {
+			widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+		}
+	}
+
+	lines := make([]string, 0, len(cells)+1)
+	lines = append(lines, alignRow(cells[0], widths))
+
+	if len(cells) > 1 {
+		underline := make([]string, columns)
+		for j, width := range widths {
+			underline[j] = strings.Repeat("-", width)
+		}
+
+		lines = append(lines, alignRow(underline, widths))
+
+		for _, row := range cells[1:] {
+			lines = append(lines, alignRow(row, widths))
+		}
+	}
+
+	return codeBlock(lines, "rows", 0, MaxLength)
+}
+
+// KV lays out the pairs of m as aligned "key: value" lines in a code
+// block, sorted by key. Values are formatted with [fmt.Sprint]. Keys are
+// cut short at [MaxCellWidth]; values are not, beyond keeping the block
+// within [MaxLength]. KV returns an empty string if m is empty.
+func KV[V any](m map[string]V) string {
+	if len(m) == 0 {
+		return ""
+	}
+
+	keys := slices.Sorted(maps.Keys(m))
+
+	labels := make([]string, len(keys))
+	width := 0
+
+	for i, key := range keys {
+		labels[i] = cut(clean(key), MaxCellWidth) + ":"
+		width = max(width, utf8.RuneCountInString(labels[i]))
+	}
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = alignRow([]string{labels[i], clean(fmt.Sprint(m[key]))}, []int{width, 0})
+	}
+
+	return codeBlock(lines, "keys", 0, MaxLength)
+}
+
+// alignRow pads each cell but the last to the width of its column.
+func alignRow(cells []string, widths []int) string {
+	var b strings.Builder
+
+	for j, cell := range cells {
+		if j > 0 {
+			b.WriteString(columnPadding)
+		}
+
+		b.WriteString(cell)
+
+		if j < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+		}
+	}
+
+	return strings.TrimRight(b.String(), " ")
+}
+
+// codeBlock joins lines into a code block of at most maxLines lines and
+// maxLength characters, where 0 means no limit. If they do not all fit, it
+// keeps as many as it can and ends with a line saying how many of what
+// were left out.
+func codeBlock(lines []string, what string, maxLines, maxLength int) string {
+	// The opening fence is followed by a newline, the closing one is not.
+	size := 2*len(codeFence) + 1
+	for _, line := range lines {
+		size += utf8.RuneCountInString(line) + 1
+	}
+
+	keep := len(lines)
+	note := ""
+
+	for keep > 0 && (maxLength > 0 && size > maxLength || maxLines > 0 && keep > maxLines) {
+		keep--
+		size -= utf8.RuneCountInString(lines[keep]) + 1
+
+		if note != "" {
+			size -= utf8.RuneCountInString(note) + 1
+		}
+
+		note = fmt.Sprintf("… %d more %s", len(lines)-keep, what)
+		size += utf8.RuneCountInString(note) + 1
+	}
+
+	var b strings.Builder
+
+	b.WriteString(codeFence + "\n")
+
+	for _, line := range lines[:keep] {
+		b.WriteString(line + "\n")
+	}
+
+	if note != "" {
+		b.WriteString(note + "\n")
+	}
+
+	b.WriteString(codeFence)
+
+	return b.String()
+}
+
+// clean makes s safe to show on one line of a code block: it replaces
+// line breaks and tabs with spaces, and breaks up code fences, which
+// would end the block.
+func clean(s string) string {
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ").Replace(s)
+	return strings.TrimSpace(breakFences(s))
+}
+
+// breakFences replaces the code fences in s with quotes.
+func breakFences(s string) string {
+	return strings.ReplaceAll(s, codeFence, "'''")
+}
+
+// cut shortens s to width characters, ending it with "…" if it was cut.
+func cut(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+
+	return string([]rune(s)[:width-1]) + "…"
+}
@@ -0,0 +1,93 @@
+package format
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	got := Table([][]string{
+		{"Host", "CPU", "Memory"},
+		{"db-1", "97%", "12.1 GiB"},
+		{"db-replica-2", "4%"},
+		{"cache\n1", "", "```x```"},
+	})
+
+	want := "```\n" +
+		"Host          CPU  Memory\n" +
+		"------------  ---  --------\n" +
+		"db-1          97%  12.1 GiB\n" +
+		"db-replica-2  4%\n" +
+		"cache 1            '''x'''\n" +
+		"```"
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if got := Table([][]string{{"only", "header"}}); got != "```\nonly  header\n```" {
+		t.Errorf("unexpected header-only table %q", got)
+	}
+
+	if Table(nil) != "" {
+		t.Error("expected an empty table for no rows")
+	}
+}
+
+func TestTable_Limits(t *testing.T) {
+	t.Parallel()
+
+	rows := make([][]string, 0, 501)
+	rows = append(rows, []string{"ID", "Message"})
+	for range 500 {
+		rows = append(rows, []string{"1234", strings.Repeat("é", 100)})
+	}
+
+	got := Table(rows)
+	if n := utf8.RuneCountInString(got); n > MaxLength {
+		t.Errorf("expected at most %d characters, got %d", MaxLength, n)
+	}
+
+	lines := strings.Split(got, "\n")
+	if note := lines[len(lines)-2]; !strings.HasPrefix(note, "… ") || !strings.HasSuffix(note, " more rows") {
+		t.Errorf("expected a note about the rows left out, got %q", note)
+	}
+
+	if width := utf8.RuneCountInString(lines[3]); width != len("1234")+len(columnPadding)+MaxCellWidth {
+		t.Errorf("expected long cells to be cut at %d characters, got line %q", MaxCellWidth, lines[3])
+	}
+
+	if !strings.HasSuffix(lines[3], "é…") {
+		t.Errorf("expected a cut cell to end with an ellipsis, got %q", lines[3])
+	}
+}
+
+func TestKV(t *testing.T) {
+	t.Parallel()
+
+	got := KV(map[string]any{"region": "eu-west-1", "host": "db-1", "replica lag": 2.5})
+
+	want := "```\n" +
+		"host:         db-1\n" +
+		"region:       eu-west-1\n" +
+		"replica lag:  2.5\n" +
+		"```"
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if KV(map[string]int(nil)) != "" {
+		t.Error("expected an empty block for no pairs")
+	}
+
+	large := make(map[string]string)
+	for i := range 1000 {
+		large[strings.Repeat("k", i%50)+string(rune('a'+i%26))+strings.Repeat("x", i/26)] = strings.Repeat("v", 20)
+	}
+
+	if got := KV(large); utf8.RuneCountInString(got) > MaxLength || !strings.Contains(got, " more keys\n```") {
+		t.Errorf("expected a block within the limit with a note, got %d characters", utf8.RuneCountInString(got))
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const graphQLEndpoint = "graphql"
+
+// GraphQLError is a single error from the "errors" array of a GraphQL
+// response.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []any                  `json:"path,omitempty"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Extensions map[string]any         `json:"extensions,omitempty"`
+}
+
+// GraphQLErrorLocation is a position in the query document.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error implements the error interface.
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	parts := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		parts[i] = fmt.Sprint(p)
+	}
+
+	return strings.Join(parts, ".") + ": " + e.Message
+}
+
+// Code returns extensions.code, if the server set one.
+func (e *GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQLErrors is returned by [Client.GraphQL] when the response contains
+// errors. Partial data, if any, has still been decoded into out. Use
+// [errors.As] to inspect the individual errors.
+type GraphQLErrors []*GraphQLError
+
+// Error implements the error interface.
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// GraphQL runs query against the API's GraphQL endpoint, with the same
+// authentication, retries and logging as every other request, and decodes
+// the "data" field of the response into out. out may be nil to discard the
+// data.
+//
+// If the response contains GraphQL errors, they are returned as
+// [GraphQLErrors] after any partial data has been decoded.
+func (c *Client) GraphQL(ctx context.Context, query string, vars map[string]any, out any) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(query) == "" {
+		return errors.New("graphql query must not be empty")
+	}
+
+	var response graphQLResponse
+	if err := c.doJSON(ctx, http.MethodPost, graphQLEndpoint, nil, graphQLRequest{Query: query, Variables: vars}, &response); err != nil {
+		return err
+	}
+
+	if out != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return fmt.Errorf("failed to decode graphql data: %w", err)
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+
+	return nil
+}
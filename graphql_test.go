@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var req graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		if r.URL.Path != "/graphql" || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected request %s with auth %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+
+		if req.Variables["team"] == "broken" {
+			_, _ = w.Write([]byte(`{"data":{"issues":null},"errors":[{"message":"team not found","path":["issues",0],"extensions":{"code":"NOT_FOUND"}}]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"issues":[{"id":"i1"},{"id":"i2"}]}}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithAuthToken("secret"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out struct {
+		Issues []struct {
+			ID string `json:"id"`
+		} `json:"issues"`
+	}
+
+	query := `query($team: String!) { issues(team: $team) { id } }`
+
+	if err := client.GraphQL(context.Background(), query, map[string]any{"team": "db"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Issues) != 2 || out.Issues[1].ID != "i2" {
+		t.Errorf("unexpected data %+v", out)
+	}
+
+	err := client.GraphQL(context.Background(), query, map[string]any{"team": "broken"}, &out)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		t.Fatalf("expected GraphQLErrors, got %v", err)
+	}
+
+	if len(gqlErrs) != 1 || gqlErrs[0].Code() != "NOT_FOUND" || err.Error() != "graphql: issues.0: team not found" {
+		t.Errorf("unexpected errors %v", err)
+	}
+}
+
+func TestGraphQL_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	if err := client.GraphQL(context.Background(), "", nil, nil); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
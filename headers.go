@@ -0,0 +1,120 @@
+package client
+
+import (
+	"errors"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// headerOverride is a header set with [Client.SetHeader], or removed with
+// [Client.DeleteHeader] when deleted is true.
+type headerOverride struct {
+	value   string
+	deleted bool
+}
+
+// headerOverrides holds the headers changed at runtime. Writers replace
+// the whole map under mu, so requests read it without locking.
+type headerOverrides struct {
+	mu      sync.Mutex
+	current atomic.Pointer[map[string]headerOverride]
+}
+
+// load returns the current overrides, which must not be modified.
+func (h *headerOverrides) load() map[string]headerOverride {
+	if current := h.current.Load(); current != nil {
+		return *current
+	}
+
+	return nil
+}
+
+// update stores a copy of the overrides changed by fn.
+func (h *headerOverrides) update(fn func(map[string]headerOverride)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	next := maps.Clone(h.load())
+	if next == nil {
+		next = make(map[string]headerOverride)
+	}
+
+	fn(next)
+	h.current.Store(&next)
+}
+
+// apply applies the overrides to req. It runs before every attempt.
+func (h *headerOverrides) apply(req *http.Request) {
+	for key, override := range h.load() {
+		if override.deleted {
+			req.Header.Del(key)
+		} else {
+			req.Header.Set(key, override.value)
+		}
+	}
+}
+
+// SetHeader sets a header sent with every subsequent request made by this
+// client, replacing any value set with [WithRequestHeader]. Unlike the
+// options, it is safe to call concurrently with requests, including after
+// [Client.Connect], so headers such as a tenant or trace baggage can change
+// at runtime. The name and value are trimmed of surrounding whitespace.
+// Derived clients created with [Client.With] start with a copy of this
+// client's runtime headers and are not affected by later changes.
+func (c *Client) SetHeader(key, value string) error {
+	key, err := runtimeHeaderKey(c, key)
+	if err != nil {
+		return err
+	}
+
+	value = strings.TrimSpace(value)
+
+	c.headers.update(func(m map[string]headerOverride) {
+		m[key] = headerOverride{value: value}
+	})
+
+	return nil
+}
+
+// DeleteHeader stops sending a header, whether it was set with
+// [Client.SetHeader] or [WithRequestHeader]. Like [Client.SetHeader], it is
+// safe to call concurrently with requests.
+func (c *Client) DeleteHeader(key string) error {
+	key, err := runtimeHeaderKey(c, key)
+	if err != nil {
+		return err
+	}
+
+	c.headers.update(func(m map[string]headerOverride) {
+		m[key] = headerOverride{deleted: true}
+	})
+
+	return nil
+}
+
+// runtimeHeaderKey validates and canonicalizes a header name passed to
+// [Client.SetHeader] or [Client.DeleteHeader].
+func runtimeHeaderKey(c *Client, key string) (string, error) {
+	if c == nil {
+		return "", errors.New("alert client is nil")
+	}
+
+	key = strings.TrimSpace(key)
+
+	if key == "" {
+		return "", errors.New("header name must not be empty")
+	}
+
+	if strings.ContainsAny(key, " \t\r\n:") {
+		return "", errors.New("header name must not contain whitespace or colons")
+	}
+
+	if strings.EqualFold(key, "Content-Type") || strings.EqualFold(key, "Accept") {
+		return "", errors.New("protected header " + key + " cannot be changed")
+	}
+
+	return http.CanonicalHeaderKey(key), nil
+}
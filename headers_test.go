@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestClient_SetHeader(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		tenants []string
+		teams   []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tenants = append(tenants, r.Header.Get("X-Tenant"))
+		teams = append(teams, r.Header.Get("X-Team"))
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRequestHeader("X-Team", "payments"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.SetHeader("x-tenant", " acme "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteHeader("X-TEAM"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Go(func() {
+			if i%2 == 0 {
+				_ = client.SetHeader("X-Tenant", "acme")
+				return
+			}
+
+			if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// The first request is the ping made by Connect.
+	if tenants[0] != "" || teams[0] != "payments" {
+		t.Errorf("expected the ping to use the original headers, got tenant %q team %q", tenants[0], teams[0])
+	}
+
+	for i := 1; i < len(tenants); i++ {
+		if tenants[i] != "acme" || teams[i] != "" {
+			t.Errorf("request %d: expected X-Tenant=acme without X-Team, got %q and %q", i, tenants[i], teams[i])
+		}
+	}
+
+	snapshot := client.EffectiveConfig()
+	if snapshot.RequestHeaders["X-Tenant"] != "acme" {
+		t.Errorf("expected the runtime header in the config snapshot, got %v", snapshot.RequestHeaders)
+	}
+
+	if _, ok := snapshot.RequestHeaders["X-Team"]; ok {
+		t.Errorf("expected the deleted header to be absent from the config snapshot, got %v", snapshot.RequestHeaders)
+	}
+}
+
+func TestClient_SetHeader_Derived(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+	_ = client.SetHeader("X-Tenant", "acme")
+
+	derived, err := client.With(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = client.SetHeader("X-Tenant", "globex")
+
+	if got := derived.headers.load()["X-Tenant"].value; got != "acme" {
+		t.Errorf("expected the derived client to keep the copied header, got %q", got)
+	}
+}
+
+func TestClient_SetHeader_Invalid(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	for _, key := range []string{"", "  ", "Content-Type", "accept", "X Tenant", "X-Tenant:"} {
+		if err := client.SetHeader(key, "v"); err == nil {
+			t.Errorf("expected an error for header %q", key)
+		}
+	}
+
+	var nilClient *Client
+	if err := nilClient.DeleteHeader("X-Tenant"); err == nil || err.Error() != "alert client is nil" {
+		t.Errorf("expected a nil client error, got %v", err)
+	}
+}
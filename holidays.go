@@ -0,0 +1,208 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const holidayDateLayout = "2006-01-02"
+
+// HolidayProvider reports whether a date is a holiday. It is consulted by
+// [Schedule.Contains], and holidays are treated as outside business hours.
+// Set it on [Schedule].Holidays to make quiet hours respect regional
+// holidays. Implementations must be safe for concurrent use.
+type HolidayProvider interface {
+	// IsHoliday reports whether the calendar date of t, in t's location, is
+	// a holiday.
+	IsHoliday(t time.Time) bool
+}
+
+// StaticHolidays is a [HolidayProvider] backed by a fixed set of dates. Use
+// [NewStaticHolidays] or [LoadHolidayFile] to create one.
+type StaticHolidays struct {
+	dates map[string]string
+}
+
+// NewStaticHolidays returns a [StaticHolidays] containing the calendar
+// dates of the given times.
+func NewStaticHolidays(dates ...time.Time) *StaticHolidays {
+	h := &StaticHolidays{dates: make(map[string]string, len(dates))}
+
+	for _, d := range dates {
+		h.dates[d.Format(holidayDateLayout)] = ""
+	}
+
+	return h
+}
+
+// IsHoliday reports whether the calendar date of t is in the set.
+func (h *StaticHolidays) IsHoliday(t time.Time) bool {
+	_, ok := h.dates[t.Format(holidayDateLayout)]
+	return ok
+}
+
+// Name returns the name recorded for the holiday on the calendar date of t,
+// if any.
+func (h *StaticHolidays) Name(t time.Time) string {
+	return h.dates[t.Format(holidayDateLayout)]
+}
+
+// Len returns the number of holidays in the set.
+func (h *StaticHolidays) Len() int {
+	return len(h.dates)
+}
+
+// LoadHolidayFile reads holidays from a file. Files with an .ics extension
+// are parsed as iCalendar (see [ParseICalHolidays]); all other files are
+// parsed as plain text (see [ParseHolidays]).
+func LoadHolidayFile(path string) (*StaticHolidays, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".ics") {
+		return ParseICalHolidays(f)
+	}
+
+	return ParseHolidays(f)
+}
+
+// ParseHolidays parses a plain-text holiday list with one date per line, in
+// YYYY-MM-DD format, optionally followed by whitespace and a name. Blank
+// lines and lines starting with '#' are ignored.
+func ParseHolidays(r io.Reader) (*StaticHolidays, error) {
+	h := &StaticHolidays{dates: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		date, name := line, ""
+		if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+			date, name = line[:i], line[i:]
+		}
+
+		if _, err := time.Parse(holidayDateLayout, date); err != nil {
+			return nil, fmt.Errorf("invalid holiday date on line %d: %q", lineNo, date)
+		}
+
+		h.dates[date] = strings.TrimSpace(name)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holidays: %w", err)
+	}
+
+	return h, nil
+}
+
+// ParseICalHolidays parses the all-day events of an iCalendar (RFC 5545)
+// feed, such as a public holiday calendar export. Every date from an
+// event's DTSTART up to, but excluding, its DTEND is a holiday. Timed
+// events and recurrence rules are not supported and are ignored.
+func ParseICalHolidays(r io.Reader) (*StaticHolidays, error) {
+	h := &StaticHolidays{dates: make(map[string]string)}
+
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		inEvent          bool
+		start, end, name string
+	)
+
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		// Drop parameters such as ";VALUE=DATE".
+		key, _, _ = strings.Cut(strings.ToUpper(key), ";")
+
+		switch {
+		case key == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent, start, end, name = true, "", "", ""
+		case key == "END" && strings.EqualFold(value, "VEVENT"):
+			if err := h.addICalEvent(start, end, name); err != nil {
+				return nil, err
+			}
+
+			inEvent = false
+		case inEvent && key == "DTSTART":
+			start = value
+		case inEvent && key == "DTEND":
+			end = value
+		case inEvent && key == "SUMMARY":
+			name = strings.ReplaceAll(value, `\,`, ",")
+		}
+	}
+
+	return h, nil
+}
+
+func (h *StaticHolidays) addICalEvent(start, end, name string) error {
+	// Timed events (e.g. 20261224T120000Z) are not whole-day holidays.
+	if len(start) != len("20060102") {
+		return nil
+	}
+
+	first, err := time.Parse("20060102", start)
+	if err != nil {
+		return fmt.Errorf("invalid iCalendar DTSTART %q: %w", start, err)
+	}
+
+	last := first.AddDate(0, 0, 1)
+
+	if end != "" {
+		if last, err = time.Parse("20060102", end); err != nil {
+			return fmt.Errorf("invalid iCalendar DTEND %q: %w", end, err)
+		}
+	}
+
+	for d := first; d.Before(last); d = d.AddDate(0, 0, 1) {
+		h.dates[d.Format(holidayDateLayout)] = name
+	}
+
+	return nil
+}
+
+// unfoldICalLines reads iCalendar content lines, joining folded
+// continuation lines (those starting with a space or tab).
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read iCalendar: %w", err)
+	}
+
+	return lines, nil
+}
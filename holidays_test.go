@@ -0,0 +1,166 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStaticHolidays(t *testing.T) {
+	t.Parallel()
+
+	h := NewStaticHolidays(time.Date(2026, 12, 25, 15, 0, 0, 0, time.UTC))
+
+	if !h.IsHoliday(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected Dec 25 to be a holiday")
+	}
+
+	if h.IsHoliday(time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected Dec 24 not to be a holiday")
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	t.Parallel()
+
+	input := `# Norwegian public holidays
+2026-05-01 Labour Day
+
+2026-05-17  Constitution Day
+2026-12-25	Christmas Day
+`
+
+	h, err := ParseHolidays(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if h.Len() != 3 {
+		t.Errorf("expected 3 holidays, got %d", h.Len())
+	}
+
+	if name := h.Name(time.Date(2026, 5, 17, 0, 0, 0, 0, time.UTC)); name != "Constitution Day" {
+		t.Errorf("expected name 'Constitution Day', got %q", name)
+	}
+
+	if name := h.Name(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)); name != "Christmas Day" {
+		t.Errorf("expected a tab-separated name 'Christmas Day', got %q", name)
+	}
+}
+
+func TestParseHolidays_InvalidDate(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseHolidays(strings.NewReader("2026-05-01\n17.05.2026\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error on line 2, got %v", err)
+	}
+}
+
+func TestParseICalHolidays(t *testing.T) {
+	t.Parallel()
+
+	input := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20261225\r\n" +
+		"DTEND;VALUE=DATE:20261227\r\n" +
+		"SUMMARY:Christmas\\, Boxing\r\n" +
+		"  Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20260101\r\n" +
+		"SUMMARY:New Year\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20260301T120000Z\r\n" +
+		"SUMMARY:Meeting\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	h, err := ParseICalHolidays(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if h.Len() != 3 {
+		t.Errorf("expected 3 holidays, got %d", h.Len())
+	}
+
+	if name := h.Name(time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)); name != "Christmas, Boxing Day" {
+		t.Errorf("unexpected name %q", name)
+	}
+
+	if h.IsHoliday(time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected DTEND to be exclusive")
+	}
+
+	if h.IsHoliday(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected timed events to be ignored")
+	}
+}
+
+func TestLoadHolidayFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	txt := filepath.Join(dir, "holidays.txt")
+	ics := filepath.Join(dir, "holidays.ICS")
+
+	if err := os.WriteFile(txt, []byte("2026-05-01\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(ics, []byte("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20260501\nEND:VEVENT\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{txt, ics} {
+		h, err := LoadHolidayFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", path, err)
+		}
+
+		if !h.IsHoliday(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected May 1 to be a holiday in %s", path)
+		}
+	}
+
+	if _, err := LoadHolidayFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestSchedule_ContainsHoliday(t *testing.T) {
+	t.Parallel()
+
+	schedule := BusinessHours(9*time.Hour, 17*time.Hour)
+	schedule.Holidays = NewStaticHolidays(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	if schedule.Contains(time.Date(2026, 12, 25, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected holiday to be outside business hours")
+	}
+
+	if !schedule.Contains(time.Date(2026, 12, 24, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected regular weekday to be inside business hours")
+	}
+
+	overnight := Schedule{
+		Days:     []time.Weekday{time.Thursday, time.Friday},
+		Start:    22 * time.Hour,
+		End:      6 * time.Hour,
+		Holidays: schedule.Holidays,
+	}
+
+	// The window starting on the holiday (Friday Dec 25) is suppressed ...
+	if overnight.Contains(time.Date(2026, 12, 26, 2, 0, 0, 0, time.UTC)) {
+		t.Error("expected overnight window starting on a holiday to be suppressed")
+	}
+
+	// ... but the one starting the evening before still extends into it.
+	if !overnight.Contains(time.Date(2026, 12, 25, 2, 0, 0, 0, time.UTC)) {
+		t.Error("expected overnight window from the previous day to apply")
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the request header that carries the idempotency
+// key of an alert batch. Every retry of a batch sends the same key, so the
+// API can recognise a batch it already accepted, for example when the
+// response to the first attempt was lost, and not post it to Slack again.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sends the batch with the given idempotency key instead
+// of a random one, for callers that retry a send themselves, e.g. after a
+// restart, and must not post the alerts twice. The key must be unique to
+// the alerts: the API may answer a later batch with the same key as if it
+// were the first. An empty key is silently ignored.
+func WithIdempotencyKey(key string) SendOption {
+	return func(so *sendOptions) {
+		if key != "" {
+			so.idempotencyKey = key
+		}
+	}
+}
+
+// withIdempotencyKey sets the idempotency key of a batch in header, which
+// may be nil. An empty key is replaced by a random one.
+func withIdempotencyKey(header http.Header, key string) http.Header {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	if key == "" {
+		key = rand.Text()
+	}
+
+	header.Set(IdempotencyKeyHeader, key)
+
+	return header
+}
+
+// repairedIdempotencyKey derives the key of a batch repaired by
+// [WithSchemaRepair] from the key of the batch the API rejected, which the
+// API may have recorded with the rejection.
+func (so *sendOptions) repairedIdempotencyKey() *sendOptions {
+	if so == nil || so.idempotencyKey == "" {
+		return so
+	}
+
+	repaired := *so
+	repaired.idempotencyKey = so.idempotencyKey + "-repaired"
+
+	return &repaired
+}
+
+// batchIdempotencyKey derives the key of one of several batches sent with
+// the same options, such as those of [Client.Backfill], from the key set
+// with [WithIdempotencyKey]: each batch must have its own.
+func (so *sendOptions) batchIdempotencyKey(offset int64) *sendOptions {
+	if so == nil || so.idempotencyKey == "" {
+		return so
+	}
+
+	batch := *so
+	batch.idempotencyKey = fmt.Sprintf("%s-%d", so.idempotencyKey, offset)
+
+	return &batch
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// newKeyServer answers pings and records the idempotency keys of alert
+// requests, failing the first attempt of each with a 503.
+func newKeyServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+
+		if len(keys)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestSend_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	server, keys := newKeyServer(t)
+
+	client := New(server.URL, WithRetryCount(1))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	for range 2 {
+		if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := keys()
+	if len(got) != 4 || got[0] == "" || got[0] != got[1] || got[2] != got[3] || got[0] == got[2] {
+		t.Errorf("expected one key per send, repeated on its retry, got %q", got)
+	}
+
+	if _, err := client.SendWithOptions(context.Background(), []*types.Alert{types.NewAlert(types.AlertError)}, WithIdempotencyKey("deploy-42")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := keys(); got[4] != "deploy-42" || got[5] != "deploy-42" {
+		t.Errorf("expected the explicit key on every attempt, got %q", got[4:])
+	}
+}
+
+func TestBatchIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	so := newSendOptions([]SendOption{WithIdempotencyKey("import"), WithIdempotencyKey("")})
+	if batch := so.batchIdempotencyKey(200); batch.idempotencyKey != "import-200" || so.idempotencyKey != "import" {
+		t.Errorf("expected a key per batch, got %q from %q", batch.idempotencyKey, so.idempotencyKey)
+	}
+
+	var none *sendOptions
+	if none.batchIdempotencyKey(1) != nil {
+		t.Error("expected nil options to stay nil")
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const incidentsEndpoint = "incidents"
+
+// IncidentStatus is the state of an [Incident].
+type IncidentStatus string
+
+// IncidentOpen and IncidentClosed are the states of an incident, before
+// and after [Client.CloseIncident].
+const (
+	IncidentOpen   IncidentStatus = "open"
+	IncidentClosed IncidentStatus = "closed"
+)
+
+// Incident groups the issues of one outage, with the updates posted while
+// it is handled. Its ID is the incident ID that alerts are linked to with
+// [SetIncidentID] or [WithIncident], so [Client.AlertsForCorrelation]
+// returns its alerts.
+type Incident struct {
+	ID       string              `json:"id"`
+	Title    string              `json:"title"`
+	Severity types.AlertSeverity `json:"severity,omitempty"`
+	Status   IncidentStatus      `json:"status"`
+
+	// SlackChannelID is the channel the incident is coordinated in, if
+	// any.
+	SlackChannelID string `json:"slackChannelId,omitempty"`
+
+	// CorrelationIDs are the issues attached with [Client.AttachAlert].
+	CorrelationIDs []string `json:"correlationIds,omitempty"`
+
+	Updates []IncidentUpdate `json:"updates,omitempty"`
+
+	// Summary is set when the incident is closed.
+	Summary string `json:"summary,omitempty"`
+
+	OpenedAt time.Time `json:"openedAt"`
+	ClosedAt time.Time `json:"closedAt,omitzero"`
+}
+
+// IncidentUpdate is a status update posted to an incident.
+type IncidentUpdate struct {
+	Text string `json:"text"`
+
+	// Author is the caller set with [WithCaller] when the update was
+	// posted, if any.
+	Author   string    `json:"author,omitempty"`
+	PostedAt time.Time `json:"postedAt"`
+}
+
+// OpenIncidentRequest holds the parameters of a new incident.
+type OpenIncidentRequest struct {
+	// ID optionally sets the incident ID, such as the key of the ticket
+	// that tracks it. The API assigns one if it is empty.
+	ID string `json:"id,omitempty"`
+
+	// Title describes the incident. Required.
+	Title string `json:"title"`
+
+	Severity types.AlertSeverity `json:"severity,omitempty"`
+
+	// SlackChannelID optionally sets [Incident.SlackChannelID].
+	SlackChannelID string `json:"slackChannelId,omitempty"`
+}
+
+// OpenIncident opens an incident. [Client.Connect] must be called first.
+func (c *Client) OpenIncident(ctx context.Context, req OpenIncidentRequest) (*Incident, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	req.ID = strings.TrimSpace(req.ID)
+
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, errors.New("incident title must not be empty")
+	}
+
+	if req.Severity != "" {
+		req.Severity = normalizeSeverity(req.Severity)
+		if !types.SeverityIsValid(req.Severity) {
+			return nil, fmt.Errorf("incident severity must be one of %s, got %q", strings.Join(types.ValidSeverities(), ", "), req.Severity)
+		}
+	}
+
+	if req.SlackChannelID != "" && !types.SlackChannelIDOrNameRegex.MatchString(req.SlackChannelID) {
+		return nil, fmt.Errorf("invalid incident channel %q", req.SlackChannelID)
+	}
+
+	incident := &Incident{}
+	if err := c.doJSON(ctx, http.MethodPost, incidentsEndpoint, nil, &req, incident); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// AttachAlert attaches the issue with correlationID, the
+// [types.Alert.CorrelationID] of its alerts, to an open incident.
+func (c *Client) AttachAlert(ctx context.Context, incidentID, correlationID string) (*Incident, error) {
+	correlationID = strings.TrimSpace(correlationID)
+	if correlationID == "" {
+		return nil, errors.New("correlation ID must not be empty")
+	}
+
+	body := struct {
+		CorrelationID string `json:"correlationId"`
+	}{correlationID}
+
+	return c.incidentRequest(ctx, incidentID, "/alerts", &body)
+}
+
+// PostIncidentUpdate posts a status update to an incident. The caller set
+// with [WithCaller] on ctx, if any, is recorded as its author.
+func (c *Client) PostIncidentUpdate(ctx context.Context, incidentID, text string) (*Incident, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("incident update text must not be empty")
+	}
+
+	if len(text) > types.MaxTextLength {
+		return nil, fmt.Errorf("incident update text must not exceed %d characters", types.MaxTextLength)
+	}
+
+	body := struct {
+		Text   string `json:"text"`
+		Author string `json:"author,omitempty"`
+	}{text, CallerFromContext(ctx)}
+
+	return c.incidentRequest(ctx, incidentID, "/updates", &body)
+}
+
+// CloseIncident closes an incident with an optional summary of its cause
+// and resolution.
+func (c *Client) CloseIncident(ctx context.Context, incidentID, summary string) (*Incident, error) {
+	body := struct {
+		Summary string `json:"summary,omitempty"`
+	}{strings.TrimSpace(summary)}
+
+	return c.incidentRequest(ctx, incidentID, "/close", &body)
+}
+
+// incidentRequest posts body to an endpoint of a single incident and
+// decodes the returned incident.
+func (c *Client) incidentRequest(ctx context.Context, id, suffix string, body any) (*Incident, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errors.New("incident ID must not be empty")
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	incident := &Incident{}
+	if err := c.doJSON(ctx, http.MethodPost, incidentsEndpoint+"/"+url.PathEscape(id)+suffix, nil, body, incident); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newIncidentServer returns a server that keeps one incident in memory and
+// records the requests made to it.
+func newIncidentServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		incident Incident
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/incidents":
+			incident = Incident{ID: body["id"], Title: body["title"], Severity: types.AlertSeverity(body["severity"]), Status: IncidentOpen, OpenedAt: time.Now()}
+		case "/incidents/INC-42/alerts":
+			incident.CorrelationIDs = append(incident.CorrelationIDs, body["correlationId"])
+		case "/incidents/INC-42/updates":
+			incident.Updates = append(incident.Updates, IncidentUpdate{Text: body["text"], Author: body["author"], PostedAt: time.Now()})
+		case "/incidents/INC-42/close":
+			incident.Status, incident.Summary, incident.ClosedAt = IncidentClosed, body["summary"], time.Now()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(incident)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestIncidents(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newIncidentServer(t)
+	client := connectTo(t, server.URL)
+	ctx := WithCaller(context.Background(), "incident-bot")
+
+	incident, err := client.OpenIncident(ctx, OpenIncidentRequest{ID: " INC-42 ", Title: "Checkout down", Severity: "Error"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if incident.ID != "INC-42" || incident.Severity != types.AlertError || incident.Status != IncidentOpen {
+		t.Errorf("unexpected incident %+v", incident)
+	}
+
+	if incident, err = client.AttachAlert(ctx, incident.ID, "checkout-5xx"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(incident.CorrelationIDs) != 1 || incident.CorrelationIDs[0] != "checkout-5xx" {
+		t.Errorf("expected the issue to be attached, got %v", incident.CorrelationIDs)
+	}
+
+	if incident, err = client.PostIncidentUpdate(ctx, incident.ID, "Rolling back the deploy"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(incident.Updates) != 1 || incident.Updates[0].Author != "incident-bot" {
+		t.Errorf("expected an update by the caller, got %+v", incident.Updates)
+	}
+
+	if incident, err = client.CloseIncident(ctx, incident.ID, "Bad deploy, rolled back"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if incident.Status != IncidentClosed || incident.Summary != "Bad deploy, rolled back" || incident.ClosedAt.IsZero() {
+		t.Errorf("expected the incident to be closed, got %+v", incident)
+	}
+
+	want := []string{"POST /incidents", "POST /incidents/INC-42/alerts", "POST /incidents/INC-42/updates", "POST /incidents/INC-42/close"}
+	if got := requests(); len(got) != len(want) || got[0] != want[0] || got[3] != want[3] {
+		t.Errorf("expected requests %v, got %v", want, got)
+	}
+
+	if _, err := client.CloseIncident(ctx, "INC-7", ""); err == nil {
+		t.Error("expected an error for an unknown incident")
+	}
+}
+
+func TestIncidents_Validation(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newIncidentServer(t)
+	client := connectTo(t, server.URL)
+	ctx := context.Background()
+
+	for _, req := range []OpenIncidentRequest{
+		{Title: " "},
+		{Title: "Checkout down", Severity: "critical"},
+		{Title: "Checkout down", SlackChannelID: "not a channel"},
+	} {
+		if _, err := client.OpenIncident(ctx, req); err == nil {
+			t.Errorf("expected an error for %+v", req)
+		}
+	}
+
+	if _, err := client.AttachAlert(ctx, "INC-42", ""); err == nil {
+		t.Error("expected an error for an empty correlation ID")
+	}
+
+	if _, err := client.PostIncidentUpdate(ctx, "INC-42", " "); err == nil {
+		t.Error("expected an error for an empty update")
+	}
+
+	if _, err := client.CloseIncident(ctx, "", "done"); err == nil {
+		t.Error("expected an error for an empty incident ID")
+	}
+
+	if got := requests(); len(got) != 0 {
+		t.Errorf("expected no requests, got %v", got)
+	}
+
+	if _, err := New(server.URL).OpenIncident(ctx, OpenIncidentRequest{Title: "Checkout down"}); err == nil {
+		t.Error("expected an error before Connect")
+	}
+}
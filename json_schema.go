@@ -0,0 +1,346 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+const alertSchemaID = "https://github.com/slackmgr/go-client/alert.schema.json"
+
+// jsonSchema is the subset of JSON Schema (draft 2020-12) needed to
+// describe an alert. It is used both to render [AlertSchema] and to
+// validate payloads in [ValidateAlertPayload].
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 []string               `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	MaxLength            int                    `json:"maxLength,omitempty"`
+	MaxItems             int                    `json:"maxItems,omitempty"`
+	MaxProperties        int                    `json:"maxProperties,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// SchemaViolation describes a single way in which a payload does not
+// conform to the alert schema. Path is a JSON Pointer to the offending
+// value, e.g. "/webhooks/0/buttonText".
+type SchemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// AlertValidationError is returned by [ValidateAlertPayload] when a payload
+// is not a valid alert. It lists every violation found.
+type AlertValidationError struct {
+	Violations []SchemaViolation
+}
+
+func (e *AlertValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Path + ": " + v.Message
+	}
+
+	return "invalid alert: " + strings.Join(msgs, "; ")
+}
+
+// AlertSchema returns the JSON Schema (draft 2020-12) describing a single
+// alert in the [AlertSchemaV1] wire format. It captures field types, length
+// and count limits, formats and enumerations; cross-field rules (such as
+// unique webhook IDs) are only enforced by [ValidateAlertPayload].
+func AlertSchema() []byte {
+	data, err := json.MarshalIndent(alertSchema(), "", "  ")
+	if err != nil {
+		// The schema is static, so this cannot happen.
+		panic(fmt.Sprintf("failed to marshal alert schema: %v", err))
+	}
+
+	return data
+}
+
+// ValidateAlertPayload validates an untyped alert payload, such as one
+// decoded from a request body, and converts it to a [types.Alert]. The
+// payload is first checked against [AlertSchema], then cleaned and
+// validated with the same rules the API applies. Any validation failure
+// is returned as an [*AlertValidationError].
+func ValidateAlertPayload(payload map[string]any) (*types.Alert, error) {
+	if payload == nil {
+		return nil, errors.New("alert payload is nil")
+	}
+
+	// Round-trip through JSON so that payloads built in Go (with ints,
+	// typed slices, etc.) are validated exactly as the API would see them.
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert payload: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode alert payload: %w", err)
+	}
+
+	var violations []SchemaViolation
+	alertSchema().validate(value, "", &violations)
+
+	if len(violations) > 0 {
+		return nil, &AlertValidationError{Violations: violations}
+	}
+
+	alert := &types.Alert{}
+	if err := json.Unmarshal(data, alert); err != nil {
+		return nil, fmt.Errorf("failed to decode alert payload: %w", err)
+	}
+
+	alert.Clean()
+
+	if err := alert.Validate(); err != nil {
+		return nil, &AlertValidationError{Violations: []SchemaViolation{{Path: "", Message: err.Error()}}}
+	}
+
+	return alert, nil
+}
+
+func (s *jsonSchema) validate(value any, path string, violations *[]SchemaViolation) {
+	fail := func(format string, args ...any) {
+		*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	kind := jsonKind(value)
+	if len(s.Type) > 0 && !slices.Contains(s.Type, kind) && (kind != schemaInteger || !slices.Contains(s.Type, schemaNumber)) {
+		fail("expected %s, got %s", strings.Join(s.Type, " or "), kind)
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.MaxLength > 0 && utf8.RuneCountInString(v) > s.MaxLength {
+			fail("length must be <= %d", s.MaxLength)
+		}
+
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+			fail("must be one of [%s]", strings.Join(s.Enum, ", "))
+		}
+
+		if s.pattern != nil && v != "" && !s.pattern.MatchString(v) {
+			fail("does not match pattern %s", s.Pattern)
+		}
+
+		if s.Format == "date-time" && v != "" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				fail("must be an RFC 3339 date-time")
+			}
+		}
+	case float64:
+		if s.Minimum != nil && v < float64(*s.Minimum) {
+			fail("must be >= %d", *s.Minimum)
+		}
+
+		if s.Maximum != nil && v > float64(*s.Maximum) {
+			fail("must be <= %d", *s.Maximum)
+		}
+	case []any:
+		if s.MaxItems > 0 && len(v) > s.MaxItems {
+			fail("must have <= %d items", s.MaxItems)
+		}
+
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, fmt.Sprintf("%s/%d", path, i), violations)
+			}
+		}
+	case map[string]any:
+		if s.MaxProperties > 0 && len(v) > s.MaxProperties {
+			fail("must have <= %d properties", s.MaxProperties)
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			child, ok := s.Properties[key]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*violations = append(*violations, SchemaViolation{Path: path + "/" + key, Message: "unknown property"})
+				}
+
+				continue
+			}
+
+			child.validate(v[key], path+"/"+key, violations)
+		}
+	}
+}
+
+// The JSON Schema type names.
+const (
+	schemaNull    = "null"
+	schemaBoolean = "boolean"
+	schemaString  = "string"
+	schemaInteger = "integer"
+	schemaNumber  = "number"
+	schemaArray   = "array"
+	schemaObject  = "object"
+)
+
+func jsonKind(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return schemaNull
+	case bool:
+		return schemaBoolean
+	case string:
+		return schemaString
+	case float64:
+		if v == float64(int64(v)) {
+			return schemaInteger
+		}
+
+		return schemaNumber
+	case []any:
+		return schemaArray
+	case map[string]any:
+		return schemaObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func alertSchema() *jsonSchema {
+	str := func(maxLength int, description string) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaString}, MaxLength: maxLength, Description: description}
+	}
+
+	withPattern := func(s *jsonSchema, re *regexp.Regexp) *jsonSchema {
+		s.Pattern = re.String()
+		s.pattern = re
+
+		return s
+	}
+
+	integer := func(minimum, maximum *int, description string) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaInteger}, Minimum: minimum, Maximum: maximum, Description: description}
+	}
+
+	boolean := func(description string) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaBoolean}, Description: description}
+	}
+
+	array := func(maxItems int, items *jsonSchema) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaArray, schemaNull}, MaxItems: maxItems, Items: items}
+	}
+
+	object := func(properties map[string]*jsonSchema) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaObject}, Properties: properties, AdditionalProperties: ptr(false)}
+	}
+
+	freeform := func(maxProperties int) *jsonSchema {
+		return &jsonSchema{Type: []string{schemaObject, schemaNull}, MaxProperties: maxProperties}
+	}
+
+	severities := append([]string{"", severityCritical}, types.ValidSeverities()...)
+
+	field := object(map[string]*jsonSchema{
+		"title": str(0, "Truncated at 30 characters."),
+		"value": str(0, "Truncated at 200 characters."),
+	})
+
+	escalation := object(map[string]*jsonSchema{
+		"severity":      {Type: []string{schemaString}, Enum: []string{string(types.AlertPanic), string(types.AlertError), string(types.AlertWarning)}},
+		"delaySeconds":  integer(ptr(types.MinEscalationDelaySeconds), nil, "Seconds after the issue was created."),
+		"slackMentions": array(types.MaxEscalationSlackMentionCount, withPattern(str(0, ""), types.SlackMentionRegex)),
+		"moveToChannel": withPattern(str(0, ""), types.SlackChannelIDOrNameRegex),
+	})
+
+	webhook := object(map[string]*jsonSchema{
+		"id":               str(types.MaxWebhookIDLength, "Unique within the alert."),
+		"url":              str(types.MaxWebhookURLLength, "Absolute http(s) URL or custom handler identifier."),
+		"confirmationText": str(types.MaxWebhookConfirmationTextLength, ""),
+		"buttonText":       str(types.MaxWebhookButtonTextLength, ""),
+		"buttonStyle":      {Type: []string{schemaString}, Enum: append([]string{"", "default"}, types.ValidWebhookButtonStyles()...)},
+		"accessLevel":      {Type: []string{schemaString}, Enum: append([]string{""}, types.ValidWebhookAccessLevels()...)},
+		"displayMode":      {Type: []string{schemaString}, Enum: append([]string{""}, types.ValidWebhookDisplayModes()...)},
+		"payload":          freeform(types.MaxWebhookPayloadCount),
+		"plainTextInput": array(types.MaxWebhookPlainTextInputCount, object(map[string]*jsonSchema{
+			"id":           str(types.MaxWebhookInputIDLength, ""),
+			"description":  str(types.MaxWebhookInputDescriptionLength, ""),
+			"minLength":    integer(ptr(0), ptr(types.MaxWebhookInputTextLength), ""),
+			"maxLength":    integer(ptr(0), ptr(types.MaxWebhookInputTextLength), ""),
+			"multiline":    boolean(""),
+			"initialValue": str(types.MaxWebhookInputTextLength, ""),
+		})),
+		"checkboxInput": array(types.MaxWebhookCheckboxInputCount, object(map[string]*jsonSchema{
+			"id":    str(types.MaxWebhookInputIDLength, ""),
+			"label": str(types.MaxWebhookInputLabelLength, ""),
+			"options": array(types.MaxWebhookCheckboxOptionCount, object(map[string]*jsonSchema{
+				"value":    str(types.MaxCheckboxOptionValueLength, ""),
+				"text":     str(types.MaxWebhookCheckboxOptionTextLength, ""),
+				"selected": boolean(""),
+			})),
+		})),
+	})
+
+	root := object(map[string]*jsonSchema{
+		"timestamp":                 {Type: []string{schemaString}, Format: "date-time", Description: "Replaced with the current time if empty or older than 7 days."},
+		"correlationId":             str(types.MaxCorrelationIDLength, "Groups related alerts into a single issue."),
+		"type":                      str(0, "Alert type, used for routing."),
+		"header":                    str(0, "Truncated at 130 characters."),
+		"headerWhenResolved":        str(0, "Truncated at 130 characters."),
+		"text":                      str(0, "Truncated at 10000 characters."),
+		"textWhenResolved":          str(0, "Truncated at 10000 characters."),
+		"fallbackText":              str(0, "Truncated at 150 characters."),
+		"author":                    str(0, "Truncated at 100 characters."),
+		"host":                      str(0, "Truncated at 100 characters."),
+		"footer":                    str(0, "Truncated at 300 characters."),
+		"link":                      {Type: []string{schemaString}, Format: "uri"},
+		"issueFollowUpEnabled":      boolean(""),
+		"autoResolveSeconds":        integer(ptr(0), ptr(types.MaxAutoResolveSeconds), "Must be >= 30 when issueFollowUpEnabled is true."),
+		"autoResolveAsInconclusive": boolean(""),
+		"severity":                  {Type: []string{schemaString}, Enum: severities, Description: "Empty and \"critical\" are treated as \"error\"."},
+		"slackChannelId":            withPattern(str(0, "Channel ID or name."), types.SlackChannelIDOrNameRegex),
+		"routeKey":                  str(types.MaxRouteKeyLength, ""),
+		"username":                  str(0, "Truncated at 100 characters."),
+		"iconEmoji":                 withPattern(str(0, ""), types.IconRegex),
+		"fields":                    array(types.MaxFieldCount, field),
+		"notificationDelaySeconds":  integer(nil, nil, ""),
+		"archivingDelaySeconds":     integer(nil, nil, ""),
+		"escalation":                array(types.MaxEscalationCount, escalation),
+		"ignoreIfTextContains":      array(types.MaxIgnoreIfTextContainsCount, str(types.MaxIgnoreIfTextContainsLength, "")),
+		"webhooks":                  array(types.MaxWebhookCount, webhook),
+		"metadata":                  freeform(0),
+		"failOnRateLimitError":      boolean("Deprecated and ignored."),
+	})
+
+	root.Schema = "https://json-schema.org/draft/2020-12/schema"
+	root.ID = alertSchemaID
+	root.Title = "Slack Manager alert"
+
+	return root
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestAlertSchema(t *testing.T) {
+	t.Parallel()
+
+	var schema map[string]any
+	if err := json.Unmarshal(AlertSchema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("unexpected $schema: %v", schema["$schema"])
+	}
+
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected an object of properties, got %T", schema["properties"])
+	}
+
+	// Every JSON field of types.Alert must be described by the schema.
+	alertType := reflect.TypeFor[types.Alert]()
+	for i := range alertType.NumField() {
+		name, _, _ := strings.Cut(alertType.Field(i).Tag.Get("json"), ",")
+		if _, ok := properties[name]; !ok {
+			t.Errorf("schema is missing property %q", name)
+		}
+	}
+
+	for name := range properties {
+		found := false
+		for i := range alertType.NumField() {
+			if tag, _, _ := strings.Cut(alertType.Field(i).Tag.Get("json"), ","); tag == name {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("schema has unknown property %q", name)
+		}
+	}
+}
+
+func TestValidateAlertPayload_Valid(t *testing.T) {
+	t.Parallel()
+
+	alert, err := ValidateAlertPayload(map[string]any{
+		"header":         "Disk full",
+		"severity":       "warning",
+		"slackChannelId": "c123",
+		"fields":         []map[string]any{{"title": "Host", "value": "db-1"}},
+		"metadata":       map[string]any{"anything": []int{1, 2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if alert.Header != "Disk full" || alert.SlackChannelID != "C123" || len(alert.Fields) != 1 {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+}
+
+func TestValidateAlertPayload_SchemaViolations(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateAlertPayload(map[string]any{
+		"header":             1,
+		"severity":           "loud",
+		"iconEmoji":          "no-colons",
+		"timestamp":          "yesterday",
+		"autoResolveSeconds": -5,
+		"unknown":            true,
+		"ignoreIfTextContains": []string{
+			strings.Repeat("x", types.MaxIgnoreIfTextContainsLength+1),
+		},
+		"webhooks": []any{map[string]any{"id": "a", "extra": 1}},
+	})
+
+	var validationErr *AlertValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected AlertValidationError, got %v", err)
+	}
+
+	paths := make(map[string]bool)
+	for _, v := range validationErr.Violations {
+		paths[v.Path] = true
+	}
+
+	for _, want := range []string{
+		"/header", "/severity", "/iconEmoji", "/timestamp", "/autoResolveSeconds",
+		"/unknown", "/ignoreIfTextContains/0", "/webhooks/0/extra",
+	} {
+		if !paths[want] {
+			t.Errorf("expected violation at %s, got %v", want, validationErr.Violations)
+		}
+	}
+}
+
+func TestValidateAlertPayload_SemanticViolation(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateAlertPayload(map[string]any{"severity": "error"})
+
+	var validationErr *AlertValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected AlertValidationError, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "header and text cannot both be empty") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateAlertPayload_Nil(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ValidateAlertPayload(nil); err == nil {
+		t.Error("expected error for nil payload")
+	}
+}
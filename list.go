@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const maxListPageSize = 1000
+
+// AlertFilter selects the alerts returned by [Client.ListAlerts]. Zero
+// fields do not filter.
+type AlertFilter struct {
+	// SlackChannelID restricts results to one channel.
+	SlackChannelID string
+
+	// RouteKey restricts results to one route key.
+	RouteKey string
+
+	// Severities restricts results to the given severities.
+	Severities []types.AlertSeverity
+
+	// IncidentID restricts results to the alerts linked to one incident
+	// (see [SetIncidentID]).
+	IncidentID string
+
+	// Since and Until restrict results to alerts whose timestamp is in
+	// [Since, Until).
+	Since time.Time
+	Until time.Time
+
+	// PageSize is the number of alerts requested per page. Zero uses the
+	// API default; the maximum is 1000.
+	PageSize int
+}
+
+// query returns the filter as URL query parameters.
+func (f AlertFilter) query() url.Values {
+	q := url.Values{}
+
+	if f.SlackChannelID != "" {
+		q.Set("slackChannelId", f.SlackChannelID)
+	}
+
+	if f.RouteKey != "" {
+		q.Set("routeKey", f.RouteKey)
+	}
+
+	if f.IncidentID != "" {
+		q.Set(IncidentIDKey, f.IncidentID)
+	}
+
+	for _, severity := range f.Severities {
+		q.Add("severity", string(normalizeSeverity(severity)))
+	}
+
+	if !f.Since.IsZero() {
+		q.Set("since", f.Since.UTC().Format(time.RFC3339Nano))
+	}
+
+	if !f.Until.IsZero() {
+		q.Set("until", f.Until.UTC().Format(time.RFC3339Nano))
+	}
+
+	if f.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(f.PageSize))
+	}
+
+	return q
+}
+
+// alertsPage is one page of results from the list endpoint.
+type alertsPage struct {
+	Alerts     []*types.Alert `json:"alerts"`
+	NextCursor string         `json:"nextCursor"`
+}
+
+// ListAlerts returns an iterator over the alerts matching filter, fetched a
+// page at a time with GET on the alerts endpoint (see [WithAlertsEndpoint]).
+// Iteration stops at the first error, which is yielded with a nil alert.
+// [Client.Connect] must be called first.
+func (c *Client) ListAlerts(ctx context.Context, filter AlertFilter) iter.Seq2[*types.Alert, error] {
+	return func(yield func(*types.Alert, error) bool) {
+		if err := c.checkListRequest(filter.PageSize); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		paginate(ctx, c, c.options.alertsEndpoint, filter.query(), func(page *alertsPage) ([]*types.Alert, string) {
+			return page.Alerts, page.NextCursor
+		}, yield)
+	}
+}
+
+// checkListRequest validates the preconditions shared by list iterators.
+func (c *Client) checkListRequest(pageSize int) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	if pageSize > maxListPageSize {
+		return fmt.Errorf("page size must not exceed %d", maxListPageSize)
+	}
+
+	return nil
+}
+
+// paginate fetches the pages of a cursor-paginated list endpoint and
+// yields their items until the last page, an error, or the consumer stops.
+// P is the page type and items extracts the items and next cursor from it.
+// A page whose next cursor is its own cursor is reported as an error rather
+// than fetched again forever.
+func paginate[P, T any](ctx context.Context, c *Client, path string, query url.Values, items func(*P) ([]T, string), yield func(T, error) bool) {
+	var zero T
+
+	for {
+		var page P
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		list, cursor := items(&page)
+
+		for _, item := range list {
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if cursor == "" {
+			return
+		}
+
+		if cursor == query.Get("cursor") {
+			yield(zero, fmt.Errorf("GET %s returned cursor %q for its own page", path, cursor))
+			return
+		}
+
+		query.Set("cursor", cursor)
+	}
+}
+
+// getJSON sends a GET request with the given query and decodes the JSON
+// response body into out.
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	return c.doJSON(ctx, http.MethodGet, path, query, nil, out)
+}
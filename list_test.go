@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newListServer serves total alerts from GET /alerts in pages of the
+// requested limit, using the offset as the cursor.
+func newListServer(t *testing.T, total int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		queries = append(queries, r.URL.RawQuery)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		if limit == 0 {
+			limit = 2
+		}
+
+		page := alertsPage{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			page.Alerts = append(page.Alerts, &types.Alert{
+				Header:    fmt.Sprintf("alert %d", i),
+				Severity:  types.AlertWarning,
+				Timestamp: time.Date(2024, 1, 1, 0, i, 0, 0, time.UTC),
+				Metadata:  map[string]any{"n": i},
+			})
+		}
+
+		if offset+limit < total {
+			page.NextCursor = strconv.Itoa(offset + limit)
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &queries
+}
+
+func TestListAlerts_Paginates(t *testing.T) {
+	t.Parallel()
+
+	server, queries := newListServer(t, 5)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filter := AlertFilter{
+		SlackChannelID: "C123",
+		Severities:     []types.AlertSeverity{"Warning", types.AlertError},
+		Since:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		PageSize:       2,
+	}
+
+	var headers []string
+
+	for alert, err := range client.ListAlerts(context.Background(), filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers = append(headers, alert.Header)
+	}
+
+	if len(headers) != 5 || headers[4] != "alert 4" {
+		t.Errorf("expected 5 alerts, got %v", headers)
+	}
+
+	if len(*queries) != 3 {
+		t.Fatalf("expected 3 page requests, got %d", len(*queries))
+	}
+
+	first := (*queries)[0]
+	for _, want := range []string{"slackChannelId=C123", "severity=warning", "severity=error", "since=2024-01-01T00%3A00%3A00Z", "limit=2"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("expected query %q to contain %q", first, want)
+		}
+	}
+
+	if !strings.Contains((*queries)[2], "cursor=4") {
+		t.Errorf("expected last request to use cursor 4, got %q", (*queries)[2])
+	}
+}
+
+func TestListAlerts_StopsEarly(t *testing.T) {
+	t.Parallel()
+
+	server, queries := newListServer(t, 10)
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, err := range client.ListAlerts(context.Background(), AlertFilter{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		break
+	}
+
+	if len(*queries) != 1 {
+		t.Errorf("expected a single page request, got %d", len(*queries))
+	}
+}
+
+func TestListAlerts_RepeatedCursor(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(alertsPage{Alerts: []*types.Alert{{Header: "stuck"}}, NextCursor: "c1"})
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var (
+		alerts int
+		last   error
+	)
+
+	for alert, err := range client.ListAlerts(context.Background(), AlertFilter{}) {
+		if err != nil {
+			last = err
+			continue
+		}
+
+		if alert.Header == "stuck" {
+			alerts++
+		}
+	}
+
+	if alerts != 2 {
+		t.Errorf("expected the first and second page to be yielded, got %d alerts", alerts)
+	}
+
+	if last == nil || !strings.Contains(last.Error(), `cursor "c1"`) {
+		t.Errorf("expected repeated cursor error, got %v", last)
+	}
+}
+
+func TestListAlerts_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range New("http://example.com").ListAlerts(context.Background(), AlertFilter{}) {
+		if err == nil || !strings.Contains(err.Error(), "not connected") {
+			t.Errorf("expected not connected error, got %v", err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRetryCount(0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	count := 0
+
+	for alert, err := range client.ListAlerts(context.Background(), AlertFilter{}) {
+		count++
+
+		if alert != nil || err == nil || !strings.Contains(err.Error(), "forbidden") {
+			t.Errorf("expected forbidden error, got %v, %v", alert, err)
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expected exactly one error to be yielded, got %d", count)
+	}
+
+	for _, err := range client.ListAlerts(context.Background(), AlertFilter{PageSize: 5000}) {
+		if err == nil || !strings.Contains(err.Error(), "page size") {
+			t.Errorf("expected page size error, got %v", err)
+		}
+	}
+}
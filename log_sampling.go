@@ -0,0 +1,118 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	logSamplingWindow  = 1 * time.Minute
+	maxLogSamplingRate = 10_000
+)
+
+// samplingLogger limits how often identical error and warning messages are
+// logged. Messages are identical if they have the same level and format
+// string, so "POST alerts failed: %v" is counted once however the error
+// varies. Within each window the first limit messages per format are
+// logged; the rest are counted and reported in a summary when the window
+// ends. Debug messages are not sampled.
+type samplingLogger struct {
+	next  RequestLogger
+	state *samplingState
+}
+
+// samplingState is shared by a samplingLogger and the loggers derived from
+// it with [samplingLogger.WithFields].
+type samplingState struct {
+	base  RequestLogger
+	limit int
+
+	mu     sync.Mutex
+	counts map[samplingKey]int
+}
+
+type samplingKey struct {
+	level  string
+	format string
+}
+
+func newSamplingLogger(next RequestLogger, limit int) *samplingLogger {
+	return &samplingLogger{
+		next: next,
+		state: &samplingState{
+			base:   next,
+			limit:  limit,
+			counts: make(map[samplingKey]int),
+		},
+	}
+}
+
+// allow counts a message and reports whether it should be logged.
+func (s *samplingState) allow(level, format string) bool {
+	key := samplingKey{level: level, format: format}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[key]++
+
+	return s.counts[key] <= s.limit
+}
+
+// flush ends the current window, logging a summary for every format that
+// had messages suppressed.
+func (s *samplingState) flush() {
+	s.mu.Lock()
+	counts := s.counts
+	s.counts = make(map[samplingKey]int)
+	s.mu.Unlock()
+
+	for key, n := range counts {
+		suppressed := n - s.limit
+		if suppressed <= 0 {
+			continue
+		}
+
+		switch key.level {
+		case "error":
+			s.base.Errorf("suppressed %d similar errors in the last %v: %q", suppressed, logSamplingWindow, key.format)
+		default:
+			s.base.Warnf("suppressed %d similar warnings in the last %v: %q", suppressed, logSamplingWindow, key.format)
+		}
+	}
+}
+
+func (l *samplingLogger) Errorf(format string, v ...any) {
+	if l.state.allow("error", format) {
+		l.next.Errorf(format, v...)
+	}
+}
+
+func (l *samplingLogger) Warnf(format string, v ...any) {
+	if l.state.allow("warn", format) {
+		l.next.Warnf(format, v...)
+	}
+}
+
+func (l *samplingLogger) Debugf(format string, v ...any) {
+	l.next.Debugf(format, v...)
+}
+
+// WithFields implements [FieldLogger], so context fields still reach a
+// structured logger through the sampler. Messages with different fields
+// share the same sampling budget.
+func (l *samplingLogger) WithFields(fields map[string]any) RequestLogger { //nolint:ireturn // signature fixed by FieldLogger
+	return &samplingLogger{next: withFields(l.next, fields), state: l.state}
+}
+
+// loggerType returns the type name of the logger configured with
+// [WithRequestLogger], looking through the sampler added by
+// [WithLogSampling].
+func loggerType(logger RequestLogger) string {
+	if sampler, ok := logger.(*samplingLogger); ok {
+		logger = sampler.state.base
+	}
+
+	return fmt.Sprintf("%T", logger)
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingLogger{}
+	logger := newSamplingLogger(recorder, 2)
+
+	for i := range 5 {
+		logger.Warnf("POST alerts failed: attempt %d", i)
+	}
+
+	logger.Warnf("GET ping failed")
+
+	if got := recorder.warnings(); len(got) != 3 || got[2] != "GET ping failed" {
+		t.Fatalf("expected 2 sampled warnings and 1 distinct warning, got %q", got)
+	}
+
+	logger.state.flush()
+
+	got := recorder.warnings()
+	if len(got) != 4 || !strings.HasPrefix(got[3], "suppressed 3 similar warnings in the last 1m0s") || !strings.Contains(got[3], "POST alerts failed") {
+		t.Fatalf("expected a suppression summary, got %q", got)
+	}
+
+	// A new window starts after the flush.
+	logger.Warnf("POST alerts failed: attempt %d", 6)
+
+	if n := len(recorder.warnings()); n != 5 {
+		t.Errorf("expected the budget to reset after a flush, got %d warnings", n)
+	}
+
+	logger.state.flush()
+
+	if n := len(recorder.warnings()); n != 5 {
+		t.Errorf("expected no summary when nothing was suppressed, got %d warnings", n)
+	}
+}
+
+func TestWithLogSampling_WrapsLogger(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingLogger{}
+	client := New(newDeprecatedServer(t).URL, WithRequestLogger(recorder), WithLogSampling(5))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if _, ok := client.options.requestLogger.(*samplingLogger); !ok {
+		t.Fatalf("expected the logger to be wrapped, got %T", client.options.requestLogger)
+	}
+
+	if got := client.EffectiveConfig(); got.RequestLogger != "*client.recordingLogger" || got.LogSampling != 5 {
+		t.Errorf("expected the configured logger to be reported, got %s (sampling %d)", got.RequestLogger, got.LogSampling)
+	}
+
+	derived, err := client.With(context.Background(), WithTimeout(defaultTimeout))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parent, _ := client.options.requestLogger.(*samplingLogger)
+	if sampler, ok := derived.options.requestLogger.(*samplingLogger); !ok || parent == nil || sampler.state != parent.state {
+		t.Error("expected a derived client to share the parent's sampler")
+	}
+}
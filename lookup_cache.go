@@ -0,0 +1,211 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	minLookupCacheTTL        = 1 * time.Second
+	maxLookupCacheTTL        = 24 * time.Hour
+	maxLookupCacheMaxEntries = 1_000_000
+)
+
+// LookupCacheStats reports the state of the [WithLookupCache] cache.
+type LookupCacheStats struct {
+	// Enabled reports whether [WithLookupCache] is in effect.
+	Enabled bool
+
+	// Hits counts lookups answered from a fresh cache entry.
+	Hits int64
+
+	// StaleHits counts lookups answered from a stale entry while it was
+	// refreshed in the background.
+	StaleHits int64
+
+	// Misses counts lookups that had to wait for the API.
+	Misses int64
+
+	// Evictions counts entries removed to stay within the size limit.
+	Evictions int64
+
+	// Entries is the current number of cached entries.
+	Entries int
+}
+
+// HitRate returns the fraction of lookups answered from the cache, fresh
+// or stale, or 0 if there have been none.
+func (s LookupCacheStats) HitRate() float64 {
+	total := s.Hits + s.StaleHits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits+s.StaleHits) / float64(total)
+}
+
+// lookupCache is an LRU cache of lookup response bodies. Entries are fresh
+// for ttl, then served stale for up to staleTTL while they are refreshed in
+// the background.
+type lookupCache struct {
+	ttl        time.Duration
+	staleTTL   time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+	stats   LookupCacheStats
+}
+
+type lookupCacheEntry struct {
+	key     string
+	body    []byte
+	fetched time.Time
+}
+
+func newLookupCache(ttl, staleTTL time.Duration, maxEntries int) *lookupCache {
+	return &lookupCache{
+		ttl:        ttl,
+		staleTTL:   staleTTL,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached body for key, and whether it is stale. It counts a
+// miss if there is no usable entry.
+func (l *lookupCache) get(key string) ([]byte, bool, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, found := l.entries[key]
+	if !found {
+		l.stats.Misses++
+		return nil, false, false
+	}
+
+	entry := cacheEntry(elem)
+	age := l.now().Sub(entry.fetched)
+	stale := false
+
+	switch {
+	case age < l.ttl:
+		l.stats.Hits++
+	case age < l.ttl+l.staleTTL:
+		l.stats.StaleHits++
+		stale = true
+	default:
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		l.stats.Misses++
+
+		return nil, false, false
+	}
+
+	l.order.MoveToFront(elem)
+
+	return entry.body, stale, true
+}
+
+// put stores body for key, evicting the least recently used entries if the
+// cache is full.
+func (l *lookupCache) put(key string, body []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.entries[key]; ok {
+		entry := cacheEntry(elem)
+		entry.body = body
+		entry.fetched = l.now()
+		l.order.MoveToFront(elem)
+
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lookupCacheEntry{key: key, body: body, fetched: l.now()})
+
+	for l.order.Len() > l.maxEntries {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, cacheEntry(oldest).key)
+		l.stats.Evictions++
+	}
+}
+
+// cacheEntry returns the entry held by elem, which is always a
+// *lookupCacheEntry.
+func cacheEntry(elem *list.Element) *lookupCacheEntry {
+	entry, _ := elem.Value.(*lookupCacheEntry)
+	return entry
+}
+
+func (l *lookupCache) snapshot() LookupCacheStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := l.stats
+	stats.Enabled = true
+	stats.Entries = l.order.Len()
+
+	return stats
+}
+
+// getCached is like [Client.getCoalesced], but answers from the lookup
+// cache when [WithLookupCache] is enabled. Stale entries are returned
+// immediately and refreshed in the background, with the values but not the
+// cancellation of ctx.
+func (c *Client) getCached(ctx context.Context, path string, out any) error {
+	if c.lookups == nil {
+		return c.getCoalesced(ctx, path, nil, out)
+	}
+
+	body, stale, ok := c.lookups.get(path)
+
+	if !ok {
+		var err error
+		if body, err = c.fetchLookup(ctx, path); err != nil {
+			return err
+		}
+	} else if stale {
+		refreshCtx := context.WithoutCancel(ctx)
+
+		go func() {
+			if _, err := c.fetchLookup(refreshCtx, path); err != nil {
+				c.logger(refreshCtx).Warnf("failed to refresh cached lookup %s: %v", path, err)
+			}
+		}()
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	return c.decodeResponse("", body, out)
+}
+
+// fetchLookup fetches path, coalescing concurrent fetches, and stores the
+// response in the lookup cache.
+func (c *Client) fetchLookup(ctx context.Context, path string) ([]byte, error) {
+	body, err := c.reads.do(ctx, path+"?", func(ctx context.Context) ([]byte, error) {
+		var raw json.RawMessage
+		if err := c.getJSON(ctx, path, nil, &raw); err != nil {
+			return nil, err
+		}
+
+		c.lookups.put(path, raw)
+
+		return raw, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLookupCache_LRU(t *testing.T) {
+	t.Parallel()
+
+	cache := newLookupCache(time.Minute, 0, 2)
+
+	cache.put("a", []byte(`1`))
+	cache.put("b", []byte(`2`))
+
+	if _, _, ok := cache.get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	cache.put("c", []byte(`3`))
+
+	if _, _, ok := cache.get("b"); ok {
+		t.Error("expected least recently used entry b to be evicted")
+	}
+
+	stats := cache.snapshot()
+	if stats.Entries != 2 || stats.Evictions != 1 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	if rate := stats.HitRate(); rate != 0.5 {
+		t.Errorf("expected hit rate 0.5, got %v", rate)
+	}
+}
+
+func TestLookupCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache := newLookupCache(time.Minute, time.Hour, 10)
+	cache.now = func() time.Time { return now }
+	cache.put("a", []byte(`1`))
+
+	tests := []struct {
+		age       time.Duration
+		wantOK    bool
+		wantStale bool
+	}{
+		{30 * time.Second, true, false},
+		{30 * time.Minute, true, true},
+		{2 * time.Hour, false, false},
+	}
+
+	for _, tt := range tests {
+		cache.now = func() time.Time { return now.Add(tt.age) }
+
+		if _, stale, ok := cache.get("a"); ok != tt.wantOK || stale != tt.wantStale {
+			t.Errorf("age %v: expected ok=%v stale=%v, got ok=%v stale=%v", tt.age, tt.wantOK, tt.wantStale, ok, stale)
+		}
+	}
+}
+
+func TestWithLookupCache_StaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	refreshed := make(chan struct{}, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		n := requests.Add(1)
+		fmt.Fprintf(w, `{"id":"U1","name":"v%d"}`, n)
+
+		if n > 1 {
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithLookupCache(time.Minute, time.Hour, 100))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var (
+		mu  sync.Mutex
+		now = time.Now()
+	)
+
+	client.lookups.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return now
+	}
+
+	ctx := context.Background()
+
+	for range 3 {
+		user, err := client.GetUser(ctx, "U1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user.Name != "v1" {
+			t.Errorf("expected cached v1, got %s", user.Name)
+		}
+	}
+
+	mu.Lock()
+	now = now.Add(2 * time.Minute)
+	mu.Unlock()
+
+	user, err := client.GetUser(ctx, "U1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.Name != "v1" {
+		t.Errorf("expected stale v1 to be returned immediately, got %s", user.Name)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a background refresh")
+	}
+
+	// The refresh stores the new entry after the response has been written.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		user, err = client.GetUser(ctx, "U1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user.Name == "v2" || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if user.Name != "v2" {
+		t.Errorf("expected refreshed v2, got %s", user.Name)
+	}
+
+	stats := client.Stats().LookupCache
+	if !stats.Enabled || stats.Misses != 1 || stats.StaleHits < 1 || stats.Hits < 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	capabilitiesEndpoint = "capabilities"
+	channelsEndpoint     = "channels"
+	usersEndpoint        = "users"
+)
+
+// Capabilities describes the features supported by the API, as returned by
+// [Client.Capabilities].
+type Capabilities struct {
+	APIVersion   string               `json:"apiVersion"`
+	Features     []string             `json:"features"`
+	AlertSchemas []AlertSchemaVersion `json:"alertSchemas"`
+	MaxBatchSize int                  `json:"maxBatchSize"`
+}
+
+// Supports reports whether the API advertises feature.
+func (c *Capabilities) Supports(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// Channel is a Slack channel known to the manager.
+type Channel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPrivate  bool   `json:"isPrivate"`
+	IsArchived bool   `json:"isArchived"`
+
+	// Managed reports whether the manager's bot is a member of the channel
+	// and can post alerts to it.
+	Managed bool `json:"managed"`
+
+	// TimeZone is the IANA time zone configured for the channel, such as
+	// "Europe/Oslo", or empty for UTC. See [WithChannelTimeZones].
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// User is a Slack user known to the manager.
+type User struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	RealName string `json:"realName,omitempty"`
+	Email    string `json:"email,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// The lookups below are coalesced: concurrent identical calls on the same
+// client share a single HTTP request, so a lookup made for every alert
+// does not multiply the load on the API when many goroutines send at once.
+// Channel and user lookups can also be cached; see [WithLookupCache].
+
+// Capabilities returns the features supported by the API. [Client.Connect]
+// must be called first.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	capabilities := &Capabilities{}
+	if err := c.getCoalesced(ctx, capabilitiesEndpoint, nil, capabilities); err != nil {
+		return nil, err
+	}
+
+	return capabilities, nil
+}
+
+// GetChannel looks up a Slack channel by ID or name.
+func (c *Client) GetChannel(ctx context.Context, idOrName string) (*Channel, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	idOrName = strings.TrimPrefix(strings.TrimSpace(idOrName), "#")
+	if idOrName == "" {
+		return nil, errors.New("channel ID or name must not be empty")
+	}
+
+	channel := &Channel{}
+	if err := c.getCached(ctx, channelsEndpoint+"/"+url.PathEscape(idOrName), channel); err != nil {
+		return nil, fmt.Errorf("failed to look up channel %s: %w", idOrName, err)
+	}
+
+	return channel, nil
+}
+
+// GetUser looks up a Slack user by ID or email address.
+func (c *Client) GetUser(ctx context.Context, idOrEmail string) (*User, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	idOrEmail = strings.TrimSpace(idOrEmail)
+	if idOrEmail == "" {
+		return nil, errors.New("user ID or email must not be empty")
+	}
+
+	user := &User{}
+	if err := c.getCached(ctx, usersEndpoint+"/"+url.PathEscape(idOrEmail), user); err != nil {
+		return nil, fmt.Errorf("failed to look up user %s: %w", idOrEmail, err)
+	}
+
+	return user, nil
+}
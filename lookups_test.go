@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLookups_Coalesced(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		requests.Add(1)
+		started <- struct{}{}
+		<-release
+
+		_, _ = w.Write([]byte(`{"id":"C1","name":"alerts","managed":true}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+
+	channels := make([]*Channel, callers)
+	errs := make([]error, callers)
+
+	for i := range callers {
+		wg.Go(func() {
+			channels[i], errs[i] = client.GetChannel(context.Background(), "#alerts")
+		})
+	}
+
+	<-started
+	time.Sleep(50 * time.Millisecond) // let the other callers join the in-flight request
+	close(release)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+
+	for i := range callers {
+		if errs[i] != nil || channels[i].ID != "C1" || !channels[i].Managed {
+			t.Fatalf("caller %d: unexpected result %+v, %v", i, channels[i], errs[i])
+		}
+	}
+
+	if channels[0] == channels[1] {
+		t.Error("expected every caller to receive its own copy")
+	}
+}
+
+func TestLookups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusOK)
+		case "/capabilities":
+			_, _ = w.Write([]byte(`{"apiVersion":"1.4","features":["silences","graphql"],"alertSchemas":[1,2],"maxBatchSize":500}`))
+		case "/users/jane@example.com":
+			_, _ = w.Write([]byte(`{"id":"U1","name":"jane","email":"jane@example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	capabilities, err := client.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !capabilities.Supports("graphql") || capabilities.Supports("webhooks") || capabilities.MaxBatchSize != 500 {
+		t.Errorf("unexpected capabilities %+v", capabilities)
+	}
+
+	user, err := client.GetUser(ctx, "jane@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.ID != "U1" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if _, err := client.GetUser(ctx, "nobody"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+}
+
+func TestFlightGroup_CallerCancellation(t *testing.T) {
+	t.Parallel()
+
+	var g flightGroup
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := g.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+		<-release
+		return nil, ctx.Err()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
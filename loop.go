@@ -0,0 +1,49 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// backgroundLoop runs a function periodically in its own goroutine until it
+// is shut down.
+type backgroundLoop struct {
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// startLoop calls fn every interval in a new goroutine.
+func startLoop(interval time.Duration, fn func()) *backgroundLoop {
+	l := &backgroundLoop{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(l.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				fn()
+			}
+		}
+	}()
+
+	return l
+}
+
+// shutdown stops the loop and waits for any in-flight call to return. It is
+// safe to call more than once.
+func (l *backgroundLoop) shutdown() {
+	l.stopOnce.Do(func() {
+		close(l.stop)
+		<-l.done
+	})
+}
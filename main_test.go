@@ -0,0 +1,38 @@
+package client
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// retryDelays holds the functions set with setRetryDelay.
+var retryDelays sync.Map
+
+func TestMain(m *testing.M) {
+	testHookRetryDelay = func(c *Client) func(time.Duration) time.Duration {
+		if delay, ok := retryDelays.Load(c); ok {
+			return delay.(func(time.Duration) time.Duration) //nolint:forcetypeassert // only delay functions are stored
+		}
+
+		return nil
+	}
+
+	os.Exit(m.Run())
+}
+
+// setRetryDelay makes client replace the wait before every retry with the
+// one delay returns, so the test can retry without sleeping. It must be
+// called before the client connects.
+func setRetryDelay(t *testing.T, client *Client, delay func(time.Duration) time.Duration) {
+	t.Helper()
+
+	retryDelays.Store(client, delay)
+	t.Cleanup(func() { retryDelays.Delete(client) })
+}
+
+// noRetryDelay makes a client retry without waiting.
+func noRetryDelay(time.Duration) time.Duration {
+	return 0
+}
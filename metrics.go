@@ -0,0 +1,216 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// MetricsCollector receives the request metrics of [WithMetrics].
+// [PrometheusMetrics] is a ready-made implementation; others, such as an
+// adapter for a metrics library, are a few lines. Implementations must be
+// safe for concurrent use and should return quickly, as they are called on
+// the request path.
+type MetricsCollector interface {
+	// ObserveRequest is called after every request sent to the API,
+	// including each retry, with its method, the status code of the
+	// response, or 0 if none arrived, and how long it took.
+	ObserveRequest(method string, statusCode int, latency time.Duration)
+
+	// ObserveRetry is called every time a request is retried.
+	ObserveRetry(method string)
+}
+
+// metricsTransport reports every request sent through next to collector.
+func metricsTransport(collector MetricsCollector, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+
+		resp, err := next.RoundTrip(req)
+
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+
+		collector.ObserveRequest(req.Method, status, time.Since(start))
+
+		return resp, err
+	})
+}
+
+// metricsRetry returns a resty retry hook that reports retries to
+// collector.
+func metricsRetry(collector MetricsCollector) resty.OnRetryFunc {
+	return func(response *resty.Response, _ error) {
+		if response != nil && response.Request != nil {
+			collector.ObserveRetry(response.Request.Method)
+		}
+	}
+}
+
+// noStatusCode is the code label of requests that received no response.
+const noStatusCode = "none"
+
+// prometheusBuckets are the upper bounds, in seconds, of the latency
+// histogram of [PrometheusMetrics], the Prometheus client defaults.
+var prometheusBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10} //nolint:gochecknoglobals // read-only bucket bounds
+
+// PrometheusMetrics is a [MetricsCollector] that serves its metrics in the
+// Prometheus text exposition format, so it can be mounted on a /metrics
+// endpoint without this module depending on a Prometheus client library.
+// It exposes:
+//
+//   - slackmgr_client_requests_total, the requests sent, by method and
+//     status code
+//   - slackmgr_client_request_errors_total, the requests that failed, with
+//     no response (code "none") or a 4xx or 5xx status, by method and code
+//   - slackmgr_client_request_duration_seconds, a histogram of request
+//     latencies by method
+//   - slackmgr_client_retries_total, the retries, by method
+//
+// A PrometheusMetrics can be shared by several clients. The zero value is
+// not usable; use [NewPrometheusMetrics].
+type PrometheusMetrics struct {
+	mu        sync.Mutex
+	requests  map[methodCode]uint64
+	errors    map[methodCode]uint64
+	latencies map[string]*latencyHistogram
+	retries   map[string]uint64
+}
+
+type methodCode struct {
+	method string
+	code   string
+}
+
+type latencyHistogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics returns an empty [PrometheusMetrics].
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		requests:  make(map[methodCode]uint64),
+		errors:    make(map[methodCode]uint64),
+		latencies: make(map[string]*latencyHistogram),
+		retries:   make(map[string]uint64),
+	}
+}
+
+// ObserveRequest implements [MetricsCollector].
+func (m *PrometheusMetrics) ObserveRequest(method string, statusCode int, latency time.Duration) {
+	key := methodCode{method: method, code: noStatusCode}
+	if statusCode > 0 {
+		key.code = strconv.Itoa(statusCode)
+	}
+
+	seconds := latency.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[key]++
+
+	if statusCode == 0 || statusCode >= http.StatusBadRequest {
+		m.errors[key]++
+	}
+
+	h, ok := m.latencies[method]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(prometheusBuckets))}
+		m.latencies[method] = h
+	}
+
+	for i, bound := range prometheusBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += seconds
+}
+
+// ObserveRetry implements [MetricsCollector].
+func (m *PrometheusMetrics) ObserveRetry(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[method]++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WriteText(w)
+}
+
+// WriteText writes the metrics to w in the Prometheus text exposition
+// format, sorted by name and labels.
+func (m *PrometheusMetrics) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	m.mu.Lock()
+
+	writeCounters(&b, "slackmgr_client_requests_total", "Requests sent to the Slack Manager API.", m.requests)
+	writeCounters(&b, "slackmgr_client_request_errors_total", "Requests to the Slack Manager API that failed.", m.errors)
+
+	b.WriteString("# HELP slackmgr_client_request_duration_seconds Latency of requests to the Slack Manager API.\n")
+	b.WriteString("# TYPE slackmgr_client_request_duration_seconds histogram\n")
+
+	for _, method := range slices.Sorted(maps.Keys(m.latencies)) {
+		h := m.latencies[method]
+
+		for i, bound := range prometheusBuckets {
+			fmt.Fprintf(&b, "slackmgr_client_request_duration_seconds_bucket{method=%q,le=%q} %d\n", method, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+
+		fmt.Fprintf(&b, "slackmgr_client_request_duration_seconds_bucket{method=%q,le=\"+Inf\"} %d\n", method, h.count)
+		fmt.Fprintf(&b, "slackmgr_client_request_duration_seconds_sum{method=%q} %s\n", method, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "slackmgr_client_request_duration_seconds_count{method=%q} %d\n", method, h.count)
+	}
+
+	b.WriteString("# HELP slackmgr_client_retries_total Retries of requests to the Slack Manager API.\n")
+	b.WriteString("# TYPE slackmgr_client_retries_total counter\n")
+
+	for _, method := range slices.Sorted(maps.Keys(m.retries)) {
+		fmt.Fprintf(&b, "slackmgr_client_retries_total{method=%q} %d\n", method, m.retries[method])
+	}
+
+	m.mu.Unlock()
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	return nil
+}
+
+// writeCounters writes a counter family labelled by method and code.
+func writeCounters(b *strings.Builder, name, help string, counters map[methodCode]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	keys := make([]methodCode, 0, len(counters))
+	for key := range counters {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b methodCode) int {
+		return strings.Compare(a.method+" "+a.code, b.method+" "+b.code)
+	})
+
+	for _, key := range keys {
+		fmt.Fprintf(b, "%s{method=%q,code=%q} %d\n", name, key.method, key.code, counters[key])
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	var posts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && posts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := NewPrometheusMetrics()
+
+	client := New(server.URL, WithMetrics(metrics), WithRetryCount(1), WithRetryWaitTime(100*time.Millisecond))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/metrics", nil))
+
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("expected the Prometheus text format, got %q", got)
+	}
+
+	body := recorder.Body.String()
+
+	for _, want := range []string{
+		`slackmgr_client_requests_total{method="GET",code="200"} 1`,
+		`slackmgr_client_requests_total{method="POST",code="200"} 1`,
+		`slackmgr_client_requests_total{method="POST",code="503"} 1`,
+		`slackmgr_client_request_errors_total{method="POST",code="503"} 1`,
+		`slackmgr_client_request_duration_seconds_count{method="POST"} 2`,
+		`slackmgr_client_request_duration_seconds_bucket{method="POST",le="+Inf"} 2`,
+		`slackmgr_client_retries_total{method="POST"} 1`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("expected %q in the metrics, got:\n%s", want, body)
+		}
+	}
+
+	if strings.Contains(body, `slackmgr_client_request_errors_total{method="POST",code="200"}`) {
+		t.Errorf("expected successful requests not to count as errors, got:\n%s", body)
+	}
+
+	if !client.EffectiveConfig().Metrics {
+		t.Error("expected the snapshot to report metrics")
+	}
+}
+
+func TestWithMetrics_NoResponse(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewPrometheusMetrics()
+	metrics.ObserveRequest(http.MethodPost, 0, 20*time.Millisecond)
+
+	var b strings.Builder
+	if err := metrics.WriteText(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`slackmgr_client_request_errors_total{method="POST",code="none"} 1`,
+		`slackmgr_client_request_duration_seconds_bucket{method="POST",le="0.01"} 0`,
+		`slackmgr_client_request_duration_seconds_bucket{method="POST",le="0.025"} 1`,
+	} {
+		if !strings.Contains(b.String(), want+"\n") {
+			t.Errorf("expected %q in the metrics, got:\n%s", want, b.String())
+		}
+	}
+
+	if client := New("http://example.com", WithMetrics(nil)); client.options.metrics != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil collector to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NegotiateTokenSource produces SPNEGO tokens for [NegotiateAuthenticator].
+// Token returns the initial security context token for the service
+// principal spn, for example from a Kerberos library such as gokrb5, or
+// from SSPI on Windows. It is called for every attempt, so it should cache
+// service tickets.
+type NegotiateTokenSource interface {
+	Token(ctx context.Context, spn string) ([]byte, error)
+}
+
+// NegotiateTokenFunc adapts a function to [NegotiateTokenSource].
+type NegotiateTokenFunc func(ctx context.Context, spn string) ([]byte, error)
+
+// Token calls f.
+func (f NegotiateTokenFunc) Token(ctx context.Context, spn string) ([]byte, error) {
+	return f(ctx, spn)
+}
+
+// negotiateAuthenticator implements HTTP Negotiate authentication
+// (RFC 4559).
+type negotiateAuthenticator struct {
+	spn    string
+	tokens NegotiateTokenSource
+}
+
+// NegotiateAuthenticator returns an [Authenticator] for gateways that use
+// HTTP Negotiate (SPNEGO, RFC 4559), as in Windows-integrated
+// authentication with Kerberos. Every request carries an
+// "Authorization: Negotiate" header with a token from tokens. spn is the
+// service principal name; if empty, "HTTP/<host>" of the request URL is
+// used. If tokens is nil, every request fails to authenticate.
+//
+// Only single-round Kerberos exchanges are supported. NTLM, and SPNEGO
+// exchanges that fall back to it, need a multi-round handshake pinned to
+// one connection, which the pooled client does not provide.
+func NegotiateAuthenticator(spn string, tokens NegotiateTokenSource) Authenticator { //nolint:ireturn // callers only need the interface
+	return &negotiateAuthenticator{spn: spn, tokens: tokens}
+}
+
+func (a *negotiateAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+	if a.tokens == nil {
+		return errors.New("negotiate token source is nil")
+	}
+
+	spn := a.spn
+	if spn == "" {
+		spn = "HTTP/" + req.URL.Hostname()
+	}
+
+	token, err := a.tokens.Token(ctx, spn)
+	if err != nil {
+		return fmt.Errorf("failed to get Negotiate token for %s: %w", spn, err)
+	}
+
+	req.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(token))
+
+	return nil
+}
+
+// OnAuthFailure retries once if the server still offers Negotiate, in
+// case the token was rejected because the ticket expired.
+func (a *negotiateAuthenticator) OnAuthFailure(_ context.Context, resp *Response) error {
+	for _, challenge := range resp.Header.Values("WWW-Authenticate") {
+		scheme, params, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+		if !strings.EqualFold(scheme, "Negotiate") {
+			continue
+		}
+
+		if strings.TrimSpace(params) != "" {
+			return errors.New("server requested a multi-round Negotiate exchange, which is not supported")
+		}
+
+		return nil
+	}
+
+	return errors.New("server does not offer Negotiate authentication")
+}
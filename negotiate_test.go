@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestNegotiateAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+		bodies   []int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Negotiate dGlja2V0LTI=" {
+			w.Header().Set("WWW-Authenticate", "Negotiate")
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.URL.Path == "/alerts" {
+			var list alertsList
+			_ = json.NewDecoder(r.Body).Decode(&list)
+
+			mu.Lock()
+			bodies = append(bodies, len(list.Alerts))
+			mu.Unlock()
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var spns []string
+
+	tokens := NegotiateTokenFunc(func(_ context.Context, spn string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		spns = append(spns, spn)
+		attempts++
+
+		// The first ticket has expired; the refreshed one is accepted.
+		if attempts == 1 {
+			return []byte("ticket-1"), nil
+		}
+
+		return []byte("ticket-2"), nil
+	})
+
+	client := New(server.URL, WithAuthenticator(NegotiateAuthenticator("", tokens)))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if attempts != 3 || spns[0] != "HTTP/127.0.0.1" {
+		t.Errorf("expected 3 tokens for HTTP/127.0.0.1, got %d for %v", attempts, spns)
+	}
+
+	if len(bodies) != 1 || bodies[0] != 1 {
+		t.Errorf("expected the send body to arrive intact, got %v", bodies)
+	}
+
+	if snapshot := client.EffectiveConfig(); snapshot.Auth != "authenticator" || snapshot.Authenticator != "*client.negotiateAuthenticator" {
+		t.Errorf("unexpected auth in the config snapshot: %q %q", snapshot.Auth, snapshot.Authenticator)
+	}
+}
+
+func TestNegotiateAuthenticator_NotOffered(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+
+		w.Header().Set("WWW-Authenticate", `Basic realm="gateway"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("login required"))
+	}))
+	defer server.Close()
+
+	tokens := NegotiateTokenFunc(func(context.Context, string) ([]byte, error) { return []byte("t"), nil })
+
+	err := New(server.URL, WithAuthenticator(NegotiateAuthenticator("HTTP/gateway.example.com", tokens))).Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "login required") {
+		t.Fatalf("expected the 401 response to be returned, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestNegotiateAuthenticator_TokenError(t *testing.T) {
+	t.Parallel()
+
+	auth := NegotiateAuthenticator("HTTP/gateway.example.com", NegotiateTokenFunc(func(context.Context, string) ([]byte, error) {
+		return nil, errors.New("no credentials cache")
+	}))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "https://gateway.example.com/ping", nil)
+
+	err := auth.Apply(context.Background(), req)
+	if err == nil || err.Error() != "failed to get Negotiate token for HTTP/gateway.example.com: no credentials cache" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNegotiateAuthenticator_NilTokenSource(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "https://gateway.example.com/ping", nil)
+
+	err := NegotiateAuthenticator("", nil).Apply(t.Context(), req)
+	if err == nil || err.Error() != "negotiate token source is nil" {
+		t.Errorf("expected nil token source error, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// oauth2ExpiryDelta is how long before its expiry a token is renewed,
+	// so it does not expire in flight.
+	oauth2ExpiryDelta = 30 * time.Second
+
+	oauth2TokenTimeout = 30 * time.Second
+	maxOAuth2Response  = 1 << 20
+)
+
+// OAuth2Token is an access token for [OAuth2Authenticator].
+type OAuth2Token struct {
+	// AccessToken is sent in the Authorization header.
+	AccessToken string
+
+	// TokenType is the scheme of the Authorization header. An empty type
+	// means "Bearer".
+	TokenType string
+
+	// Expiry is when the token expires, or the zero time if it does not.
+	Expiry time.Time
+}
+
+// valid reports whether the token is set and not about to expire.
+func (t *OAuth2Token) valid(now time.Time) bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(oauth2ExpiryDelta).Before(t.Expiry))
+}
+
+// OAuth2TokenSource issues access tokens for [OAuth2Authenticator], such
+// as [OAuth2ClientCredentials] or an adapter for a golang.org/x/oauth2
+// TokenSource. Implementations must be safe for concurrent use.
+type OAuth2TokenSource interface {
+	Token(ctx context.Context) (*OAuth2Token, error)
+}
+
+// OAuth2TokenFunc adapts a function to [OAuth2TokenSource].
+type OAuth2TokenFunc func(ctx context.Context) (*OAuth2Token, error)
+
+// Token calls f.
+func (f OAuth2TokenFunc) Token(ctx context.Context) (*OAuth2Token, error) {
+	return f(ctx)
+}
+
+// oauth2Authenticator sends tokens from a source in the Authorization
+// header, reusing each until it is about to expire or is rejected.
+type oauth2Authenticator struct {
+	source OAuth2TokenSource
+
+	mu    sync.Mutex
+	token *OAuth2Token
+}
+
+// OAuth2Authenticator returns an [Authenticator] that sends access tokens
+// from source in the Authorization header. A token is reused until 30
+// seconds before its expiry, or until the API rejects it with 401, after
+// which the request is sent once more with a new token.
+// [WithOAuth2] is a shorthand for it with [OAuth2ClientCredentials].
+func OAuth2Authenticator(source OAuth2TokenSource) Authenticator { //nolint:ireturn // callers only need the interface
+	return &oauth2Authenticator{source: source}
+}
+
+func (a *oauth2Authenticator) Apply(ctx context.Context, req *http.Request) error {
+	token, err := a.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	scheme := token.TokenType
+	if scheme == "" || strings.EqualFold(scheme, "bearer") {
+		scheme = "Bearer"
+	}
+
+	req.Header.Set("Authorization", scheme+" "+token.AccessToken)
+
+	return nil
+}
+
+// OnAuthFailure drops the cached token, so the request is sent again with
+// a new one.
+func (a *oauth2Authenticator) OnAuthFailure(context.Context, *Response) error {
+	a.mu.Lock()
+	a.token = nil
+	a.mu.Unlock()
+
+	return nil
+}
+
+// current returns the cached token, fetching a new one if it is missing
+// or about to expire.
+func (a *oauth2Authenticator) current(ctx context.Context) (*OAuth2Token, error) {
+	if a.source == nil {
+		return nil, errors.New("OAuth2 token source is nil")
+	}
+
+	a.mu.Lock()
+	token := a.token
+	a.mu.Unlock()
+
+	if token.valid(time.Now()) {
+		return token, nil
+	}
+
+	token, err := a.source.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OAuth2 access token: %w", err)
+	}
+
+	if token == nil || token.AccessToken == "" {
+		return nil, errors.New("OAuth2 token source returned no access token")
+	}
+
+	a.mu.Lock()
+	a.token = token
+	a.mu.Unlock()
+
+	return token, nil
+}
+
+// clientCredentials fetches tokens with the OAuth2 client credentials
+// grant.
+type clientCredentials struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	scopes       []string
+	client       *http.Client
+
+	flights flightGroup
+}
+
+// OAuth2ClientCredentials returns an [OAuth2TokenSource] that fetches access
+// tokens from tokenURL with the OAuth2 client credentials grant
+// (RFC 6749, section 4.4), authenticating with clientID and clientSecret
+// as HTTP Basic credentials. Concurrent requests for a token share a
+// single fetch. Token requests are sent with [http.DefaultTransport] and a
+// 30 second timeout.
+func OAuth2ClientCredentials(clientID, clientSecret, tokenURL string, scopes ...string) OAuth2TokenSource { //nolint:ireturn // callers only need the interface
+	return &clientCredentials{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		tokenURL:     tokenURL,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: oauth2TokenTimeout},
+	}
+}
+
+// oauth2TokenResponse is the token endpoint response of RFC 6749,
+// sections 5.1 and 5.2.
+type oauth2TokenResponse struct {
+	AccessToken      string `json:"access_token"` //nolint:tagliatelle // RFC 6749 schema
+	TokenType        string `json:"token_type"`   //nolint:tagliatelle // RFC 6749 schema
+	ExpiresIn        int64  `json:"expires_in"`   //nolint:tagliatelle // RFC 6749 schema
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"` //nolint:tagliatelle // RFC 6749 schema
+}
+
+func (s *clientCredentials) Token(ctx context.Context) (*OAuth2Token, error) {
+	start := time.Now()
+
+	body, err := s.flights.do(ctx, "token", s.fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	var response oauth2TokenResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode OAuth2 token response: %w", err)
+	}
+
+	token := &OAuth2Token{AccessToken: response.AccessToken, TokenType: response.TokenType}
+	if response.ExpiresIn > 0 {
+		token.Expiry = start.Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// fetch requests a token and returns the body of a successful response.
+func (s *clientCredentials) fetch(ctx context.Context) ([]byte, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OAuth2 token URL: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", jsonContentType)
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOAuth2Response))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth2 token response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var failure oauth2TokenResponse
+		if json.Unmarshal(body, &failure) == nil && failure.Error != "" {
+			return nil, fmt.Errorf("OAuth2 token request failed with status code %d: %s %s", resp.StatusCode, failure.Error, failure.ErrorDescription)
+		}
+
+		return nil, fmt.Errorf("OAuth2 token request failed with status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newTokenServer returns a token endpoint issuing tok-1, tok-2, ... to the
+// client credentials of id and secret.
+func newTokenServer(t *testing.T, fetches *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "id" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "unknown client"}`))
+
+			return
+		}
+
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "alerts:write alerts:read" {
+			t.Errorf("unexpected token request %v", r.PostForm)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "tok-%d", "token_type": "bearer", "expires_in": 3600}`, fetches.Add(1))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWithOAuth2(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+
+	tokens := newTokenServer(t, &fetches)
+
+	// The first token has been revoked; the refreshed one is accepted.
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := New(api.URL, WithOAuth2("id", "s3cret", tokens.URL, "alerts:write", "alerts:read"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	for range 3 {
+		if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected the token to be fetched once and refreshed once, got %d fetches", n)
+	}
+
+	if snapshot := client.EffectiveConfig(); snapshot.Authenticator != "*client.oauth2Authenticator" {
+		t.Errorf("unexpected authenticator in the config snapshot: %q", snapshot.Authenticator)
+	}
+}
+
+func TestOAuth2ClientCredentials_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+
+	source := OAuth2ClientCredentials("id", "s3cret", newTokenServer(t, &fetches).URL, "alerts:write", "alerts:read")
+	auth := OAuth2Authenticator(source)
+
+	done := make(chan error)
+	for range 10 {
+		go func() {
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.com/ping", nil)
+			done <- auth.Apply(context.Background(), req)
+		}()
+	}
+
+	for range 10 {
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected concurrent requests to share one token, got %d fetches", n)
+	}
+}
+
+func TestOAuth2ClientCredentials_Error(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+
+	source := OAuth2ClientCredentials("id", "wrong", newTokenServer(t, &fetches).URL)
+
+	_, err := source.Token(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "status code 401: invalid_client unknown client") {
+		t.Errorf("expected the OAuth2 error, got %v", err)
+	}
+}
+
+func TestOAuth2Authenticator_Expiry(t *testing.T) {
+	t.Parallel()
+
+	var fetches int
+
+	auth := OAuth2Authenticator(OAuth2TokenFunc(func(context.Context) (*OAuth2Token, error) {
+		fetches++
+
+		// A token expiring within the renewal margin is not reused.
+		return &OAuth2Token{AccessToken: fmt.Sprintf("tok-%d", fetches), TokenType: "MAC", Expiry: time.Now().Add(oauth2ExpiryDelta / 2)}, nil
+	}))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.com/ping", nil)
+
+	for range 2 {
+		if err := auth.Apply(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := req.Header.Get("Authorization"); got != "MAC tok-2" || fetches != 2 {
+		t.Errorf("expected a new token for each request, got %q after %d fetches", got, fetches)
+	}
+}
+
+func TestWithOAuth2_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, opt := range []Option{
+		WithOAuth2("id", "s3cret", "/oauth2/token"),
+		WithOAuth2("id", "s3cret", "ftp://auth.example.com/token"),
+		WithOAuth2("", "s3cret", "https://auth.example.com/token"),
+	} {
+		if client := New("http://localhost", opt); client.options.authenticator != nil || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+
+	if err := New("http://localhost", WithOAuth2("id", "s3cret", "https://auth.example.com/token"), WithAuthToken("t")).Connect(context.Background()); err == nil {
+		t.Error("expected an error combining OAuth2 and token auth")
+	}
+}
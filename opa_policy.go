@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+const defaultOPAAddress = "http://127.0.0.1:8181"
+
+// OPAPolicyConfig configures [OPAPolicy].
+type OPAPolicyConfig struct {
+	// Address is the URL of the OPA REST API. Defaults to the local
+	// agent, http://127.0.0.1:8181.
+	Address string
+
+	// Path is the path of the policy decision in the data API, such as
+	// "slackmgr/send".
+	Path string
+
+	// Token is sent as a bearer token, if set.
+	Token string
+
+	// HTTPClient is used for the requests to OPA, for example to
+	// configure TLS. Defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+}
+
+// opaInput is the input document of an OPA policy query.
+type opaInput struct {
+	Alert  *types.Alert `json:"alert"`
+	Caller string       `json:"caller,omitempty"`
+}
+
+// opaResult is an OPA policy decision given as an object.
+type opaResult struct {
+	Allow  *bool        `json:"allow"`
+	Reason string       `json:"reason"`
+	Alert  *types.Alert `json:"alert"`
+}
+
+// OPAPolicy returns a [SendPolicy] that queries an Open Policy Agent
+// decision through the data API. The input document holds the alert and,
+// if set with [WithCaller], the caller:
+//
+//	{"input": {"alert": {...}, "caller": "deploy-bot"}}
+//
+// The decision is either a boolean or an object with a boolean "allow",
+// an optional "reason" for denials and an optional "alert" to send in
+// place of the input alert. An undefined decision, or a failed query,
+// denies the alert, so that an unreachable agent cannot bypass policy.
+func OPAPolicy(cfg OPAPolicyConfig) SendPolicy {
+	address := strings.TrimSuffix(cmp.Or(cfg.Address, defaultOPAAddress), "/")
+	httpClient := cfg.HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	endpoint := address + "/v1/data/" + strings.Trim(cfg.Path, "/")
+
+	return func(ctx context.Context, alert *types.Alert) Decision {
+		if strings.Trim(cfg.Path, "/") == "" {
+			return DenyAlert("OPA policy requires a decision path")
+		}
+
+		decision, err := queryOPA(ctx, httpClient, endpoint, cfg.Token, opaInput{Alert: alert, Caller: CallerFromContext(ctx)})
+		if err != nil {
+			return DenyAlert(fmt.Sprintf("OPA policy query failed: %v", err))
+		}
+
+		return decision
+	}
+}
+
+// queryOPA sends input to the decision at endpoint and converts the
+// result to a [Decision].
+func queryOPA(ctx context.Context, httpClient *http.Client, endpoint, token string, input opaInput) (Decision, error) {
+	body, err := json.Marshal(map[string]opaInput{"input": input})
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := doDiscoveryRequest(httpClient, req, &resp); err != nil {
+		return Decision{}, err
+	}
+
+	result := bytes.TrimSpace(resp.Result)
+
+	switch {
+	case len(result) == 0 || bytes.Equal(result, []byte("null")):
+		return DenyAlert("OPA policy decision " + req.URL.Path + " is undefined"), nil
+	case bytes.Equal(result, []byte("true")):
+		return AllowAlert(), nil
+	case bytes.Equal(result, []byte("false")):
+		return DenyAlert("denied by OPA policy"), nil
+	}
+
+	var decision opaResult
+	if err := json.Unmarshal(result, &decision); err != nil || decision.Allow == nil {
+		return Decision{}, fmt.Errorf("decision %s must be a boolean or an object with a boolean allow", req.URL.Path)
+	}
+
+	switch {
+	case !*decision.Allow:
+		return DenyAlert(cmp.Or(decision.Reason, "denied by OPA policy")), nil
+	case decision.Alert != nil:
+		return ModifyAlert(decision.Alert), nil
+	default:
+		return AllowAlert(), nil
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
 )
 
 const (
@@ -32,6 +37,7 @@ const (
 	defaultAuthScheme      = "Bearer"
 	defaultAlertsEndpoint  = "alerts"
 	defaultPingEndpoint    = "ping"
+	defaultAuditEndpoint   = "audit"
 )
 
 // Option is a functional option for configuring a [Client].
@@ -44,12 +50,17 @@ type Options struct {
 	retryWaitTime     time.Duration
 	retryMaxWaitTime  time.Duration
 	requestLogger     RequestLogger
-	retryPolicy       func(*resty.Response, error) bool
+	retryPolicy       func(*Response, error) bool
+	customRetry       bool // retryPolicy was set by an option
+	retryStrategy     RetryStrategy
+	endpointRetry     map[Endpoint]RetryConfig
 	requestHeaders    map[string]string
 	basicAuthUsername string
 	basicAuthPassword string
 	authScheme        string
 	authToken         string
+	authTokenProvider AuthTokenProvider
+	authenticator     Authenticator
 	timeout           time.Duration
 	userAgent         string
 	maxIdleConns      int
@@ -58,8 +69,70 @@ type Options struct {
 	disableKeepAlive  bool
 	maxRedirects      int
 	tlsConfig         *tls.Config
+	clientCert        *clientCertificate
+	cookieJar         http.CookieJar
 	alertsEndpoint    string
 	pingEndpoint      string
+	auditEndpoint     string
+	digestSelector    ChannelSelector
+	digestInterval    time.Duration
+	quietHoursRules   []*quietHoursRule
+	severityMapper    *severityMapper
+	alertSchema       AlertSchemaVersion
+	maxMetadataSize   int
+	spoolThreshold    int64
+	progress          func(sent, total int64)
+	bandwidthLimit    int64
+	headerCallback    func(http.Header)
+	adaptiveBackoff   float64
+	reresolve         bool
+	discovery         EndpointDiscovery
+	discoveryRefresh  time.Duration
+	bodyTransforms    []BodyTransformer
+	encryptKeys       KeyProvider
+	encryptFields     []string
+	piiMasker         *piiMasker
+	auditSink         func(AuditRecord)
+	channels          *channelPolicy
+	sendPolicies      []SendPolicy
+	snippetThreshold  int
+	theme             Theme
+	plainText         bool
+	channelZones      bool
+	zoneFallback      *time.Location
+	timestampLayout   string
+	runbooks          RunbookResolver
+	ackEscalation     AckEscalation
+	repeatInterval    time.Duration
+	repeatMax         int
+	repeatUntil       RepeatCondition
+	tracer            Tracer
+	metrics           MetricsCollector
+	asyncQueueSize    int
+	asyncInterval     time.Duration
+	asyncOnError      AsyncErrorHandler
+	asyncOnResult     ResultCallback
+	rateLimit         float64
+	rateLimitBurst    int
+	quarantineStore   QuarantineStore
+	quarantineLimit   int
+	schemaRepair      bool
+	strictResponses   bool
+	priorityReserve   float64
+	localSilences     []*Silence
+	silenceSync       time.Duration
+	silencePolicy     SilenceConflictPolicy
+	contextFields     ContextFieldExtractor
+	logSampling       int
+	slowThreshold     time.Duration
+	deliveryHealth    *DeliveryHealthAlert
+	fallbackWebhook   string
+	emailFallback     *EmailFallback
+	backend           Transport
+	lookupTTL         time.Duration
+	lookupStaleTTL    time.Duration
+	lookupMaxEntries  int
+	ignored           []string
 }
 
 func newClientOptions() *Options {
@@ -68,10 +141,10 @@ func newClientOptions() *Options {
 		retryWaitTime:    500 * time.Millisecond,
 		retryMaxWaitTime: 3 * time.Second,
 		requestLogger:    &NoopLogger{},
-		retryPolicy:      DefaultRetryPolicy,
+		retryPolicy:      DefaultRetryCondition,
 		requestHeaders: map[string]string{
-			"Content-Type": "application/json",
-			"Accept":       "application/json",
+			"Content-Type": jsonContentType,
+			"Accept":       jsonContentType,
 		},
 		timeout:          defaultTimeout,
 		userAgent:        defaultUserAgent,
@@ -83,6 +156,9 @@ func newClientOptions() *Options {
 		authScheme:       defaultAuthScheme,
 		alertsEndpoint:   defaultAlertsEndpoint,
 		pingEndpoint:     defaultPingEndpoint,
+		auditEndpoint:    defaultAuditEndpoint,
+		alertSchema:      AlertSchemaV1,
+		spoolThreshold:   defaultSpoolThreshold,
 	}
 }
 
@@ -93,7 +169,10 @@ func WithRetryCount(count int) Option {
 	return func(o *Options) {
 		if count >= 0 {
 			o.retryCount = count
+			return
 		}
+
+		o.reject("WithRetryCount", count, "must be non-negative")
 	}
 }
 
@@ -104,7 +183,10 @@ func WithRetryWaitTime(waitTime time.Duration) Option {
 	return func(o *Options) {
 		if waitTime >= 100*time.Millisecond {
 			o.retryWaitTime = waitTime
+			return
 		}
+
+		o.reject("WithRetryWaitTime", waitTime, "must be at least 100ms")
 	}
 }
 
@@ -116,7 +198,10 @@ func WithRetryMaxWaitTime(maxWaitTime time.Duration) Option {
 	return func(o *Options) {
 		if maxWaitTime >= 100*time.Millisecond {
 			o.retryMaxWaitTime = maxWaitTime
+			return
 		}
+
+		o.reject("WithRetryMaxWaitTime", maxWaitTime, "must be at least 100ms")
 	}
 }
 
@@ -130,18 +215,139 @@ func WithRequestLogger(logger RequestLogger) Option {
 	return func(o *Options) {
 		if logger != nil {
 			o.requestLogger = logger
+			return
 		}
+
+		o.reject("WithRequestLogger", "nil", "must not be nil")
 	}
 }
 
-// WithRetryPolicy sets a custom function that decides whether a failed
-// request should be retried. The default is [DefaultRetryPolicy], which
-// retries on 429, 5xx, and transient connection errors. Nil values are
-// silently ignored and the default is retained.
+// WithContextFields attaches the fields returned by extractor, such as a
+// trace ID or tenant taken from the request context, to every message the
+// client logs while handling a request, so they can be correlated with
+// application logs. If the logger implements [FieldLogger] the fields are
+// passed to WithFields; otherwise they are appended to the message as
+// key=value pairs, sorted by key. Background work such as digest flushes
+// is logged without fields. A nil extractor is silently ignored.
+func WithContextFields(extractor ContextFieldExtractor) Option {
+	return func(o *Options) {
+		if extractor != nil {
+			o.contextFields = extractor
+			return
+		}
+
+		o.reject("WithContextFields", "nil", "extractor must not be nil")
+	}
+}
+
+// WithLogSampling limits identical error and warning log lines to
+// perMinute per minute, so a flapping endpoint does not flood the logs.
+// Lines are identical if they have the same format string, whatever their
+// arguments. Once a minute the client logs a "suppressed N similar errors"
+// summary for every message that exceeded the limit. Debug messages are
+// not sampled. Valid range is 1–10,000; other values are silently ignored
+// and sampling remains disabled.
+func WithLogSampling(perMinute int) Option {
+	return func(o *Options) {
+		if perMinute >= 1 && perMinute <= maxLogSamplingRate {
+			o.logSampling = perMinute
+			return
+		}
+
+		o.reject("WithLogSampling", perMinute, fmt.Sprintf("must be between 1 and %d", maxLogSamplingRate))
+	}
+}
+
+// WithSlowRequestThreshold logs a warning for every request attempt that
+// takes longer than threshold, with a timing breakdown (DNS lookup, TCP
+// connect, TLS handshake and time to first byte) captured with
+// [net/http/httptrace]. Slow requests are counted in [Client.Stats]. Valid
+// range is 1ms–5min; other values are silently ignored and the check
+// remains disabled.
+func WithSlowRequestThreshold(threshold time.Duration) Option {
+	return func(o *Options) {
+		if threshold >= minSlowRequestThreshold && threshold <= maxSlowRequestThreshold {
+			o.slowThreshold = threshold
+			return
+		}
+
+		o.reject("WithSlowRequestThreshold", threshold, fmt.Sprintf("must be between %v and %v", minSlowRequestThreshold, maxSlowRequestThreshold))
+	}
+}
+
+// WithRetryCondition sets a custom function that decides whether a failed
+// request should be retried. The [Response] is nil when no response was
+// received, in which case err is set. The default is
+// [DefaultRetryCondition], which retries on 429, 5xx, and transient
+// connection errors. Nil values are silently ignored and the default is
+// retained.
+func WithRetryCondition(condition func(*Response, error) bool) Option {
+	return func(o *Options) {
+		if condition != nil {
+			o.retryPolicy = condition
+			o.customRetry = true
+
+			return
+		}
+
+		o.reject("WithRetryCondition", "nil", "must not be nil")
+	}
+}
+
+// WithRetryStrategy sets a [RetryStrategy], which can consult the request
+// context and attempt number and choose its own wait. It takes precedence
+// over [WithRetryCondition]. Nil values are silently ignored.
+func WithRetryStrategy(strategy RetryStrategy) Option {
+	return func(o *Options) {
+		if strategy != nil {
+			o.retryStrategy = strategy
+			return
+		}
+
+		o.reject("WithRetryStrategy", "nil", "must not be nil")
+	}
+}
+
+// WithEndpointRetry sets the retry settings of one endpoint class,
+// overriding the global ones, for example aggressive retries for
+// [EndpointSend] and none for [EndpointDelete]. Later calls for the same
+// endpoint replace earlier ones. Unknown endpoints are silently ignored;
+// invalid settings are rejected when [Client.Connect] is called.
+func WithEndpointRetry(endpoint Endpoint, cfg RetryConfig) Option {
+	return func(o *Options) {
+		if !endpoint.valid() {
+			o.reject("WithEndpointRetry", endpoint, "unknown endpoint")
+			return
+		}
+
+		if o.endpointRetry == nil {
+			o.endpointRetry = make(map[Endpoint]RetryConfig)
+		}
+
+		o.endpointRetry[endpoint] = cfg
+	}
+}
+
+// WithRetryPolicy is the resty-typed form of [WithRetryCondition]. The
+// policy receives the underlying resty response, or nil when no response
+// was received. Nil values are silently ignored and the default is
+// retained.
+//
+// Deprecated: Use [WithRetryCondition], which does not depend on resty.
 func WithRetryPolicy(policy func(*resty.Response, error) bool) Option {
 	return func(o *Options) {
-		if policy != nil {
-			o.retryPolicy = policy
+		if policy == nil {
+			o.reject("WithRetryPolicy", "nil", "must not be nil")
+			return
+		}
+
+		o.customRetry = true
+		o.retryPolicy = func(r *Response, err error) bool {
+			if r == nil {
+				return policy(nil, err)
+			}
+
+			return policy(r.raw, err)
 		}
 	}
 }
@@ -155,7 +361,13 @@ func WithRequestHeader(header, value string) Option {
 		header = strings.TrimSpace(header)
 		value = strings.TrimSpace(value)
 
-		if header == "" || strings.EqualFold(header, "Content-Type") || strings.EqualFold(header, "Accept") {
+		if header == "" {
+			o.reject("WithRequestHeader", `""`, "header name must not be empty")
+			return
+		}
+
+		if strings.EqualFold(header, "Content-Type") || strings.EqualFold(header, "Accept") {
+			o.reject("WithRequestHeader", header, "protected header cannot be overridden")
 			return
 		}
 
@@ -163,9 +375,10 @@ func WithRequestHeader(header, value string) Option {
 	}
 }
 
-// WithBasicAuth configures HTTP Basic authentication. Mutually exclusive
-// with [WithAuthToken]; supplying both is rejected when [Client.Connect]
-// is called.
+// WithBasicAuth configures HTTP Basic authentication, as a shorthand for
+// [WithAuthenticator] with [BasicAuthenticator]. Mutually exclusive with
+// [WithAuthToken]; supplying both is rejected when [Client.Connect] is
+// called.
 func WithBasicAuth(username, password string) Option {
 	return func(o *Options) {
 		o.basicAuthUsername = username
@@ -173,7 +386,47 @@ func WithBasicAuth(username, password string) Option {
 	}
 }
 
-// WithAuthScheme sets the authentication scheme used with [WithAuthToken].
+// WithAuthenticator sets an [Authenticator] that adds credentials to every
+// request to the base URL's host, such as [NegotiateAuthenticator]. Mutually exclusive with
+// [WithBasicAuth] and [WithAuthToken]; combining them is rejected when
+// [Client.Connect] is called. Nil values are silently ignored.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(o *Options) {
+		if auth != nil {
+			o.authenticator = auth
+			return
+		}
+
+		o.reject("WithAuthenticator", "nil", "must not be nil")
+	}
+}
+
+// WithOAuth2 authenticates requests with access tokens fetched from
+// tokenURL with the OAuth2 client credentials grant, as a shorthand for
+// [WithAuthenticator] with [OAuth2Authenticator] and [OAuth2ClientCredentials].
+// Tokens are fetched when first needed and renewed before they expire, or
+// when the API rejects them. Mutually exclusive with [WithBasicAuth] and
+// [WithAuthToken]; combining them is rejected when [Client.Connect] is
+// called. A tokenURL that is not an absolute http or https URL, or an
+// empty clientID, is silently ignored.
+func WithOAuth2(clientID, clientSecret, tokenURL string, scopes ...string) Option {
+	return func(o *Options) {
+		if u, err := url.Parse(tokenURL); err != nil || !isHTTPURL(u) {
+			o.reject("WithOAuth2", tokenURL, "token URL must be an absolute http or https URL")
+			return
+		}
+
+		if clientID == "" {
+			o.reject("WithOAuth2", `""`, "client ID must not be empty")
+			return
+		}
+
+		o.authenticator = OAuth2Authenticator(OAuth2ClientCredentials(clientID, clientSecret, tokenURL, scopes...))
+	}
+}
+
+// WithAuthScheme sets the authentication scheme used with [WithAuthToken]
+// and [WithAuthTokenProvider].
 // The default is "Bearer".
 func WithAuthScheme(scheme string) Option {
 	return func(o *Options) {
@@ -181,7 +434,8 @@ func WithAuthScheme(scheme string) Option {
 	}
 }
 
-// WithAuthToken sets the token sent in the Authorization header. Mutually
+// WithAuthToken sets the token sent in the Authorization header, as a
+// shorthand for [WithAuthenticator] with [TokenAuthenticator]. Mutually
 // exclusive with [WithBasicAuth]; supplying both is rejected when
 // [Client.Connect] is called.
 func WithAuthToken(token string) Option {
@@ -190,6 +444,25 @@ func WithAuthToken(token string) Option {
 	}
 }
 
+// WithAuthTokenProvider sets a function that returns the token sent in the
+// Authorization header, as a shorthand for [WithAuthenticator] with
+// [TokenProviderAuthenticator]. It is called before every request,
+// including retries, so a token rotated by a secret reload takes effect
+// without rebuilding the client. It should be fast, for example reading a
+// cached value. Mutually exclusive with [WithBasicAuth] and
+// [WithAuthToken]; combining them is rejected when [Client.Connect] is
+// called. Nil values are silently ignored.
+func WithAuthTokenProvider(provider AuthTokenProvider) Option {
+	return func(o *Options) {
+		if provider == nil {
+			o.reject("WithAuthTokenProvider", "nil", "must not be nil")
+			return
+		}
+
+		o.authTokenProvider = provider
+	}
+}
+
 // WithTimeout sets the per-request timeout. The default is 30 seconds.
 // Valid range is 1 second–5 minutes. Values outside this range are silently
 // ignored and the default is retained.
@@ -197,7 +470,10 @@ func WithTimeout(timeout time.Duration) Option {
 	return func(o *Options) {
 		if timeout >= minTimeout && timeout <= maxTimeout {
 			o.timeout = timeout
+			return
 		}
+
+		o.reject("WithTimeout", timeout, fmt.Sprintf("must be between %v and %v", minTimeout, maxTimeout))
 	}
 }
 
@@ -208,7 +484,10 @@ func WithUserAgent(userAgent string) Option {
 	return func(o *Options) {
 		if userAgent != "" {
 			o.userAgent = userAgent
+			return
 		}
+
+		o.reject("WithUserAgent", `""`, "must not be empty")
 	}
 }
 
@@ -219,7 +498,10 @@ func WithMaxIdleConns(n int) Option {
 	return func(o *Options) {
 		if n >= 1 {
 			o.maxIdleConns = n
+			return
 		}
+
+		o.reject("WithMaxIdleConns", n, "must be at least 1")
 	}
 }
 
@@ -230,7 +512,10 @@ func WithMaxConnsPerHost(n int) Option {
 	return func(o *Options) {
 		if n >= 1 && n <= maxMaxConnsPerHost {
 			o.maxConnsPerHost = n
+			return
 		}
+
+		o.reject("WithMaxConnsPerHost", n, fmt.Sprintf("must be between 1 and %d", maxMaxConnsPerHost))
 	}
 }
 
@@ -242,7 +527,10 @@ func WithIdleConnTimeout(timeout time.Duration) Option {
 	return func(o *Options) {
 		if timeout >= minIdleConnTimeout && timeout <= maxIdleConnTimeout {
 			o.idleConnTimeout = timeout
+			return
 		}
+
+		o.reject("WithIdleConnTimeout", timeout, fmt.Sprintf("must be between %v and %v", minIdleConnTimeout, maxIdleConnTimeout))
 	}
 }
 
@@ -262,7 +550,43 @@ func WithMaxRedirects(n int) Option {
 	return func(o *Options) {
 		if n >= 0 && n <= maxMaxRedirects {
 			o.maxRedirects = n
+			return
+		}
+
+		o.reject("WithMaxRedirects", n, fmt.Sprintf("must be between 0 and %d", maxMaxRedirects))
+	}
+}
+
+// WithCookieJar sets the cookie jar used for all requests, for gateways
+// such as corporate SSO proxies that issue a session cookie after the
+// first request. Cookies set by any response, including redirects and
+// failed attempts, are sent with later attempts, retries and requests to
+// other endpoints. By default each client keeps cookies in its own
+// in-memory jar, which derived clients share. Nil values are silently
+// ignored.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(o *Options) {
+		if jar != nil {
+			o.cookieJar = jar
+			return
+		}
+
+		o.reject("WithCookieJar", "nil", "must not be nil")
+	}
+}
+
+// WithTransport replaces the HTTP transport used to send alert batches
+// and pings with t, for example to use another protocol or a fake in
+// tests. The other API methods still use HTTP. The client closes t when
+// [Client.Close] is called. Nil values are silently ignored.
+func WithTransport(t Transport) Option {
+	return func(o *Options) {
+		if t != nil {
+			o.backend = t
+			return
 		}
+
+		o.reject("WithTransport", "nil", "must not be nil")
 	}
 }
 
@@ -274,7 +598,36 @@ func WithTLSConfig(config *tls.Config) Option {
 	return func(o *Options) {
 		if config != nil {
 			o.tlsConfig = config
+			return
 		}
+
+		o.reject("WithTLSConfig", "nil", "must not be nil")
+	}
+}
+
+// WithClientCertificate authenticates HTTPS connections with the client
+// certificate and private key in the PEM files certFile and keyFile, for
+// APIs that require mutual TLS, and verifies the server against the CA
+// certificates in the PEM file caFile, for APIs behind a private CA. Leave
+// caFile empty to use the system roots, or certFile and keyFile empty to
+// only trust a private CA. The files are loaded when [Client.Connect] is
+// called, which fails if they cannot be; the certificates are added to a
+// copy of the configuration of [WithTLSConfig], if any. The certificate
+// and key must be given together; otherwise, or if all files are empty,
+// the option is silently ignored.
+func WithClientCertificate(certFile, keyFile, caFile string) Option {
+	return func(o *Options) {
+		if (certFile == "") != (keyFile == "") {
+			o.reject("WithClientCertificate", certFile+", "+keyFile, "certificate and key files must be given together")
+			return
+		}
+
+		if certFile == "" && caFile == "" {
+			o.reject("WithClientCertificate", `""`, "no files given")
+			return
+		}
+
+		o.clientCert = &clientCertificate{certFile: certFile, keyFile: keyFile, caFile: caFile}
 	}
 }
 
@@ -286,7 +639,10 @@ func WithAlertsEndpoint(endpoint string) Option {
 		endpoint = strings.TrimSpace(endpoint)
 		if endpoint != "" {
 			o.alertsEndpoint = endpoint
+			return
 		}
+
+		o.reject("WithAlertsEndpoint", `""`, "must not be empty")
 	}
 }
 
@@ -298,7 +654,810 @@ func WithPingEndpoint(endpoint string) Option {
 		endpoint = strings.TrimSpace(endpoint)
 		if endpoint != "" {
 			o.pingEndpoint = endpoint
+			return
+		}
+
+		o.reject("WithPingEndpoint", `""`, "must not be empty")
+	}
+}
+
+// WithAuditEndpoint sets the API endpoint path used by [Client.AuditEvents].
+// The default is "audit". Empty and whitespace-only values are silently
+// ignored and the default is retained.
+func WithAuditEndpoint(endpoint string) Option {
+	return func(o *Options) {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint != "" {
+			o.auditEndpoint = endpoint
+			return
+		}
+
+		o.reject("WithAuditEndpoint", `""`, "must not be empty")
+	}
+}
+
+// WithDigest enables digest mode. Warning and info alerts are held back and
+// summarised in a single digest alert per channel, posted every interval.
+// Panic, error and resolved alerts are always sent immediately. The selector
+// decides which channel each held-back alert is summarised in; returning an
+// empty string sends the alert immediately instead. Valid interval range is
+// 1 second–24 hours. Nil selectors and intervals outside this range are
+// silently ignored and digest mode remains disabled.
+//
+// Pending digests are posted by the background loop or by [Client.Flush].
+// Call [Client.Shutdown] instead of [Client.Close] to avoid losing them.
+func WithDigest(selector ChannelSelector, interval time.Duration) Option {
+	return func(o *Options) {
+		if selector != nil && interval >= minDigestInterval && interval <= maxDigestInterval {
+			o.digestSelector = selector
+			o.digestInterval = interval
+			return
+		}
+
+		o.reject("WithDigest", interval, fmt.Sprintf("requires a non-nil selector and an interval between %v and %v", minDigestInterval, maxDigestInterval))
+	}
+}
+
+// WithQuietHours defers warning and info alerts sent outside the given
+// business hours, evaluated in the time zone tz. Panic, error and resolved
+// alerts are always sent immediately. The behavior decides whether held-back
+// alerts are sent unchanged, summarised in a digest, or dropped once
+// business hours begin. Held-back alerts are kept in memory; call
+// [Client.Shutdown] instead of [Client.Close] to deliver them before the
+// process exits.
+//
+// When route keys are given the rule only applies to alerts with one of
+// those (case-insensitive) route keys; otherwise it applies to all alerts.
+// The option may be supplied multiple times, and route-specific rules take
+// precedence over rules without route keys. A nil tz is treated as UTC.
+// Invalid schedules are rejected when [Client.Connect] is called.
+func WithQuietHours(schedule Schedule, tz *time.Location, behavior QuietHoursBehavior, routeKeys ...string) Option {
+	return func(o *Options) {
+		if tz == nil {
+			tz = time.UTC
+		}
+
+		rule := &quietHoursRule{
+			schedule: schedule,
+			location: tz,
+			behavior: behavior,
+		}
+
+		for _, key := range routeKeys {
+			if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+				rule.routeKeys = append(rule.routeKeys, key)
+			}
+		}
+
+		o.quietHoursRules = append(o.quietHoursRules, rule)
+	}
+}
+
+// WithSilences suppresses alerts matched by any of the given silences while
+// they are active. Suppressed alerts are dropped before they are sent, and
+// are not held back for digests or quiet hours. The option may be supplied
+// multiple times. Invalid silences, and silences with duplicate IDs, are
+// rejected when [Client.Connect] is called.
+//
+// Combine with [WithSilenceSync] to also apply the silences defined on the
+// server.
+func WithSilences(silences ...Silence) Option {
+	return func(o *Options) {
+		for _, silence := range silences {
+			copied := silence
+			copied.Severities = slices.Clone(silence.Severities)
+			o.localSilences = append(o.localSilences, &copied)
+		}
+	}
+}
+
+// WithSilenceSync enables client-side suppression of alerts matched by the
+// silences defined on the server. The silences are fetched when
+// [Client.Connect] is called and refreshed every interval, so alerts are
+// suppressed locally without waiting for the server to drop them. If a
+// refresh fails the error is logged and the previous silences are kept.
+//
+// The policy decides which silence wins when a local silence from
+// [WithSilences] has the same ID as a server silence. Valid interval range
+// is 10 seconds–24 hours. Intervals outside this range are silently
+// ignored and sync remains disabled. Invalid policies are rejected when
+// [Client.Connect] is called.
+func WithSilenceSync(interval time.Duration, policy SilenceConflictPolicy) Option {
+	return func(o *Options) {
+		if interval >= minSilenceSyncInterval && interval <= maxSilenceSyncInterval {
+			o.silenceSync = interval
+			o.silencePolicy = policy
+			return
+		}
+
+		o.reject("WithSilenceSync", interval, fmt.Sprintf("must be between %v and %v", minSilenceSyncInterval, maxSilenceSyncInterval))
+	}
+}
+
+// WithLookupCache caches the results of [Client.GetChannel] and
+// [Client.GetUser] in an in-memory LRU cache of up to maxEntries entries.
+// Entries are fresh for ttl. For a further staleTTL they are still
+// returned immediately, while a background request refreshes them, so
+// alert sending does not block on directory lookups once an entry has
+// been cached. A staleTTL of zero disables stale reads. Hit rates are
+// reported by [Client.Stats].
+//
+// Valid ranges are 1 second–24 hours for ttl, 0–24 hours for staleTTL and
+// 1–1,000,000 for maxEntries. Values outside these ranges are silently
+// ignored and the cache remains disabled.
+func WithLookupCache(ttl, staleTTL time.Duration, maxEntries int) Option {
+	return func(o *Options) {
+		if ttl >= minLookupCacheTTL && ttl <= maxLookupCacheTTL && staleTTL >= 0 && staleTTL <= maxLookupCacheTTL && maxEntries >= 1 && maxEntries <= maxLookupCacheMaxEntries {
+			o.lookupTTL = ttl
+			o.lookupStaleTTL = staleTTL
+			o.lookupMaxEntries = maxEntries
+
+			return
 		}
+
+		o.reject("WithLookupCache", fmt.Sprintf("%v, %v, %d", ttl, staleTTL, maxEntries), fmt.Sprintf("requires a ttl between %v and %v, a staleTTL of at most %v and 1-%d entries", minLookupCacheTTL, maxLookupCacheTTL, maxLookupCacheTTL, maxLookupCacheMaxEntries))
+	}
+}
+
+// WithDeliveryHealthAlert makes delivery failures visible: when at least
+// cfg.FailureRate of the client's send requests fail in each of
+// cfg.Minutes consecutive minutes, the client emits an "Alert delivery
+// degraded" alert to cfg.SlackChannelID through the API, to cfg.WebhookURL
+// directly, or both. A matching resolved alert is emitted once a minute
+// passes with sends below the failure rate. Invalid configurations are
+// rejected when [Client.Connect] is called.
+func WithDeliveryHealthAlert(cfg DeliveryHealthAlert) Option {
+	return func(o *Options) {
+		o.deliveryHealth = &cfg
+	}
+}
+
+// WithFallbackWebhook sets a Slack incoming webhook used as a last-resort
+// delivery path. When a send fails without any response from the manager
+// API, because it is down or unreachable, the panic and error alerts in the
+// batch are posted to webhookURL as a plain message, and [Client.Send]
+// returns a [*FallbackError]. Other alerts are not sent to the webhook. The
+// URL must be an absolute https URL; it is checked when [Client.Connect] is
+// called.
+func WithFallbackWebhook(webhookURL string) Option {
+	return func(o *Options) {
+		o.fallbackWebhook = webhookURL
+	}
+}
+
+// WithEmailFallback mails critical alerts through an SMTP server when the
+// manager API cannot be reached and the [WithFallbackWebhook] fallback, if
+// set, also fails. It is the last tier of delivery: the batch's panic and
+// error alerts are sent as one plain-text email to cfg.To, and
+// [Client.Send] returns a [*FallbackError] with Transport "email". Invalid
+// configurations are rejected when [Client.Connect] is called.
+func WithEmailFallback(cfg EmailFallback) Option {
+	return func(o *Options) {
+		cfg.To = slices.Clone(cfg.To)
+		o.emailFallback = &cfg
+	}
+}
+
+// WithSeverityMapping rewrites producer-specific alert severities (such as
+// "ERROR", "sev2" or "P1") to the canonical [types.AlertSeverity] values
+// before alerts are sent. Keys are matched case-insensitively, and the
+// Severity field of each alert is updated in place. Canonical severities
+// and empty severities are left as they are. In [SeverityMappingStrict]
+// mode, alerts with any other severity are rejected by [Client.Send]; in
+// [SeverityMappingLenient] mode they are sent unchanged.
+//
+// Use [DefaultSeverityMapping] as a starting point. Mappings to
+// non-canonical severities are rejected when [Client.Connect] is called.
+func WithSeverityMapping(mapping map[string]types.AlertSeverity, mode SeverityMappingMode) Option {
+	return func(o *Options) {
+		o.severityMapper = &severityMapper{
+			mapping: normalizeSeverityMapping(mapping),
+			mode:    mode,
+		}
+	}
+}
+
+// WithAlertSchema sets the wire format used when posting alerts. The
+// default is [AlertSchemaV1]. Switch to [AlertSchemaV2] once the API
+// accepts it; alerts are converted automatically, so no changes to the
+// alerts themselves are required. Unknown versions are rejected when
+// [Client.Connect] is called.
+func WithAlertSchema(version AlertSchemaVersion) Option {
+	return func(o *Options) {
+		o.alertSchema = version
+	}
+}
+
+// WithMaxMetadataSize rejects alerts whose Metadata map (where [SetExtra]
+// stores custom fields) exceeds the given JSON-encoded size in bytes,
+// before any request is made. The default is 0, which disables the check.
+// Values less than 1 are silently ignored and the default is retained.
+func WithMaxMetadataSize(bytes int) Option {
+	return func(o *Options) {
+		if bytes >= 1 {
+			o.maxMetadataSize = bytes
+			return
+		}
+
+		o.reject("WithMaxMetadataSize", bytes, "must be at least 1")
+	}
+}
+
+// WithBodySpoolThreshold sets the size in bytes above which request bodies
+// read from an [io.Reader], such as those passed to
+// [Client.UploadAssetFrom], are buffered to a temporary file instead of
+// memory. Bodies are buffered so that retries resend them in full. The
+// default is 1 MiB. Negative values are silently ignored and the default is
+// retained; 0 buffers every non-empty body to a file.
+func WithBodySpoolThreshold(bytes int64) Option {
+	return func(o *Options) {
+		if bytes >= 0 {
+			o.spoolThreshold = bytes
+			return
+		}
+
+		o.reject("WithBodySpoolThreshold", bytes, "must be non-negative")
+	}
+}
+
+// WithProgress registers a function that is called as request bodies are
+// uploaded, such as alert batches, backfill batches and assets, so CLIs and
+// UIs can render progress bars and detect stalled uploads. It is called with
+// sent set to 0 before each attempt, including retries, and again after
+// every chunk written to the connection; total is the size of the body, or
+// -1 if it is not known in advance. The function must be fast and safe for
+// concurrent use. A nil function is silently ignored.
+func WithProgress(progress func(sent, total int64)) Option {
+	return func(o *Options) {
+		if progress != nil {
+			o.progress = progress
+			return
+		}
+
+		o.reject("WithProgress", "nil", "must not be nil")
+	}
+}
+
+// WithBandwidthLimit caps the combined throughput of bulk operations,
+// [Client.Backfill] and [Client.ExportAlerts], at bytesPerSec, counting
+// both uploaded and downloaded bytes, so bulk jobs do not saturate shared
+// links such as NAT gateways. Regular sends, pings and lookups are not
+// limited. The default is 0, which disables the limit. Values less than
+// 1024 (1 KiB/s) are silently ignored and the default is retained.
+func WithBandwidthLimit(bytesPerSec int64) Option {
+	return func(o *Options) {
+		if bytesPerSec >= minBandwidthLimit {
+			o.bandwidthLimit = bytesPerSec
+			return
+		}
+
+		o.reject("WithBandwidthLimit", bytesPerSec, fmt.Sprintf("must be at least %d", minBandwidthLimit))
+	}
+}
+
+// WithResponseHeaderCallback registers a function that is called with the
+// response headers of every successful request, including pings. Use it to
+// harvest deprecation warnings, rate-limit information or server version
+// headers. The callback receives a copy of the headers and must be safe for
+// concurrent use. A nil callback is silently ignored.
+func WithResponseHeaderCallback(callback func(http.Header)) Option {
+	return func(o *Options) {
+		if callback != nil {
+			o.headerCallback = callback
+			return
+		}
+
+		o.reject("WithResponseHeaderCallback", "nil", "must not be nil")
+	}
+}
+
+// WithAdaptiveBackoff widens retry backoff automatically while the server
+// appears overloaded. The outcomes of recent request attempts are tracked;
+// while at least half of them failed with HTTP 429, a 5xx status or a
+// connection error, every further failure doubles a backoff multiplier, and
+// every success lowers it by 0.25 until it is back to 1. Retry wait times,
+// including the [WithRetryMaxWaitTime] cap, are scaled by the multiplier,
+// which never exceeds maxMultiplier. Retry-After headers are still honoured
+// as-is. The current state is reported by [Client.Stats]. Valid range for
+// maxMultiplier is greater than 1 up to 16; values outside this range are
+// silently ignored and adaptive backoff stays disabled.
+func WithAdaptiveBackoff(maxMultiplier float64) Option {
+	return func(o *Options) {
+		if maxMultiplier > 1 && maxMultiplier <= maxAdaptiveMultiplier {
+			o.adaptiveBackoff = maxMultiplier
+			return
+		}
+
+		o.reject("WithAdaptiveBackoff", maxMultiplier, fmt.Sprintf("must be greater than 1 and at most %v", maxAdaptiveMultiplier))
+	}
+}
+
+// WithPriorityReservation limits concurrent alert requests to
+// [WithMaxConnsPerHost] and reserves the given fraction of them for
+// high-priority sends, so that bulk sends of warning and info alerts cannot
+// starve pages. A send is high priority if any of its alerts has the panic,
+// error or critical severity. At least one slot is reserved and at least
+// one is left for other sends. Valid range is greater than 0 and less than
+// 1; values outside this range are silently ignored and no limit applies.
+func WithPriorityReservation(fraction float64) Option {
+	return func(o *Options) {
+		if fraction > 0 && fraction < 1 {
+			o.priorityReserve = fraction
+			return
+		}
+
+		o.reject("WithPriorityReservation", fraction, "must be greater than 0 and less than 1")
+	}
+}
+
+// WithReresolveOnFailure closes the client's idle connections after three
+// request attempts in a row fail with a connection error, such as a refused
+// connection or a dial timeout. New connections resolve the host name of
+// the base URL again, so a failover that moves the name to another address
+// takes effect on the next attempt instead of retrying connections pinned
+// to the old one. Any response, including a 5xx, resets the count.
+func WithReresolveOnFailure() Option {
+	return func(o *Options) {
+		o.reresolve = true
+	}
+}
+
+// WithEndpointDiscovery finds the addresses of the API with discover, for
+// deployments where they change, such as behind Consul or DNS SRV records
+// (see [SRVDiscovery]). discover is called when [Client.Connect] is called
+// and, if refresh is non-zero, again every refresh; if a refresh fails the
+// error is logged and the previous addresses are kept.
+//
+// Requests go to the current address, with the scheme and path of the base
+// URL; the address is also used for the Host header and TLS verification.
+// When a connection to it fails, the client moves on to the next address,
+// and a request whose connection could not be established is resent to it
+// straight away. Valid refresh range is 0 or 1 second–24 hours. A nil
+// discover or a refresh outside this range is silently ignored and the
+// base URL is used as is.
+func WithEndpointDiscovery(discover EndpointDiscovery, refresh time.Duration) Option {
+	return func(o *Options) {
+		if discover != nil && (refresh == 0 || refresh >= minDiscoveryRefresh && refresh <= maxDiscoveryRefresh) {
+			o.discovery = discover
+			o.discoveryRefresh = refresh
+
+			return
+		}
+
+		o.reject("WithEndpointDiscovery", refresh, fmt.Sprintf("requires a discovery function and a refresh of 0 or between %v and %v", minDiscoveryRefresh, maxDiscoveryRefresh))
+	}
+}
+
+// WithBodyTransformer adds a function that rewrites each JSON request body
+// after it is encoded, such as alert batches and the bodies of admin
+// requests, for example to encrypt fields or tag it for legal hold required
+// by compliance. Transformers run in the order they are added, each seeing
+// the output of the previous one. A transformer's error fails the request
+// without sending it. Bodies are transformed once per call, so retries send
+// the same bytes. A nil function is silently ignored.
+func WithBodyTransformer(transform BodyTransformer) Option {
+	return func(o *Options) {
+		if transform != nil {
+			o.bodyTransforms = append(o.bodyTransforms, transform)
+			return
+		}
+
+		o.reject("WithBodyTransformer", "nil", "must not be nil")
+	}
+}
+
+// WithFieldEncryption encrypts the given alert fields, named as in the
+// alert's JSON encoding such as "text" or "fields", before alerts leave the
+// process, so sensitive content is never sent to or stored by the manager
+// in plaintext. It uses envelope encryption: each batch gets a new data key
+// from kms, each field value is encrypted with it using AES-256-GCM and
+// replaced with a string starting with [EncryptedFieldPrefix], and the data
+// key, encrypted by kms, is added to the body with the list of fields. The
+// ID of the master key is sent in the [EncryptionKeyIDHeader] header.
+//
+// Fields the manager needs to route alerts, such as "severity" and
+// "routeKey", cannot be encrypted. A nil kms, no fields or such a field is
+// silently ignored.
+func WithFieldEncryption(kms KeyProvider, fields ...string) Option {
+	return func(o *Options) {
+		err := validateEncryptedFields(fields)
+		if kms != nil && err == nil {
+			o.encryptKeys = kms
+			o.encryptFields = slices.Clone(fields)
+
+			return
+		}
+
+		if err == nil {
+			err = errors.New("key provider must not be nil")
+		}
+
+		o.reject("WithFieldEncryption", fields, err.Error())
+	}
+}
+
+// WithPIIMasking masks personal data in the text of every alert before it
+// is sent, using [MaskPII] with the given rules, or [EmailMaskRule] and
+// [PhoneMaskRule] if none are given. Alerts are updated in place. If report
+// is not nil, it is called with the masked fields of each batch that had
+// any, for audit logs or metrics; it must be fast and safe for concurrent
+// use. Invalid rules are silently ignored, along with the option.
+func WithPIIMasking(report func([]MaskedField), rules ...MaskRule) Option {
+	return func(o *Options) {
+		if len(rules) == 0 {
+			rules = []MaskRule{EmailMaskRule(), PhoneMaskRule()}
+		}
+
+		for _, rule := range rules {
+			if err := rule.validate(); err != nil {
+				o.reject("WithPIIMasking", rule.Name, err.Error())
+				return
+			}
+		}
+
+		o.piiMasker = &piiMasker{rules: slices.Clone(rules), report: report}
+	}
+}
+
+// WithAuditSink calls sink with an [AuditRecord] for every alert batch and
+// server template sent, and every create, update or delete made through
+// the admin API, once the call has completed, so that teams can ship a
+// client-side audit log to their SIEM. Use [WithCaller] to record who each
+// call acts for. Batches sent in the background, such as digests, are
+// recorded with the values of the context passed to [Client.Connect].
+// sink is called synchronously and must be fast and safe for concurrent
+// use. A nil sink is silently ignored.
+func WithAuditSink(sink func(AuditRecord)) Option {
+	return func(o *Options) {
+		if sink != nil {
+			o.auditSink = sink
+			return
+		}
+
+		o.reject("WithAuditSink", "nil", "must not be nil")
+	}
+}
+
+// WithChannelAllowlist restricts the Slack channels alerts may be posted
+// to, so that a misconfigured producer cannot post to executive or
+// customer-facing channels. [Client.Send] rejects a batch with a
+// [*PolicyError], without sending any of it, if an alert's SlackChannelID
+// is not in the list, or is empty, since alerts routed by route key could
+// end up in any channel. Channels are matched case-insensitively, ignoring
+// a leading "#". Repeated calls extend the list. An empty list is silently
+// ignored.
+func WithChannelAllowlist(channels ...string) Option {
+	return func(o *Options) {
+		if o.channels == nil {
+			o.channels = &channelPolicy{}
+		}
+
+		if !addChannels(&o.channels.allow, channels) {
+			o.reject("WithChannelAllowlist", channels, "at least one channel must be set")
+		}
+	}
+}
+
+// WithChannelDenylist makes [Client.Send] reject batches with an alert
+// posted to one of channels with a [*PolicyError], without sending any of
+// it. Alerts without a SlackChannelID are not checked. Channels are
+// matched as for [WithChannelAllowlist]; a channel in both lists is
+// denied. Repeated calls extend the list. An empty list is silently
+// ignored.
+func WithChannelDenylist(channels ...string) Option {
+	return func(o *Options) {
+		if o.channels == nil {
+			o.channels = &channelPolicy{}
+		}
+
+		if !addChannels(&o.channels.deny, channels) {
+			o.reject("WithChannelDenylist", channels, "at least one channel must be set")
+		}
+	}
+}
+
+// WithSendPolicy adds a policy that [Client.Send] evaluates for every alert
+// before sending, to keep governance rules in one place rather than in
+// every producing service. The policy allows, denies or modifies each
+// alert; if it denies one, none of the batch is sent and Send returns a
+// [*PolicyError]. Policies run in the order they are added, after
+// severity mapping and before [WithChannelAllowlist], so channel rules
+// also apply to modified alerts. [OPAPolicy] queries an Open Policy Agent.
+// The policy must be safe for concurrent use. A nil policy is silently
+// ignored.
+func WithSendPolicy(policy SendPolicy) Option {
+	return func(o *Options) {
+		if policy == nil {
+			o.reject("WithSendPolicy", "nil", "policy must not be nil")
+			return
+		}
+
+		o.sendPolicies = append(o.sendPolicies, policy)
+	}
+}
+
+// WithSnippetConversion uploads the text of alerts longer than threshold
+// characters as an [AssetSnippet] when they are sent, and replaces it with
+// its start and a link to the snippet, instead of letting the API cut it
+// off at [types.MaxTextLength]. If the upload fails, a warning is logged
+// and the alert is sent as it is. Alerts whose text is encrypted with
+// [WithFieldEncryption] are never converted. Values below 200 or above
+// [types.MaxTextLength] are silently ignored and conversion stays
+// disabled.
+func WithSnippetConversion(threshold int) Option {
+	return func(o *Options) {
+		if threshold >= minSnippetThreshold && threshold <= types.MaxTextLength {
+			o.snippetThreshold = threshold
+			return
+		}
+
+		o.reject("WithSnippetConversion", threshold, fmt.Sprintf("must be between %d and %d", minSnippetThreshold, types.MaxTextLength))
+	}
+}
+
+// WithTheme styles every alert by its severity before it is sent, so alerts
+// from every producer look the same: it puts the severity's emoji in front
+// of the header, sets the icon and stores the colour under
+// [ThemeColorKey]. Values set on the alert are kept. The theme is also
+// sent with [Client.SendServerTemplate], for the API to apply to the
+// rendered alert. [DefaultTheme] returns a ready-made theme. A theme with
+// an unknown severity or an invalid colour or icon is silently ignored.
+func WithTheme(theme Theme) Option {
+	return func(o *Options) {
+		if err := theme.validate(); err != nil {
+			o.reject("WithTheme", theme, err.Error())
+			return
+		}
+
+		if len(theme) > 0 {
+			o.theme = maps.Clone(theme)
+		}
+	}
+}
+
+// WithPlainTextFallback fills in the FallbackText of every alert that has
+// none with [PlainText] before it is sent, so screen readers and
+// notification previews get readable text instead of mrkdwn. It runs
+// after [WithTheme] and before [WithPIIMasking], so the fallback text is
+// masked too. The caller's alerts are not changed.
+func WithPlainTextFallback() Option {
+	return func(o *Options) {
+		o.plainText = true
+	}
+}
+
+// WithChannelTimeZones renders [Timestamp] placeholders in the time zone
+// of each alert's Slack channel, as configured in the channels API,
+// instead of in UTC. Zones are cached for an hour. Alerts without a
+// SlackChannelID, such as those routed by route key, and alerts whose
+// channel zone cannot be found use fallback, or UTC if it is nil.
+// Timestamps are formatted with layout, or [DefaultTimestampLayout] if it
+// is empty.
+func WithChannelTimeZones(fallback *time.Location, layout string) Option {
+	return func(o *Options) {
+		if fallback == nil {
+			fallback = time.UTC
+		}
+
+		o.channelZones = true
+		o.zoneFallback = fallback
+		o.timestampLayout = layout
+	}
+}
+
+// WithRunbookResolver adds a "Runbook" field linking to the runbook the
+// resolver returns for each alert, such as the Resolve method of
+// [RunbookRules]. The resolver sees alerts as the producer built them,
+// after [WithSeverityMapping] and before [WithTheme], so rules can match
+// their [Fingerprint]. An alert that already has a Runbook field is left
+// as it is, and one with no room for another field gets the runbook as its
+// Link instead. The caller's alerts are not changed. A nil resolver is
+// silently ignored.
+func WithRunbookResolver(resolver RunbookResolver) Option {
+	return func(o *Options) {
+		if resolver == nil {
+			o.reject("WithRunbookResolver", "nil", "resolver must not be nil")
+			return
+		}
+
+		o.runbooks = resolver
+	}
+}
+
+// WithAckEscalation sets the escalation for alerts sent with [RequireAck]
+// that nobody acknowledged in time. It is called once per alert, in its
+// own goroutine, and is not called after [Client.Close]. A nil escalation
+// is silently ignored.
+func WithAckEscalation(escalate AckEscalation) Option {
+	return func(o *Options) {
+		if escalate == nil {
+			o.reject("WithAckEscalation", "nil", "escalation must not be nil")
+			return
+		}
+
+		o.ackEscalation = escalate
+	}
+}
+
+// WithRepeat sends panic, error and critical alerts again while nobody has
+// dealt with them: first after interval, then with the wait doubling after
+// every repeat, up to a day, until they have been sent maxRepeats more
+// times or until reports that their issue was dealt with. A nil until is
+// [UntilAcked]; [UntilResolved] keeps repeating acknowledged alerts. The
+// condition is checked against the alert's [AckReceipt], so alerts must
+// have a CorrelationID, and the client must be told about their issues'
+// events with [Client.ObserveAckEvent]. Sending a resolved alert with the
+// same CorrelationID also stops the repeats, and later sends of a repeated
+// alert replace the alert that is repeated. Silenced alerts are not
+// repeated. An interval outside 1s to 24h, or a maxRepeats outside 1 to
+// 100, is silently ignored.
+func WithRepeat(interval time.Duration, maxRepeats int, until RepeatCondition) Option {
+	return func(o *Options) {
+		if interval < minRepeatInterval || interval > maxRepeatInterval {
+			o.reject("WithRepeat", interval, fmt.Sprintf("interval must be between %v and %v", minRepeatInterval, maxRepeatInterval))
+			return
+		}
+
+		if maxRepeats < 1 || maxRepeats > maxRepeatCount {
+			o.reject("WithRepeat", maxRepeats, fmt.Sprintf("max repeats must be between 1 and %d", maxRepeatCount))
+			return
+		}
+
+		o.repeatInterval = interval
+		o.repeatMax = maxRepeats
+		o.repeatUntil = until
+	}
+}
+
+// WithTracing creates a client span with tracer for every [Client.Connect]
+// and send, such as [Client.Send], recording each request attempt and
+// retry, with its status code, as a span event. The trace context of the
+// request context is propagated to the API with every request, such as
+// with W3C traceparent headers. A nil tracer is silently ignored.
+func WithTracing(tracer Tracer) Option {
+	return func(o *Options) {
+		if tracer == nil {
+			o.reject("WithTracing", "nil", "tracer must not be nil")
+			return
+		}
+
+		o.tracer = tracer
+	}
+}
+
+// WithMetrics reports every request sent to the API, including retries,
+// with its method, status code and latency, and every retry, to collector,
+// such as a [PrometheusMetrics]. A nil collector is silently ignored.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(o *Options) {
+		if collector == nil {
+			o.reject("WithMetrics", "nil", "collector must not be nil")
+			return
+		}
+
+		o.metrics = collector
+	}
+}
+
+// WithAsyncMode queues the alerts given to [Client.SendAsync] in memory,
+// up to queueSize alerts, and sends them from a background goroutine in
+// batches of up to 100, every flushInterval or as soon as a batch is full.
+// Batches are sent like [Client.Send], so silences, quiet hours and
+// digests still apply. onError is called with the alerts of each batch
+// that could not be sent; if it is nil, the error is logged unless
+// [WithResultCallback] is set. Call
+// [Client.Shutdown] instead of [Client.Close] to send the queue before the
+// process exits. A queueSize outside 1 to 1,000,000, or a flushInterval
+// outside 10ms to 1 minute, is silently ignored.
+func WithAsyncMode(queueSize int, flushInterval time.Duration, onError AsyncErrorHandler) Option {
+	return func(o *Options) {
+		if queueSize < minAsyncQueueSize || queueSize > maxAsyncQueueSize {
+			o.reject("WithAsyncMode", queueSize, fmt.Sprintf("queue size must be between %d and %d", minAsyncQueueSize, maxAsyncQueueSize))
+			return
+		}
+
+		if flushInterval < minAsyncFlushInterval || flushInterval > maxAsyncFlushInterval {
+			o.reject("WithAsyncMode", flushInterval, fmt.Sprintf("flush interval must be between %v and %v", minAsyncFlushInterval, maxAsyncFlushInterval))
+			return
+		}
+
+		o.asyncQueueSize = queueSize
+		o.asyncInterval = flushInterval
+		o.asyncOnError = onError
+	}
+}
+
+// WithResultCallback calls fn with the result of every batch sent by
+// [WithAsyncMode], and the error if it could not be sent, after the
+// [AsyncErrorHandler]. Failures are not logged when fn is set and there is
+// no error handler. fn is called from the goroutine sending the batches,
+// so it should return quickly. A nil fn is silently ignored.
+func WithResultCallback(fn ResultCallback) Option {
+	return func(o *Options) {
+		if fn == nil {
+			o.reject("WithResultCallback", "nil", "callback must not be nil")
+			return
+		}
+
+		o.asyncOnResult = fn
+	}
+}
+
+// WithRateLimit caps the requests sent to the API at rps per second, with
+// bursts of up to burst requests, so the client slows down before the API
+// rejects it with 429. Every request attempt waits for the limit, retries
+// included, and clients derived with [Client.With] share it. A request
+// whose context ends while waiting fails with the context error. The
+// default is no limit. An rps that is not positive or exceeds 10,000, or a
+// burst outside 1 to 10,000, is silently ignored.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(o *Options) {
+		if rps <= 0 || rps > maxRateLimit {
+			o.reject("WithRateLimit", rps, fmt.Sprintf("rate must be greater than 0 and at most %d", maxRateLimit))
+			return
+		}
+
+		if burst < 1 || burst > maxRateLimitBurst {
+			o.reject("WithRateLimit", burst, fmt.Sprintf("burst must be between 1 and %d", maxRateLimitBurst))
+			return
+		}
+
+		o.rateLimit = rps
+		o.rateLimitBurst = burst
+	}
+}
+
+// WithQuarantine moves alerts that the API keeps rejecting as invalid,
+// with HTTP 400, 413 or 422, out of the queues of [WithAsyncMode] and
+// [WithQuietHours] into store, so a single bad alert does not fail its
+// batch on every flush. When the API rejects a batch from one of these
+// queues, each of its alerts is sent on its own to find those at fault;
+// the others are delivered. An alert rejected attempts times is put in
+// store, reported as a warning and counted in [Client.Stats]; until then
+// it is retried with the next flush, behind the rest of the async queue.
+// [Client.Quarantined] lists the quarantined alerts. Digest summaries,
+// which the client builds, are not quarantined. A nil store, or an
+// attempts outside 1 to 100, is silently ignored.
+func WithQuarantine(store QuarantineStore, attempts int) Option {
+	return func(o *Options) {
+		if store == nil {
+			o.reject("WithQuarantine", "nil", "store must not be nil")
+			return
+		}
+
+		if attempts < 1 || attempts > maxQuarantineAttempts {
+			o.reject("WithQuarantine", attempts, fmt.Sprintf("attempts must be between 1 and %d", maxQuarantineAttempts))
+			return
+		}
+
+		o.quarantineStore = store
+		o.quarantineLimit = attempts
+	}
+}
+
+// WithSchemaRepair makes the client fix trivial validation errors itself:
+// when the API rejects alerts with [ViolationTooLong] errors, the fields
+// are trimmed to their limit, ending with an ellipsis, and the alerts are
+// sent again, once per round of fixes, logging a warning. The caller's
+// alerts are not modified. Other violations are returned in a
+// [*FieldValidationError] as usual.
+func WithSchemaRepair() Option {
+	return func(o *Options) {
+		o.schemaRepair = true
+	}
+}
+
+// WithStrictResponses makes the client check that successful API responses
+// match the schema it expects, to catch API contract changes in staging
+// before they reach production. A response with a field the client does
+// not know, a value of the wrong type, data after the JSON value or a
+// Content-Type other than JSON fails with a [*ResponseSchemaError] holding
+// the raw body, instead of being decoded as far as possible. The data of
+// [Client.GraphQL], which is decoded into the caller's own type, is not
+// checked.
+func WithStrictResponses() Option {
+	return func(o *Options) {
+		o.strictResponses = true
 	}
 }
 
@@ -340,10 +1499,24 @@ func (o *Options) Validate() error {
 		return errors.New("retryPolicy must not be nil")
 	}
 
+	for _, endpoint := range slices.Sorted(maps.Keys(o.endpointRetry)) {
+		if err := o.endpointRetry[endpoint].validate(endpoint); err != nil {
+			return err
+		}
+	}
+
 	if o.basicAuthUsername != "" && o.authToken != "" {
 		return errors.New("cannot use both basic auth and token auth - choose one")
 	}
 
+	if o.authTokenProvider != nil && (o.basicAuthUsername != "" || o.authToken != "") {
+		return errors.New("cannot combine an auth token provider with basic auth or token auth - choose one")
+	}
+
+	if o.authenticator != nil && (o.basicAuthUsername != "" || o.authToken != "" || o.authTokenProvider != nil) {
+		return errors.New("cannot combine an authenticator with basic auth or token auth - choose one")
+	}
+
 	if o.timeout < minTimeout {
 		return fmt.Errorf("timeout must be at least %v", minTimeout)
 	}
@@ -392,5 +1565,105 @@ func (o *Options) Validate() error {
 		return errors.New("pingEndpoint must not be empty")
 	}
 
+	if o.auditEndpoint == "" {
+		return errors.New("auditEndpoint must not be empty")
+	}
+
+	if o.alertSchema != AlertSchemaV1 && o.alertSchema != AlertSchemaV2 {
+		return fmt.Errorf("unsupported alert schema version %d", o.alertSchema)
+	}
+
+	for i, rule := range o.quietHoursRules {
+		if err := rule.schedule.validate(); err != nil {
+			return fmt.Errorf("quiet hours rule %d: %w", i, err)
+		}
+
+		if rule.behavior < QuietHoursDefer || rule.behavior > QuietHoursDrop {
+			return fmt.Errorf("quiet hours rule %d: invalid behavior %d", i, rule.behavior)
+		}
+	}
+
+	if o.severityMapper != nil {
+		if o.severityMapper.mode != SeverityMappingLenient && o.severityMapper.mode != SeverityMappingStrict {
+			return fmt.Errorf("invalid severity mapping mode %d", o.severityMapper.mode)
+		}
+
+		for from, to := range o.severityMapper.mapping {
+			if !types.SeverityIsValid(to) {
+				return fmt.Errorf("severity mapping for %q must be one of %s, got %q", from, strings.Join(types.ValidSeverities(), ", "), to)
+			}
+		}
+	}
+
+	if o.deliveryHealth != nil {
+		if err := o.deliveryHealth.validate(); err != nil {
+			return err
+		}
+	}
+
+	if o.fallbackWebhook != "" {
+		if err := validateWebhookURL(o.fallbackWebhook); err != nil {
+			return fmt.Errorf("fallback webhook: %w", err)
+		}
+	}
+
+	if o.emailFallback != nil {
+		if err := o.emailFallback.validate(); err != nil {
+			return err
+		}
+	}
+
+	ids := make(map[string]bool, len(o.localSilences))
+
+	for _, silence := range o.localSilences {
+		if err := silence.validate(); err != nil {
+			return err
+		}
+
+		if ids[silence.ID] {
+			return fmt.Errorf("duplicate silence ID %q", silence.ID)
+		}
+
+		ids[silence.ID] = true
+	}
+
+	if o.silencePolicy < SilencePreferServer || o.silencePolicy > SilencePreferNewest {
+		return fmt.Errorf("invalid silence conflict policy %d", o.silencePolicy)
+	}
+
+	if o.digestSelector != nil {
+		if o.digestInterval < minDigestInterval {
+			return fmt.Errorf("digestInterval must be at least %v", minDigestInterval)
+		}
+
+		if o.digestInterval > maxDigestInterval {
+			return fmt.Errorf("digestInterval must not exceed %v", maxDigestInterval)
+		}
+	}
+
 	return nil
 }
+
+// clone returns a copy of the options that can be modified without
+// affecting the original.
+func (o *Options) clone() *Options {
+	clone := *o
+	clone.requestHeaders = maps.Clone(o.requestHeaders)
+	clone.endpointRetry = maps.Clone(o.endpointRetry)
+	clone.quietHoursRules = slices.Clone(o.quietHoursRules)
+	clone.localSilences = slices.Clone(o.localSilences)
+	clone.bodyTransforms = slices.Clone(o.bodyTransforms)
+	clone.encryptFields = slices.Clone(o.encryptFields)
+	clone.channels = o.channels.clone()
+	clone.sendPolicies = slices.Clone(o.sendPolicies)
+	clone.theme = maps.Clone(o.theme)
+	clone.ignored = slices.Clone(o.ignored)
+
+	return &clone
+}
+
+// reject records that an option value was ignored, for reporting by
+// [NewStrict] and [Client.ConfigWarnings].
+func (o *Options) reject(option string, value any, reason string) {
+	o.ignored = append(o.ignored, fmt.Sprintf("%s(%v) ignored: %s", option, value, reason))
+}
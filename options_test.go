@@ -1,11 +1,14 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
 )
 
 func TestNewClientOptions(t *testing.T) {
@@ -81,9 +84,17 @@ func TestNewClientOptions(t *testing.T) {
 		t.Errorf("expected pingEndpoint=ping, got %s", opts.pingEndpoint)
 	}
 
+	if opts.auditEndpoint != "audit" {
+		t.Errorf("expected auditEndpoint=audit, got %s", opts.auditEndpoint)
+	}
+
 	if opts.tlsConfig != nil {
 		t.Errorf("expected tlsConfig=nil, got %v", opts.tlsConfig)
 	}
+
+	if opts.alertSchema != AlertSchemaV1 {
+		t.Errorf("expected alertSchema=1, got %d", opts.alertSchema)
+	}
 }
 
 func TestWithRetryCount(t *testing.T) {
@@ -641,6 +652,137 @@ func TestOptionsValidate(t *testing.T) {
 			modify:    func(o *Options) { o.pingEndpoint = "" },
 			wantError: "pingEndpoint must not be empty",
 		},
+		{
+			name:      "empty auditEndpoint",
+			modify:    func(o *Options) { o.auditEndpoint = "" },
+			wantError: "auditEndpoint must not be empty",
+		},
+		{
+			name: "digestInterval below minimum",
+			modify: func(o *Options) {
+				o.digestSelector = func(*types.Alert) string { return "C123" }
+				o.digestInterval = 500 * time.Millisecond
+			},
+			wantError: "digestInterval must be at least 1s",
+		},
+		{
+			name: "digestInterval exceeds max",
+			modify: func(o *Options) {
+				o.digestSelector = func(*types.Alert) string { return "C123" }
+				o.digestInterval = 25 * time.Hour
+			},
+			wantError: "digestInterval must not exceed 24h0m0s",
+		},
+		{
+			name: "invalid quiet hours schedule",
+			modify: func(o *Options) {
+				WithQuietHours(Schedule{Start: time.Hour, End: 2 * time.Hour}, nil, QuietHoursDefer)(o)
+			},
+			wantError: "quiet hours rule 0: schedule must include at least one day",
+		},
+		{
+			name: "invalid quiet hours behavior",
+			modify: func(o *Options) {
+				WithQuietHours(BusinessHours(9*time.Hour, 17*time.Hour), nil, QuietHoursBehavior(42))(o)
+			},
+			wantError: "quiet hours rule 0: invalid behavior 42",
+		},
+		{
+			name: "severity mapping to unknown severity",
+			modify: func(o *Options) {
+				WithSeverityMapping(map[string]types.AlertSeverity{"sev9": "bogus"}, SeverityMappingStrict)(o)
+			},
+			wantError: `severity mapping for "sev9" must be one of panic, error, warning, resolved, info, got "bogus"`,
+		},
+		{
+			name: "invalid severity mapping mode",
+			modify: func(o *Options) {
+				WithSeverityMapping(nil, SeverityMappingMode(7))(o)
+			},
+			wantError: "invalid severity mapping mode 7",
+		},
+		{
+			name: "silence without matcher",
+			modify: func(o *Options) {
+				WithSilences(Silence{ID: "s1", EndsAt: time.Now().Add(time.Hour)})(o)
+			},
+			wantError: `silence "s1": at least one matcher must be set`,
+		},
+		{
+			name: "duplicate silence ID",
+			modify: func(o *Options) {
+				silence := Silence{ID: "s1", RouteKey: "db", EndsAt: time.Now().Add(time.Hour)}
+				WithSilences(silence, silence)(o)
+			},
+			wantError: `duplicate silence ID "s1"`,
+		},
+		{
+			name:      "invalid silence conflict policy",
+			modify:    func(o *Options) { WithSilenceSync(time.Minute, SilenceConflictPolicy(9))(o) },
+			wantError: "invalid silence conflict policy 9",
+		},
+		{
+			name: "delivery health alert without destination",
+			modify: func(o *Options) {
+				WithDeliveryHealthAlert(DeliveryHealthAlert{FailureRate: 0.5, Minutes: 5})(o)
+			},
+			wantError: "delivery health alert: a Slack channel or webhook URL must be set",
+		},
+		{
+			name: "delivery health alert with http webhook",
+			modify: func(o *Options) {
+				WithDeliveryHealthAlert(DeliveryHealthAlert{FailureRate: 0.5, Minutes: 5, WebhookURL: "http://hooks.slack.com/x"})(o)
+			},
+			wantError: `delivery health alert: invalid webhook URL "http://hooks.slack.com/x": must be an absolute https URL`,
+		},
+		{
+			name: "fallback webhook without https",
+			modify: func(o *Options) {
+				WithFallbackWebhook("hooks.slack.com/services/x")(o)
+			},
+			wantError: `fallback webhook: invalid webhook URL "hooks.slack.com/services/x": must be an absolute https URL`,
+		},
+		{
+			name: "email fallback without recipients",
+			modify: func(o *Options) {
+				WithEmailFallback(EmailFallback{Host: "smtp.example.com", From: "alerts@example.com"})(o)
+			},
+			wantError: "email fallback: at least one recipient must be set",
+		},
+		{
+			name: "email fallback with invalid sender",
+			modify: func(o *Options) {
+				WithEmailFallback(EmailFallback{Host: "smtp.example.com", From: "alerts", To: []string{"oncall@example.com"}})(o)
+			},
+			wantError: `email fallback: invalid sender address "alerts": mail: missing '@' or angle-addr`,
+		},
+		{
+			name: "endpoint retry count too high",
+			modify: func(o *Options) {
+				WithEndpointRetry(EndpointSend, RetryConfig{Count: 101})(o)
+			},
+			wantError: "send endpoint retry count must be between 0 and 100, got 101",
+		},
+		{
+			name: "endpoint retry max wait below wait",
+			modify: func(o *Options) {
+				WithEndpointRetry(EndpointRead, RetryConfig{Count: 1, WaitTime: time.Second, MaxWaitTime: 500 * time.Millisecond})(o)
+			},
+			wantError: "read endpoint retry max wait time (500ms) must be greater than or equal to its wait time (1s)",
+		},
+		{
+			name: "authenticator with token auth",
+			modify: func(o *Options) {
+				WithAuthToken("token")(o)
+				WithAuthenticator(NegotiateAuthenticator("", nil))(o)
+			},
+			wantError: "cannot combine an authenticator with basic auth or token auth - choose one",
+		},
+		{
+			name:      "unsupported alert schema",
+			modify:    func(o *Options) { o.alertSchema = 3 },
+			wantError: "unsupported alert schema version 3",
+		},
 	}
 
 	for _, tt := range tests {
@@ -698,6 +840,39 @@ func TestWithTLSConfig(t *testing.T) {
 	})
 }
 
+func TestWithEndpointRetry_UnknownEndpoint(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithEndpointRetry(Endpoint(42), RetryConfig{Count: 1})(opts)
+
+	if len(opts.endpointRetry) != 0 || len(opts.ignored) != 1 {
+		t.Errorf("expected an unknown endpoint to be rejected, got %v (%q)", opts.endpointRetry, opts.ignored)
+	}
+}
+
+func TestWithCookieJar_Nil(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithCookieJar(nil)(opts)
+
+	if opts.cookieJar != nil || len(opts.ignored) != 1 {
+		t.Errorf("expected a nil cookie jar to be rejected, got %v (%q)", opts.cookieJar, opts.ignored)
+	}
+}
+
+func TestWithTransport_Nil(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithTransport(nil)(opts)
+
+	if opts.backend != nil || len(opts.ignored) != 1 {
+		t.Errorf("expected a nil transport to be rejected, got %v (%q)", opts.backend, opts.ignored)
+	}
+}
+
 func TestWithAlertsEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -754,6 +929,34 @@ func TestWithPingEndpoint(t *testing.T) {
 	}
 }
 
+func TestWithAuditEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"valid endpoint", "v1/audit", "v1/audit"},
+		{"empty ignored", "", "audit"},
+		{"whitespace ignored", "   ", "audit"},
+		{"whitespace trimmed", "  events  ", "events"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithAuditEndpoint(tt.input)(opts)
+
+			if opts.auditEndpoint != tt.expected {
+				t.Errorf("expected auditEndpoint=%s, got %s", tt.expected, opts.auditEndpoint)
+			}
+		})
+	}
+}
+
 func TestWithRequestHeader_ValueTrimmed(t *testing.T) {
 	t.Parallel()
 
@@ -764,3 +967,415 @@ func TestWithRequestHeader_ValueTrimmed(t *testing.T) {
 		t.Errorf("expected trimmed value, got %q", opts.requestHeaders["X-Custom"])
 	}
 }
+
+func TestWithDigest(t *testing.T) {
+	t.Parallel()
+
+	selector := func(*types.Alert) string { return "C123" }
+
+	tests := []struct {
+		name     string
+		selector ChannelSelector
+		interval time.Duration
+		enabled  bool
+	}{
+		{"valid", selector, time.Minute, true},
+		{"minimum interval", selector, time.Second, true},
+		{"maximum interval", selector, 24 * time.Hour, true},
+		{"nil selector ignored", nil, time.Minute, false},
+		{"interval too short ignored", selector, 500 * time.Millisecond, false},
+		{"interval too long ignored", selector, 25 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithDigest(tt.selector, tt.interval)(opts)
+
+			if (opts.digestSelector != nil) != tt.enabled {
+				t.Errorf("expected digest enabled=%v, got %v", tt.enabled, opts.digestSelector != nil)
+			}
+
+			if tt.enabled && opts.digestInterval != tt.interval {
+				t.Errorf("expected digestInterval=%v, got %v", tt.interval, opts.digestInterval)
+			}
+		})
+	}
+}
+
+func TestWithQuietHours(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithQuietHours(BusinessHours(9*time.Hour, 17*time.Hour), nil, QuietHoursDigest, " Payments ", "")(opts)
+
+	if len(opts.quietHoursRules) != 1 {
+		t.Fatalf("expected one rule, got %d", len(opts.quietHoursRules))
+	}
+
+	rule := opts.quietHoursRules[0]
+
+	if rule.location != time.UTC {
+		t.Errorf("expected nil time zone to default to UTC, got %v", rule.location)
+	}
+
+	if rule.behavior != QuietHoursDigest {
+		t.Errorf("expected behavior=QuietHoursDigest, got %v", rule.behavior)
+	}
+
+	if len(rule.routeKeys) != 1 || rule.routeKeys[0] != "payments" {
+		t.Errorf("expected normalized route keys [payments], got %v", rule.routeKeys)
+	}
+}
+
+func TestWithSeverityMapping(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithSeverityMapping(map[string]types.AlertSeverity{" SEV2 ": " Error ", "": types.AlertInfo}, SeverityMappingStrict)(opts) //nolint:gocritic // the padded key checks that keys are normalized
+
+	if opts.severityMapper == nil {
+		t.Fatal("expected severity mapper to be set")
+	}
+
+	if opts.severityMapper.mode != SeverityMappingStrict {
+		t.Errorf("expected strict mode, got %v", opts.severityMapper.mode)
+	}
+
+	if len(opts.severityMapper.mapping) != 1 || opts.severityMapper.mapping["sev2"] != types.AlertError {
+		t.Errorf("expected normalized mapping {sev2: error}, got %v", opts.severityMapper.mapping)
+	}
+}
+
+func TestWithAlertSchema(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAlertSchema(AlertSchemaV2)(opts)
+
+	if opts.alertSchema != AlertSchemaV2 {
+		t.Errorf("expected alertSchema=2, got %d", opts.alertSchema)
+	}
+}
+
+func TestWithMaxMetadataSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 1024, 1024},
+		{"zero ignored", 0, 0},
+		{"negative ignored", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxMetadataSize(tt.input)(opts)
+
+			if opts.maxMetadataSize != tt.expected {
+				t.Errorf("expected maxMetadataSize=%d, got %d", tt.expected, opts.maxMetadataSize)
+			}
+		})
+	}
+}
+
+func TestWithBodySpoolThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int64
+		expected int64
+	}{
+		{"valid", 4096, 4096},
+		{"zero", 0, 0},
+		{"negative ignored", -1, defaultSpoolThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithBodySpoolThreshold(tt.input)(opts)
+
+			if opts.spoolThreshold != tt.expected {
+				t.Errorf("expected spoolThreshold=%d, got %d", tt.expected, opts.spoolThreshold)
+			}
+		})
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithProgress(nil)(opts)
+
+	if opts.progress != nil {
+		t.Error("expected nil progress function to be ignored")
+	}
+
+	if len(opts.ignored) != 1 {
+		t.Errorf("expected nil progress function to be reported, got %v", opts.ignored)
+	}
+
+	WithProgress(func(int64, int64) {})(opts)
+
+	if opts.progress == nil {
+		t.Error("expected progress function to be set")
+	}
+}
+
+func TestWithBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int64
+		expected int64
+	}{
+		{"valid", 1 << 20, 1 << 20},
+		{"minimum", 1024, 1024},
+		{"too low ignored", 1023, 0},
+		{"zero ignored", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithBandwidthLimit(tt.input)(opts)
+
+			if opts.bandwidthLimit != tt.expected {
+				t.Errorf("expected bandwidthLimit=%d, got %d", tt.expected, opts.bandwidthLimit)
+			}
+		})
+	}
+}
+
+func TestWithResponseHeaderCallback(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithResponseHeaderCallback(nil)(opts)
+
+	if opts.headerCallback != nil {
+		t.Error("expected nil callback to be ignored")
+	}
+
+	called := false
+	WithResponseHeaderCallback(func(http.Header) { called = true })(opts)
+
+	if opts.headerCallback == nil {
+		t.Fatal("expected callback to be set")
+	}
+
+	opts.headerCallback(nil)
+
+	if !called {
+		t.Error("expected the registered callback to be stored")
+	}
+}
+
+func TestWithAdaptiveBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    float64
+		expected float64
+	}{
+		{"valid", 4, 4},
+		{"maximum", 16, 16},
+		{"one ignored", 1, 0},
+		{"above maximum ignored", 17, 0},
+		{"negative ignored", -2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithAdaptiveBackoff(tt.input)(opts)
+
+			if opts.adaptiveBackoff != tt.expected {
+				t.Errorf("expected adaptiveBackoff=%v, got %v", tt.expected, opts.adaptiveBackoff)
+			}
+		})
+	}
+}
+
+func TestWithPriorityReservation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    float64
+		expected float64
+	}{
+		{"valid", 0.2, 0.2},
+		{"zero ignored", 0, 0},
+		{"one ignored", 1, 0},
+		{"negative ignored", -0.5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithPriorityReservation(tt.input)(opts)
+
+			if opts.priorityReserve != tt.expected {
+				t.Errorf("expected priorityReserve=%v, got %v", tt.expected, opts.priorityReserve)
+			}
+		})
+	}
+}
+
+func TestWithSilenceSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", time.Minute, time.Minute},
+		{"minimum", 10 * time.Second, 10 * time.Second},
+		{"below minimum ignored", time.Second, 0},
+		{"above maximum ignored", 25 * time.Hour, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithSilenceSync(tt.input, SilencePreferLocal)(opts)
+
+			if opts.silenceSync != tt.expected {
+				t.Errorf("expected silenceSync=%v, got %v", tt.expected, opts.silenceSync)
+			}
+		})
+	}
+}
+
+func TestWithLookupCache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		staleTTL   time.Duration
+		maxEntries int
+		enabled    bool
+	}{
+		{"valid", time.Minute, time.Hour, 1000, true},
+		{"no stale reads", time.Minute, 0, 1, true},
+		{"ttl too short", time.Millisecond, 0, 10, false},
+		{"negative stale ttl", time.Minute, -time.Second, 10, false},
+		{"no entries", time.Minute, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithLookupCache(tt.ttl, tt.staleTTL, tt.maxEntries)(opts)
+
+			if enabled := opts.lookupTTL > 0; enabled != tt.enabled {
+				t.Errorf("expected enabled=%v, got %v", tt.enabled, enabled)
+			}
+
+			if tt.enabled != (len(opts.ignored) == 0) {
+				t.Errorf("unexpected ignored values %v", opts.ignored)
+			}
+		})
+	}
+}
+
+func TestWithContextFields(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithContextFields(nil)(opts)
+
+	if opts.contextFields != nil || len(opts.ignored) != 1 {
+		t.Errorf("expected nil extractor to be ignored, got ignored=%v", opts.ignored)
+	}
+
+	WithContextFields(func(context.Context) map[string]any { return nil })(opts)
+
+	if opts.contextFields == nil {
+		t.Error("expected extractor to be set")
+	}
+}
+
+func TestWithLogSampling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 10, 10},
+		{"maximum", 10_000, 10_000},
+		{"zero ignored", 0, 0},
+		{"above maximum ignored", 10_001, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithLogSampling(tt.input)(opts)
+
+			if opts.logSampling != tt.expected {
+				t.Errorf("expected logSampling=%d, got %d", tt.expected, opts.logSampling)
+			}
+		})
+	}
+}
+
+func TestWithSlowRequestThreshold_Range(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", time.Second, time.Second},
+		{"minimum", time.Millisecond, time.Millisecond},
+		{"zero ignored", 0, 0},
+		{"above maximum ignored", 6 * time.Minute, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithSlowRequestThreshold(tt.input)(opts)
+
+			if opts.slowThreshold != tt.expected {
+				t.Errorf("expected slowThreshold=%v, got %v", tt.expected, opts.slowThreshold)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/slackmgr/types"
+)
+
+// PendingQueue names a queue in which the client holds back alerts.
+type PendingQueue string
+
+const (
+	// QueueAsync is the queue of [WithAsyncMode].
+	QueueAsync PendingQueue = "async"
+
+	// QueueDigest holds the alerts waiting for the next digest of
+	// [WithDigest].
+	QueueDigest PendingQueue = "digest"
+
+	// QueueQuietHours holds the alerts deferred by [WithQuietHours].
+	QueueQuietHours PendingQueue = "quietHours"
+)
+
+// errPendingDiscarded fails the [AsyncResult] of a discarded alert.
+var errPendingDiscarded = errors.New("alert discarded from the queue")
+
+// PendingAlert is an alert held back by the client, as listed by
+// [Client.PendingAlerts].
+type PendingAlert struct {
+	// Queue is the queue holding the alert.
+	Queue PendingQueue
+
+	// Channel is the digest channel of an alert in [QueueDigest].
+	Channel string
+
+	// Alert is the queued alert. It identifies the entry to
+	// [Client.DiscardPending] and [Client.RequeuePending], and must not be
+	// modified.
+	Alert *types.Alert
+}
+
+// PendingAlerts returns the alerts the client is holding back, in the
+// async queue, digests and quiet-hours spools, in the order they will be
+// sent within each queue. It is a snapshot; the queues keep changing while
+// the client runs. Clients derived with [Client.With] list the parent's
+// queues.
+func (c *Client) PendingAlerts() []PendingAlert {
+	if c == nil {
+		return nil
+	}
+
+	var pending []PendingAlert
+
+	if c.async != nil {
+		for _, alert := range c.async.alerts() {
+			pending = append(pending, PendingAlert{Queue: QueueAsync, Alert: alert})
+		}
+	}
+
+	if c.digest != nil {
+		for _, batch := range c.digest.batches() {
+			for _, alert := range batch.alerts {
+				pending = append(pending, PendingAlert{Queue: QueueDigest, Channel: batch.channel, Alert: alert})
+			}
+		}
+	}
+
+	if c.quietHours != nil {
+		for _, alert := range c.quietHours.alerts() {
+			pending = append(pending, PendingAlert{Queue: QueueQuietHours, Alert: alert})
+		}
+	}
+
+	return pending
+}
+
+// DiscardPending removes the given alerts, as returned by
+// [Client.PendingAlerts], from the queues without sending them, for
+// example to clear an alert the API keeps rejecting. The [AsyncResult] of
+// an alert discarded from the async queue fails. Alerts no longer queued
+// are ignored. It returns how many alerts were removed.
+func (c *Client) DiscardPending(alerts ...*types.Alert) int {
+	if c == nil {
+		return 0
+	}
+
+	items, batches, spools := c.extractPending(alerts)
+
+	if c.quarantine != nil {
+		c.quarantine.forget(alerts...)
+	}
+
+	for _, item := range items {
+		item.result.complete(errPendingDiscarded)
+	}
+
+	n := len(items)
+	for _, batch := range batches {
+		n += len(batch.alerts)
+	}
+
+	for _, spooled := range spools {
+		n += len(spooled)
+	}
+
+	if n > 0 {
+		c.options.requestLogger.Warnf("discarded %d pending alert(s)", n)
+	}
+
+	return n
+}
+
+// RequeuePending takes the given alerts, as returned by
+// [Client.PendingAlerts], out of their queues and sends them right away,
+// instead of waiting for their queue to be flushed.
+// Alerts from the async queue are sent like [Client.Send], reporting to
+// their [AsyncResult]; digested and deferred alerts are sent on their own,
+// not summarised. Digested and deferred alerts that fail to send go back
+// to the front of their queue. Alerts no longer queued are ignored.
+// [Client.Connect] must be called first.
+func (c *Client) RequeuePending(ctx context.Context, alerts ...*types.Alert) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	items, batches, spools := c.extractPending(alerts)
+
+	var (
+		errs    []error
+		pending []pendingSend
+	)
+
+	if len(items) > 0 {
+		queued := make([]*types.Alert, len(items))
+		for i, item := range items {
+			queued[i] = item.alert
+		}
+
+		_, err := c.SendWithOptions(ctx, queued, alreadyPrepared())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send %d queued alert(s): %w", len(queued), err))
+		}
+
+		for _, item := range items {
+			item.result.complete(err)
+		}
+	}
+
+	for _, batch := range batches {
+		pending = append(pending, pendingSend{
+			alerts:  batch.alerts,
+			failure: fmt.Sprintf("failed to send %d alert(s) of the digest for channel %s", len(batch.alerts), batch.channel),
+			queue:   QueueDigest,
+			requeue: func(alerts []*types.Alert) { c.digest.requeue(digestBatch{channel: batch.channel, alerts: alerts}) },
+		})
+	}
+
+	for r, spooled := range spools {
+		pending = append(pending, pendingSend{
+			alerts:  spooled,
+			failure: fmt.Sprintf("failed to send %d alert(s) deferred by quiet hours", len(spooled)),
+			queue:   QueueQuietHours,
+			requeue: func(alerts []*types.Alert) { c.quietHours.requeue(r, alerts) },
+		})
+	}
+
+	return errors.Join(append(errs, c.sendPending(ctx, pending))...)
+}
+
+// extractPending removes the given alerts from the queues holding them.
+func (c *Client) extractPending(alerts []*types.Alert) ([]asyncItem, []digestBatch, map[*quietHoursRule][]*types.Alert) {
+	selected := make(map[*types.Alert]bool, len(alerts))
+	for _, alert := range alerts {
+		selected[alert] = true
+	}
+
+	match := func(alert *types.Alert) bool { return selected[alert] }
+
+	var (
+		items   []asyncItem
+		batches []digestBatch
+		spools  map[*quietHoursRule][]*types.Alert
+	)
+
+	if len(alerts) == 0 {
+		return items, batches, spools
+	}
+
+	if c.async != nil {
+		items = c.async.extract(match)
+	}
+
+	if c.digest != nil {
+		batches = c.digest.extract(match)
+	}
+
+	if c.quietHours != nil {
+		spools = c.quietHours.extract(match)
+	}
+
+	return items, batches, spools
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestPendingAlerts_Digest(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	poison := &types.Alert{Header: "poison", Severity: types.AlertWarning}
+	stuck := &types.Alert{Header: "stuck", Severity: types.AlertInfo}
+
+	if err := client.Send(context.Background(), poison, stuck); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pending := client.PendingAlerts()
+	if len(pending) != 2 || pending[0].Queue != QueueDigest || pending[0].Channel != "C123" || pending[0].Alert != poison {
+		t.Fatalf("unexpected pending alerts %+v", pending)
+	}
+
+	if n := client.DiscardPending(poison, poison); n != 1 {
+		t.Errorf("expected one alert to be discarded, got %d", n)
+	}
+
+	if err := client.RequeuePending(context.Background(), stuck); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batches := server.received(); len(batches) != 1 || len(batches[0]) != 1 || batches[0][0].Header != "stuck" {
+		t.Errorf("expected the requeued alert to be sent on its own, got %+v", batches)
+	}
+
+	if pending := client.PendingAlerts(); len(pending) != 0 {
+		t.Errorf("expected the queues to be empty, got %+v", pending)
+	}
+}
+
+func TestRequeuePending_QuietHoursFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, _ := connectWithQuietHours(t, server.URL, QuietHoursDefer)
+
+	alert := &types.Alert{Header: "deferred", Severity: types.AlertWarning}
+	if err := client.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server.setStatus(http.StatusBadRequest)
+
+	if err := client.RequeuePending(context.Background(), alert); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if pending := client.PendingAlerts(); len(pending) != 1 || pending[0].Queue != QueueQuietHours || pending[0].Alert != alert {
+		t.Fatalf("expected the alert to go back to its spool, got %+v", pending)
+	}
+
+	server.setStatus(http.StatusOK)
+
+	if err := client.RequeuePending(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pending := client.PendingAlerts(); len(pending) != 0 {
+		t.Errorf("expected the spool to be empty, got %+v", pending)
+	}
+}
+
+func TestPendingAlerts_Async(t *testing.T) {
+	t.Parallel()
+
+	client, server := newAsyncClient(t, 10, time.Minute, nil)
+
+	first, second := types.NewAlert(types.AlertError), types.NewAlert(types.AlertError)
+
+	discarded, err := client.SendAsync(context.Background(), first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requeued, err := client.SendAsync(context.Background(), second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pending := client.PendingAlerts(); len(pending) != 2 || pending[0].Queue != QueueAsync || pending[1].Alert != second {
+		t.Fatalf("unexpected pending alerts %+v", pending)
+	}
+
+	client.DiscardPending(first)
+
+	if err := discarded.Await(context.Background()); !errors.Is(err, errPendingDiscarded) {
+		t.Errorf("expected the discarded result to fail, got %v", err)
+	}
+
+	if err := client.RequeuePending(context.Background(), second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := requeued.Await(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if n := countAlerts(server.received()); n != 1 {
+		t.Errorf("expected only the requeued alert to be sent, got %d", n)
+	}
+}
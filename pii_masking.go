@@ -0,0 +1,174 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/slackmgr/types"
+)
+
+// MaskRule detects one kind of personal data for [WithPIIMasking].
+type MaskRule struct {
+	// Name identifies the rule in reports, such as "email". Required.
+	Name string
+
+	// Pattern matches the data to mask. Required.
+	Pattern *regexp.Regexp
+
+	// Replacement replaces each match. Defaults to "[redacted <Name>]".
+	Replacement string
+
+	// Allow lists matches that are kept, such as a support address that
+	// alerts are meant to show.
+	Allow []string
+}
+
+// MaskedField reports the masking of one alert field.
+type MaskedField struct {
+	// Alert is the index of the alert in the sent batch.
+	Alert int
+
+	// Field is the JSON name of the field, such as "text" or
+	// "fields[2].value".
+	Field string
+
+	// Rule is the name of the rule that matched.
+	Rule string
+
+	// Count is the number of matches masked.
+	Count int
+}
+
+// EmailMaskRule returns a rule that masks email addresses, except those in
+// allow.
+func EmailMaskRule(allow ...string) MaskRule {
+	return MaskRule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+		Allow:   allow,
+	}
+}
+
+// PhoneMaskRule returns a rule that masks phone numbers, except those in
+// allow. It matches an optional "+" and country code followed by groups of
+// digits separated by spaces, dots, dashes or parentheses, such as
+// "+1 (555) 010-9999" or "555.010.9999", so that plain numbers such as IDs
+// and timestamps are left alone.
+func PhoneMaskRule(allow ...string) MaskRule {
+	return MaskRule{
+		Name:    "phone",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{2,4}\)|\d{2,4})[ .-]\d{3,4}[ .-]\d{3,4}\b`),
+		Allow:   allow,
+	}
+}
+
+// MaskPII masks the matches of rules in the text fields of alert in place:
+// the header, text, fallback text, author, footer and field titles and
+// values. It returns a report of the masked fields, with Alert set to 0;
+// the report never contains the masked data.
+func MaskPII(alert *types.Alert, rules ...MaskRule) []MaskedField {
+	if alert == nil {
+		return nil
+	}
+
+	var masked []MaskedField
+
+	mask := func(field string, value *string) {
+		for _, rule := range rules {
+			count := 0
+
+			*value = rule.Pattern.ReplaceAllStringFunc(*value, func(match string) string {
+				if slices.Contains(rule.Allow, match) {
+					return match
+				}
+
+				count++
+
+				return rule.replacement()
+			})
+
+			if count > 0 {
+				masked = append(masked, MaskedField{Field: field, Rule: rule.Name, Count: count})
+			}
+		}
+	}
+
+	mask("header", &alert.Header)
+	mask("headerWhenResolved", &alert.HeaderWhenResolved)
+	mask("text", &alert.Text)
+	mask("textWhenResolved", &alert.TextWhenResolved)
+	mask("fallbackText", &alert.FallbackText)
+	mask("author", &alert.Author)
+	mask("footer", &alert.Footer)
+
+	for i, field := range alert.Fields {
+		if field == nil {
+			continue
+		}
+
+		prefix := "fields[" + strconv.Itoa(i) + "]."
+		mask(prefix+"title", &field.Title)
+		mask(prefix+"value", &field.Value)
+	}
+
+	return masked
+}
+
+func (r MaskRule) replacement() string {
+	if r.Replacement != "" {
+		return r.Replacement
+	}
+
+	return "[redacted " + r.Name + "]"
+}
+
+func (r MaskRule) validate() error {
+	if r.Name == "" {
+		return errors.New("rule name must not be empty")
+	}
+
+	if r.Pattern == nil {
+		return fmt.Errorf("rule %q has no pattern", r.Name)
+	}
+
+	return nil
+}
+
+// piiMasker masks alerts before they are sent; see [WithPIIMasking].
+type piiMasker struct {
+	rules  []MaskRule
+	report func([]MaskedField)
+}
+
+// ruleNames returns the names of the rules, or nil if m is nil.
+func (m *piiMasker) ruleNames() []string {
+	if m == nil {
+		return nil
+	}
+
+	names := make([]string, len(m.rules))
+	for i, rule := range m.rules {
+		names[i] = rule.Name
+	}
+
+	return names
+}
+
+// apply masks alerts in place and reports what was masked.
+func (m *piiMasker) apply(alerts []*types.Alert) {
+	var masked []MaskedField
+
+	for i, alert := range alerts {
+		for _, field := range MaskPII(alert, m.rules...) {
+			field.Alert = i
+			masked = append(masked, field)
+		}
+	}
+
+	if len(masked) > 0 && m.report != nil {
+		m.report(masked)
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestMaskPII(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = "Login failed for jane.doe@example.com"
+	alert.Text = "Call +1 (555) 010-9999 or 555.010.1234, or mail support@example.com. Order 20240101123456 is stuck."
+	alert.Fields = []*types.Field{nil, {Title: "Customer", Value: "bob@example.org"}}
+
+	masked := MaskPII(alert, EmailMaskRule("support@example.com"), PhoneMaskRule())
+
+	if want := "Login failed for [redacted email]"; alert.Header != want {
+		t.Errorf("expected header %q, got %q", want, alert.Header)
+	}
+
+	if want := "Call [redacted phone] or [redacted phone], or mail support@example.com. Order 20240101123456 is stuck."; alert.Text != want {
+		t.Errorf("expected text %q, got %q", want, alert.Text)
+	}
+
+	if alert.Fields[1].Value != "[redacted email]" {
+		t.Errorf("expected the field value to be masked, got %q", alert.Fields[1].Value)
+	}
+
+	want := []MaskedField{
+		{Field: "header", Rule: "email", Count: 1},
+		{Field: "text", Rule: "phone", Count: 2},
+		{Field: "fields[1].value", Rule: "email", Count: 1},
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("expected report %+v, got %+v", want, masked)
+	}
+
+	if MaskPII(nil, EmailMaskRule()) != nil {
+		t.Error("expected no report for a nil alert")
+	}
+}
+
+func TestWithPIIMasking(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	var (
+		mu      sync.Mutex
+		reports [][]MaskedField
+	)
+
+	ticket := MaskRule{Name: "ticket", Pattern: regexp.MustCompile(`SEC-\d+`), Replacement: "SEC-***"}
+
+	client := New(server.URL, WithPIIMasking(func(masked []MaskedField) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		reports = append(reports, masked)
+	}, ticket))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	clean := types.NewAlert(types.AlertInfo)
+	clean.Text = "nothing to see"
+
+	secret := types.NewAlert(types.AlertError)
+	secret.Text = "see SEC-1234 and SEC-99"
+
+	if err := client.Send(context.Background(), clean, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := server.received(); len(got) != 1 || got[0][1].Text != "see SEC-*** and SEC-***" {
+		t.Errorf("expected the ticket numbers to be masked, got %v", got)
+	}
+
+	if want := [][]MaskedField{{{Alert: 1, Field: "text", Rule: "ticket", Count: 2}}}; !reflect.DeepEqual(reports, want) {
+		t.Errorf("expected report %+v, got %+v", want, reports)
+	}
+}
+
+func TestWithPIIMasking_Options(t *testing.T) {
+	t.Parallel()
+
+	if rules := New("http://localhost", WithPIIMasking(nil)).EffectiveConfig().PIIMaskRules; !reflect.DeepEqual(rules, []string{"email", "phone"}) {
+		t.Errorf("expected the default rules, got %v", rules)
+	}
+
+	for _, rule := range []MaskRule{{Pattern: regexp.MustCompile("x")}, {Name: "empty"}} {
+		client := New("http://localhost", WithPIIMasking(nil, rule))
+		if client.options.piiMasker != nil || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected rule %+v to be rejected, got %v", rule, client.ConfigWarnings())
+		}
+	}
+}
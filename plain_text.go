@@ -0,0 +1,115 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+var (
+	// slackLinkRegex matches Slack links, mentions and special mentions,
+	// such as <https://x|label>, <@U123> and <!here>.
+	slackLinkRegex = regexp.MustCompile(`<([^<>|]*)(?:\|([^<>]*))?>`)
+
+	// emojiRegex matches emoji shortcodes such as :red_circle:, but not
+	// times such as 12:30:45.
+	emojiRegex = regexp.MustCompile(`:[a-z0-9_+'\-]*[a-z][a-z0-9_+'\-]*:`)
+
+	// openMarkupRegex and closeMarkupRegex match mrkdwn bold, italic and
+	// strikethrough markers at the start and end of words.
+	openMarkupRegex  = regexp.MustCompile(`(^|\s)[*_~]+`)
+	closeMarkupRegex = regexp.MustCompile(`[*_~]+($|[\s.,;:!?])`)
+)
+
+// PlainText returns the header, text and fields of alert as one line of
+// plain text, for screen readers and notification previews: emoji
+// shortcodes, code fences and mrkdwn markers are removed, links are
+// replaced by their labels, and mentions by their names. It is cut short
+// with "…" at [types.MaxFallbackTextLength] characters, the limit of
+// [types.Alert.FallbackText].
+func PlainText(alert *types.Alert) string {
+	if alert == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(alert.Fields)+2)
+
+	for _, s := range []string{alert.Header, alert.Text} {
+		if s = stripMarkup(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	for _, field := range alert.Fields {
+		if field == nil {
+			continue
+		}
+
+		title, value := stripMarkup(field.Title), stripMarkup(field.Value)
+
+		switch {
+		case title != "" && value != "":
+			parts = append(parts, title+": "+value)
+		case title+value != "":
+			parts = append(parts, title+value)
+		}
+	}
+
+	text := strings.Join(parts, " - ")
+	if utf8.RuneCountInString(text) <= types.MaxFallbackTextLength {
+		return text
+	}
+
+	return strings.TrimSpace(string([]rune(text)[:types.MaxFallbackTextLength-1])) + "…"
+}
+
+// stripMarkup turns mrkdwn into plain text on one line.
+func stripMarkup(s string) string {
+	s = slackLinkRegex.ReplaceAllStringFunc(s, func(link string) string {
+		match := slackLinkRegex.FindStringSubmatch(link)
+		target, label := match[1], match[2]
+
+		switch {
+		case label != "":
+			return label
+		case strings.HasPrefix(target, "@"), strings.HasPrefix(target, "#"):
+			return target
+		case strings.HasPrefix(target, "!"):
+			return "@" + strings.TrimPrefix(target, "!")
+		default:
+			return target
+		}
+	})
+
+	s = emojiRegex.ReplaceAllString(s, "")
+	s = strings.NewReplacer("```", " ", "`", "", "&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(s)
+	s = openMarkupRegex.ReplaceAllString(s, "$1")
+	s = closeMarkupRegex.ReplaceAllString(s, "$1")
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// addPlainTextFallbacks returns alerts with a [PlainText] fallback text on
+// every alert that has none, for [WithPlainTextFallback]. The caller's
+// alerts are not changed.
+func addPlainTextFallbacks(alerts []*types.Alert) []*types.Alert {
+	result := make([]*types.Alert, len(alerts))
+
+	for i, alert := range alerts {
+		result[i] = alert
+
+		if strings.TrimSpace(alert.FallbackText) != "" {
+			continue
+		}
+
+		if fallback := PlainText(alert); fallback != "" {
+			copied := *alert
+			copied.FallbackText = fallback
+			result[i] = &copied
+		}
+	}
+
+	return result
+}
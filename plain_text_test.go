@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+func TestPlainText(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = ":red_circle: *Disk full* on <https://grafana/d/db1|db1>"
+	alert.Text = "Paging <!here> and <@U123>: `/var` is at 98% since 12:30:45\n```df -h```\n_see_ ~runbook~ &amp; <https://wiki/disk>"
+	alert.Fields = []*types.Field{nil, {Title: "Host", Value: "*db1*"}, {Value: "no title"}}
+
+	want := "Disk full on db1 - Paging @here and @U123: /var is at 98% since 12:30:45 df -h see runbook & https://wiki/disk - Host: db1 - no title"
+	if got := PlainText(alert); got != want {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+
+	alert.Text = strings.Repeat("word ", 100)
+	if got := PlainText(alert); utf8.RuneCountInString(got) > types.MaxFallbackTextLength || !strings.HasSuffix(got, "…") {
+		t.Errorf("expected the text to be cut at %d characters, got %q", types.MaxFallbackTextLength, got)
+	}
+
+	if PlainText(nil) != "" {
+		t.Error("expected no text for a nil alert")
+	}
+}
+
+func TestWithPlainTextFallback(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithPlainTextFallback(), WithPIIMasking(nil), WithTheme(Theme{types.AlertError: {Emoji: ":x:"}}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	generated := types.NewAlert(types.AlertError)
+	generated.Header = "Login failed"
+	generated.Text = "for *jane@example.com*"
+
+	own := types.NewAlert(types.AlertInfo)
+	own.Text = "hello"
+	own.FallbackText = "custom"
+
+	if err := client.Send(context.Background(), generated, own); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if generated.FallbackText != "" {
+		t.Errorf("expected the caller's alert to be left unchanged, got %q", generated.FallbackText)
+	}
+
+	got := server.received()
+	if len(got) != 1 {
+		t.Fatalf("expected one batch, got %d", len(got))
+	}
+
+	if fallback := got[0][0].FallbackText; fallback != "Login failed - for [redacted email]" {
+		t.Errorf("expected a masked fallback without the theme emoji, got %q", fallback)
+	}
+
+	if got[0][1].FallbackText != "custom" {
+		t.Errorf("expected the producer's fallback text to be kept, got %q", got[0][1].FallbackText)
+	}
+
+	if !client.EffectiveConfig().PlainTextFallback {
+		t.Error("expected the snapshot to report the fallback")
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultPreStopTimeout is the time [PreStopHandler] gives
+// [Client.Shutdown] when no timeout is set. It leaves a few seconds of the
+// default 30 second termination grace period of a Kubernetes pod.
+const defaultPreStopTimeout = 25 * time.Second
+
+// PreStopHandler returns an [http.Handler] for a Kubernetes preStop hook.
+// The first request flushes and closes c by calling [Client.Shutdown] with
+// the given timeout, and responds once it returns: with 200 OK, or with
+// 500 Internal Server Error and the error text if alerts were lost. Later
+// requests do not shut down again and get the same response. A
+// non-positive timeout means 25 seconds.
+//
+// Shutdown is not cut short if the kubelet drops the connection, since the
+// pod is being terminated either way. Keep timeout below the pod's
+// terminationGracePeriodSeconds, so that the alerts are delivered before
+// the container is killed.
+func PreStopHandler(c *Client, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		timeout = defaultPreStopTimeout
+	}
+
+	var (
+		once sync.Once
+		err  error
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { //nolint:contextcheck // shutdown outlives the request on purpose
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), timeout)
+			defer cancel()
+
+			err = c.Shutdown(ctx)
+		})
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
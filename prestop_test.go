@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestPreStopHandler_ShutsDownOnce(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(func(*types.Alert) string { return "C1" }, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "fyi", Severity: types.AlertInfo}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler := PreStopHandler(client, time.Second)
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/prestop", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	if batches := server.received(); len(batches) != 1 {
+		t.Errorf("expected the digest to be posted once, got %d batches", len(batches))
+	}
+}
+
+func TestPreStopHandler_ReportsShutdownError(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	PreStopHandler(nil, 0).ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/prestop", nil))
+
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "alert client is nil") {
+		t.Errorf("expected a 500 with the shutdown error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestPreStopHandler_IgnoresDroppedConnection(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(func(*types.Alert) string { return "C1" }, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "fyi", Severity: types.AlertInfo}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	rec := httptest.NewRecorder()
+	PreStopHandler(client, time.Second).ServeHTTP(rec, httptest.NewRequestWithContext(ctx, http.MethodGet, "/prestop", nil))
+
+	if rec.Code != http.StatusOK || len(server.received()) != 1 {
+		t.Errorf("expected the digest to be posted after the caller went away, got %d and %d batches", rec.Code, len(server.received()))
+	}
+}
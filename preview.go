@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/slackmgr/types"
+)
+
+const previewEndpoint = "preview"
+
+// RenderedPreview is the Slack message the API would post for an alert, as
+// returned by [Client.Preview].
+type RenderedPreview struct {
+	// SlackChannelID is the channel the alert would be routed to.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// Text is the rendered mrkdwn message text.
+	Text string `json:"text"`
+
+	// FallbackText is the plain-text notification text.
+	FallbackText string `json:"fallbackText"`
+
+	// Blocks holds the Block Kit blocks of the message, in Slack's JSON
+	// format.
+	Blocks []json.RawMessage `json:"blocks,omitempty"`
+
+	// Warnings lists problems the API found while rendering, such as
+	// truncated fields or unknown route keys.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Preview asks the API to render alert exactly as it would be posted,
+// without posting it, so UIs can show "this is what will be posted".
+// Severity mapping and metadata limits are applied as for [Client.Send],
+// to a copy of alert; digest mode, quiet hours and silences are not.
+func (c *Client) Preview(ctx context.Context, alert *types.Alert) (RenderedPreview, error) {
+	var preview RenderedPreview
+
+	if err := c.checkConnected(); err != nil {
+		return preview, err
+	}
+
+	if alert == nil {
+		return preview, errors.New("alert is nil")
+	}
+
+	copied := *alert
+	alerts := []*types.Alert{&copied}
+
+	alerts, err := c.prepareAlerts(ctx, alerts)
+	if err != nil {
+		return preview, err
+	}
+
+	body, err := encodeAlerts(alerts, c.options.alertSchema)
+	if err != nil {
+		return preview, fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	if body, _, err = c.encryptFields(ctx, body); err != nil {
+		return preview, fmt.Errorf("failed to encrypt alert fields: %w", err)
+	}
+
+	if err := c.doJSON(ctx, http.MethodPost, previewEndpoint, nil, json.RawMessage(body), &preview); err != nil {
+		return RenderedPreview{}, err
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	var received alertsList
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/preview" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_, _ = w.Write([]byte(`{"slackChannelId":"C1","text":":red_circle: *Disk full*","fallbackText":"Disk full","blocks":[{"type":"section"}],"warnings":["header truncated"]}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithSeverityMapping(DefaultSeverityMapping(), SeverityMappingLenient))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	alert := &types.Alert{Header: "Disk full", Severity: "ERROR"}
+
+	preview, err := client.Preview(context.Background(), alert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.SlackChannelID != "C1" || preview.FallbackText != "Disk full" || len(preview.Blocks) != 1 || len(preview.Warnings) != 1 {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+
+	if len(received.Alerts) != 1 || received.Alerts[0].Severity != types.AlertError {
+		t.Errorf("expected mapped severity to be sent, got %+v", received.Alerts)
+	}
+
+	if alert.Severity != "ERROR" {
+		t.Errorf("expected original alert to be left unmodified, got severity %q", alert.Severity)
+	}
+}
+
+func TestPreview_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	if _, err := client.Preview(context.Background(), &types.Alert{}); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
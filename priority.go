@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/slackmgr/types"
+)
+
+// prioritySemaphore limits the number of concurrent requests, keeping a
+// number of slots that only high-priority requests may use.
+type prioritySemaphore struct {
+	total    int
+	reserved int
+
+	mu      sync.Mutex
+	inUse   int
+	changed chan struct{}
+}
+
+// newPrioritySemaphore returns a semaphore with total slots, of which the
+// given fraction is reserved for high-priority requests. At least one slot
+// is reserved and at least one is left for other requests.
+func newPrioritySemaphore(total int, fraction float64) *prioritySemaphore {
+	reserved := int(math.Ceil(float64(total) * fraction))
+	reserved = min(max(reserved, 1), total-1)
+
+	return &prioritySemaphore{
+		total:    total,
+		reserved: reserved,
+		changed:  make(chan struct{}),
+	}
+}
+
+// acquire blocks until a slot is available or ctx is done.
+func (s *prioritySemaphore) acquire(ctx context.Context, high bool) error {
+	limit := s.total
+	if !high {
+		limit -= s.reserved
+	}
+
+	for {
+		s.mu.Lock()
+
+		if s.inUse < limit {
+			s.inUse++
+			s.mu.Unlock()
+
+			return nil
+		}
+
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release frees a slot acquired with acquire.
+func (s *prioritySemaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inUse--
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// highPriority reports whether any of the alerts is a page: a panic, error
+// or critical alert.
+func highPriority(alerts []*types.Alert) bool {
+	for _, alert := range alerts {
+		switch normalizeSeverity(alert.Severity) {
+		case types.AlertPanic, types.AlertError, severityCritical:
+			return true
+		case types.AlertWarning, types.AlertInfo, types.AlertResolved:
+		default:
+		}
+	}
+
+	return false
+}
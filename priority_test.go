@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestNewPrioritySemaphore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		total    int
+		fraction float64
+		reserved int
+	}{
+		{10, 0.2, 2},
+		{10, 0.25, 3},
+		{10, 0.01, 1},
+		{2, 0.9, 1},
+	}
+
+	for _, tt := range tests {
+		if got := newPrioritySemaphore(tt.total, tt.fraction).reserved; got != tt.reserved {
+			t.Errorf("newPrioritySemaphore(%d, %v): expected %d reserved, got %d", tt.total, tt.fraction, tt.reserved, got)
+		}
+	}
+}
+
+func TestPrioritySemaphore_ReservesSlots(t *testing.T) {
+	t.Parallel()
+
+	s := newPrioritySemaphore(3, 0.3)
+	ctx := context.Background()
+
+	for range 2 {
+		if err := s.acquire(ctx, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The remaining slot is reserved, so a low-priority send must wait.
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(short, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected low-priority acquire to block, got %v", err)
+	}
+
+	if err := s.acquire(ctx, true); err != nil {
+		t.Fatalf("expected high-priority acquire to use the reserved slot, got %v", err)
+	}
+
+	acquired := make(chan struct{})
+
+	go func() {
+		_ = s.acquire(ctx, false)
+		close(acquired)
+	}()
+
+	// Releasing the high-priority slot leaves the low-priority limit
+	// reached; releasing a low-priority slot lets the waiter in.
+	s.release()
+	s.release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected waiting low-priority acquire to proceed after release")
+	}
+}
+
+func TestHighPriority(t *testing.T) {
+	t.Parallel()
+
+	if highPriority([]*types.Alert{{Severity: types.AlertWarning}, {Severity: types.AlertInfo}}) {
+		t.Error("expected warning and info alerts to be low priority")
+	}
+
+	if !highPriority([]*types.Alert{{Severity: types.AlertInfo}, {Severity: "Error"}}) {
+		t.Error("expected a batch containing an error alert to be high priority")
+	}
+
+	if !highPriority([]*types.Alert{{Severity: types.AlertPanic}}) {
+		t.Error("expected panic alerts to be high priority")
+	}
+}
+
+func TestSend_PriorityReservation(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL, WithMaxConnsPerHost(2), WithPriorityReservation(0.5))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	// Occupy the only low-priority slot.
+	if err := client.priority.acquire(context.Background(), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := client.Send(ctx, &types.Alert{Severity: types.AlertWarning}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected low-priority send to wait for a slot, got %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Severity: types.AlertError}); err != nil {
+		t.Errorf("expected high-priority send to use the reserved slot, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"io"
+	"net/http"
+)
+
+// trackProgress wraps the body of request, if it has one, so that progress
+// is reported as it is read by the HTTP transport. The total is -1 if the
+// size of the body is not known in advance. The GetBody function is
+// wrapped too, so bodies resent after a redirect or an authentication
+// challenge are reported from zero again.
+func trackProgress(request *http.Request, progress func(sent, total int64)) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return
+	}
+
+	total := request.ContentLength
+	if total == 0 {
+		total = -1
+	}
+
+	request.Body = newProgressReader(request.Body, total, progress)
+
+	if getBody := request.GetBody; getBody != nil {
+		request.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil || body == http.NoBody {
+				return body, err
+			}
+
+			return newProgressReader(body, total, progress), nil
+		}
+	}
+}
+
+// progressReader reports the bytes read from an [io.ReadCloser].
+type progressReader struct {
+	body     io.ReadCloser
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func newProgressReader(body io.ReadCloser, total int64, progress func(sent, total int64)) *progressReader {
+	progress(0, total)
+
+	return &progressReader{body: body, total: total, progress: progress}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.body.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	return p.body.Close()
+}
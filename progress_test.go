@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// progressRecorder collects the calls made to a [WithProgress] function.
+type progressRecorder struct {
+	mu    sync.Mutex
+	calls [][2]int64
+}
+
+func (p *progressRecorder) record(sent, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, [2]int64{sent, total})
+}
+
+func (p *progressRecorder) snapshot() [][2]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([][2]int64(nil), p.calls...)
+}
+
+func TestWithProgress_ReportsUploadedBytes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+		size     int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		attempts++
+		size = len(data)
+		first := attempts == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	recorder := &progressRecorder{}
+
+	client := New(server.URL, WithProgress(recorder.record))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := recorder.snapshot(); len(calls) != 0 {
+		t.Fatalf("expected no progress for the ping, got %v", calls)
+	}
+
+	alert := types.NewAlert(types.AlertError)
+	alert.SlackChannelID = "C123"
+	alert.Header = "disk full"
+
+	if err := client.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	total := int64(size)
+	mu.Unlock()
+
+	calls := recorder.snapshot()
+
+	var starts int
+	for i, call := range calls {
+		if call[1] != total {
+			t.Fatalf("call %d: expected total %d, got %d", i, total, call[1])
+		}
+
+		if call[0] == 0 {
+			starts++
+		}
+	}
+
+	if starts != 2 {
+		t.Errorf("expected progress to restart for each of 2 attempts, got %d starts in %v", starts, calls)
+	}
+
+	if last := calls[len(calls)-1]; last[0] != total {
+		t.Errorf("expected final progress %d/%d, got %v", total, total, last)
+	}
+}
+
+func TestTrackProgress_UnknownLength(t *testing.T) {
+	t.Parallel()
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodPut, "http://example.com", io.NopCloser(strings.NewReader("hello")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recorder := &progressRecorder{}
+	trackProgress(request, recorder.record)
+
+	if _, err := io.ReadAll(request.Body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := recorder.snapshot()
+	if first, last := calls[0], calls[len(calls)-1]; first != [2]int64{0, -1} || last != [2]int64{5, -1} {
+		t.Errorf("unexpected progress calls %v", calls)
+	}
+}
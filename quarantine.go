@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const maxQuarantineAttempts = 100
+
+// ErrQuarantined fails the [AsyncResult] of an alert moved to quarantine by
+// [WithQuarantine].
+var ErrQuarantined = errors.New("alert quarantined after repeated rejections")
+
+// QuarantinedAlert is an alert that the API rejected too often, as kept by a
+// [QuarantineStore].
+type QuarantinedAlert struct {
+	// Alert is the rejected alert.
+	Alert *types.Alert
+
+	// Queue is the queue that held the alert.
+	Queue PendingQueue
+
+	// Attempts is the number of times the API rejected the alert.
+	Attempts int
+
+	// StatusCode is the status code of the last rejection.
+	StatusCode int
+
+	// Error is the error of the last rejection.
+	Error string
+
+	// At is when the alert was quarantined.
+	At time.Time
+}
+
+// QuarantineStore keeps the alerts quarantined by [WithQuarantine], backed
+// for example by a database table or an object store, so they can be
+// inspected and fixed after a restart. Implementations must be safe for
+// concurrent use.
+type QuarantineStore interface {
+	// Put adds a quarantined alert.
+	Put(ctx context.Context, alert QuarantinedAlert) error
+
+	// List returns the quarantined alerts, oldest first.
+	List(ctx context.Context) ([]QuarantinedAlert, error)
+}
+
+// MemoryQuarantineStore is a [QuarantineStore] kept in memory, which loses
+// its alerts when the process exits.
+type MemoryQuarantineStore struct {
+	mu     sync.Mutex
+	alerts []QuarantinedAlert
+}
+
+// NewMemoryQuarantineStore returns an empty [MemoryQuarantineStore].
+func NewMemoryQuarantineStore() *MemoryQuarantineStore {
+	return &MemoryQuarantineStore{}
+}
+
+// Put implements [QuarantineStore].
+func (s *MemoryQuarantineStore) Put(_ context.Context, alert QuarantinedAlert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.alerts = append(s.alerts, alert)
+
+	return nil
+}
+
+// List implements [QuarantineStore].
+func (s *MemoryQuarantineStore) List(context.Context) ([]QuarantinedAlert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.alerts), nil
+}
+
+// QuarantineStats reports the state of [WithQuarantine].
+type QuarantineStats struct {
+	// Enabled reports whether [WithQuarantine] is in effect.
+	Enabled bool
+
+	// Quarantined counts the alerts moved to quarantine.
+	Quarantined int64
+
+	// StoreErrors counts the alerts the store failed to keep, which were
+	// dropped.
+	StoreErrors int64
+}
+
+// Quarantined returns the alerts moved to quarantine by [WithQuarantine],
+// as listed by its store. It returns nil if quarantine is not enabled.
+func (c *Client) Quarantined(ctx context.Context) ([]QuarantinedAlert, error) {
+	if c == nil || c.options.quarantineStore == nil {
+		return nil, nil
+	}
+
+	alerts, err := c.options.quarantineStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// quarantine counts the rejections of queued alerts for [WithQuarantine].
+type quarantine struct {
+	store    QuarantineStore
+	attempts int
+	logger   RequestLogger
+
+	mu       sync.Mutex
+	failures map[*types.Alert]int
+
+	quarantined atomic.Int64
+	storeErrors atomic.Int64
+}
+
+func newQuarantine(store QuarantineStore, attempts int, logger RequestLogger) *quarantine {
+	return &quarantine{
+		store:    store,
+		attempts: attempts,
+		logger:   logger,
+		failures: make(map[*types.Alert]int),
+	}
+}
+
+// siftResult sorts the alerts of a batch the API rejected.
+type siftResult struct {
+	// rejected are the alerts the API rejected, not yet often enough to be
+	// quarantined.
+	rejected []*types.Alert
+
+	// failed are the alerts that failed for another reason.
+	failed []*types.Alert
+
+	// quarantined are the alerts moved to quarantine.
+	quarantined []*types.Alert
+
+	err error
+}
+
+// retry returns the alerts to send again, the rejected and failed ones, in
+// the order of alerts.
+func (r siftResult) retry(alerts []*types.Alert) []*types.Alert {
+	var retry []*types.Alert
+
+	for _, alert := range alerts {
+		if slices.Contains(r.rejected, alert) || slices.Contains(r.failed, alert) {
+			retry = append(retry, alert)
+		}
+	}
+
+	return retry
+}
+
+// undelivered reports whether alert was rejected, failed or quarantined.
+func (r siftResult) undelivered(alert *types.Alert) bool {
+	return slices.Contains(r.rejected, alert) || slices.Contains(r.failed, alert) || slices.Contains(r.quarantined, alert)
+}
+
+// sift handles a batch of alerts from queue that post failed to deliver,
+// with the response meta and error err. If the API rejected the batch as
+// invalid, each alert is posted on its own to find those at fault, and an
+// alert rejected for the configured number of attempts is quarantined.
+// The other alerts were delivered.
+func (q *quarantine) sift(ctx context.Context, queue PendingQueue, alerts []*types.Alert, meta *ResponseMetadata, err error, post func(context.Context, []*types.Alert) (*ResponseMetadata, error)) siftResult {
+	if !rejection(meta) {
+		return siftResult{failed: alerts, err: err}
+	}
+
+	if len(alerts) == 1 {
+		return q.reject(ctx, queue, alerts[0], meta, err)
+	}
+
+	var (
+		result siftResult
+		errs   []error
+	)
+
+	for _, alert := range alerts {
+		if ctx.Err() != nil {
+			result.failed = append(result.failed, alert)
+			continue
+		}
+
+		meta, err := post(ctx, []*types.Alert{alert})
+		if err == nil {
+			q.forget(alert)
+			continue
+		}
+
+		one := siftResult{failed: []*types.Alert{alert}, err: err}
+		if rejection(meta) {
+			one = q.reject(ctx, queue, alert, meta, err)
+		}
+
+		result.rejected = append(result.rejected, one.rejected...)
+		result.failed = append(result.failed, one.failed...)
+		result.quarantined = append(result.quarantined, one.quarantined...)
+		errs = append(errs, one.err)
+	}
+
+	result.err = errors.Join(errs...)
+
+	return result
+}
+
+// reject counts a rejection of alert, and quarantines it once it has been
+// rejected for the configured number of attempts.
+func (q *quarantine) reject(ctx context.Context, queue PendingQueue, alert *types.Alert, meta *ResponseMetadata, err error) siftResult {
+	q.mu.Lock()
+	q.failures[alert]++
+	attempts := q.failures[alert]
+
+	if attempts >= q.attempts {
+		delete(q.failures, alert)
+	}
+	q.mu.Unlock()
+
+	if attempts < q.attempts {
+		return siftResult{rejected: []*types.Alert{alert}, err: err}
+	}
+
+	entry := QuarantinedAlert{
+		Alert:      alert,
+		Queue:      queue,
+		Attempts:   attempts,
+		StatusCode: meta.status(),
+		Error:      err.Error(),
+		At:         time.Now(),
+	}
+
+	if storeErr := q.store.Put(ctx, entry); storeErr != nil {
+		q.storeErrors.Add(1)
+		q.logger.Errorf("failed to quarantine alert %q, dropping it: %v", alert.Header, storeErr)
+	} else {
+		q.quarantined.Add(1)
+		q.logger.Warnf("quarantined alert %q after %d rejection(s): %v", alert.Header, attempts, err)
+	}
+
+	return siftResult{quarantined: []*types.Alert{alert}, err: fmt.Errorf("%w: %w", ErrQuarantined, err)}
+}
+
+// forget drops the rejection counts of alerts that left their queue.
+func (q *quarantine) forget(alerts ...*types.Alert) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, alert := range alerts {
+		delete(q.failures, alert)
+	}
+}
+
+func (q *quarantine) stats() QuarantineStats {
+	return QuarantineStats{Enabled: true, Quarantined: q.quarantined.Load(), StoreErrors: q.storeErrors.Load()}
+}
+
+// rejection reports whether the API rejected a request as invalid, with a
+// status that sending the same alerts again cannot fix. Statuses such as
+// 401 or 429 concern every request, not the alerts.
+func rejection(meta *ResponseMetadata) bool {
+	switch meta.status() {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newPoisonServer rejects every batch that contains an alert with the
+// header "poison" with a 400, and records the headers of the alerts of the
+// other batches.
+func newPoisonServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu        sync.Mutex
+		delivered []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		var input alertsList
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if slices.ContainsFunc(input.Alerts, func(alert *types.Alert) bool { return alert.Header == "poison" }) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid alert"}`))
+
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, alert := range input.Alerts {
+			delivered = append(delivered, alert.Header)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(delivered)
+	}
+}
+
+func TestQuarantine_Async(t *testing.T) {
+	t.Parallel()
+
+	server, delivered := newPoisonServer(t)
+	store := NewMemoryQuarantineStore()
+
+	client := New(server.URL, WithRetryCount(0), WithAsyncMode(10, 10*time.Millisecond, func([]*types.Alert, error) {}), WithQuarantine(store, 2))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	poison, err := client.SendAsync(context.Background(), &types.Alert{Header: "poison", Severity: types.AlertError})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	good, err := client.SendAsync(context.Background(), &types.Alert{Header: "good", Severity: types.AlertError})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := good.Await(ctx); err != nil {
+		t.Errorf("expected the good alert to be delivered, got %v", err)
+	}
+
+	if err := poison.Await(ctx); !errors.Is(err, ErrQuarantined) {
+		t.Fatalf("expected the poison alert to be quarantined, got %v", err)
+	}
+
+	if got := delivered(); !slices.Equal(got, []string{"good"}) {
+		t.Errorf("expected only the good alert to be delivered, got %q", got)
+	}
+
+	quarantined, err := client.Quarantined(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(quarantined) != 1 || quarantined[0].Queue != QueueAsync || quarantined[0].Attempts != 2 || quarantined[0].StatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected quarantined alerts %+v", quarantined)
+	}
+
+	if stats := client.Stats(); stats.Quarantine.Quarantined != 1 || stats.Async.Queued != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQuarantine_AsyncRetriesOncePerFlush(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		requests.Add(1)
+
+		var input alertsList
+		_ = json.NewDecoder(r.Body).Decode(&input)
+
+		if slices.ContainsFunc(input.Alerts, func(alert *types.Alert) bool { return alert.Header == "poison" }) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	// The interval is long enough that only the flushes of the test run.
+	client := New(server.URL, WithRetryCount(0), WithAsyncMode(10, time.Minute, func([]*types.Alert, error) {}), WithQuarantine(NewMemoryQuarantineStore(), 5))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	for _, header := range []string{"poison", "good"} {
+		if _, err := client.SendAsync(context.Background(), &types.Alert{Header: header, Severity: types.AlertError}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	flush := func() int32 {
+		before := requests.Load()
+		_ = client.async.send(context.Background())
+
+		return requests.Load() - before
+	}
+
+	// The batch, then each of its alerts on its own.
+	if got := flush(); got != 3 {
+		t.Errorf("expected 3 requests in the first flush, got %d", got)
+	}
+
+	if got := flush(); got != 1 {
+		t.Errorf("expected the rejected alert to be sent once in the next flush, got %d requests", got)
+	}
+
+	if queued := client.Stats().Async.Queued; queued != 1 {
+		t.Errorf("expected the rejected alert to stay queued, got %d queued", queued)
+	}
+}
+
+func TestQuarantine_AsyncResultCallback(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newPoisonServer(t)
+
+	type callback struct {
+		headers []string
+		err     error
+	}
+
+	calls := make(chan callback, 10)
+
+	client := New(server.URL, WithRetryCount(0), WithAsyncMode(10, time.Minute, func([]*types.Alert, error) {}), WithQuarantine(NewMemoryQuarantineStore(), 1),
+		WithResultCallback(func(result SendResult, err error) {
+			headers := make([]string, 0, len(result.Alerts))
+			for _, alert := range result.Alerts {
+				headers = append(headers, alert.Header)
+			}
+
+			calls <- callback{headers: headers, err: err}
+		}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	for _, header := range []string{"good", "poison"} {
+		if _, err := client.SendAsync(context.Background(), &types.Alert{Header: header, Severity: types.AlertError}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_ = client.async.send(context.Background())
+
+	if len(calls) != 1 {
+		t.Fatalf("expected one callback for the batch, got %d", len(calls))
+	}
+
+	call := <-calls
+	if !slices.Equal(call.headers, []string{"poison"}) || !errors.Is(call.err, ErrQuarantined) {
+		t.Errorf("expected only the quarantined alert and its error, got %q and %v", call.headers, call.err)
+	}
+
+	if client := New(server.URL, WithResultCallback(nil)); client.options.asyncOnResult != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil callback to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+}
+
+func TestQuarantine_QuietHours(t *testing.T) {
+	t.Parallel()
+
+	server, delivered := newPoisonServer(t)
+
+	client := New(server.URL, WithRetryCount(0), WithQuietHours(BusinessHours(9*time.Hour, 17*time.Hour), time.UTC, QuietHoursDefer), WithQuarantine(NewMemoryQuarantineStore(), 1))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	client.quietHours.now = func() time.Time { return time.Date(2026, 3, 4, 22, 0, 0, 0, time.UTC) }
+
+	err := client.Send(context.Background(),
+		&types.Alert{Header: "poison", Severity: types.AlertWarning},
+		&types.Alert{Header: "deferred", Severity: types.AlertWarning},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Shutdown(context.Background()); !errors.Is(err, ErrQuarantined) {
+		t.Errorf("expected the quarantine to be reported, got %v", err)
+	}
+
+	if got := delivered(); !slices.Equal(got, []string{"deferred"}) {
+		t.Errorf("expected the rest of the spool to be delivered, got %q", got)
+	}
+
+	if pending := client.PendingAlerts(); len(pending) != 0 {
+		t.Errorf("expected nothing to be requeued, got %+v", pending)
+	}
+}
+
+func TestQuarantine_Disabled(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://localhost")
+	if alerts, err := client.Quarantined(context.Background()); alerts != nil || err != nil {
+		t.Errorf("expected nothing without quarantine, got %v, %v", alerts, err)
+	}
+
+	for _, opt := range []Option{
+		WithQuarantine(nil, 3),
+		WithQuarantine(NewMemoryQuarantineStore(), 0),
+		WithQuarantine(NewMemoryQuarantineStore(), maxQuarantineAttempts+1),
+	} {
+		if client := New("http://localhost", opt); client.options.quarantineStore != nil || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+}
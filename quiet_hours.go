@@ -0,0 +1,339 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const quietHoursCheckInterval = 1 * time.Minute
+
+// QuietHoursBehavior controls what happens to warning and info alerts that
+// are sent outside business hours. See [WithQuietHours].
+type QuietHoursBehavior int
+
+const (
+	// QuietHoursDefer holds alerts back and sends them unchanged when business
+	// hours begin.
+	QuietHoursDefer QuietHoursBehavior = iota
+
+	// QuietHoursDigest holds alerts back and posts a single digest alert per
+	// channel or route key when business hours begin.
+	QuietHoursDigest
+
+	// QuietHoursDrop discards alerts sent outside business hours.
+	QuietHoursDrop
+)
+
+// Schedule describes recurring business hours. Start and End are offsets
+// from midnight in the schedule's time zone. If End is before Start the
+// window spans midnight and ends on the following day. Days reported by
+// Holidays, if set, are treated as outside business hours.
+type Schedule struct {
+	Days     []time.Weekday
+	Start    time.Duration
+	End      time.Duration
+	Holidays HolidayProvider
+}
+
+// BusinessHours returns a Monday–Friday [Schedule] between the given offsets
+// from midnight, e.g. BusinessHours(9*time.Hour, 17*time.Hour).
+func BusinessHours(start, end time.Duration) Schedule {
+	return Schedule{
+		Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start: start,
+		End:   end,
+	}
+}
+
+// Contains reports whether t falls within the schedule. The caller is
+// responsible for converting t to the schedule's time zone.
+func (s Schedule) Contains(t time.Time) bool {
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if s.Start < s.End {
+		return offset >= s.Start && offset < s.End && s.businessDay(t)
+	}
+
+	// Overnight window: the evening part belongs to today, the early-morning
+	// part belongs to the previous day's window.
+	if offset >= s.Start {
+		return s.businessDay(t)
+	}
+
+	return offset < s.End && s.businessDay(t.AddDate(0, 0, -1))
+}
+
+// businessDay reports whether a business-hours window starts on the date of t.
+func (s Schedule) businessDay(t time.Time) bool {
+	if !slices.Contains(s.Days, t.Weekday()) {
+		return false
+	}
+
+	return s.Holidays == nil || !s.Holidays.IsHoliday(t)
+}
+
+func (s Schedule) validate() error {
+	if len(s.Days) == 0 {
+		return errors.New("schedule must include at least one day")
+	}
+
+	if s.Start < 0 || s.Start >= 24*time.Hour || s.End < 0 || s.End > 24*time.Hour {
+		return errors.New("schedule start and end must be within a single day")
+	}
+
+	if s.Start == s.End {
+		return errors.New("schedule start and end must differ")
+	}
+
+	return nil
+}
+
+// quietHoursRule is the configuration of a single [WithQuietHours] option.
+type quietHoursRule struct {
+	schedule  Schedule
+	location  *time.Location
+	behavior  QuietHoursBehavior
+	routeKeys []string
+}
+
+// matches reports whether the rule applies to the alert.
+func (r *quietHoursRule) matches(alert *types.Alert) bool {
+	if len(r.routeKeys) == 0 {
+		return true
+	}
+
+	return slices.Contains(r.routeKeys, strings.ToLower(strings.TrimSpace(alert.RouteKey)))
+}
+
+func (r *quietHoursRule) inBusinessHours(now time.Time) bool {
+	return r.schedule.Contains(now.In(r.location))
+}
+
+// quietHours holds back low-priority alerts that are sent outside business
+// hours, one spool per configured rule.
+type quietHours struct {
+	rules []*quietHoursRule
+	now   func() time.Time
+
+	mu     sync.Mutex
+	spools map[*quietHoursRule][]*types.Alert
+}
+
+func newQuietHours(rules []*quietHoursRule) *quietHours {
+	return &quietHours{
+		rules:  rules,
+		now:    time.Now,
+		spools: make(map[*quietHoursRule][]*types.Alert),
+	}
+}
+
+// rule returns the rule that applies to the alert. Route-specific rules take
+// precedence over rules that apply to all alerts.
+func (q *quietHours) rule(alert *types.Alert) *quietHoursRule {
+	var fallback *quietHoursRule
+
+	for _, r := range q.rules {
+		if !r.matches(alert) {
+			continue
+		}
+
+		if len(r.routeKeys) > 0 {
+			return r
+		}
+
+		if fallback == nil {
+			fallback = r
+		}
+	}
+
+	return fallback
+}
+
+// capture holds back or drops low-priority alerts sent outside business
+// hours and returns the remaining alerts, which must be sent immediately.
+func (q *quietHours) capture(alerts []*types.Alert, logger RequestLogger) []*types.Alert {
+	now := q.now()
+	passthrough := make([]*types.Alert, 0, len(alerts))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, alert := range alerts {
+		r := q.rule(alert)
+		if !lowPriority(alert) || r == nil || r.inBusinessHours(now) {
+			passthrough = append(passthrough, alert)
+			continue
+		}
+
+		if r.behavior == QuietHoursDrop {
+			logger.Debugf("dropping %s alert outside business hours: %s", normalizeSeverity(alert.Severity), alert.Header)
+			continue
+		}
+
+		q.spools[r] = append(q.spools[r], alert)
+	}
+
+	return passthrough
+}
+
+// due removes and returns the spooled alerts of every rule whose business
+// hours have begun.
+func (q *quietHours) due() map[*quietHoursRule][]*types.Alert {
+	now := q.now()
+
+	return q.take(func(r *quietHoursRule) bool { return r.inBusinessHours(now) })
+}
+
+// drain removes and returns the spooled alerts of every rule.
+func (q *quietHours) drain() map[*quietHoursRule][]*types.Alert {
+	return q.take(func(*quietHoursRule) bool { return true })
+}
+
+// take removes and returns the spooled alerts of the rules selected by
+// include.
+func (q *quietHours) take(include func(*quietHoursRule) bool) map[*quietHoursRule][]*types.Alert {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	taken := make(map[*quietHoursRule][]*types.Alert)
+
+	for r, spooled := range q.spools {
+		if include(r) {
+			taken[r] = spooled
+			delete(q.spools, r)
+		}
+	}
+
+	return taken
+}
+
+// alerts returns the spooled alerts, in the order of their rules, without
+// removing them.
+func (q *quietHours) alerts() []*types.Alert {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var alerts []*types.Alert
+	for _, r := range q.rules {
+		alerts = append(alerts, q.spools[r]...)
+	}
+
+	return alerts
+}
+
+// extract removes and returns the spooled alerts selected by match.
+func (q *quietHours) extract(match func(*types.Alert) bool) map[*quietHoursRule][]*types.Alert {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	taken := make(map[*quietHoursRule][]*types.Alert)
+
+	for r, spooled := range q.spools {
+		q.spools[r] = slices.DeleteFunc(spooled, func(alert *types.Alert) bool {
+			if match(alert) {
+				taken[r] = append(taken[r], alert)
+				return true
+			}
+
+			return false
+		})
+
+		if len(q.spools[r]) == 0 {
+			delete(q.spools, r)
+		}
+	}
+
+	return taken
+}
+
+// len returns the number of spooled alerts.
+func (q *quietHours) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	n := 0
+	for _, spooled := range q.spools {
+		n += len(spooled)
+	}
+
+	return n
+}
+
+// requeue returns alerts that failed to send to the front of their rule's
+// spool.
+func (q *quietHours) requeue(r *quietHoursRule, alerts []*types.Alert) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.spools[r] = append(alerts, q.spools[r]...)
+}
+
+// quietHoursDigests summarises alerts in one digest per destination, keyed
+// by Slack channel or, failing that, route key.
+func quietHoursDigests(alerts []*types.Alert) []*types.Alert {
+	type destination struct{ channel, routeKey string }
+
+	var order []destination
+	grouped := make(map[destination][]*types.Alert)
+
+	for _, alert := range alerts {
+		dest := destination{channel: alert.SlackChannelID}
+		if dest.channel == "" {
+			dest.routeKey = alert.RouteKey
+		}
+
+		if _, ok := grouped[dest]; !ok {
+			order = append(order, dest)
+		}
+
+		grouped[dest] = append(grouped[dest], alert)
+	}
+
+	summaries := make([]*types.Alert, 0, len(order))
+	for _, dest := range order {
+		summary := buildDigestAlert(dest.channel, grouped[dest])
+		summary.RouteKey = dest.routeKey
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// releaseQuietHours sends alerts held back by [WithQuietHours] whose business
+// hours have begun. Alerts that fail to send are kept and retried on the next
+// check.
+func (c *Client) releaseQuietHours(ctx context.Context) error {
+	return c.sendPending(ctx, c.pendingQuietHours(c.quietHours.due()))
+}
+
+// pendingQuietHours returns the sends for spools taken from the quiet-hours
+// state.
+func (c *Client) pendingQuietHours(spools map[*quietHoursRule][]*types.Alert) []pendingSend {
+	pending := make([]pendingSend, 0, len(spools))
+
+	for r, spooled := range spools {
+		p := pendingSend{
+			alerts:  spooled,
+			failure: fmt.Sprintf("failed to send %d alert(s) deferred by quiet hours", len(spooled)),
+			queue:   QueueQuietHours,
+			requeue: func(alerts []*types.Alert) { c.quietHours.requeue(r, alerts) },
+		}
+
+		if r.behavior == QuietHoursDigest {
+			p.alerts = quietHoursDigests(spooled)
+			p.queue = ""
+			p.requeue = func([]*types.Alert) { c.quietHours.requeue(r, spooled) }
+		}
+
+		pending = append(pending, p)
+	}
+
+	return pending
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSchedule_Contains(t *testing.T) {
+	t.Parallel()
+
+	office := BusinessHours(9*time.Hour, 17*time.Hour)
+	overnight := Schedule{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 6 * time.Hour}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		at       time.Time
+		expected bool
+	}{
+		{"weekday inside", office, time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC), true},
+		{"weekday at start", office, time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC), true},
+		{"weekday at end", office, time.Date(2026, 3, 4, 17, 0, 0, 0, time.UTC), false},
+		{"weekday before", office, time.Date(2026, 3, 4, 8, 59, 59, 0, time.UTC), false},
+		{"weekend", office, time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC), false},
+		{"overnight evening", overnight, time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC), true},
+		{"overnight next morning", overnight, time.Date(2026, 3, 7, 5, 0, 0, 0, time.UTC), true},
+		{"overnight wrong day", overnight, time.Date(2026, 3, 6, 5, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.schedule.Contains(tt.at); got != tt.expected {
+				t.Errorf("expected Contains=%v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		schedule  Schedule
+		wantError string
+	}{
+		{"valid", BusinessHours(9*time.Hour, 17*time.Hour), ""},
+		{"no days", Schedule{Start: time.Hour, End: 2 * time.Hour}, "schedule must include at least one day"},
+		{"end out of range", BusinessHours(9*time.Hour, 25*time.Hour), "schedule start and end must be within a single day"},
+		{"empty window", BusinessHours(9*time.Hour, 9*time.Hour), "schedule start and end must differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.schedule.validate()
+			if tt.wantError == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+
+			if tt.wantError != "" && (err == nil || err.Error() != tt.wantError) {
+				t.Errorf("expected error %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestQuietHours_RulePrecedence(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithQuietHours(BusinessHours(9*time.Hour, 17*time.Hour), nil, QuietHoursDefer)(opts)
+	WithQuietHours(BusinessHours(8*time.Hour, 20*time.Hour), nil, QuietHoursDrop, " Payments ")(opts)
+
+	q := newQuietHours(opts.quietHoursRules)
+
+	if r := q.rule(&types.Alert{RouteKey: "payments"}); r != opts.quietHoursRules[1] {
+		t.Error("expected route-specific rule to take precedence")
+	}
+
+	if r := q.rule(&types.Alert{RouteKey: "other"}); r != opts.quietHoursRules[0] {
+		t.Error("expected global rule for other route keys")
+	}
+}
+
+// connectWithQuietHours returns a connected client whose quiet hours clock
+// is fixed at the returned pointer's value.
+func connectWithQuietHours(t *testing.T, url string, behavior QuietHoursBehavior) (*Client, *time.Time) {
+	t.Helper()
+
+	client := New(url, WithRetryCount(0), WithQuietHours(BusinessHours(9*time.Hour, 17*time.Hour), time.UTC, behavior))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	now := time.Date(2026, 3, 4, 22, 0, 0, 0, time.UTC) // Wednesday evening
+	client.quietHours.now = func() time.Time { return now }
+
+	return client, &now
+}
+
+func TestSend_QuietHoursDefer(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, now := connectWithQuietHours(t, server.URL, QuietHoursDefer)
+
+	err := client.Send(context.Background(),
+		&types.Alert{Header: "low", Severity: types.AlertWarning},
+		&types.Alert{Header: "critical", Severity: types.AlertPanic},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 || len(batches[0]) != 1 || batches[0][0].Header != "critical" {
+		t.Fatalf("expected only the critical alert outside business hours, got %+v", batches)
+	}
+
+	if err := client.releaseQuietHours(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(server.received()) != 1 {
+		t.Fatal("expected nothing to be released outside business hours")
+	}
+
+	*now = time.Date(2026, 3, 5, 9, 1, 0, 0, time.UTC)
+
+	if err := client.releaseQuietHours(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches = server.received()
+	if len(batches) != 2 || batches[1][0].Header != "low" {
+		t.Fatalf("expected deferred alert to be released, got %+v", batches)
+	}
+}
+
+func TestSend_QuietHoursDigest(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, now := connectWithQuietHours(t, server.URL, QuietHoursDigest)
+
+	_ = client.Send(context.Background(),
+		&types.Alert{Header: "one", Severity: types.AlertInfo, SlackChannelID: "C1"},
+		&types.Alert{Header: "two", Severity: types.AlertInfo, SlackChannelID: "C1"},
+		&types.Alert{Header: "three", Severity: types.AlertInfo, RouteKey: "payments"},
+	)
+
+	if len(server.received()) != 0 {
+		t.Fatal("expected all alerts to be held back")
+	}
+
+	*now = time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	if err := client.releaseQuietHours(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected one batch with two digests, got %+v", batches)
+	}
+
+	if batches[0][0].SlackChannelID != "C1" || !strings.Contains(batches[0][0].Header, "2 alert(s)") {
+		t.Errorf("unexpected channel digest: %+v", batches[0][0])
+	}
+
+	if batches[0][1].RouteKey != "payments" {
+		t.Errorf("expected route key digest, got %+v", batches[0][1])
+	}
+}
+
+func TestSend_QuietHoursDrop(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, now := connectWithQuietHours(t, server.URL, QuietHoursDrop)
+
+	_ = client.Send(context.Background(), &types.Alert{Header: "low", Severity: types.AlertInfo})
+
+	*now = time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	_ = client.releaseQuietHours(context.Background())
+
+	if len(server.received()) != 0 {
+		t.Error("expected dropped alert never to be sent")
+	}
+}
+
+func TestReleaseQuietHours_RequeuesOnFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, now := connectWithQuietHours(t, server.URL, QuietHoursDefer)
+
+	_ = client.Send(context.Background(), &types.Alert{Header: "low", Severity: types.AlertInfo})
+
+	*now = time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	server.setStatus(http.StatusInternalServerError)
+
+	if err := client.releaseQuietHours(context.Background()); err == nil {
+		t.Fatal("expected release error")
+	}
+
+	server.setStatus(http.StatusOK)
+
+	if err := client.releaseQuietHours(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batches := server.received(); len(batches) != 1 || batches[0][0].Header != "low" {
+		t.Errorf("expected requeued alert to be sent, got %+v", batches)
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	maxRateLimit      = 10_000
+	maxRateLimitBurst = 10_000
+)
+
+// RateLimitStats reports the state of [WithRateLimit].
+type RateLimitStats struct {
+	// Enabled reports whether [WithRateLimit] is in effect.
+	Enabled bool
+
+	// Throttled counts the requests that waited for the limit.
+	Throttled int64
+
+	// Wait is the total time requests waited for the limit.
+	Wait time.Duration
+}
+
+// rateLimiter is a token bucket that caps the number of requests sent to
+// the API. Tokens are requests; the bucket holds up to burst of them.
+type rateLimiter struct {
+	rate  float64
+	burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	throttled atomic.Int64
+	waited    atomic.Int64
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rps,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleepFor,
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done. The request is
+// reserved immediately, so concurrent callers queue up fairly. A caller
+// whose ctx is done before its turn gives its reservation back.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, float64(l.burst))
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	l.throttled.Add(1)
+	l.waited.Add(int64(delay))
+
+	if err := l.sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens = min(l.tokens+1, float64(l.burst))
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (l *rateLimiter) stats() RateLimitStats {
+	return RateLimitStats{Enabled: true, Throttled: l.throttled.Load(), Wait: time.Duration(l.waited.Load())}
+}
+
+// transport waits for the limit before every request attempt, retries
+// included, so the client slows down before the API answers with 429.
+func (l *rateLimiter) transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err := l.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		return next.RoundTrip(req)
+	})
+}
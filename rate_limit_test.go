@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// fakeRateClock makes the limiter's clock move only when it sleeps, and
+// records the waits it asks for.
+func fakeRateClock(l *rateLimiter) *[]time.Duration {
+	var (
+		mu    sync.Mutex
+		now   = l.last
+		waits []time.Duration
+	)
+
+	l.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return now
+	}
+
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		waits = append(waits, d)
+		now = now.Add(d)
+
+		return nil
+	}
+
+	return &waits
+}
+
+func TestRateLimiter_Throttles(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(10, 2)
+	waits := fakeRateClock(limiter)
+
+	for range 4 {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The burst of 2 goes out at once; each further request waits 100ms.
+	if len(*waits) != 2 || (*waits)[0] != 100*time.Millisecond || (*waits)[1] != 100*time.Millisecond {
+		t.Errorf("expected two 100ms waits, got %v", *waits)
+	}
+
+	if stats := limiter.stats(); stats.Throttled != 2 || stats.Wait != 200*time.Millisecond {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiter_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(1, 1)
+
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if limiter.tokens < -0.5 || limiter.tokens > 0.5 {
+		t.Errorf("expected the cancelled request to give its token back, got %v tokens", limiter.tokens)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithRateLimit(20, 1))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	for range 3 {
+		if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if stats := client.Stats().RateLimit; !stats.Enabled || stats.Throttled == 0 {
+		t.Errorf("expected requests to wait for the limit, got %+v", stats)
+	}
+
+	if snapshot := client.EffectiveConfig(); snapshot.RateLimit != 20 || snapshot.RateLimitBurst != 1 {
+		t.Errorf("unexpected snapshot %v/%d", snapshot.RateLimit, snapshot.RateLimitBurst)
+	}
+
+	for _, opt := range []Option{
+		WithRateLimit(0, 1),
+		WithRateLimit(maxRateLimit+1, 1),
+		WithRateLimit(10, 0),
+	} {
+		if client := New("http://localhost", opt); client.options.rateLimit != 0 || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+}
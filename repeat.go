@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	minRepeatInterval = time.Second
+	maxRepeatInterval = 24 * time.Hour
+	maxRepeatCount    = 100
+)
+
+// RepeatCondition reports whether an alert repeated with [WithRepeat] has
+// been dealt with, so repeats stop. It is given the alert's receipt,
+// which is updated by the events passed to [Client.ObserveAckEvent].
+type RepeatCondition func(receipt AckReceipt) bool
+
+// UntilAcked stops repeats once the alert's issue is acknowledged or
+// resolved.
+func UntilAcked(receipt AckReceipt) bool {
+	return !receipt.AckedAt.IsZero() || receipt.Status == AckResolved
+}
+
+// UntilResolved stops repeats once the alert's issue is resolved, even
+// if it was acknowledged.
+func UntilResolved(receipt AckReceipt) bool {
+	return receipt.Status == AckResolved
+}
+
+// repeater sends critical alerts again for [WithRepeat], with the wait
+// doubling after every repeat, until their receipts meet the condition.
+type repeater struct {
+	c        *Client
+	ctx      context.Context //nolint:containedctx // repeats run in the background, until Close
+	interval time.Duration
+	max      int
+	until    RepeatCondition
+
+	mu      sync.Mutex
+	entries map[string]*repeatEntry
+	closed  bool
+}
+
+type repeatEntry struct {
+	alert   *types.Alert
+	repeats int
+	timer   *time.Timer
+}
+
+func newRepeater(ctx context.Context, c *Client, interval time.Duration, maxRepeats int, until RepeatCondition) *repeater {
+	if until == nil {
+		until = UntilAcked
+	}
+
+	return &repeater{
+		c:        c,
+		ctx:      ctx,
+		interval: interval,
+		max:      maxRepeats,
+		until:    until,
+		entries:  make(map[string]*repeatEntry),
+	}
+}
+
+// schedule starts repeating the critical alerts of a batch that was sent.
+// An alert that is already repeated replaces the alert sent next, and a
+// resolved alert stops the repeats of its issue.
+func (r *repeater) schedule(alerts []*types.Alert) {
+	critical := make([]*types.Alert, 0, len(alerts))
+
+	r.mu.Lock()
+
+	for _, alert := range alerts {
+		correlationID := strings.TrimSpace(alert.CorrelationID)
+		if correlationID == "" || r.closed {
+			continue
+		}
+
+		entry, ok := r.entries[correlationID]
+
+		switch {
+		case normalizeSeverity(alert.Severity) == types.AlertResolved:
+			if ok {
+				entry.timer.Stop()
+				delete(r.entries, correlationID)
+			}
+		case !highPriority([]*types.Alert{alert}):
+		case ok:
+			entry.alert = alert
+		default:
+			entry = &repeatEntry{alert: alert}
+			entry.timer = time.AfterFunc(r.interval, func() { r.repeat(correlationID, entry) })
+			r.entries[correlationID] = entry
+			critical = append(critical, alert)
+		}
+	}
+
+	r.mu.Unlock()
+
+	r.c.acks.track(critical, 0)
+}
+
+// repeat sends entry again, unless its receipt meets the condition, and
+// schedules the next repeat.
+func (r *repeater) repeat(correlationID string, entry *repeatEntry) {
+	if receipt, ok := r.c.acks.receipt(correlationID); ok && r.until(receipt) {
+		r.stop(correlationID, entry)
+		return
+	}
+
+	r.mu.Lock()
+	alert, current := entry.alert, r.entries[correlationID] == entry && !r.closed
+	r.mu.Unlock()
+
+	if !current {
+		return
+	}
+
+	silenced := r.c.silences != nil && len(r.c.silences.filter([]*types.Alert{alert}, r.c.options.requestLogger)) == 0
+	if !silenced {
+		if _, err := r.c.postAlerts(r.ctx, []*types.Alert{alert}, nil); err != nil {
+			r.c.options.requestLogger.Warnf("failed to repeat alert %q: %s", alert.Header, err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries[correlationID] != entry {
+		return
+	}
+
+	entry.repeats++
+
+	if r.closed || entry.repeats >= r.max {
+		delete(r.entries, correlationID)
+		return
+	}
+
+	entry.timer.Reset(repeatDelay(r.interval, entry.repeats))
+}
+
+// repeatDelay returns the wait before the repeat after the given number
+// of repeats: interval doubled that many times, up to a day.
+func repeatDelay(interval time.Duration, repeats int) time.Duration {
+	delay := interval
+
+	for range repeats {
+		if delay *= 2; delay >= maxRepeatInterval {
+			return maxRepeatInterval
+		}
+	}
+
+	return delay
+}
+
+// stop drops entry if it is still the one repeated for correlationID.
+func (r *repeater) stop(correlationID string, entry *repeatEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries[correlationID] == entry {
+		delete(r.entries, correlationID)
+	}
+}
+
+// close stops all repeats.
+func (r *repeater) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	for _, entry := range r.entries {
+		entry.timer.Stop()
+	}
+}
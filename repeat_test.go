@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newRepeatClient returns a client sending to an alert recorder that
+// repeats alerts every 10ms, doubling, up to maxRepeats times.
+func newRepeatClient(t *testing.T, maxRepeats int, until RepeatCondition) (*Client, *alertRecorder) {
+	t.Helper()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithRepeat(time.Second, maxRepeats, until))
+	client.options.repeatInterval = 10 * time.Millisecond
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client, server
+}
+
+// waitForBatches waits until server has received n batches.
+func waitForBatches(t *testing.T, server *alertRecorder, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(server.received()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d batches, got %d", n, len(server.received()))
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithRepeat(t *testing.T) {
+	t.Parallel()
+
+	client, server := newRepeatClient(t, 3, nil)
+
+	warning := ackAlert("disk")
+	warning.Severity = types.AlertWarning
+
+	if err := client.Send(context.Background(), ackAlert("checkout"), warning, ackAlert("")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitForBatches(t, server, 4)
+	time.Sleep(150 * time.Millisecond)
+
+	batches := server.received()
+	if len(batches) != 4 {
+		t.Fatalf("expected the alert to be repeated 3 times, got %d batches", len(batches))
+	}
+
+	for _, batch := range batches[1:] {
+		if len(batch) != 1 || batch[0].CorrelationID != "checkout" {
+			t.Errorf("expected only the critical alert to be repeated, got %+v", batch)
+		}
+	}
+}
+
+func TestWithRepeat_StopsWhenAcked(t *testing.T) {
+	t.Parallel()
+
+	client, server := newRepeatClient(t, 100, UntilAcked)
+
+	if err := client.Send(context.Background(), ackAlert("checkout")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.ObserveAckEvent(AckEvent{Type: EventAlertCreated, IssueID: "I1", CorrelationID: "checkout"})
+	waitForBatches(t, server, 2)
+	client.ObserveAckEvent(AckEvent{Type: EventAlertAcked, IssueID: "I1", By: "alice"})
+
+	// One repeat may already be under way when the ack arrives.
+	time.Sleep(50 * time.Millisecond)
+	sent := len(server.received())
+	time.Sleep(200 * time.Millisecond)
+
+	if got := len(server.received()); got != sent {
+		t.Errorf("expected repeats to stop after the ack, got %d batches, then %d", sent, got)
+	}
+
+	if receipt, _ := client.AckReceipt("checkout"); receipt.Status != AckAcked || !receipt.Deadline.IsZero() {
+		t.Errorf("expected an acknowledged receipt without a deadline, got %+v", receipt)
+	}
+}
+
+func TestWithRepeat_StopsWhenResolved(t *testing.T) {
+	t.Parallel()
+
+	client, server := newRepeatClient(t, 100, UntilResolved)
+
+	if err := client.Send(context.Background(), ackAlert("checkout")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitForBatches(t, server, 2)
+
+	resolved := ackAlert("checkout")
+	resolved.Severity = types.AlertResolved
+
+	if err := client.Send(context.Background(), resolved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	sent := len(server.received())
+	time.Sleep(200 * time.Millisecond)
+
+	if got := len(server.received()); got != sent {
+		t.Errorf("expected a resolved alert to stop the repeats, got %d batches, then %d", sent, got)
+	}
+}
+
+func TestRepeatConditions(t *testing.T) {
+	t.Parallel()
+
+	acked := AckReceipt{Status: AckAcked, AckedAt: time.Now()}
+	late := AckReceipt{Status: AckEscalated, AckedAt: time.Now()}
+	resolved := AckReceipt{Status: AckResolved}
+	pending := AckReceipt{Status: AckPending}
+
+	if !UntilAcked(acked) || !UntilAcked(late) || !UntilAcked(resolved) || UntilAcked(pending) {
+		t.Error("unexpected UntilAcked result")
+	}
+
+	if UntilResolved(acked) || !UntilResolved(resolved) || UntilResolved(pending) {
+		t.Error("unexpected UntilResolved result")
+	}
+}
+
+func TestRepeatDelay(t *testing.T) {
+	t.Parallel()
+
+	for repeats, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute} {
+		if got := repeatDelay(time.Minute, repeats); got != want {
+			t.Errorf("expected %v after %d repeats, got %v", want, repeats, got)
+		}
+	}
+
+	if got := repeatDelay(time.Hour, maxRepeatCount); got != maxRepeatInterval {
+		t.Errorf("expected the delay to be capped at %v, got %v", maxRepeatInterval, got)
+	}
+}
+
+func TestWithRepeat_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, opt := range []Option{
+		WithRepeat(time.Millisecond, 3, nil),
+		WithRepeat(48*time.Hour, 3, nil),
+		WithRepeat(time.Minute, 0, nil),
+		WithRepeat(time.Minute, maxRepeatCount+1, nil),
+	} {
+		client := New("http://localhost", opt)
+		if client.options.repeatInterval != 0 || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// defaultSpoolThreshold is the size above which a streamed request body is
+// buffered to a temporary file instead of memory.
+const defaultSpoolThreshold = 1 << 20
+
+// errBodyTooLarge is returned by newReplayBody when the reader holds more
+// than the permitted number of bytes.
+var errBodyTooLarge = errors.New("body exceeds the size limit")
+
+// replayBody is a request body read from an [io.Reader] that can be sent
+// any number of times. Bodies up to the spool threshold are kept in memory;
+// larger bodies are copied to a temporary file, which is removed by Close.
+// Every attempt made for the request, including retries and the resend
+// after an [Authenticator] refreshes its credentials, reads the body from
+// the start, so retries never send a truncated body.
+type replayBody struct {
+	data []byte
+	file *os.File
+	size int64
+}
+
+// newReplayBody reads r to the end. If limit is positive and r holds more
+// than limit bytes, errBodyTooLarge is returned and nothing is kept.
+func newReplayBody(r io.Reader, threshold, limit int64) (*replayBody, error) {
+	if r == nil {
+		return nil, errors.New("body reader must not be nil")
+	}
+
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(r, threshold+1)); err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	var body *replayBody
+
+	if int64(buf.Len()) <= threshold {
+		body = newReplayBodyBytes(buf.Bytes())
+	} else {
+		file, err := os.CreateTemp("", "slackmgr-body-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create body spool file: %w", err)
+		}
+
+		body = &replayBody{file: file}
+
+		if body.size, err = io.Copy(file, io.MultiReader(&buf, r)); err != nil {
+			_ = body.Close()
+			return nil, fmt.Errorf("failed to spool body: %w", err)
+		}
+	}
+
+	if limit > 0 && body.size > limit {
+		_ = body.Close()
+		return nil, errBodyTooLarge
+	}
+
+	return body, nil
+}
+
+// newReplayBodyBytes wraps data, which must not be modified afterwards.
+func newReplayBodyBytes(data []byte) *replayBody {
+	return &replayBody{data: data, size: int64(len(data))}
+}
+
+// Close removes the temporary file, if one was created.
+func (b *replayBody) Close() error {
+	if b == nil || b.file == nil {
+		return nil
+	}
+
+	name := b.file.Name()
+	err := b.file.Close()
+	b.file = nil
+
+	return errors.Join(err, os.Remove(name))
+}
+
+// open returns a reader positioned at the start of the body. Readers are
+// independent of each other and need not be closed.
+func (b *replayBody) open() io.Reader {
+	if b.file != nil {
+		return io.NewSectionReader(b.file, 0, b.size)
+	}
+
+	return bytes.NewReader(b.data)
+}
+
+type replayBodyKey struct{}
+
+// withReplayBody returns a context that makes every attempt of a request
+// made with it send body.
+func withReplayBody(ctx context.Context, body *replayBody) context.Context {
+	return context.WithValue(ctx, replayBodyKey{}, body)
+}
+
+// attachReplayBody is called before every attempt of every request. If the
+// request context carries a [replayBody], a fresh reader for it is
+// installed, together with the length and a GetBody function so redirects
+// and authentication retries can also resend it.
+func attachReplayBody(request *http.Request) {
+	body, ok := request.Context().Value(replayBodyKey{}).(*replayBody)
+	if !ok {
+		return
+	}
+
+	request.Body = io.NopCloser(body.open())
+	request.ContentLength = body.size
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(body.open()), nil
+	}
+
+	if body.size == 0 {
+		request.Body = http.NoBody
+	}
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewReplayBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		size      int
+		threshold int64
+		limit     int64
+		spooled   bool
+		wantErr   error
+	}{
+		{"memory", 10, 16, 0, false, nil},
+		{"at threshold", 16, 16, 0, false, nil},
+		{"spooled", 100, 16, 0, true, nil},
+		{"spooled at limit", 100, 16, 100, true, nil},
+		{"spooled over limit", 101, 16, 100, false, errBodyTooLarge},
+		{"memory over limit", 11, 16, 10, false, errBodyTooLarge},
+		{"empty", 0, 16, 0, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := bytes.Repeat([]byte("x"), tt.size)
+
+			body, err := newReplayBody(struct{ io.Reader }{bytes.NewReader(data)}, tt.threshold, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if (body.file != nil) != tt.spooled {
+				t.Errorf("expected spooled=%v", tt.spooled)
+			}
+
+			for range 2 {
+				got, _ := io.ReadAll(body.open())
+				if !bytes.Equal(got, data) {
+					t.Fatalf("expected %d bytes, got %d", len(data), len(got))
+				}
+			}
+
+			var name string
+			if body.file != nil {
+				name = body.file.Name()
+			}
+
+			if err := body.Close(); err != nil {
+				t.Fatalf("unexpected close error: %v", err)
+			}
+
+			if name != "" {
+				if _, err := os.Stat(name); !os.IsNotExist(err) {
+					t.Errorf("expected spool file %s to be removed, got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewReplayBody_ReadError(t *testing.T) {
+	t.Parallel()
+
+	failing := io.MultiReader(strings.NewReader(strings.Repeat("x", 64)), iotest.ErrReader(errors.New("disk on fire")))
+
+	if _, err := newReplayBody(failing, 16, 0); err == nil || !strings.Contains(err.Error(), "failed to spool body") {
+		t.Fatalf("expected spool error, got %v", err)
+	}
+}
+
+func TestUploadAssetFrom_RetryResendsFullBody(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("0123456789"), 100)
+
+	var (
+		mu       sync.Mutex
+		received [][]byte
+		lengths  []int64
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ping":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"assets":[]}`))
+		case r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			received = append(received, data)
+			lengths = append(lengths, r.ContentLength)
+			attempt := len(received)
+			mu.Unlock()
+
+			if attempt == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"kind":"icon","name":"logo.png"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithBodySpoolThreshold(64))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	asset, err := client.UploadAssetFrom(context.Background(), AssetIcon, "logo.png", "image/png", struct{ io.Reader }{bytes.NewReader(content)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if asset.Name != "logo.png" {
+		t.Errorf("unexpected asset %+v", asset)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(received))
+	}
+
+	for i, data := range received {
+		if !bytes.Equal(data, content) {
+			t.Errorf("attempt %d: expected %d bytes, got %d", i+1, len(content), len(data))
+		}
+
+		if lengths[i] != int64(len(content)) {
+			t.Errorf("attempt %d: expected Content-Length %d, got %d", i+1, len(content), lengths[i])
+		}
+	}
+}
+
+func TestUploadAssetFrom_Validation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&assetServer{stored: map[string]*Asset{}})
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := client.UploadAssetFrom(ctx, AssetIcon, "empty.png", "", strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Errorf("expected empty error, got %v", err)
+	}
+
+	tooLarge := io.LimitReader(neverEnding('x'), maxAssetSize+1)
+	if _, err := client.UploadAssetFrom(ctx, AssetIcon, "huge.png", "", tooLarge); err == nil || !strings.Contains(err.Error(), "exceeds the") {
+		t.Errorf("expected size error, got %v", err)
+	}
+
+	if _, err := client.UploadAssetFrom(ctx, AssetIcon, "nil.png", "", nil); err == nil {
+		t.Error("expected error for nil reader")
+	}
+
+	asset, err := client.UploadAssetFrom(ctx, AssetEmoji, "ok", "", strings.NewReader("\x89PNG\r\n\x1a\nimage"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if asset.ContentType != "image/png" {
+		t.Errorf("expected detected content type image/png, got %q", asset.ContentType)
+	}
+}
+
+type neverEnding byte
+
+func (b neverEnding) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b)
+	}
+
+	return len(p), nil
+}
@@ -1,5 +1,13 @@
 package client
 
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
 // RequestLogger is the interface used by [Client] for logging HTTP requests
 // and errors. Implement this interface to integrate with your logging library
 // and supply the implementation via [WithRequestLogger].
@@ -16,3 +24,68 @@ type NoopLogger struct{}
 func (l *NoopLogger) Errorf(_ string, _ ...any) {}
 func (l *NoopLogger) Warnf(_ string, _ ...any)  {}
 func (l *NoopLogger) Debugf(_ string, _ ...any) {}
+
+// ContextFieldExtractor returns the fields, such as a trace ID or tenant,
+// that should be attached to log messages written while handling a request
+// with ctx. See [WithContextFields].
+type ContextFieldExtractor func(ctx context.Context) map[string]any
+
+// FieldLogger is an optional extension of [RequestLogger] for structured
+// loggers. When [WithContextFields] is set and the logger implements
+// FieldLogger, the client calls WithFields and logs through the returned
+// logger instead of appending the fields to the message.
+type FieldLogger interface {
+	RequestLogger
+	WithFields(fields map[string]any) RequestLogger
+}
+
+// fieldSuffixLogger appends formatted fields to every message.
+type fieldSuffixLogger struct {
+	next   RequestLogger
+	suffix string
+}
+
+func (l *fieldSuffixLogger) Errorf(format string, v ...any) {
+	l.next.Errorf(format+"%s", append(v, l.suffix)...)
+}
+
+func (l *fieldSuffixLogger) Warnf(format string, v ...any) {
+	l.next.Warnf(format+"%s", append(v, l.suffix)...)
+}
+
+func (l *fieldSuffixLogger) Debugf(format string, v ...any) {
+	l.next.Debugf(format+"%s", append(v, l.suffix)...)
+}
+
+// logger returns the request logger, enriched with the fields extracted
+// from ctx when [WithContextFields] is set.
+func (c *Client) logger(ctx context.Context) RequestLogger { //nolint:ireturn // the configured logger is user-supplied
+	logger := c.options.requestLogger
+
+	if c.options.contextFields == nil || ctx == nil {
+		return logger
+	}
+
+	fields := c.options.contextFields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	return withFields(logger, fields)
+}
+
+// withFields returns a logger that attaches fields to every message, using
+// [FieldLogger] if logger implements it.
+func withFields(logger RequestLogger, fields map[string]any) RequestLogger { //nolint:ireturn // FieldLogger.WithFields returns the interface
+	if fl, ok := logger.(FieldLogger); ok {
+		return fl.WithFields(fields)
+	}
+
+	var suffix strings.Builder
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		fmt.Fprintf(&suffix, " %s=%v", key, fields[key])
+	}
+
+	return &fieldSuffixLogger{next: logger, suffix: suffix.String()}
+}
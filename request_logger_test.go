@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+type ctxKey string
+
+func tenantFields(ctx context.Context) map[string]any {
+	tenant, _ := ctx.Value(ctxKey("tenant")).(string)
+	if tenant == "" {
+		return nil
+	}
+
+	return map[string]any{"tenant": tenant, "traceId": "abc123"}
+}
+
+// fieldRecordingLogger is a FieldLogger that records the fields of every
+// warning.
+type fieldRecordingLogger struct {
+	NoopLogger
+
+	mu     sync.Mutex
+	fields []map[string]any
+}
+
+func (l *fieldRecordingLogger) WithFields(fields map[string]any) RequestLogger { //nolint:ireturn // signature fixed by FieldLogger
+	return &fieldWarnLogger{parent: l, fields: fields}
+}
+
+type fieldWarnLogger struct {
+	NoopLogger
+
+	parent *fieldRecordingLogger
+	fields map[string]any
+}
+
+func (l *fieldWarnLogger) Warnf(_ string, _ ...any) {
+	l.parent.mu.Lock()
+	defer l.parent.mu.Unlock()
+
+	l.parent.fields = append(l.parent.fields, l.fields)
+}
+
+func newDeprecatedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.Header().Set("Deprecation", "true")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWithContextFields_AppendsFields(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	client := New(newDeprecatedServer(t).URL, WithRequestLogger(logger), WithContextFields(tenantFields))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey("tenant"), "payments")
+
+	if err := client.Send(ctx, types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := logger.warnings()
+	if len(warnings) != 1 || !strings.HasSuffix(warnings[0], " tenant=payments traceId=abc123") {
+		t.Errorf("expected fields to be appended, got %q", warnings)
+	}
+}
+
+func TestWithContextFields_FieldLogger(t *testing.T) {
+	t.Parallel()
+
+	logger := &fieldRecordingLogger{}
+	client := New(newDeprecatedServer(t).URL, WithRequestLogger(logger), WithContextFields(tenantFields))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey("tenant"), "payments")
+
+	if err := client.Send(ctx, types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if len(logger.fields) != 1 || logger.fields[0]["tenant"] != "payments" {
+		t.Errorf("expected fields to be passed to WithFields, got %v", logger.fields)
+	}
+}
+
+func TestClientLogger_NoFields(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	client := New("http://example.com", WithRequestLogger(logger), WithContextFields(tenantFields))
+
+	if got := client.logger(context.Background()); got != RequestLogger(logger) {
+		t.Errorf("expected the plain logger when the context has no fields, got %T", got)
+	}
+}
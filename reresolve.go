@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// reresolveAfterFailures is the number of consecutive connection failures
+// after which [WithReresolveOnFailure] drops the idle connections.
+const reresolveAfterFailures = 3
+
+// reresolver closes the idle connections of a transport after repeated
+// connection failures. Go resolves the host name for every new connection,
+// so the next attempt dials whatever address the name points to now rather
+// than reusing a connection pinned to the old one.
+type reresolver struct {
+	idle     interface{ CloseIdleConnections() }
+	logger   RequestLogger
+	failures atomic.Int32
+	resets   atomic.Int64
+}
+
+func newReresolver(idle interface{ CloseIdleConnections() }, logger RequestLogger) *reresolver {
+	return &reresolver{idle: idle, logger: logger}
+}
+
+// record registers the outcome of one attempt, and drops the idle
+// connections once reresolveAfterFailures attempts in a row failed.
+func (r *reresolver) record(failed bool) {
+	if !failed {
+		r.failures.Store(0)
+		return
+	}
+
+	if r.failures.Add(1) < reresolveAfterFailures {
+		return
+	}
+
+	r.failures.Store(0)
+	r.resets.Add(1)
+	r.idle.CloseIdleConnections()
+	r.logger.Warnf("%d consecutive connection failures; closed idle connections so the host name is resolved again", reresolveAfterFailures)
+}
+
+// transport wraps next so that the outcome of every attempt is recorded.
+// Only connection errors count as failures: any response, even a 5xx,
+// shows that the address still reaches a server.
+func (r *reresolver) transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+
+		// Cancellation says nothing about the server's address.
+		if err == nil || req.Context().Err() == nil {
+			r.record(err != nil)
+		}
+
+		return resp, err
+	})
+}
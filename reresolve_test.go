@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+type countingCloser struct {
+	closed int
+}
+
+func (c *countingCloser) CloseIdleConnections() {
+	c.closed++
+}
+
+func TestReresolver_ClosesAfterConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+
+	closer := &countingCloser{}
+	r := newReresolver(closer, &NoopLogger{})
+
+	r.record(true)
+	r.record(true)
+	r.record(false)
+	r.record(true)
+	r.record(true)
+
+	if closer.closed != 0 {
+		t.Fatalf("expected a success to reset the count, got %d closes", closer.closed)
+	}
+
+	r.record(true)
+
+	if closer.closed != 1 || r.resets.Load() != 1 {
+		t.Errorf("expected one close after three failures in a row, got %d", closer.closed)
+	}
+}
+
+func TestWithReresolveOnFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	listener := listenTCP(t)
+	deadURL := "http://" + listener.Addr().String()
+	_ = listener.Close()
+
+	logger := &recordingLogger{}
+
+	client := New(server.URL, WithReresolveOnFailure(), WithRequestLogger(logger))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	// Refused connections are not retried, so each request is one attempt.
+	dead := client.RestyClient().Clone().SetBaseURL(deadURL)
+	for range reresolveAfterFailures {
+		if _, err := dead.R().SetContext(context.Background()).Get("ping"); err == nil {
+			t.Fatal("expected the request to a closed port to fail")
+		}
+	}
+
+	if got := client.Stats().Reresolves; got != 1 {
+		t.Errorf("expected the idle connections to be closed once, got %d", got)
+	}
+
+	want := strconv.Itoa(reresolveAfterFailures) + " consecutive connection failures; closed idle connections so the host name is resolved again"
+	if warnings := logger.warnings(); !slices.Contains(warnings, want) {
+		t.Errorf("expected a warning about the reset, got %q", warnings)
+	}
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Errorf("expected sends to work after the reset, got %v", err)
+	}
+}
+
+func TestWithReresolveOnFailure_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	if !New("http://localhost", WithReresolveOnFailure()).EffectiveConfig().ReresolveOnFailure {
+		t.Error("expected the snapshot to report the option")
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Response is an HTTP response from the manager API, as seen by a retry
+// condition set with [WithRetryCondition]. It does not expose the
+// underlying HTTP library.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Body is the response body.
+	Body []byte
+
+	// raw is the resty response, kept for the deprecated [WithRetryPolicy].
+	raw *resty.Response
+}
+
+// newResponse converts a resty response. It returns nil if r is nil or was
+// not received, as when the request failed with a connection error.
+func newResponse(r *resty.Response) *Response {
+	if r == nil || r.RawResponse == nil {
+		return nil
+	}
+
+	return &Response{
+		StatusCode: r.StatusCode(),
+		Header:     r.Header(),
+		Body:       r.Body(),
+		raw:        r,
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryStrategy decides whether a failed request should be retried and how
+// long to wait first. ctx is the request's context, so a strategy can give
+// up when the deadline is too close for another attempt. attempt is the
+// number of the attempt that just finished, starting at 1. resp is nil
+// when no response was received, in which case err is set.
+//
+// A zero wait uses the client's backoff: the Retry-After header,
+// [WithAdaptiveBackoff] and exponential backoff between [WithRetryWaitTime]
+// and [WithRetryMaxWaitTime]. A non-zero wait is clamped to that range.
+// The total number of attempts is still bounded by [WithRetryCount].
+type RetryStrategy func(ctx context.Context, attempt int, resp *Response, err error) (retry bool, wait time.Duration)
+
+// conditionStrategy adapts a retry condition set with [WithRetryCondition].
+func conditionStrategy(condition func(*Response, error) bool) RetryStrategy {
+	return func(_ context.Context, _ int, resp *Response, err error) (bool, time.Duration) {
+		return condition(resp, err), 0
+	}
+}
+
+// retrier applies the global and per-endpoint retry settings to a resty
+// client. resty only supports client-wide settings, so the client is
+// configured with the widest of them, and retrier enforces each request's
+// own count and wait range.
+type retrier struct {
+	global    RetryConfig
+	endpoints map[Endpoint]RetryConfig
+	next      resty.RetryAfterFunc
+
+	// waits hands the wait chosen by a strategy from the retry condition
+	// to the retry-after callback, which receive the same request.
+	waits sync.Map // *resty.Request -> time.Duration
+}
+
+// newRetrier returns a retrier for the client's options, using next for
+// the Retry-After header and adaptive backoff.
+func (c *Client) newRetrier(next resty.RetryAfterFunc) *retrier {
+	global := RetryConfig{
+		Count:       c.options.retryCount,
+		WaitTime:    c.options.retryWaitTime,
+		MaxWaitTime: c.options.retryMaxWaitTime,
+		Strategy:    c.retryStrategy(),
+	}
+
+	endpoints := make(map[Endpoint]RetryConfig, len(c.options.endpointRetry))
+	for endpoint, cfg := range c.options.endpointRetry {
+		endpoints[endpoint] = cfg.resolve(global)
+	}
+
+	return &retrier{global: global, endpoints: endpoints, next: next}
+}
+
+// bounds returns the widest retry count and wait range of all settings,
+// which configure resty. Its Strategy is not used.
+func (rt *retrier) bounds() RetryConfig {
+	bounds := rt.global
+
+	for _, cfg := range rt.endpoints {
+		bounds.Count = max(bounds.Count, cfg.Count)
+		bounds.WaitTime = min(bounds.WaitTime, cfg.WaitTime)
+		bounds.MaxWaitTime = max(bounds.MaxWaitTime, cfg.MaxWaitTime)
+	}
+
+	return bounds
+}
+
+// config returns the retry settings for request.
+func (rt *retrier) config(request *resty.Request) RetryConfig {
+	if request == nil {
+		return rt.global
+	}
+
+	if cfg, ok := rt.endpoints[endpointOf(request.Context(), request.Method)]; ok {
+		return cfg
+	}
+
+	return rt.global
+}
+
+// condition is the resty retry condition.
+func (rt *retrier) condition(r *resty.Response, err error) bool {
+	ctx := context.Background()
+	attempt := 1
+
+	var request *resty.Request
+
+	if r != nil && r.Request != nil {
+		request = r.Request
+		ctx = request.Context()
+		attempt = request.Attempt
+	}
+
+	cfg := rt.config(request)
+	if attempt > cfg.Count {
+		return false
+	}
+
+	retry, wait := cfg.Strategy(ctx, attempt, newResponse(r), err)
+
+	if retry && wait > 0 && request != nil {
+		rt.waits.Store(request, min(max(wait, cfg.WaitTime), cfg.MaxWaitTime))
+	}
+
+	return retry
+}
+
+// retryAfter is the resty retry-after callback.
+func (rt *retrier) retryAfter(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if wait, ok := rt.waits.LoadAndDelete(resp.Request); ok {
+		return wait.(time.Duration), nil //nolint:forcetypeassert // only durations are stored
+	}
+
+	if wait, err := rt.next(client, resp); err != nil || wait != 0 {
+		return wait, err
+	}
+
+	cfg := rt.config(resp.Request)
+	attempt := max(resp.Request.Attempt-1, 0)
+	capped := math.Min(float64(cfg.MaxWaitTime), float64(cfg.WaitTime)*math.Exp2(float64(attempt)))
+
+	return max(time.Duration(capped/2+rand.Float64()*capped/2), cfg.WaitTime), nil //nolint:gosec // jitter does not need a secure source
+}
+
+// testHookRetryDelay, if set, returns the function that replaces the waits
+// before the retries of a client, or nil to keep them, so tests can retry
+// without sleeping.
+var testHookRetryDelay func(*Client) func(time.Duration) time.Duration //nolint:gochecknoglobals // set by tests only
+
+// retryDelayFunc returns the function set by testHookRetryDelay for c, or
+// nil.
+func (c *Client) retryDelayFunc() func(time.Duration) time.Duration {
+	if testHookRetryDelay == nil {
+		return nil
+	}
+
+	return testHookRetryDelay(c)
+}
+
+// withRetryDelay returns next with every wait replaced by the one delay
+// returns, or next itself if delay is nil.
+func withRetryDelay(next resty.RetryAfterFunc, delay func(time.Duration) time.Duration) resty.RetryAfterFunc {
+	if delay == nil {
+		return next
+	}
+
+	return func(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+		wait, err := next(client, resp)
+		if err == nil {
+			wait = delay(wait)
+		}
+
+		return wait, err
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithRetryStrategy(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var (
+		attempts []int
+		tenants  []any
+		waits    []time.Duration
+	)
+
+	client := New(server.URL,
+		WithRetryCount(5),
+		WithRetryWaitTime(100*time.Millisecond),
+		WithRetryMaxWaitTime(time.Second),
+		WithRetryStrategy(func(ctx context.Context, attempt int, resp *Response, err error) (bool, time.Duration) {
+			if resp != nil && resp.StatusCode == http.StatusOK {
+				return false, 0
+			}
+
+			attempts = append(attempts, attempt)
+			tenants = append(tenants, ctx.Value(ctxKey("tenant")))
+
+			return err == nil && resp.StatusCode == http.StatusServiceUnavailable, 150 * time.Millisecond
+		}),
+	)
+	setRetryDelay(t, client, func(wait time.Duration) time.Duration {
+		waits = append(waits, wait)
+		return 0
+	})
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.WithValue(context.Background(), ctxKey("tenant"), "payments")
+	if err := client.Send(ctx, types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("expected the retries to succeed, got %v", err)
+	}
+
+	if len(waits) != 2 || waits[0] != 150*time.Millisecond || waits[1] != 150*time.Millisecond {
+		t.Errorf("expected two waits of 150ms, got %v", waits)
+	}
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("expected attempts [1 2], got %v", attempts)
+	}
+
+	for _, tenant := range tenants {
+		if tenant != "payments" {
+			t.Errorf("expected the request context, got tenant %v", tenant)
+		}
+	}
+
+	if client.EffectiveConfig().RetryPolicy != "custom" {
+		t.Errorf("expected a custom retry policy in the config snapshot")
+	}
+}
+
+func TestWithRetryStrategy_StopsBeforeDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL,
+		WithRetryStrategy(func(ctx context.Context, _ int, resp *Response, err error) (bool, time.Duration) {
+			deadline, ok := ctx.Deadline()
+			if ok && time.Until(deadline) < time.Second {
+				return false, 0
+			}
+
+			return DefaultRetryCondition(resp, err), 0
+		}),
+	)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if err := client.Send(ctx, types.NewAlert(types.AlertError)); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected no retries close to the deadline, got %d attempts", calls.Load())
+	}
+}
+
+func TestRetrier_LastAttemptNotStored(t *testing.T) {
+	t.Parallel()
+
+	rt := &retrier{global: RetryConfig{
+		Count:       1,
+		WaitTime:    100 * time.Millisecond,
+		MaxWaitTime: time.Second,
+		Strategy: func(context.Context, int, *Response, error) (bool, time.Duration) {
+			return true, time.Second
+		},
+	}}
+
+	resp := createRestyResponse(t, 503)
+	resp.Request.Attempt = 2
+
+	if rt.condition(resp, nil) {
+		t.Error("expected no retry after the last attempt")
+	}
+
+	resp.Request.Attempt = 1
+
+	if !rt.condition(resp, nil) {
+		t.Fatal("expected the strategy's decision to be returned")
+	}
+
+	if wait, ok := rt.waits.Load(resp.Request); !ok || wait != time.Second {
+		t.Errorf("expected the strategy's wait to be stored, got %v", wait)
+	}
+}
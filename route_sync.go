@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// SyncMode selects whether [Client.SyncRoutes] changes anything.
+type SyncMode int
+
+const (
+	// SyncDryRun computes the changes without making them.
+	SyncDryRun SyncMode = iota
+
+	// SyncApply computes the changes and makes them.
+	SyncApply
+)
+
+// RouteAction is the kind of a [RouteChange].
+type RouteAction string
+
+const (
+	RouteCreate RouteAction = "create"
+	RouteUpdate RouteAction = "update"
+	RouteDelete RouteAction = "delete"
+)
+
+// RouteChange is one change made, or to be made, by [Client.SyncRoutes].
+type RouteChange struct {
+	Action RouteAction
+	Name   string
+
+	// Before is the existing rule; nil for a create.
+	Before *RoutingRule
+
+	// After is the desired rule, with the ID of Before for an update; nil
+	// for a delete.
+	After *RoutingRule
+
+	// Fields lists the JSON names of the fields an update changes.
+	Fields []string
+
+	// Applied reports whether the change was made.
+	Applied bool
+}
+
+// RouteSyncReport describes the outcome of [Client.SyncRoutes].
+type RouteSyncReport struct {
+	// Mode is the mode SyncRoutes was called with.
+	Mode SyncMode
+
+	// Changes lists the deletes, updates and creates, in the order they
+	// are applied.
+	Changes []RouteChange
+
+	// Unchanged lists the names of the desired rules that already match.
+	Unchanged []string
+}
+
+// Empty reports whether the existing rules already match the desired ones.
+func (r RouteSyncReport) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// SyncRoutes makes the routing rules match desired, for GitOps pipelines
+// that keep rules in version control. It fetches the existing rules,
+// computes the changes with [DiffRoutes] and, in [SyncApply] mode, makes
+// only those: rules that already match are not written. In [SyncDryRun]
+// mode nothing is changed, and the report shows what would be.
+//
+// If a change fails, SyncRoutes stops and returns the report, in which the
+// changes made so far have Applied set, together with the error. Running
+// it again makes the remaining changes. Use [Client.RunExclusive] to keep
+// concurrent syncs from interleaving.
+func (c *Client) SyncRoutes(ctx context.Context, desired []*RoutingRule, mode SyncMode) (RouteSyncReport, error) {
+	report := RouteSyncReport{Mode: mode}
+
+	if mode != SyncDryRun && mode != SyncApply {
+		return report, fmt.Errorf("invalid sync mode %d", mode)
+	}
+
+	actual, err := c.ListRoutes(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list routing rules: %w", err)
+	}
+
+	diff, err := DiffRoutes(desired, actual)
+	if err != nil {
+		return report, err
+	}
+
+	report.Changes = diff.changes(actual)
+
+	for _, rule := range desired {
+		if !slices.ContainsFunc(report.Changes, func(change RouteChange) bool { return change.Name == rule.Name }) {
+			report.Unchanged = append(report.Unchanged, rule.Name)
+		}
+	}
+
+	if mode == SyncDryRun {
+		return report, nil
+	}
+
+	for i := range report.Changes {
+		if err := c.applyRouteChange(ctx, report.Changes[i]); err != nil {
+			return report, err
+		}
+
+		report.Changes[i].Applied = true
+	}
+
+	return report, nil
+}
+
+// changes lists the changes of d in the order [Client.ApplyRouteDiff]
+// makes them, looking up the existing rule of each update in actual.
+func (d RouteDiff) changes(actual []*RoutingRule) []RouteChange {
+	byID := make(map[string]*RoutingRule, len(actual))
+	for _, rule := range actual {
+		if rule != nil {
+			byID[rule.ID] = rule
+		}
+	}
+
+	changes := make([]RouteChange, 0, len(d.Delete)+len(d.Update)+len(d.Create))
+
+	for _, rule := range d.Delete {
+		changes = append(changes, RouteChange{Action: RouteDelete, Name: rule.Name, Before: rule})
+	}
+
+	for _, rule := range d.Update {
+		before := byID[rule.ID]
+		changes = append(changes, RouteChange{Action: RouteUpdate, Name: rule.Name, Before: before, After: rule, Fields: changedRouteFields(before, rule)})
+	}
+
+	for _, rule := range d.Create {
+		changes = append(changes, RouteChange{Action: RouteCreate, Name: rule.Name, After: rule})
+	}
+
+	return changes
+}
+
+// changedRouteFields returns the JSON names of the fields that differ
+// between before and after, comparing severities as a set.
+func changedRouteFields(before, after *RoutingRule) []string {
+	if before == nil {
+		return nil
+	}
+
+	var fields []string
+
+	if before.RouteKey != after.RouteKey {
+		fields = append(fields, "routeKey")
+	}
+
+	if !slices.Equal(sortedSeverities(before.Severities), sortedSeverities(after.Severities)) {
+		fields = append(fields, "severities")
+	}
+
+	if before.SlackChannelID != after.SlackChannelID {
+		fields = append(fields, "slackChannelId")
+	}
+
+	if before.Priority != after.Priority {
+		fields = append(fields, "priority")
+	}
+
+	return fields
+}
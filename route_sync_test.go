@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// routesServer serves a fixed list of routing rules and records the writes
+// made to it. Creates fail if failCreate is set.
+type routesServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	writes []string
+}
+
+func newRoutesServer(t *testing.T, failCreate bool) *routesServer {
+	t.Helper()
+
+	s := &routesServer{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ping":
+			w.WriteHeader(http.StatusOK)
+			return
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"routes":[` +
+				`{"id":"r1","name":"db","routeKey":"db","slackChannelId":"C1","severities":["error","warning"]},` +
+				`{"id":"r2","name":"web","routeKey":"web","slackChannelId":"C2"},` +
+				`{"id":"r3","name":"legacy","routeKey":"old","slackChannelId":"C3"}]}`))
+
+			return
+		}
+
+		s.mu.Lock()
+		s.writes = append(s.writes, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		if failCreate && r.Method == http.MethodPost {
+			http.Error(w, `{"error":"quota exceeded"}`, http.StatusConflict)
+			return
+		}
+
+		var rule RoutingRule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+
+		_ = json.NewEncoder(w).Encode(rule)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *routesServer) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.writes...)
+}
+
+func desiredRoutes() []*RoutingRule {
+	return []*RoutingRule{
+		{Name: "db", RouteKey: "db", SlackChannelID: "C1", Severities: []types.AlertSeverity{types.AlertWarning, types.AlertError}},
+		{Name: "web", RouteKey: "web", SlackChannelID: "C9", Priority: 2},
+		{Name: "queue", RouteKey: "queue", SlackChannelID: "C4"},
+	}
+}
+
+func TestSyncRoutes_DryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newRoutesServer(t, false)
+	client := connectTo(t, server.URL)
+
+	report, err := client.SyncRoutes(context.Background(), desiredRoutes(), SyncDryRun)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if writes := server.recorded(); len(writes) != 0 {
+		t.Errorf("expected a dry run not to write, got %v", writes)
+	}
+
+	got := make([]string, 0, len(report.Changes))
+	for _, change := range report.Changes {
+		got = append(got, string(change.Action)+" "+change.Name)
+
+		if change.Applied {
+			t.Errorf("expected %s not to be applied", change.Name)
+		}
+	}
+
+	if want := []string{"delete legacy", "update web", "create queue"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected changes %v, got %v", want, got)
+	}
+
+	if update := report.Changes[1]; update.Before.SlackChannelID != "C2" || update.After.ID != "r2" || !reflect.DeepEqual(update.Fields, []string{"slackChannelId", "priority"}) {
+		t.Errorf("unexpected update %+v", update)
+	}
+
+	if !reflect.DeepEqual(report.Unchanged, []string{"db"}) || report.Empty() {
+		t.Errorf("expected db to be unchanged, got %v", report.Unchanged)
+	}
+}
+
+func TestSyncRoutes_Apply(t *testing.T) {
+	t.Parallel()
+
+	server := newRoutesServer(t, false)
+	client := connectTo(t, server.URL)
+
+	report, err := client.SyncRoutes(context.Background(), desiredRoutes(), SyncApply)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"DELETE /routes/r3", "PUT /routes/r2", "POST /routes"}; !reflect.DeepEqual(server.recorded(), want) {
+		t.Errorf("expected writes %v, got %v", want, server.recorded())
+	}
+
+	for _, change := range report.Changes {
+		if !change.Applied {
+			t.Errorf("expected %s to be applied", change.Name)
+		}
+	}
+}
+
+func TestSyncRoutes_StopsAtFirstFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newRoutesServer(t, true)
+	client := connectTo(t, server.URL)
+
+	report, err := client.SyncRoutes(context.Background(), desiredRoutes(), SyncApply)
+	if err == nil || !strings.Contains(err.Error(), `failed to create routing rule "queue"`) {
+		t.Fatalf("expected the create to fail, got %v", err)
+	}
+
+	applied := []bool{report.Changes[0].Applied, report.Changes[1].Applied, report.Changes[2].Applied}
+	if !reflect.DeepEqual(applied, []bool{true, true, false}) {
+		t.Errorf("expected only the create to be left, got %v", applied)
+	}
+}
+
+func TestSyncRoutes_InvalidMode(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("http://localhost").SyncRoutes(context.Background(), nil, SyncMode(7)); err == nil {
+		t.Error("expected an invalid mode error")
+	}
+}
+
+// connectTo returns a client connected to url, closed when the test ends.
+func connectTo(t *testing.T, url string) *Client {
+	t.Helper()
+
+	client := New(url)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	t.Cleanup(client.Close)
+
+	return client
+}
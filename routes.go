@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+const routesEndpoint = "routes"
+
+// RoutingRule routes alerts with a matching route key to a Slack channel.
+type RoutingRule struct {
+	// ID is assigned by the API.
+	ID string `json:"id,omitempty"`
+
+	// Name uniquely identifies the rule and is used by [DiffRoutes] to
+	// match desired rules to existing ones. Required.
+	Name string `json:"name"`
+
+	// RouteKey is matched against [types.Alert.RouteKey]. Required.
+	RouteKey string `json:"routeKey"`
+
+	// Severities optionally restricts the rule to alerts with these
+	// severities. Empty means all severities.
+	Severities []types.AlertSeverity `json:"severities,omitempty"`
+
+	// SlackChannelID is the channel ID or name that matching alerts are
+	// posted to. Required.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// Priority orders overlapping rules; higher values are evaluated
+	// first.
+	Priority int `json:"priority,omitempty"`
+}
+
+// validate checks the fields required by the API.
+func (r *RoutingRule) validate() error {
+	if r == nil {
+		return errors.New("routing rule is nil")
+	}
+
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("routing rule name must not be empty")
+	}
+
+	if strings.TrimSpace(r.RouteKey) == "" {
+		return fmt.Errorf("routing rule %q: route key must not be empty", r.Name)
+	}
+
+	if !types.SlackChannelIDOrNameRegex.MatchString(r.SlackChannelID) {
+		return fmt.Errorf("routing rule %q: invalid Slack channel %q", r.Name, r.SlackChannelID)
+	}
+
+	return nil
+}
+
+// equal reports whether r and other route alerts the same way, ignoring ID
+// and the order of Severities.
+func (r *RoutingRule) equal(other *RoutingRule) bool {
+	if r.Name != other.Name || r.RouteKey != other.RouteKey || r.SlackChannelID != other.SlackChannelID || r.Priority != other.Priority {
+		return false
+	}
+
+	return slices.Equal(sortedSeverities(r.Severities), sortedSeverities(other.Severities))
+}
+
+func sortedSeverities(severities []types.AlertSeverity) []types.AlertSeverity {
+	sorted := make([]types.AlertSeverity, len(severities))
+	for i, s := range severities {
+		sorted[i] = normalizeSeverity(s)
+	}
+
+	slices.Sort(sorted)
+
+	return sorted
+}
+
+type routesList struct {
+	Routes []*RoutingRule `json:"routes"`
+}
+
+// ListRoutes returns all routing rules. [Client.Connect] must be called
+// first.
+func (c *Client) ListRoutes(ctx context.Context) ([]*RoutingRule, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	var list routesList
+	if err := c.doJSON(ctx, http.MethodGet, routesEndpoint, nil, nil, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Routes, nil
+}
+
+// CreateRoute creates a routing rule and returns it with its ID set.
+func (c *Client) CreateRoute(ctx context.Context, rule *RoutingRule) (*RoutingRule, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	created := &RoutingRule{}
+	if err := c.doJSON(ctx, http.MethodPost, routesEndpoint, nil, rule, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateRoute replaces the routing rule with rule.ID.
+func (c *Client) UpdateRoute(ctx context.Context, rule *RoutingRule) (*RoutingRule, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	if rule.ID == "" {
+		return nil, fmt.Errorf("routing rule %q: ID must be set to update it", rule.Name)
+	}
+
+	updated := &RoutingRule{}
+	if err := c.doJSON(ctx, http.MethodPut, routesEndpoint+"/"+url.PathEscape(rule.ID), nil, rule, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteRoute deletes the routing rule with the given ID.
+func (c *Client) DeleteRoute(ctx context.Context, id string) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	if id == "" {
+		return errors.New("routing rule ID must not be empty")
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, routesEndpoint+"/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// RouteDiff is the set of changes that turns the actual routing rules into
+// the desired ones. See [DiffRoutes].
+type RouteDiff struct {
+	// Create holds desired rules with no existing rule of the same name.
+	Create []*RoutingRule
+
+	// Update holds desired rules that differ from the existing rule of the
+	// same name. Their ID is copied from the existing rule.
+	Update []*RoutingRule
+
+	// Delete holds existing rules with no desired rule of the same name.
+	Delete []*RoutingRule
+}
+
+// Empty reports whether the diff contains no changes.
+func (d RouteDiff) Empty() bool {
+	return len(d.Create) == 0 && len(d.Update) == 0 && len(d.Delete) == 0
+}
+
+// DiffRoutes compares desired routing rules with the actual rules returned
+// by [Client.ListRoutes], matching them by name. Rules are compared on
+// everything except ID, and severities are compared as a set. The result
+// preserves the order of desired for Create and Update, and of actual for
+// Delete. Neither input is modified.
+//
+// An error is returned if desired contains an invalid rule or the same
+// name twice.
+func DiffRoutes(desired, actual []*RoutingRule) (RouteDiff, error) {
+	var diff RouteDiff
+
+	existing := make(map[string]*RoutingRule, len(actual))
+	for _, rule := range actual {
+		if rule != nil {
+			existing[rule.Name] = rule
+		}
+	}
+
+	seen := make(map[string]bool, len(desired))
+
+	for _, rule := range desired {
+		if err := rule.validate(); err != nil {
+			return RouteDiff{}, err
+		}
+
+		if seen[rule.Name] {
+			return RouteDiff{}, fmt.Errorf("duplicate routing rule name %q", rule.Name)
+		}
+
+		seen[rule.Name] = true
+
+		current, ok := existing[rule.Name]
+
+		switch {
+		case !ok:
+			diff.Create = append(diff.Create, rule)
+		case !rule.equal(current):
+			update := *rule
+			update.ID = current.ID
+			diff.Update = append(diff.Update, &update)
+		}
+	}
+
+	for _, rule := range actual {
+		if rule != nil && !seen[rule.Name] {
+			diff.Delete = append(diff.Delete, rule)
+		}
+	}
+
+	return diff, nil
+}
+
+// ApplyRouteDiff applies diff by deleting, updating and then creating
+// rules, in that order. It stops at the first error; because the diff is
+// computed by name, calling [Client.ListRoutes] and [DiffRoutes] again
+// afterwards yields the remaining changes.
+func (c *Client) ApplyRouteDiff(ctx context.Context, diff RouteDiff) error {
+	for _, change := range diff.changes(nil) {
+		if err := c.applyRouteChange(ctx, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyRouteChange makes one change of a [RouteDiff].
+func (c *Client) applyRouteChange(ctx context.Context, change RouteChange) error {
+	var err error
+
+	switch change.Action {
+	case RouteDelete:
+		err = c.DeleteRoute(ctx, change.Before.ID)
+	case RouteUpdate:
+		_, err = c.UpdateRoute(ctx, change.After)
+	case RouteCreate:
+		_, err = c.CreateRoute(ctx, change.After)
+	default:
+		err = fmt.Errorf("unknown action %q", change.Action)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to %s routing rule %q: %w", change.Action, change.Name, err)
+	}
+
+	return nil
+}
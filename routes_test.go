@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	t.Parallel()
+
+	actual := []*RoutingRule{
+		{ID: "r1", Name: "db", RouteKey: "db", SlackChannelID: "C1", Severities: []types.AlertSeverity{types.AlertError, types.AlertWarning}},
+		{ID: "r2", Name: "web", RouteKey: "web", SlackChannelID: "C2"},
+		{ID: "r3", Name: "legacy", RouteKey: "old", SlackChannelID: "C3"},
+	}
+
+	desired := []*RoutingRule{
+		{Name: "db", RouteKey: "db", SlackChannelID: "C1", Severities: []types.AlertSeverity{"Warning", types.AlertError}},
+		{Name: "web", RouteKey: "web", SlackChannelID: "C9"},
+		{Name: "queue", RouteKey: "queue", SlackChannelID: "C4"},
+	}
+
+	diff, err := DiffRoutes(desired, actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diff.Create) != 1 || diff.Create[0].Name != "queue" {
+		t.Errorf("unexpected creates %+v", diff.Create)
+	}
+
+	if len(diff.Update) != 1 || diff.Update[0].Name != "web" || diff.Update[0].ID != "r2" || diff.Update[0].SlackChannelID != "C9" {
+		t.Errorf("unexpected updates %+v", diff.Update)
+	}
+
+	if desired[1].ID != "" {
+		t.Error("expected desired rules to be left unmodified")
+	}
+
+	if len(diff.Delete) != 1 || diff.Delete[0].ID != "r3" {
+		t.Errorf("unexpected deletes %+v", diff.Delete)
+	}
+
+	same, err := DiffRoutes(desired[:1], actual[:1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !same.Empty() {
+		t.Errorf("expected empty diff, got %+v", same)
+	}
+}
+
+func TestDiffRoutes_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired []*RoutingRule
+		want    string
+	}{
+		{"nil rule", []*RoutingRule{nil}, "routing rule is nil"},
+		{"no name", []*RoutingRule{{RouteKey: "a", SlackChannelID: "C1"}}, "name must not be empty"},
+		{"no route key", []*RoutingRule{{Name: "a", SlackChannelID: "C1"}}, "route key must not be empty"},
+		{"bad channel", []*RoutingRule{{Name: "a", RouteKey: "a", SlackChannelID: "not a channel!"}}, "invalid Slack channel"},
+		{"duplicate", []*RoutingRule{{Name: "a", RouteKey: "a", SlackChannelID: "C1"}, {Name: "a", RouteKey: "b", SlackChannelID: "C2"}}, "duplicate routing rule name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := DiffRoutes(tt.desired, nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestApplyRouteDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"routes":[{"id":"r1","name":"db","routeKey":"db","slackChannelId":"C1"},{"id":"r2","name":"old","routeKey":"old","slackChannelId":"C2"}]}`))
+			return
+		}
+
+		var rule RoutingRule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+
+		if rule.ID == "" {
+			rule.ID = "new"
+		}
+
+		_ = json.NewEncoder(w).Encode(rule)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	actual, err := client.ListRoutes(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diff, err := DiffRoutes([]*RoutingRule{
+		{Name: "db", RouteKey: "db", SlackChannelID: "C5"},
+		{Name: "queue", RouteKey: "queue", SlackChannelID: "C6"},
+	}, actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.ApplyRouteDiff(ctx, diff); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"GET /routes", "DELETE /routes/r2", "PUT /routes/r1", "POST /routes"}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestRoutes_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+
+	if _, err := client.ListRoutes(context.Background()); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+
+	if err := client.DeleteRoute(context.Background(), "r1"); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
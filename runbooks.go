@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// RunbookFieldTitle is the title of the field [WithRunbookResolver] adds
+// to alerts that have a runbook.
+const RunbookFieldTitle = "Runbook"
+
+// RunbookResolver returns the URL of the runbook for alert, and false if
+// it has none. It must be safe for concurrent use.
+type RunbookResolver func(alert *types.Alert) (string, bool)
+
+// RunbookRule maps alerts to a runbook. A rule matches an alert by its
+// [Fingerprint], or by every other matcher that is set. RouteKey and Type
+// are compared case-insensitively.
+type RunbookRule struct {
+	Fingerprint   string `json:"fingerprint,omitempty"`
+	RouteKey      string `json:"routeKey,omitempty"`
+	Type          string `json:"type,omitempty"`
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	// URL is the runbook, an absolute http or https URL. Required.
+	URL string `json:"url"`
+}
+
+// matches reports whether the matchers of r, other than the fingerprint,
+// select alert.
+func (r *RunbookRule) matches(alert *types.Alert) bool {
+	if r.RouteKey != "" && !strings.EqualFold(r.RouteKey, strings.TrimSpace(alert.RouteKey)) {
+		return false
+	}
+
+	if r.Type != "" && !strings.EqualFold(r.Type, strings.TrimSpace(alert.Type)) {
+		return false
+	}
+
+	if r.CorrelationID != "" && r.CorrelationID != strings.TrimSpace(alert.CorrelationID) {
+		return false
+	}
+
+	return true
+}
+
+// RunbookRules is a [RunbookResolver] backed by a list of rules. Use
+// [ParseRunbookRules] or [LoadRunbookFile] to create one, and pass its
+// Resolve method to [WithRunbookResolver].
+type RunbookRules struct {
+	fingerprints map[string]string
+	rules        []*RunbookRule
+}
+
+// LoadRunbookFile reads runbook rules from a JSON file (see
+// [ParseRunbookRules]).
+func LoadRunbookFile(path string) (*RunbookRules, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open runbook file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseRunbookRules(f)
+}
+
+// ParseRunbookRules parses a JSON array of [RunbookRule]s:
+//
+//	[
+//	  {"fingerprint": "9f86d081884c7d65...", "url": "https://wiki.example.com/runbooks/disk-full"},
+//	  {"routeKey": "payments", "type": "latency", "url": "https://wiki.example.com/runbooks/payments-latency"}
+//	]
+//
+// Rules with a fingerprint take precedence; the others are tried in order
+// and the first match wins. Every rule must have a URL and at least one
+// matcher.
+func ParseRunbookRules(r io.Reader) (*RunbookRules, error) {
+	var rules []*RunbookRule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to decode runbook rules: %w", err)
+	}
+
+	runbooks := &RunbookRules{fingerprints: make(map[string]string)}
+
+	for i, rule := range rules {
+		if rule == nil {
+			return nil, fmt.Errorf("runbook rule %d is empty", i+1)
+		}
+
+		if u, err := url.Parse(rule.URL); err != nil || !isHTTPURL(u) {
+			return nil, fmt.Errorf("runbook rule %d: URL must be an absolute http or https URL, got %q", i+1, rule.URL)
+		}
+
+		fingerprint := strings.ToLower(strings.TrimSpace(rule.Fingerprint))
+
+		switch {
+		case fingerprint != "":
+			if _, ok := runbooks.fingerprints[fingerprint]; !ok {
+				runbooks.fingerprints[fingerprint] = rule.URL
+			}
+		case rule.RouteKey+rule.Type+rule.CorrelationID == "":
+			return nil, fmt.Errorf("runbook rule %d: at least one matcher must be set", i+1)
+		default:
+			runbooks.rules = append(runbooks.rules, rule)
+		}
+	}
+
+	return runbooks, nil
+}
+
+// Resolve returns the URL of the runbook for alert. It implements
+// [RunbookResolver].
+func (r *RunbookRules) Resolve(alert *types.Alert) (string, bool) {
+	if r == nil || alert == nil {
+		return "", false
+	}
+
+	if len(r.fingerprints) > 0 {
+		if u, ok := r.fingerprints[Fingerprint(alert)]; ok {
+			return u, true
+		}
+	}
+
+	for _, rule := range r.rules {
+		if rule.matches(alert) {
+			return rule.URL, true
+		}
+	}
+
+	return "", false
+}
+
+// Len returns the number of rules.
+func (r *RunbookRules) Len() int {
+	return len(r.fingerprints) + len(r.rules)
+}
+
+// attachRunbooks returns alerts with a link to the runbook found by the
+// resolver set with [WithRunbookResolver]. The caller's alerts are not
+// changed; alerts that get a link are copied.
+func (c *Client) attachRunbooks(ctx context.Context, alerts []*types.Alert) []*types.Alert {
+	result := make([]*types.Alert, len(alerts))
+
+	for i, alert := range alerts {
+		result[i] = alert
+
+		u, ok := c.options.runbooks(alert)
+		if !ok || u == "" || hasRunbook(alert, u) {
+			continue
+		}
+
+		linked, err := withRunbook(alert, u)
+		if err != nil {
+			c.logger(ctx).Warnf("failed to attach runbook %s to alert %q: %s", u, alert.Header, err)
+			continue
+		}
+
+		result[i] = linked
+	}
+
+	return result
+}
+
+// hasRunbook reports whether alert already links to a runbook, such as
+// one added by the producer or by an earlier send of the same alert.
+func hasRunbook(alert *types.Alert, u string) bool {
+	if alert.Link == u {
+		return true
+	}
+
+	for _, field := range alert.Fields {
+		if field != nil && strings.EqualFold(field.Title, RunbookFieldTitle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withRunbook returns a copy of alert with a Runbook field linking to u.
+// If the alert has no room for another field, or u is too long for one,
+// the runbook becomes the alert's link instead, if it has none.
+func withRunbook(alert *types.Alert, u string) (*types.Alert, error) {
+	linked := *alert
+
+	value := "<" + u + "|Open runbook>"
+	if len(alert.Fields) < types.MaxFieldCount && len(value) <= types.MaxFieldValueLength {
+		linked.Fields = append(append(make([]*types.Field, 0, len(alert.Fields)+1), alert.Fields...), &types.Field{Title: RunbookFieldTitle, Value: value})
+		return &linked, nil
+	}
+
+	if alert.Link != "" {
+		return nil, errors.New("the alert has no room for another field and already has a link")
+	}
+
+	linked.Link = u
+
+	return &linked, nil
+}
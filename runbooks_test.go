@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestParseRunbookRules(t *testing.T) {
+	t.Parallel()
+
+	disk := types.NewAlert(types.AlertError)
+	disk.Header = "Disk full"
+	disk.RouteKey = "storage"
+
+	rules, err := ParseRunbookRules(strings.NewReader(`[
+		{"fingerprint": "` + strings.ToUpper(Fingerprint(disk)) + `", "url": "https://wiki/disk-full"},
+		{"routeKey": "Payments", "type": "latency", "url": "https://wiki/payments-latency"},
+		{"routeKey": "payments", "url": "https://wiki/payments"},
+		{"routeKey": "storage", "url": "https://wiki/storage"}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rules.Len() != 4 {
+		t.Errorf("expected 4 rules, got %d", rules.Len())
+	}
+
+	latency := &types.Alert{RouteKey: "payments", Type: "Latency"}
+	failing := &types.Alert{RouteKey: "payments", Type: "errors"}
+	other := types.NewAlert(types.AlertWarning)
+	other.Header = "Disk nearly full"
+	other.RouteKey = "storage"
+
+	for _, tc := range []struct {
+		alert *types.Alert
+		want  string
+	}{
+		{disk, "https://wiki/disk-full"},
+		{latency, "https://wiki/payments-latency"},
+		{failing, "https://wiki/payments"},
+		{other, "https://wiki/storage"},
+		{&types.Alert{RouteKey: "search"}, ""},
+	} {
+		if got, ok := rules.Resolve(tc.alert); got != tc.want || ok != (tc.want != "") {
+			t.Errorf("expected %q for %+v, got %q", tc.want, tc.alert, got)
+		}
+	}
+}
+
+func TestParseRunbookRules_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		`{}`,
+		`[null]`,
+		`[{"routeKey": "payments"}]`,
+		`[{"routeKey": "payments", "url": "/wiki/payments"}]`,
+		`[{"url": "https://wiki/payments"}]`,
+	} {
+		if _, err := ParseRunbookRules(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error for %s", input)
+		}
+	}
+}
+
+func TestLoadRunbookFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "runbooks.json")
+	if err := os.WriteFile(path, []byte(`[{"type": "latency", "url": "https://wiki/latency"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := LoadRunbookFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, _ := rules.Resolve(&types.Alert{Type: "latency"}); got != "https://wiki/latency" {
+		t.Errorf("unexpected runbook %q", got)
+	}
+
+	if _, err := LoadRunbookFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestWithRunbookResolver(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	logger := &recordingLogger{}
+
+	client := New(server.URL, WithRequestLogger(logger), WithRunbookResolver(func(alert *types.Alert) (string, bool) {
+		return "https://wiki/" + alert.RouteKey, alert.RouteKey != ""
+	}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	plain := types.NewAlert(types.AlertError)
+	plain.RouteKey = "payments"
+
+	own := types.NewAlert(types.AlertError)
+	own.RouteKey = "payments"
+	own.Fields = []*types.Field{{Title: "runbook", Value: "<https://wiki/own|Ours>"}}
+
+	full := types.NewAlert(types.AlertError)
+	full.RouteKey = "payments"
+	for range types.MaxFieldCount {
+		full.Fields = append(full.Fields, &types.Field{Title: "x", Value: "y"})
+	}
+
+	linked := types.NewAlert(types.AlertError)
+	linked.RouteKey = "payments"
+	linked.Fields = full.Fields
+	linked.Link = "https://grafana/d/payments"
+
+	none := types.NewAlert(types.AlertError)
+
+	if err := client.Send(context.Background(), plain, own, full, linked, none); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := server.received()[0]
+
+	if got := batch[0].Fields; len(got) != 1 || got[0].Title != RunbookFieldTitle || got[0].Value != "<https://wiki/payments|Open runbook>" {
+		t.Errorf("expected a runbook field, got %+v", got)
+	}
+
+	if len(plain.Fields) != 0 {
+		t.Error("expected the caller's alert to be left unchanged")
+	}
+
+	if got := batch[1].Fields; len(got) != 1 || got[0].Value != "<https://wiki/own|Ours>" {
+		t.Errorf("expected the producer's runbook to be kept, got %+v", got)
+	}
+
+	if batch[2].Link != "https://wiki/payments" || len(batch[2].Fields) != types.MaxFieldCount {
+		t.Errorf("expected the runbook as the link of a full alert, got %q", batch[2].Link)
+	}
+
+	if batch[3].Link != "https://grafana/d/payments" || len(logger.warnings()) != 1 {
+		t.Errorf("expected a warning for an alert with no room, got %q and %v", batch[3].Link, logger.warnings())
+	}
+
+	if len(batch[4].Fields) != 0 || batch[4].Link != "" {
+		t.Error("expected an alert without a runbook to be left as it is")
+	}
+
+	if client := New(server.URL, WithRunbookResolver(nil)); client.options.runbooks != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil resolver to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// Codes of the field-level validation errors returned by the API, as found
+// in [FieldViolation].
+const (
+	// ViolationTooLong is a string longer than the limit. [WithSchemaRepair]
+	// trims such fields.
+	ViolationTooLong = "too_long"
+
+	// ViolationTooMany is a list or map with more entries than the limit.
+	ViolationTooMany = "too_many"
+
+	// ViolationRequired is a missing field.
+	ViolationRequired = "required"
+
+	// ViolationInvalidFormat is a value that does not have the expected
+	// format, such as a channel ID or URL.
+	ViolationInvalidFormat = "invalid_format"
+
+	// ViolationInvalidValue is a value that is not one of those allowed.
+	ViolationInvalidValue = "invalid_value"
+)
+
+// FieldViolation is a field-level validation error returned by the API for
+// an alert it rejected.
+type FieldViolation struct {
+	// Alert is the index of the alert in the batch.
+	Alert int `json:"index"`
+
+	// Path is a JSON Pointer to the field within the alert, e.g. "/header"
+	// or "/webhooks/0/url".
+	Path string `json:"path"`
+
+	// Code identifies the kind of violation, such as [ViolationTooLong].
+	Code string `json:"code"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+
+	// Limit is the maximum length or number of entries, for
+	// [ViolationTooLong] and [ViolationTooMany].
+	Limit int `json:"limit,omitempty"`
+
+	// Suggestion is what the client suggests doing about the violation,
+	// or empty if the code is unknown. It is filled in by the client.
+	Suggestion string `json:"-"`
+}
+
+// FieldValidationError is returned by [Client.Send] when the API rejects
+// alerts with field-level validation errors. Check for it with errors.As.
+type FieldValidationError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+
+	// Violations lists the errors, with a suggestion for each known code.
+	Violations []FieldViolation
+
+	message string
+}
+
+func (e *FieldValidationError) Error() string {
+	var suggestions []string
+
+	for _, v := range e.Violations {
+		if v.Suggestion != "" {
+			suggestions = append(suggestions, fmt.Sprintf("alert at index %d: %s", v.Alert, v.Suggestion))
+		}
+	}
+
+	if len(suggestions) == 0 {
+		return e.message
+	}
+
+	return e.message + " (" + strings.Join(suggestions, "; ") + ")"
+}
+
+// newFieldValidationError returns the error for a rejection with the
+// given field violations, suggesting a repair for each.
+func newFieldValidationError(message string, status int, violations []FieldViolation) *FieldValidationError {
+	violations = slices.Clone(violations)
+	for i := range violations {
+		violations[i].Suggestion = suggestRepair(violations[i])
+	}
+
+	return &FieldValidationError{StatusCode: status, Violations: violations, message: message}
+}
+
+// suggestRepair returns an actionable suggestion for a violation, or an
+// empty string if its code is unknown.
+func suggestRepair(v FieldViolation) string {
+	field := strings.TrimPrefix(v.Path, "/")
+	if field == "" {
+		field = "the alert"
+	}
+
+	switch v.Code {
+	case ViolationTooLong:
+		if v.Limit > 0 {
+			return fmt.Sprintf("shorten %s to at most %d characters, or use WithSchemaRepair to trim it", field, v.Limit)
+		}
+
+		return "shorten " + field
+	case ViolationTooMany:
+		if v.Limit > 0 {
+			return fmt.Sprintf("send at most %d entries in %s", v.Limit, field)
+		}
+
+		return "send fewer entries in " + field
+	case ViolationRequired:
+		return "set " + field
+	case ViolationInvalidFormat:
+		switch {
+		case field == channelField:
+			return "use a Slack channel ID, such as C0123456789, or a channel name"
+		case field == "iconEmoji":
+			return "use an emoji code, such as :warning:"
+		case strings.HasSuffix(field, "url") || strings.HasSuffix(field, "Url"):
+			return "use an absolute http or https URL in " + field
+		}
+
+		return "fix the format of " + field
+	case ViolationInvalidValue:
+		if field == severityField {
+			return fmt.Sprintf("use one of the severities %s, %s, %s, %s or %s", types.AlertPanic, types.AlertError, types.AlertWarning, types.AlertResolved, types.AlertInfo)
+		}
+
+		return "use one of the values allowed for " + field
+	default:
+		return ""
+	}
+}
+
+// repairAlerts returns copies of alerts with the fields that err reports
+// as too long trimmed to their limit, and the number of fields trimmed.
+// It returns nil if err has nothing to repair.
+func repairAlerts(alerts []*types.Alert, err error) ([]*types.Alert, int) {
+	var validation *FieldValidationError
+	if !errors.As(err, &validation) {
+		return nil, 0
+	}
+
+	docs := make(map[int]map[string]any)
+	trimmed := 0
+
+	for _, v := range validation.Violations {
+		if v.Code != ViolationTooLong || v.Limit <= 0 || v.Alert < 0 || v.Alert >= len(alerts) {
+			continue
+		}
+
+		doc, ok := docs[v.Alert]
+		if !ok {
+			data, err := json.Marshal(alerts[v.Alert])
+			if err != nil || json.Unmarshal(data, &doc) != nil {
+				continue
+			}
+
+			docs[v.Alert] = doc
+		}
+
+		if trimAt(doc, v.Path, v.Limit) {
+			trimmed++
+		}
+	}
+
+	if trimmed == 0 {
+		return nil, 0
+	}
+
+	repaired := slices.Clone(alerts)
+
+	for i, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, 0
+		}
+
+		alert := &types.Alert{}
+		if err := json.Unmarshal(data, alert); err != nil {
+			return nil, 0
+		}
+
+		repaired[i] = alert
+	}
+
+	return repaired, trimmed
+}
+
+// trimAt shortens the string at the JSON Pointer path within doc to limit
+// characters, ending it with an ellipsis. It reports whether it did.
+func trimAt(doc map[string]any, path string, limit int) bool {
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	last := len(tokens) - 1
+
+	var parent any = doc
+
+	for _, token := range tokens[:last] {
+		parent = pointerChild(parent, unescapePointer(token))
+	}
+
+	key := unescapePointer(tokens[last])
+
+	value, ok := pointerChild(parent, key).(string)
+	if !ok || len([]rune(value)) <= limit {
+		return false
+	}
+
+	trimmed := truncateRunes(value, limit)
+
+	switch p := parent.(type) {
+	case map[string]any:
+		p[key] = trimmed
+	case []any:
+		i, _ := strconv.Atoi(key)
+		p[i] = trimmed
+	}
+
+	return true
+}
+
+// pointerChild returns the member or element named token of a decoded
+// JSON value, or nil.
+func pointerChild(value any, token string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return v[token]
+	case []any:
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(v) {
+			return v[i]
+		}
+	}
+
+	return nil
+}
+
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// truncateRunes shortens s to limit runes, the last of them an ellipsis.
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if limit <= 1 {
+		return string(runes[:limit])
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
+
+// fieldViolations returns the field-level validation errors of an error
+// response body, if it has any.
+func fieldViolations(status int, body []byte) []FieldViolation {
+	if status != http.StatusBadRequest && status != http.StatusUnprocessableEntity {
+		return nil
+	}
+
+	var apiErr apiErrorResponse
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		return nil
+	}
+
+	return apiErr.Fields
+}
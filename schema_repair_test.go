@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+// newValidatingServer rejects alerts whose header is longer than 10
+// characters with a field-level error, and records the headers it accepts.
+func newValidatingServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		accepted []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			return
+		}
+
+		var input alertsList
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var violations []FieldViolation
+
+		for i, alert := range input.Alerts {
+			if utf8.RuneCountInString(alert.Header) > 10 {
+				violations = append(violations, FieldViolation{Alert: i, Path: "/header", Code: ViolationTooLong, Message: "header is too long", Limit: 10})
+			}
+
+			if alert.Severity == "urgent" {
+				violations = append(violations, FieldViolation{Alert: i, Path: "/severity", Code: ViolationInvalidValue, Message: "unknown severity"})
+			}
+		}
+
+		if len(violations) > 0 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(apiErrorResponse{Error: "invalid alerts", Fields: violations})
+
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, alert := range input.Alerts {
+			accepted = append(accepted, alert.Header)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), accepted...)
+	}
+}
+
+func TestSend_FieldValidationError(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newValidatingServer(t)
+	client := connectTo(t, server.URL)
+
+	err := client.Send(context.Background(), &types.Alert{Header: "disk almost full on db-1", Severity: "urgent"})
+
+	var validation *FieldValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected a FieldValidationError, got %v", err)
+	}
+
+	if validation.StatusCode != http.StatusUnprocessableEntity || len(validation.Violations) != 2 {
+		t.Fatalf("unexpected error %+v", validation)
+	}
+
+	if got := validation.Violations[0].Suggestion; !strings.Contains(got, "at most 10 characters") {
+		t.Errorf("unexpected suggestion %q", got)
+	}
+
+	if got := validation.Violations[1].Suggestion; !strings.Contains(got, "panic, error, warning, resolved or info") {
+		t.Errorf("unexpected suggestion %q", got)
+	}
+
+	if !strings.Contains(err.Error(), "invalid alerts") || !strings.Contains(err.Error(), "alert at index 0: shorten header") {
+		t.Errorf("expected the message and suggestions in the error, got %q", err)
+	}
+}
+
+func TestWithSchemaRepair(t *testing.T) {
+	t.Parallel()
+
+	server, accepted := newValidatingServer(t)
+
+	client := New(server.URL, WithSchemaRepair())
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	alert := &types.Alert{Header: "disk almost full on db-1", Severity: types.AlertWarning}
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertInfo), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := accepted(); len(got) != 2 || got[1] != "disk almo…" {
+		t.Errorf("expected the header to be trimmed, got %q", got)
+	}
+
+	if alert.Header != "disk almost full on db-1" {
+		t.Errorf("expected the caller's alert to be left alone, got %q", alert.Header)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "short", Severity: "urgent"}); err == nil {
+		t.Error("expected violations that cannot be repaired to be returned")
+	}
+}
+
+func TestTrimAt(t *testing.T) {
+	t.Parallel()
+
+	doc := map[string]any{
+		"webhooks": []any{map[string]any{"a/b": "press this button"}},
+		"text":     "ok",
+	}
+
+	if !trimAt(doc, "/webhooks/0/a~1b", 5) {
+		t.Fatal("expected a nested field to be trimmed")
+	}
+
+	if got := doc["webhooks"].([]any)[0].(map[string]any)["a/b"]; got != "pres…" { //nolint:forcetypeassert // built above
+		t.Errorf("unexpected value %q", got)
+	}
+
+	for _, path := range []string{"/text", "/missing", "/webhooks/3/url", "/webhooks"} {
+		if trimAt(doc, path, 5) {
+			t.Errorf("expected %s not to be trimmed", path)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SendOption configures a single call to [Client.SendWithOptions].
+type SendOption func(*sendOptions)
+
+// sendOptions holds the per-request settings built from [SendOption]s. A
+// nil *sendOptions applies nothing.
+type sendOptions struct {
+	query  url.Values
+	header http.Header
+
+	// ackWithin is the time set with [RequireAck].
+	ackWithin time.Duration
+
+	// idempotencyKey is the key set with [WithIdempotencyKey].
+	idempotencyKey string
+
+	// prepared marks alerts already run through prepareAlerts, such as
+	// those queued by [Client.SendAsync].
+	prepared bool
+}
+
+func newSendOptions(opts []SendOption) *sendOptions {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	so := &sendOptions{query: url.Values{}}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(so)
+		}
+	}
+
+	return so
+}
+
+// apply adds the options to request.
+func (so *sendOptions) apply(request *resty.Request) {
+	if so == nil {
+		return
+	}
+
+	if len(so.query) > 0 {
+		request.SetQueryParamsFromValues(so.query)
+	}
+
+	for key, values := range so.header {
+		request.SetHeaderMultiValues(map[string][]string{key: values})
+	}
+}
+
+// alreadyPrepared sends alerts that prepareAlerts has already been
+// applied to as they are.
+func alreadyPrepared() SendOption {
+	return func(so *sendOptions) {
+		so.prepared = true
+	}
+}
+
+// WithPreservedTimestamps asks the API to keep each alert's Timestamp
+// instead of replacing it with the time the alert was received. Use it
+// when importing historical alerts, e.g. from [ReadSlackExport].
+func WithPreservedTimestamps() SendOption {
+	return func(so *sendOptions) {
+		so.query.Set("preserveTimestamps", "true")
+	}
+}
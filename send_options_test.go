@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSendWithOptions_PreservedTimestamps(t *testing.T) {
+	t.Parallel()
+
+	queries := make(chan string, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			queries <- r.URL.RawQuery
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	alerts := []*types.Alert{{Header: "test"}}
+
+	if _, err := client.SendWithOptions(context.Background(), alerts, WithPreservedTimestamps()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q := <-queries; q != "preserveTimestamps=true" {
+		t.Errorf("expected preserveTimestamps=true, got %q", q)
+	}
+
+	if err := client.Send(context.Background(), alerts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q := <-queries; strings.Contains(q, "preserveTimestamps") {
+		t.Errorf("expected options not to leak into later sends, got %q", q)
+	}
+}
+
+func TestSendWithOptions_Validation(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("http://example.com").SendWithOptions(context.Background(), nil); err == nil {
+		t.Error("expected error when not connected")
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/slackmgr/types"
+)
+
+// PolicyEffect is the effect of a [Decision].
+type PolicyEffect int
+
+const (
+	// PolicyAllow sends the alert as it is.
+	PolicyAllow PolicyEffect = iota
+
+	// PolicyDeny rejects the batch with a [*PolicyError].
+	PolicyDeny
+
+	// PolicyModify sends [Decision.Alert] in place of the alert.
+	PolicyModify
+)
+
+// Decision is the result of a [SendPolicy] for one alert.
+type Decision struct {
+	Effect PolicyEffect
+
+	// Reason explains a denial. It is reported in the [*PolicyError].
+	Reason string
+
+	// Alert replaces the evaluated alert if Effect is [PolicyModify].
+	Alert *types.Alert
+}
+
+// AllowAlert returns a decision that sends the alert as it is.
+func AllowAlert() Decision {
+	return Decision{Effect: PolicyAllow}
+}
+
+// DenyAlert returns a decision that rejects the alert for reason.
+func DenyAlert(reason string) Decision {
+	return Decision{Effect: PolicyDeny, Reason: reason}
+}
+
+// ModifyAlert returns a decision that sends alert in place of the
+// evaluated alert.
+func ModifyAlert(alert *types.Alert) Decision {
+	return Decision{Effect: PolicyModify, Alert: alert}
+}
+
+// SendPolicy decides whether an alert may be sent, for [WithSendPolicy].
+// It must not change the alert it is given; it returns a modified copy
+// with [ModifyAlert] instead.
+type SendPolicy func(ctx context.Context, alert *types.Alert) Decision
+
+// applySendPolicies evaluates the policies set with [WithSendPolicy] for
+// each alert, in order. It returns the alerts to send, which is a copy of
+// alerts if a policy modified one, or a [*PolicyError] for the first
+// denied alert.
+func (c *Client) applySendPolicies(ctx context.Context, alerts []*types.Alert) ([]*types.Alert, error) {
+	copied := false
+
+	for i := range alerts {
+		for _, policy := range c.options.sendPolicies {
+			decision := policy(ctx, alerts[i])
+
+			var reason string
+
+			switch decision.Effect {
+			case PolicyAllow:
+				continue
+			case PolicyDeny:
+				reason = cmp.Or(decision.Reason, "denied")
+			case PolicyModify:
+				if decision.Alert != nil {
+					if !copied {
+						alerts = slices.Clone(alerts)
+						copied = true
+					}
+
+					alerts[i] = decision.Alert
+
+					continue
+				}
+
+				reason = "modify decision has no alert"
+			default:
+				reason = "unknown decision effect"
+			}
+
+			return nil, &PolicyError{Policy: "send policy", Alert: i, Channel: alerts[i].SlackChannelID, Reason: reason}
+		}
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithSendPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	footer := func(_ context.Context, alert *types.Alert) Decision {
+		if alert.Footer != "" {
+			return AllowAlert()
+		}
+
+		modified := *alert
+		modified.Footer = "governed"
+		modified.SlackChannelID = "C0ALERTS"
+
+		return ModifyAlert(&modified)
+	}
+
+	noPanics := func(_ context.Context, alert *types.Alert) Decision {
+		if alert.Severity == types.AlertPanic {
+			return DenyAlert("no panics")
+		}
+
+		return AllowAlert()
+	}
+
+	client := New(server.URL, WithSendPolicy(footer), WithSendPolicy(noPanics), WithSendPolicy(nil), WithChannelAllowlist("C0ALERTS"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	alerts := []*types.Alert{types.NewAlert(types.AlertError), types.NewAlert(types.AlertInfo)}
+	alerts[1].Footer = "own footer"
+	alerts[1].SlackChannelID = "C0ALERTS"
+
+	if err := client.Send(context.Background(), alerts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if alerts[0].Footer != "" {
+		t.Errorf("expected the caller's alert to be left unchanged, got footer %q", alerts[0].Footer)
+	}
+
+	got := server.received()
+	if len(got) != 1 || got[0][0].Footer != "governed" || got[0][1].Footer != "own footer" {
+		t.Fatalf("expected the modified batch to be sent, got %v", got)
+	}
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertInfo), types.NewAlert(types.AlertPanic))
+
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) || policyErr.Policy != "send policy" || policyErr.Alert != 1 || policyErr.Reason != "no panics" {
+		t.Fatalf("expected a send policy error for the second alert, got %v", err)
+	}
+
+	if got := server.received(); len(got) != 1 {
+		t.Errorf("expected the denied batch not to be sent, got %v", got)
+	}
+
+	if n := client.EffectiveConfig().SendPolicies; n != 2 {
+		t.Errorf("expected 2 send policies in the snapshot, got %d", n)
+	}
+
+	if warnings := client.ConfigWarnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "WithSendPolicy") {
+		t.Errorf("expected a warning for the nil policy, got %v", warnings)
+	}
+}
+
+func TestApplySendPolicies_InvalidDecisions(t *testing.T) {
+	t.Parallel()
+
+	for reason, decision := range map[string]Decision{
+		"denied":                       {Effect: PolicyDeny},
+		"modify decision has no alert": {Effect: PolicyModify},
+		"unknown decision effect":      {Effect: PolicyEffect(9)},
+	} {
+		client := New("http://localhost", WithSendPolicy(func(context.Context, *types.Alert) Decision { return decision }))
+
+		_, err := client.applySendPolicies(context.Background(), []*types.Alert{types.NewAlert(types.AlertInfo)})
+
+		var policyErr *PolicyError
+		if !errors.As(err, &policyErr) || policyErr.Reason != reason {
+			t.Errorf("expected reason %q, got %v", reason, err)
+		}
+	}
+}
+
+func TestOPAPolicy(t *testing.T) {
+	t.Parallel()
+
+	var result string
+
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Input opaInput `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil || r.URL.Path != "/v1/data/slackmgr/send" || r.Header.Get("Authorization") != "Bearer opa-token" {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+
+		if query.Input.Caller != "deploy-bot" || query.Input.Alert.Header != "disk full" {
+			http.Error(w, "unexpected input", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"result": ` + result + `}`))
+	}))
+	defer opa.Close()
+
+	policy := OPAPolicy(OPAPolicyConfig{Address: opa.URL + "/", Path: "/slackmgr/send", Token: "opa-token"})
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = "disk full"
+
+	ctx := WithCaller(context.Background(), "deploy-bot")
+
+	tests := []struct {
+		result string
+		want   Decision
+	}{
+		{"true", AllowAlert()},
+		{"false", DenyAlert("denied by OPA policy")},
+		{`{"allow": true}`, AllowAlert()},
+		{`{"allow": false, "reason": "no pages at night"}`, DenyAlert("no pages at night")},
+		{"null", DenyAlert("OPA policy decision /v1/data/slackmgr/send is undefined")},
+	}
+
+	for _, tt := range tests {
+		result = tt.result
+		if got := policy(ctx, alert); got != tt.want {
+			t.Errorf("result %s: expected %+v, got %+v", tt.result, tt.want, got)
+		}
+	}
+
+	result = `{"allow": true, "alert": {"header": "disk full", "footer": "checked"}}`
+	if got := policy(ctx, alert); got.Effect != PolicyModify || got.Alert.Footer != "checked" {
+		t.Errorf("expected a modify decision, got %+v", got)
+	}
+
+	for _, bad := range []string{`{"reason": "x"}`, `"yes"`} {
+		result = bad
+		if got := policy(ctx, alert); got.Effect != PolicyDeny || !strings.Contains(got.Reason, "must be a boolean") {
+			t.Errorf("result %s: expected a denial, got %+v", bad, got)
+		}
+	}
+
+	if got := policy(context.Background(), alert); got.Effect != PolicyDeny || !strings.Contains(got.Reason, "status code 400") {
+		t.Errorf("expected a failed query to deny, got %+v", got)
+	}
+
+	if got := OPAPolicy(OPAPolicyConfig{Address: opa.URL})(ctx, alert); got.Effect != PolicyDeny || !strings.Contains(got.Reason, "decision path") {
+		t.Errorf("expected a missing path to deny, got %+v", got)
+	}
+}
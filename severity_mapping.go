@@ -0,0 +1,104 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// SeverityMappingMode controls how [WithSeverityMapping] treats severities
+// that are neither canonical nor present in the mapping.
+type SeverityMappingMode int
+
+const (
+	// SeverityMappingLenient leaves unknown severities unchanged, deferring
+	// to the API's own handling.
+	SeverityMappingLenient SeverityMappingMode = iota
+
+	// SeverityMappingStrict rejects alerts with unknown severities before
+	// any request is made.
+	SeverityMappingStrict
+)
+
+// DefaultSeverityMapping returns a mapping of common producer severity
+// vocabularies (syslog levels, "sevN", "PN", low/medium/high) to the
+// canonical severities. The returned map is a fresh copy and may be
+// modified before being passed to [WithSeverityMapping].
+func DefaultSeverityMapping() map[string]types.AlertSeverity {
+	return map[string]types.AlertSeverity{
+		"emergency":      types.AlertPanic,
+		"emerg":          types.AlertPanic,
+		"fatal":          types.AlertPanic,
+		"alert":          types.AlertPanic,
+		"sev1":           types.AlertPanic,
+		"p1":             types.AlertPanic,
+		severityCritical: types.AlertError,
+		"crit":           types.AlertError,
+		"err":            types.AlertError,
+		"high":           types.AlertError,
+		"sev2":           types.AlertError,
+		"p2":             types.AlertError,
+		"warn":           types.AlertWarning,
+		"medium":         types.AlertWarning,
+		"sev3":           types.AlertWarning,
+		"p3":             types.AlertWarning,
+		"notice":         types.AlertInfo,
+		"informational":  types.AlertInfo,
+		"debug":          types.AlertInfo,
+		"low":            types.AlertInfo,
+		"sev4":           types.AlertInfo,
+		"p4":             types.AlertInfo,
+		"p5":             types.AlertInfo,
+		"ok":             types.AlertResolved,
+		"clear":          types.AlertResolved,
+		"recovered":      types.AlertResolved,
+	}
+}
+
+// severityMapper rewrites producer-specific severities to canonical ones.
+type severityMapper struct {
+	mapping map[string]types.AlertSeverity
+	mode    SeverityMappingMode
+}
+
+// apply rewrites the severity of every alert in place. An empty severity is
+// left empty, so the API default applies.
+func (m *severityMapper) apply(alerts []*types.Alert) error {
+	for i, alert := range alerts {
+		severity := normalizeSeverity(alert.Severity)
+		if severity == "" {
+			continue
+		}
+
+		if mapped, ok := m.mapping[string(severity)]; ok {
+			alert.Severity = mapped
+			continue
+		}
+
+		if types.SeverityIsValid(severity) {
+			alert.Severity = severity
+			continue
+		}
+
+		if m.mode == SeverityMappingStrict {
+			return fmt.Errorf("alert at index %d has unknown severity %q", i, alert.Severity)
+		}
+	}
+
+	return nil
+}
+
+// normalizeSeverityMapping returns a copy of mapping with lower-case,
+// trimmed keys and values.
+func normalizeSeverityMapping(mapping map[string]types.AlertSeverity) map[string]types.AlertSeverity {
+	normalized := make(map[string]types.AlertSeverity, len(mapping))
+
+	for from, to := range mapping {
+		if from = strings.ToLower(strings.TrimSpace(from)); from != "" {
+			normalized[from] = normalizeSeverity(to)
+		}
+	}
+
+	return normalized
+}
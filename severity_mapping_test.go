@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSeverityMapper_Apply(t *testing.T) {
+	t.Parallel()
+
+	m := &severityMapper{mapping: normalizeSeverityMapping(DefaultSeverityMapping()), mode: SeverityMappingLenient}
+
+	tests := []struct {
+		input    types.AlertSeverity
+		expected types.AlertSeverity
+	}{
+		{"P1", types.AlertPanic},
+		{" sev2 ", types.AlertError},
+		{"WARN", types.AlertWarning},
+		{"Debug", types.AlertInfo},
+		{"ok", types.AlertResolved},
+		{"ERROR", types.AlertError},
+		{"", ""},
+		{"whatever", "whatever"},
+	}
+
+	for _, tt := range tests {
+		alert := &types.Alert{Severity: tt.input}
+
+		if err := m.apply([]*types.Alert{alert}); err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.input, err)
+		}
+
+		if alert.Severity != tt.expected {
+			t.Errorf("expected %q to map to %q, got %q", tt.input, tt.expected, alert.Severity)
+		}
+	}
+}
+
+func TestSeverityMapper_Strict(t *testing.T) {
+	t.Parallel()
+
+	m := &severityMapper{mapping: map[string]types.AlertSeverity{"sev2": types.AlertError}, mode: SeverityMappingStrict}
+
+	alerts := []*types.Alert{{Severity: "sev2"}, {Severity: "P1"}}
+
+	err := m.apply(alerts)
+	if err == nil || err.Error() != `alert at index 1 has unknown severity "P1"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSend_SeverityMapping(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithSeverityMapping(DefaultSeverityMapping(), SeverityMappingStrict))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "x", Severity: "sev1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 || batches[0][0].Severity != types.AlertPanic {
+		t.Fatalf("expected mapped severity panic, got %+v", batches)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "x", Severity: "sev9"}); err == nil {
+		t.Error("expected unknown severity to be rejected in strict mode")
+	}
+
+	if len(server.received()) != 1 {
+		t.Error("expected rejected alert not to be sent")
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"strings"
+	"sync"
+)
+
+// sharedClients is the registry behind [Shared], keyed by base URL.
+var sharedClients = struct { //nolint:gochecknoglobals // Shared hands out process-wide clients
+
+	mu      sync.Mutex
+	entries map[string]*sharedEntry
+}{entries: make(map[string]*sharedEntry)}
+
+// sharedEntry is a reference-counted client in the [Shared] registry.
+type sharedEntry struct {
+	key    string
+	client *Client
+	refs   int
+}
+
+// Shared returns the process-wide client for baseURL, creating it with
+// [New] and opts on first use. Later calls with the same base URL return
+// the same client and ignore opts, so libraries embedded in many services
+// share one connection pool instead of each opening their own.
+//
+// Each call to Shared must be balanced by a call to [Client.Close]; the
+// client is only closed, and removed from the registry, when the last
+// reference is released. [Client.Connect] may be called by every holder and
+// only initializes once.
+func Shared(baseURL string, opts ...Option) *Client {
+	key := strings.TrimRight(baseURL, "/")
+
+	sharedClients.mu.Lock()
+	defer sharedClients.mu.Unlock()
+
+	entry, ok := sharedClients.entries[key]
+	if !ok {
+		entry = &sharedEntry{key: key, client: New(baseURL, opts...)}
+		entry.client.shared = entry
+		sharedClients.entries[key] = entry
+	}
+
+	entry.refs++
+
+	return entry.client
+}
+
+// release drops one reference to the entry and reports whether it was the
+// last, in which case the entry is removed from the registry.
+func (e *sharedEntry) release() bool {
+	sharedClients.mu.Lock()
+	defer sharedClients.mu.Unlock()
+
+	if e.refs > 0 {
+		e.refs--
+	}
+
+	if e.refs > 0 {
+		return false
+	}
+
+	if sharedClients.entries[e.key] == e {
+		delete(sharedClients.entries, e.key)
+	}
+
+	return true
+}
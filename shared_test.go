@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestShared_ReturnsSameClient(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	first := Shared(rec.URL, WithRetryCount(1))
+	second := Shared(rec.URL+"/", WithRetryCount(9))
+
+	if first != second {
+		t.Fatal("expected Shared to return the same client for the same base URL")
+	}
+
+	if first.options.retryCount != 1 {
+		t.Errorf("expected options from the first call to win, got retryCount=%d", first.options.retryCount)
+	}
+
+	if err := first.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := second.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first.Close()
+
+	if err := second.Ping(context.Background()); err != nil {
+		t.Errorf("expected client to stay usable while references remain, got %v", err)
+	}
+
+	second.Close()
+
+	if third := Shared(rec.URL); third == first {
+		t.Error("expected a new client after the last reference was released")
+	} else {
+		third.Close()
+	}
+}
+
+func TestShared_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	const url = "http://shared-concurrent.example.com"
+
+	clients := make([]*Client, 20)
+
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Go(func() { clients[i] = Shared(url) })
+	}
+	wg.Wait()
+
+	for _, c := range clients[1:] {
+		if c != clients[0] {
+			t.Fatal("expected all concurrent callers to receive the same client")
+		}
+	}
+
+	if refs := clients[0].shared.refs; refs != len(clients) {
+		t.Errorf("expected %d references, got %d", len(clients), refs)
+	}
+
+	for _, c := range clients {
+		c.Close()
+	}
+
+	sharedClients.mu.Lock()
+	_, ok := sharedClients.entries[url]
+	sharedClients.mu.Unlock()
+
+	if ok {
+		t.Error("expected entry to be removed after all references were released")
+	}
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/slackmgr/types"
+)
+
+// pendingSend is a batch of alerts held back by the client, such as a
+// digest or a quiet-hours spool, that is ready to be posted.
+type pendingSend struct {
+	alerts  []*types.Alert
+	failure string
+
+	// queue is the queue that held alerts, which [WithQuarantine] applies
+	// to. It is empty when alerts are summaries built by the client, which
+	// are prepared like the alerts of [Client.Send] before they are posted.
+	queue PendingQueue
+
+	// requeue puts the given alerts that could not be posted back.
+	requeue func(alerts []*types.Alert)
+}
+
+// sendTracker counts the high-priority sends in flight, so that
+// [Client.Shutdown] can let them finish before doing anything else.
+type sendTracker struct {
+	mu       sync.Mutex
+	inFlight int
+	idle     chan struct{} // closed when inFlight drops to zero
+}
+
+func (t *sendTracker) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.inFlight == 0 {
+		t.idle = make(chan struct{})
+	}
+
+	t.inFlight++
+}
+
+func (t *sendTracker) done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+
+	if t.inFlight == 0 {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// wait blocks until no high-priority send is in flight or ctx is done.
+func (t *sendTracker) wait(ctx context.Context) error {
+	t.mu.Lock()
+	idle, inFlight := t.idle, t.inFlight
+	t.mu.Unlock()
+
+	if idle == nil {
+		return nil
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d high-priority send(s) still in flight: %w", inFlight, ctx.Err())
+	}
+}
+
+// Shutdown delivers everything the client still holds and then closes it,
+// for use when the process is about to exit, for example within the
+// termination grace period of a Kubernetes pod.
+//
+// Critical alerts come first: Shutdown waits for high-priority sends
+// (panic, error and critical alerts) made with [Client.Send] and its
+// variants that are still in flight, and only then starts on the rest, so
+// they keep the whole of ctx's deadline. It then sends the queue of
+// [WithAsyncMode], and posts pending digests and the alerts deferred by
+// [WithQuietHours], even outside business hours, most severe first. These are best effort: alerts that cannot be posted before
+// ctx is done are dropped and reported in the returned error.
+//
+// For a client returned by [Shared], the held-back alerts are delivered but
+// the client is only closed when the last reference is released.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	defer c.Close()
+
+	if c.client == nil {
+		return nil
+	}
+
+	if err := c.sends.wait(ctx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	var errs []error
+
+	if c.async != nil && c.parent == nil {
+		errs = append(errs, c.shutdownAsync(ctx))
+	}
+
+	var pending []pendingSend
+
+	if c.digest != nil {
+		pending = append(pending, c.pendingDigests()...)
+	}
+
+	if c.quietHours != nil {
+		pending = append(pending, c.pendingQuietHours(c.quietHours.drain())...)
+	}
+
+	return errors.Join(append(errs, c.sendPending(ctx, pending))...)
+}
+
+// shutdownAsync stops the background sends of [WithAsyncMode] and sends
+// the queue with ctx.
+func (c *Client) shutdownAsync(ctx context.Context) error {
+	c.async.close()
+
+	if err := c.async.send(ctx); err != nil {
+		return fmt.Errorf("failed to send queued alerts: %w", err)
+	}
+
+	if n := c.async.len(); n > 0 {
+		return fmt.Errorf("%d queued alert(s) not sent: %w", n, ctx.Err())
+	}
+
+	return nil
+}
+
+// sendPending posts pending sends, most severe first, and requeues those
+// that fail.
+func (c *Client) sendPending(ctx context.Context, pending []pendingSend) error {
+	slices.SortStableFunc(pending, func(a, b pendingSend) int {
+		return cmp.Compare(severityRank(b.alerts), severityRank(a.alerts))
+	})
+
+	var errs []error
+
+	for _, p := range pending {
+		alerts := p.alerts
+
+		// Summaries are built by the client, so unlike the alerts they
+		// summarise they have not been through [Client.Send] yet.
+		if p.queue == "" {
+			prepared, err := c.prepareAlerts(ctx, alerts)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", p.failure, err))
+				p.requeue(alerts)
+
+				continue
+			}
+
+			alerts = prepared
+		}
+
+		meta, err := c.postAlerts(ctx, alerts, nil)
+		if err == nil {
+			if c.quarantine != nil && p.queue != "" {
+				c.quarantine.forget(p.alerts...)
+			}
+
+			continue
+		}
+
+		retry := p.alerts
+
+		if c.quarantine != nil && p.queue != "" {
+			sifted := c.quarantine.sift(ctx, p.queue, p.alerts, meta, err, func(ctx context.Context, alerts []*types.Alert) (*ResponseMetadata, error) {
+				return c.postAlerts(ctx, alerts, nil)
+			})
+
+			retry = sifted.retry(p.alerts)
+			err = sifted.err
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.failure, err))
+		}
+
+		if len(retry) > 0 {
+			p.requeue(retry)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// heldBack returns the number of alerts held back by async mode, digest
+// mode and quiet hours.
+func (c *Client) heldBack() int {
+	n := 0
+
+	if c.async != nil {
+		n += c.async.len()
+	}
+
+	if c.digest != nil {
+		n += c.digest.len()
+	}
+
+	if c.quietHours != nil {
+		n += c.quietHours.len()
+	}
+
+	return n
+}
+
+// severityRank orders batches by their most severe alert, for
+// [Client.sendPending]. Unknown severities rank lowest.
+func severityRank(alerts []*types.Alert) int {
+	rank := 0
+
+	for _, alert := range alerts {
+		switch normalizeSeverity(alert.Severity) {
+		case types.AlertPanic, severityCritical:
+			rank = max(rank, 5)
+		case types.AlertError:
+			rank = max(rank, 4)
+		case types.AlertWarning:
+			rank = max(rank, 3)
+		case types.AlertInfo:
+			rank = max(rank, 2)
+		case types.AlertResolved:
+			rank = max(rank, 1)
+		default:
+		}
+	}
+
+	return rank
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestShutdown_PostsMostSevereFirst(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	client := New(server.URL, WithDigest(func(a *types.Alert) string { return "C-" + string(a.Severity) }, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := client.Send(context.Background(),
+		&types.Alert{Header: "fyi", Severity: types.AlertInfo},
+		&types.Alert{Header: "disk filling up", Severity: types.AlertWarning},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 digests, got %d", len(batches))
+	}
+
+	if batches[0][0].SlackChannelID != "C-warning" || batches[1][0].SlackChannelID != "C-info" {
+		t.Errorf("expected the warning digest first, got %s then %s", batches[0][0].SlackChannelID, batches[1][0].SlackChannelID)
+	}
+}
+
+func TestShutdown_ReleasesQuietHours(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	client, _ := connectWithQuietHours(t, server.URL, QuietHoursDefer)
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "low", Severity: types.AlertWarning}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(server.received()) != 0 {
+		t.Fatal("expected the alert to be deferred")
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	batches := server.received()
+	if len(batches) != 1 || batches[0][0].Header != "low" {
+		t.Fatalf("expected the deferred alert to be released on shutdown, got %+v", batches)
+	}
+}
+
+// blockingAlertServer records the headers of the alerts it receives, in the
+// order the requests complete. Requests for error alerts block until
+// release is closed.
+type blockingAlertServer struct {
+	*httptest.Server
+
+	started chan struct{}
+	release chan struct{}
+
+	mu        sync.Mutex
+	completed []string
+}
+
+func newBlockingAlertServer(t *testing.T) *blockingAlertServer {
+	t.Helper()
+
+	s := &blockingAlertServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input alertsList
+		if r.URL.Path == "/alerts" {
+			_ = json.NewDecoder(r.Body).Decode(&input)
+		}
+
+		for _, alert := range input.Alerts {
+			if alert.Severity == types.AlertError {
+				s.started <- struct{}{}
+				<-s.release
+			}
+
+			s.mu.Lock()
+			s.completed = append(s.completed, alert.Header)
+			s.mu.Unlock()
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(s.Close)
+	t.Cleanup(func() {
+		select {
+		case <-s.release:
+		default:
+			close(s.release)
+		}
+	})
+
+	return s
+}
+
+func (s *blockingAlertServer) completions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.completed...)
+}
+
+func TestShutdown_WaitsForHighPriorityInFlight(t *testing.T) {
+	t.Parallel()
+
+	server := newBlockingAlertServer(t)
+
+	client := New(server.URL, WithRetryCount(0), WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "low", Severity: types.AlertWarning}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- client.Send(context.Background(), &types.Alert{Header: "db down", Severity: types.AlertError})
+	}()
+	<-server.started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- client.Shutdown(context.Background()) }()
+
+	time.Sleep(20 * time.Millisecond)
+
+	if got := server.completions(); len(got) != 0 {
+		t.Fatalf("expected nothing to be posted while the error alert is in flight, got %q", got)
+	}
+
+	close(server.release)
+
+	if err := <-sent; err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	if err := <-shutdown; err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	if got := server.completions(); len(got) != 2 || got[0] != "db down" || !strings.HasPrefix(got[1], "Digest") {
+		t.Errorf("expected the error alert before the digest, got %q", got)
+	}
+}
+
+func TestShutdown_HighPriorityTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := newBlockingAlertServer(t)
+
+	client := New(server.URL, WithRetryCount(0))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	go func() {
+		_ = client.Send(context.Background(), &types.Alert{Header: "db down", Severity: types.AlertError})
+	}()
+	<-server.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := client.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "1 high-priority send(s) still in flight") {
+		t.Fatalf("expected an in-flight error, got %v", err)
+	}
+}
+
+func TestShutdown_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	if err := New("http://localhost").Shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var client *Client
+	if err := client.Shutdown(context.Background()); err == nil {
+		t.Error("expected error for nil client")
+	}
+}
+
+func TestClose_WarnsAboutHeldBackAlerts(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	logger := &recordingLogger{}
+
+	client := New(server.URL, WithRequestLogger(logger), WithDigest(digestAll, time.Hour))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := client.Send(context.Background(), &types.Alert{Severity: types.AlertInfo}, &types.Alert{Severity: types.AlertWarning}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.Close()
+
+	warnings := logger.warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "drops 2 held-back alert(s)") {
+		t.Errorf("expected a warning about the dropped alerts, got %q", warnings)
+	}
+}
+
+func TestSeverityRank(t *testing.T) {
+	t.Parallel()
+
+	ordered := []types.AlertSeverity{"unknown", types.AlertResolved, types.AlertInfo, types.AlertWarning, types.AlertError, types.AlertPanic}
+
+	for i := 1; i < len(ordered); i++ {
+		lower := severityRank([]*types.Alert{{Severity: ordered[i-1]}})
+		higher := severityRank([]*types.Alert{{Severity: ordered[i]}})
+
+		if lower >= higher {
+			t.Errorf("expected %s to rank below %s", ordered[i-1], ordered[i])
+		}
+	}
+
+	if got, want := severityRank([]*types.Alert{{Severity: types.AlertInfo}, {Severity: " Critical "}}), severityRank([]*types.Alert{{Severity: types.AlertPanic}}); got != want {
+		t.Errorf("expected a mixed batch to rank as its most severe alert, got %d, want %d", got, want)
+	}
+}
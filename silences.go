@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	silencesEndpoint = "silences"
+
+	minSilenceSyncInterval = 10 * time.Second
+	maxSilenceSyncInterval = 24 * time.Hour
+)
+
+// Silence suppresses matching alerts between StartsAt and EndsAt. Empty
+// matcher fields match any value, but a silence must set at least one of
+// RouteKey, SlackChannelID, CorrelationID or Severities. When Severities is
+// empty, resolved alerts are never suppressed, so issues opened before the
+// silence began can still be closed.
+type Silence struct {
+	// ID identifies the silence. A local silence with the same ID as a
+	// server silence conflicts with it; see [SilenceConflictPolicy].
+	ID string `json:"id"`
+
+	RouteKey       string                `json:"routeKey,omitempty"`
+	SlackChannelID string                `json:"slackChannelId,omitempty"`
+	CorrelationID  string                `json:"correlationId,omitempty"`
+	Severities     []types.AlertSeverity `json:"severities,omitempty"`
+
+	// StartsAt is when the silence takes effect. The zero value means
+	// immediately.
+	StartsAt time.Time `json:"startsAt,omitzero"`
+
+	// EndsAt is when the silence expires. Required.
+	EndsAt time.Time `json:"endsAt"`
+
+	Comment   string    `json:"comment,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+}
+
+// Active reports whether the silence is in effect at t.
+func (s *Silence) Active(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// Matches reports whether the silence's matchers select alert, regardless
+// of the current time. Route keys and severities are compared
+// case-insensitively.
+func (s *Silence) Matches(alert *types.Alert) bool {
+	if s.RouteKey != "" && !strings.EqualFold(s.RouteKey, strings.TrimSpace(alert.RouteKey)) {
+		return false
+	}
+
+	if s.SlackChannelID != "" && s.SlackChannelID != alert.SlackChannelID {
+		return false
+	}
+
+	if s.CorrelationID != "" && s.CorrelationID != alert.CorrelationID {
+		return false
+	}
+
+	severity := normalizeSeverity(alert.Severity)
+
+	if len(s.Severities) == 0 {
+		return severity != types.AlertResolved
+	}
+
+	return slices.ContainsFunc(s.Severities, func(sev types.AlertSeverity) bool {
+		return normalizeSeverity(sev) == severity
+	})
+}
+
+// validate checks that the silence has an ID, a valid time range and at
+// least one matcher.
+func (s *Silence) validate() error {
+	if strings.TrimSpace(s.ID) == "" {
+		return errors.New("silence ID must not be empty")
+	}
+
+	if s.EndsAt.IsZero() {
+		return fmt.Errorf("silence %q: end time must be set", s.ID)
+	}
+
+	if !s.StartsAt.IsZero() && !s.EndsAt.After(s.StartsAt) {
+		return fmt.Errorf("silence %q: end time must be after start time", s.ID)
+	}
+
+	if s.RouteKey == "" && s.SlackChannelID == "" && s.CorrelationID == "" && len(s.Severities) == 0 {
+		return fmt.Errorf("silence %q: at least one matcher must be set", s.ID)
+	}
+
+	for _, severity := range s.Severities {
+		if !types.SeverityIsValid(normalizeSeverity(severity)) {
+			return fmt.Errorf("silence %q: severity must be one of %s, got %q", s.ID, strings.Join(types.ValidSeverities(), ", "), severity)
+		}
+	}
+
+	return nil
+}
+
+// SilenceConflictPolicy decides which silence wins when a local silence
+// configured with [WithSilences] has the same ID as a server silence. See
+// [WithSilenceSync].
+type SilenceConflictPolicy int
+
+const (
+	// SilencePreferServer keeps the server silence, so changes made on the
+	// server take effect without redeploying producers.
+	SilencePreferServer SilenceConflictPolicy = iota
+
+	// SilencePreferLocal keeps the local silence.
+	SilencePreferLocal
+
+	// SilencePreferNewest keeps the silence with the later UpdatedAt. Ties
+	// go to the server silence.
+	SilencePreferNewest
+)
+
+type silencesList struct {
+	Silences []*Silence `json:"silences"`
+}
+
+// silenceCache holds the local and most recently fetched server silences,
+// and drops alerts matched by any active silence.
+type silenceCache struct {
+	local  []*Silence
+	policy SilenceConflictPolicy
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	effective []*Silence
+}
+
+func newSilenceCache(local []*Silence, policy SilenceConflictPolicy) *silenceCache {
+	s := &silenceCache{
+		local:  local,
+		policy: policy,
+		now:    time.Now,
+	}
+
+	s.update(nil)
+
+	return s
+}
+
+// update replaces the server silences and recomputes the effective set.
+func (s *silenceCache) update(remote []*Silence) {
+	effective := mergeSilences(s.local, remote, s.policy)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.effective = effective
+}
+
+// mergeSilences combines local and remote silences, resolving ID conflicts
+// with policy. Remote silences come first, in their original order.
+func mergeSilences(local, remote []*Silence, policy SilenceConflictPolicy) []*Silence {
+	merged := make([]*Silence, 0, len(local)+len(remote))
+	index := make(map[string]int, len(remote))
+
+	for _, silence := range remote {
+		if silence == nil {
+			continue
+		}
+
+		index[silence.ID] = len(merged)
+		merged = append(merged, silence)
+	}
+
+	for _, silence := range local {
+		i, ok := index[silence.ID]
+		if !ok {
+			merged = append(merged, silence)
+			continue
+		}
+
+		switch policy {
+		case SilencePreferLocal:
+			merged[i] = silence
+		case SilencePreferNewest:
+			if silence.UpdatedAt.After(merged[i].UpdatedAt) {
+				merged[i] = silence
+			}
+		case SilencePreferServer:
+		}
+	}
+
+	return merged
+}
+
+// filter returns the alerts that are not matched by an active silence.
+func (s *silenceCache) filter(alerts []*types.Alert, logger RequestLogger) []*types.Alert {
+	now := s.now()
+	passthrough := make([]*types.Alert, 0, len(alerts))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, alert := range alerts {
+		idx := slices.IndexFunc(s.effective, func(silence *Silence) bool {
+			return silence.Active(now) && silence.Matches(alert)
+		})
+
+		if idx < 0 {
+			passthrough = append(passthrough, alert)
+			continue
+		}
+
+		logger.Debugf("dropping %s alert silenced by %s: %s", normalizeSeverity(alert.Severity), s.effective[idx].ID, alert.Header)
+	}
+
+	return passthrough
+}
+
+// snapshot returns copies of the effective silences that have not expired.
+func (s *silenceCache) snapshot() []Silence {
+	now := s.now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	silences := make([]Silence, 0, len(s.effective))
+
+	for _, silence := range s.effective {
+		if now.Before(silence.EndsAt) {
+			copied := *silence
+			copied.Severities = slices.Clone(silence.Severities)
+			silences = append(silences, copied)
+		}
+	}
+
+	return silences
+}
+
+// ListSilences returns all silences defined on the server. [Client.Connect]
+// must be called first. When [WithSilenceSync] is enabled, the result also
+// refreshes the client's silence cache.
+func (c *Client) ListSilences(ctx context.Context) ([]*Silence, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	var list silencesList
+	if err := c.doJSON(ctx, http.MethodGet, silencesEndpoint, nil, nil, &list); err != nil {
+		return nil, err
+	}
+
+	if c.silences != nil && c.options.silenceSync > 0 {
+		c.silences.update(list.Silences)
+	}
+
+	return list.Silences, nil
+}
+
+// Silences returns the silences currently used to suppress alerts: the
+// local silences from [WithSilences] merged with the server silences from
+// the last [WithSilenceSync] refresh. Expired silences are omitted. It
+// returns nil if neither option is set.
+func (c *Client) Silences() []Silence {
+	if c == nil || c.silences == nil {
+		return nil
+	}
+
+	return c.silences.snapshot()
+}
+
+// syncSilences refreshes the silence cache from the server.
+func (c *Client) syncSilences(ctx context.Context) {
+	if _, err := c.ListSilences(ctx); err != nil {
+		c.options.requestLogger.Errorf("failed to sync silences: %v", err)
+	}
+}
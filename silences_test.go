@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSilence_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		silence Silence
+		alert   *types.Alert
+		want    bool
+	}{
+		{"route key case-insensitive", Silence{RouteKey: "DB"}, &types.Alert{RouteKey: "db", Severity: types.AlertError}, true},
+		{"route key mismatch", Silence{RouteKey: "db"}, &types.Alert{RouteKey: "web", Severity: types.AlertError}, false},
+		{"channel", Silence{SlackChannelID: "C1"}, &types.Alert{SlackChannelID: "C1", Severity: types.AlertInfo}, true},
+		{"resolved not matched by default", Silence{RouteKey: "db"}, &types.Alert{RouteKey: "db", Severity: types.AlertResolved}, false},
+		{"resolved matched when listed", Silence{Severities: []types.AlertSeverity{types.AlertResolved}}, &types.Alert{Severity: types.AlertResolved}, true},
+		{"severity mismatch", Silence{Severities: []types.AlertSeverity{types.AlertWarning}}, &types.Alert{Severity: types.AlertError}, false},
+		{"all matchers", Silence{RouteKey: "db", CorrelationID: "x", Severities: []types.AlertSeverity{"Error"}}, &types.Alert{RouteKey: "db", CorrelationID: "x", Severity: types.AlertError}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.silence.Matches(tt.alert); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMergeSilences(t *testing.T) {
+	t.Parallel()
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	remote := []*Silence{{ID: "a", Comment: "server", UpdatedAt: older}, {ID: "b", Comment: "server", UpdatedAt: newer}}
+	local := []*Silence{{ID: "a", Comment: "local", UpdatedAt: newer}, {ID: "b", Comment: "local", UpdatedAt: older}, {ID: "c", Comment: "local"}}
+
+	tests := []struct {
+		policy SilenceConflictPolicy
+		want   []string
+	}{
+		{SilencePreferServer, []string{"server", "server", "local"}},
+		{SilencePreferLocal, []string{"local", "local", "local"}},
+		{SilencePreferNewest, []string{"local", "server", "local"}},
+	}
+
+	for _, tt := range tests {
+		merged := mergeSilences(local, remote, tt.policy)
+		if len(merged) != len(tt.want) {
+			t.Fatalf("policy %d: expected %d silences, got %d", tt.policy, len(tt.want), len(merged))
+		}
+
+		for i, silence := range merged {
+			if silence.Comment != tt.want[i] {
+				t.Errorf("policy %d: silence %s: expected %s, got %s", tt.policy, silence.ID, tt.want[i], silence.Comment)
+			}
+		}
+	}
+}
+
+func TestSilences_SuppressAndSync(t *testing.T) {
+	t.Parallel()
+
+	var posted, listed atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusOK)
+		case "/silences":
+			listed.Add(1)
+			_, _ = w.Write([]byte(`{"silences":[{"id":"maint","routeKey":"db","endsAt":"2999-01-01T00:00:00Z"}]}`))
+		case "/alerts":
+			posted.Add(1)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL,
+		WithSilences(Silence{ID: "local", SlackChannelID: "C-quiet", EndsAt: time.Now().Add(time.Hour)}),
+		WithSilenceSync(time.Hour, SilencePreferServer),
+	)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if listed.Load() != 1 {
+		t.Errorf("expected silences to be synced on connect, got %d requests", listed.Load())
+	}
+
+	if got := client.Silences(); len(got) != 2 || got[0].ID != "maint" || got[1].ID != "local" {
+		t.Errorf("unexpected effective silences %+v", got)
+	}
+
+	ctx := context.Background()
+
+	meta, err := client.SendWithResponse(ctx,
+		&types.Alert{RouteKey: "db", Severity: types.AlertError, Header: "silenced by server"},
+		&types.Alert{SlackChannelID: "C-quiet", Severity: types.AlertWarning, Header: "silenced locally"},
+	)
+	if err != nil || meta == nil || !meta.Skipped {
+		t.Fatalf("expected all alerts to be silenced, got %v, %v", meta, err)
+	}
+
+	if posted.Load() != 0 {
+		t.Errorf("expected no alerts to be posted, got %d", posted.Load())
+	}
+
+	if err := client.Send(ctx, &types.Alert{RouteKey: "db", Severity: types.AlertResolved}, &types.Alert{RouteKey: "web", Severity: types.AlertError}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if posted.Load() != 1 {
+		t.Errorf("expected unsilenced alerts to be posted, got %d requests", posted.Load())
+	}
+}
+
+func TestSilences_ExpiredIgnored(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cache := newSilenceCache([]*Silence{
+		{ID: "past", RouteKey: "db", EndsAt: now.Add(-time.Minute)},
+		{ID: "future", RouteKey: "db", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	}, SilencePreferServer)
+	cache.now = func() time.Time { return now }
+
+	alerts := cache.filter([]*types.Alert{{RouteKey: "db", Severity: types.AlertError}}, &NoopLogger{})
+	if len(alerts) != 1 {
+		t.Errorf("expected alert to pass, got %d alerts", len(alerts))
+	}
+
+	if got := cache.snapshot(); len(got) != 1 || got[0].ID != "future" {
+		t.Errorf("expected only the pending silence in the snapshot, got %+v", got)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical GET requests, so that many
+// goroutines looking up the same resource at once produce a single HTTP
+// request. The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-flight or completed request shared by every caller
+// with the same key.
+type flightCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// do returns the result of fn for key, calling fn only if no call for key
+// is already in flight. Each caller stops waiting when its own ctx is done;
+// the shared call itself is not cancelled by any single caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+
+		go func() {
+			call.body, call.err = fn(context.WithoutCancel(ctx))
+
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+
+			close(call.done)
+		}()
+	}
+
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// getCoalesced is like [Client.getJSON], but concurrent calls with the same
+// path and query share one request. Every caller decodes its own copy of
+// the response into out.
+func (c *Client) getCoalesced(ctx context.Context, path string, query url.Values, out any) error {
+	body, err := c.reads.do(ctx, path+"?"+query.Encode(), func(ctx context.Context) ([]byte, error) {
+		var raw json.RawMessage
+		err := c.getJSON(ctx, path, query, &raw)
+
+		return raw, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	return c.decodeResponse("", body, out)
+}
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"archive/zip"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// SlackExportOptions controls which messages [ReadSlackExport] converts.
+type SlackExportOptions struct {
+	// Channels restricts the import to channels with these names or IDs.
+	// Empty means all channels.
+	Channels []string
+
+	// BotMessagesOnly skips messages posted by people, keeping only
+	// messages from bots and integrations.
+	BotMessagesOnly bool
+
+	// Since and Until restrict the import to messages posted in
+	// [Since, Until). Zero values do not filter.
+	Since time.Time
+	Until time.Time
+
+	// DefaultSeverity is used for messages whose attachment colour does not
+	// imply a severity. The default is info.
+	DefaultSeverity types.AlertSeverity
+}
+
+// The slackExport types mirror the files of a Slack workspace export, so
+// their JSON tags follow Slack's snake_case names rather than ours.
+type slackExportChannel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type slackExportUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	RealName string `json:"real_name"` //nolint:tagliatelle // Slack export schema
+}
+
+type slackExportMessage struct {
+	Type        string                  `json:"type"`
+	Subtype     string                  `json:"subtype"`
+	TS          string                  `json:"ts"`
+	User        string                  `json:"user"`
+	BotID       string                  `json:"bot_id"` //nolint:tagliatelle // Slack export schema
+	Username    string                  `json:"username"`
+	Text        string                  `json:"text"`
+	Attachments []slackExportAttachment `json:"attachments"`
+	BotProfile  *struct {
+		Name string `json:"name"`
+	} `json:"bot_profile"` //nolint:tagliatelle // Slack export schema
+}
+
+type slackExportAttachment struct {
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	Fallback string `json:"fallback"`
+	Color    string `json:"color"`
+}
+
+// OpenSlackExport reads a Slack workspace export from path, which may be
+// the export zip file or a directory it was extracted to. See
+// [ReadSlackExport].
+func OpenSlackExport(path string, opts SlackExportOptions) ([]*types.Alert, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Slack export: %w", err)
+	}
+
+	if info.IsDir() {
+		return ReadSlackExport(os.DirFS(path), opts)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Slack export: %w", err)
+	}
+	defer archive.Close()
+
+	return ReadSlackExport(archive, opts)
+}
+
+// ReadSlackExport converts the messages in a Slack workspace export to
+// alerts, sorted by time. fsys is the root of the export, containing
+// channels.json and one directory of daily message files per channel; a
+// [zip.Reader] over the export archive works directly.
+//
+// Each alert keeps the message's original time in Timestamp; send the
+// alerts with [WithPreservedTimestamps] so the API does not replace it.
+// CorrelationID is derived from the channel and message timestamp, so
+// importing the same export twice does not create duplicates. The severity
+// is taken from the first attachment colour ("danger", "warning", "good")
+// where present. Join, leave, topic and other housekeeping messages are
+// skipped.
+func ReadSlackExport(fsys fs.FS, opts SlackExportOptions) ([]*types.Alert, error) {
+	var channels []slackExportChannel
+
+	for _, name := range []string{"channels.json", "groups.json"} {
+		var list []slackExportChannel
+
+		if err := readSlackExportJSON(fsys, name, &list); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		channels = append(channels, list...)
+	}
+
+	if len(channels) == 0 {
+		return nil, errors.New("slack export contains no channels.json or groups.json")
+	}
+
+	users := map[string]string{}
+
+	var userList []slackExportUser
+	if err := readSlackExportJSON(fsys, "users.json", &userList); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, u := range userList {
+		users[u.ID] = cmp.Or(u.RealName, u.Name)
+	}
+
+	defaultSeverity := cmp.Or(normalizeSeverity(opts.DefaultSeverity), types.AlertInfo)
+
+	var alerts []*types.Alert
+
+	for _, channel := range channels {
+		if len(opts.Channels) > 0 && !slices.Contains(opts.Channels, channel.Name) && !slices.Contains(opts.Channels, channel.ID) {
+			continue
+		}
+
+		files, err := fs.Glob(fsys, path.Join(channel.Name, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list messages for channel %s: %w", channel.Name, err)
+		}
+
+		slices.Sort(files)
+
+		for _, file := range files {
+			var messages []slackExportMessage
+			if err := readSlackExportJSON(fsys, file, &messages); err != nil {
+				return nil, err
+			}
+
+			for _, msg := range messages {
+				alert, ok := convertSlackMessage(channel, msg, users, defaultSeverity, opts)
+				if ok {
+					alerts = append(alerts, alert)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(alerts, func(a, b *types.Alert) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return alerts, nil
+}
+
+// convertSlackMessage converts one exported message, reporting false if it
+// should be skipped.
+func convertSlackMessage(channel slackExportChannel, msg slackExportMessage, users map[string]string, defaultSeverity types.AlertSeverity, opts SlackExportOptions) (*types.Alert, bool) {
+	if msg.Type != "message" {
+		return nil, false
+	}
+
+	switch msg.Subtype {
+	case "", "bot_message", "thread_broadcast":
+	default:
+		return nil, false
+	}
+
+	isBot := msg.BotID != "" || msg.Subtype == "bot_message"
+	if opts.BotMessagesOnly && !isBot {
+		return nil, false
+	}
+
+	timestamp, err := parseSlackTS(msg.TS)
+	if err != nil {
+		return nil, false
+	}
+
+	if (!opts.Since.IsZero() && timestamp.Before(opts.Since)) || (!opts.Until.IsZero() && !timestamp.Before(opts.Until)) {
+		return nil, false
+	}
+
+	var attachment slackExportAttachment
+	if len(msg.Attachments) > 0 {
+		attachment = msg.Attachments[0]
+	}
+
+	text := cmp.Or(strings.TrimSpace(msg.Text), strings.TrimSpace(attachment.Text), strings.TrimSpace(attachment.Fallback))
+	if text == "" && attachment.Title == "" {
+		return nil, false
+	}
+
+	header := strings.TrimSpace(attachment.Title)
+	if header == "" {
+		header, _, _ = strings.Cut(text, "\n")
+	}
+
+	author := msg.Username
+	if author == "" && msg.BotProfile != nil {
+		author = msg.BotProfile.Name
+	}
+
+	if author == "" {
+		author = users[msg.User]
+	}
+
+	alert := types.NewAlert(slackColorSeverity(attachment.Color, defaultSeverity))
+	alert.Timestamp = timestamp
+	alert.SlackChannelID = channel.ID
+	alert.CorrelationID = "slack-export/" + channel.ID + "/" + msg.TS
+	alert.Header = header
+	alert.Text = text
+	alert.FallbackText = header
+	alert.Author = author
+	alert.Metadata = map[string]any{
+		"slackExportChannel": channel.Name,
+		"slackExportTs":      msg.TS,
+	}
+
+	return alert, true
+}
+
+// slackColorSeverity maps a Slack attachment colour to a severity.
+func slackColorSeverity(color string, fallback types.AlertSeverity) types.AlertSeverity {
+	switch strings.ToLower(color) {
+	case "danger":
+		return types.AlertError
+	case "warning":
+		return types.AlertWarning
+	case "good":
+		return types.AlertResolved
+	default:
+		return fallback
+	}
+}
+
+// parseSlackTS parses a Slack message timestamp such as "1700000000.123456".
+func parseSlackTS(ts string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(ts, ".")
+
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Slack timestamp %q", ts)
+	}
+
+	var nsec int64
+
+	if frac != "" {
+		frac = (frac + "000000000")[:9]
+
+		if nsec, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid Slack timestamp %q", ts)
+		}
+	}
+
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+func readSlackExportJSON(fsys fs.FS, name string, out any) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s from Slack export: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s from Slack export: %w", name, err)
+	}
+
+	return nil
+}
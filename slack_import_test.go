@@ -0,0 +1,159 @@
+package client
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func slackExportFS() fstest.MapFS {
+	return fstest.MapFS{
+		"channels.json": {Data: []byte(`[{"id":"C001","name":"alerts"},{"id":"C002","name":"general"}]`)},
+		"users.json":    {Data: []byte(`[{"id":"U1","name":"alice","real_name":"Alice Smith"}]`)},
+		"alerts/2024-01-02.json": {Data: []byte(`[
+			{"type":"message","subtype":"bot_message","ts":"1704200000.000200","bot_id":"B1","username":"nagios",
+			 "attachments":[{"title":"Disk full on db1","text":"95% used","color":"danger"}]},
+			{"type":"message","subtype":"channel_join","ts":"1704200100.000000","user":"U1","text":"joined"}
+		]`)},
+		"alerts/2024-01-01.json": {Data: []byte(`[
+			{"type":"message","ts":"1704100000.500000","user":"U1","text":"Restarted db1\nall good now"}
+		]`)},
+		"general/2024-01-01.json": {Data: []byte(`[
+			{"type":"message","ts":"1704150000.000000","user":"U1","text":"hello",
+			 "attachments":[{"color":"warning"}]}
+		]`)},
+	}
+}
+
+func TestReadSlackExport(t *testing.T) {
+	t.Parallel()
+
+	alerts, err := ReadSlackExport(slackExportFS(), SlackExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(alerts) != 3 {
+		t.Fatalf("expected 3 alerts, got %d", len(alerts))
+	}
+
+	first := alerts[0]
+
+	if first.Header != "Restarted db1" || first.Author != "Alice Smith" || first.Severity != types.AlertInfo {
+		t.Errorf("unexpected first alert: %+v", first)
+	}
+
+	if want := time.Unix(1704100000, 500000000).UTC(); !first.Timestamp.Equal(want) {
+		t.Errorf("expected timestamp %v, got %v", want, first.Timestamp)
+	}
+
+	if alerts[1].SlackChannelID != "C002" || alerts[1].Severity != types.AlertWarning {
+		t.Errorf("expected general channel warning second, got %+v", alerts[1])
+	}
+
+	bot := alerts[2]
+
+	if bot.Header != "Disk full on db1" || bot.Text != "95% used" || bot.Severity != types.AlertError || bot.Author != "nagios" {
+		t.Errorf("unexpected bot alert: %+v", bot)
+	}
+
+	if bot.CorrelationID != "slack-export/C001/1704200000.000200" {
+		t.Errorf("unexpected correlation ID %q", bot.CorrelationID)
+	}
+
+	if bot.Metadata["slackExportChannel"] != "alerts" {
+		t.Errorf("unexpected metadata %v", bot.Metadata)
+	}
+}
+
+func TestReadSlackExport_Filters(t *testing.T) {
+	t.Parallel()
+
+	alerts, err := ReadSlackExport(slackExportFS(), SlackExportOptions{Channels: []string{"alerts"}, BotMessagesOnly: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(alerts) != 1 || alerts[0].Header != "Disk full on db1" {
+		t.Errorf("expected only the bot message, got %d alerts", len(alerts))
+	}
+
+	alerts, err = ReadSlackExport(slackExportFS(), SlackExportOptions{
+		Since:           time.Unix(1704120000, 0),
+		Until:           time.Unix(1704200000, 0),
+		DefaultSeverity: types.AlertWarning,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(alerts) != 1 || alerts[0].SlackChannelID != "C002" {
+		t.Errorf("expected only the general message in range, got %d alerts", len(alerts))
+	}
+
+	if _, err := ReadSlackExport(fstest.MapFS{}, SlackExportOptions{}); err == nil {
+		t.Error("expected error for export without channels")
+	}
+}
+
+func TestOpenSlackExport_Zip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "export.zip")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	zw := zip.NewWriter(f)
+	for name, file := range slackExportFS() {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, _ = w.Write(file.Data)
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f.Close()
+
+	alerts, err := OpenSlackExport(path, SlackExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(alerts) != 3 {
+		t.Errorf("expected 3 alerts, got %d", len(alerts))
+	}
+
+	if _, err := OpenSlackExport(filepath.Join(t.TempDir(), "missing.zip"), SlackExportOptions{}); err == nil {
+		t.Error("expected error for missing export")
+	}
+}
+
+func TestParseSlackTS(t *testing.T) {
+	t.Parallel()
+
+	ts, err := parseSlackTS("1700000000.000123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ts.Unix() != 1700000000 || ts.Nanosecond() != 123000 {
+		t.Errorf("unexpected time %v", ts)
+	}
+
+	if _, err := parseSlackTS("abc"); err == nil {
+		t.Error("expected error for invalid timestamp")
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// validateWebhookURL checks that rawURL is an absolute https URL.
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: must be an absolute https URL", sanitizeURL(rawURL))
+	}
+
+	return nil
+}
+
+// slackWebhookMessage is the body of a Slack incoming webhook request.
+type slackWebhookMessage struct {
+	Text string `json:"text"`
+}
+
+// postSlackWebhook posts text to a Slack incoming webhook. It deliberately
+// bypasses the manager API client, since it is used when the API is
+// failing.
+func (c *Client) postSlackWebhook(ctx context.Context, webhookURL, text string) error {
+	body, err := json.Marshal(slackWebhookMessage{Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", c.options.userAgent)
+
+	client := c.webhookClient
+	if client == nil {
+		client = &http.Client{Timeout: c.options.timeout}
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		// The URL contains the webhook secret, so only report the host.
+		return fmt.Errorf("POST to Slack webhook at %s failed: %w", request.URL.Host, unwrapURLError(err))
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("POST to Slack webhook at %s failed with status code %d: %s", request.URL.Host, response.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// unwrapURLError strips the *url.Error wrapper, whose message includes the
+// full request URL.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// SlogLogger is a [RequestLogger] that writes to a [slog.Logger]. Errorf,
+// Warnf and Debugf log at [slog.LevelError], [slog.LevelWarn] and
+// [slog.LevelDebug], and the fields of [WithContextFields] are logged as
+// attributes, since SlogLogger implements [FieldLogger]. Messages below
+// the handler's level are not formatted.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a [SlogLogger] writing to logger, or to
+// [slog.Default] if logger is nil.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &SlogLogger{logger: logger}
+}
+
+func (l *SlogLogger) Errorf(format string, v ...any) {
+	l.log(slog.LevelError, format, v)
+}
+
+func (l *SlogLogger) Warnf(format string, v ...any) {
+	l.log(slog.LevelWarn, format, v)
+}
+
+func (l *SlogLogger) Debugf(format string, v ...any) {
+	l.log(slog.LevelDebug, format, v)
+}
+
+// WithFields implements [FieldLogger], returning a logger that adds fields
+// as attributes, sorted by key.
+func (l *SlogLogger) WithFields(fields map[string]any) RequestLogger { //nolint:ireturn // signature fixed by FieldLogger
+	args := make([]any, 0, 2*len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		args = append(args, key, fields[key])
+	}
+
+	return &SlogLogger{logger: l.logger.With(args...)}
+}
+
+// log logs the message at level, with the caller of Errorf, Warnf or
+// Debugf as its source.
+func (l *SlogLogger) log(level slog.Level, format string, v []any) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip Callers, log and Errorf, Warnf or Debugf
+
+	record := slog.NewRecord(time.Now(), level, fmt.Sprintf(format, v...), pcs[0])
+	_ = l.logger.Handler().Handle(ctx, record)
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON log records written to b.
+func (b *syncBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]any
+
+	for line := range strings.Lines(b.buf.String()) {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log record %q: %v", line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	var out syncBuffer
+
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn, AddSource: true})))
+
+	logger.Errorf("POST %s failed: %v", "/alerts", "timeout")
+	logger.Warnf("retrying in %v", "1s")
+	logger.Debugf("not logged %d", 1)
+	logger.WithFields(map[string]any{"tenant": "payments", "attempt": 2}).Warnf("slow request")
+
+	records := out.records(t)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %v", records)
+	}
+
+	if records[0]["level"] != "ERROR" || records[0]["msg"] != "POST /alerts failed: timeout" {
+		t.Errorf("unexpected error record %v", records[0])
+	}
+
+	if records[1]["level"] != "WARN" || records[1]["msg"] != "retrying in 1s" {
+		t.Errorf("unexpected warning record %v", records[1])
+	}
+
+	if records[2]["tenant"] != "payments" || records[2]["attempt"] != float64(2) {
+		t.Errorf("expected fields as attributes, got %v", records[2])
+	}
+
+	source, _ := records[0]["source"].(map[string]any)
+	if file, _ := source["file"].(string); !strings.HasSuffix(file, "slog_logger_test.go") {
+		t.Errorf("expected the caller as the source, got %v", records[0]["source"])
+	}
+}
+
+func TestSlogLogger_ContextFields(t *testing.T) {
+	t.Parallel()
+
+	var out syncBuffer
+
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&out, nil)))
+	client := New(newDeprecatedServer(t).URL, WithRequestLogger(logger), WithContextFields(tenantFields))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey("tenant"), "payments")
+
+	if err := client.Send(ctx, types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := out.records(t)
+	if len(records) != 1 || records[0]["level"] != "WARN" || records[0]["tenant"] != "payments" || records[0]["traceId"] != "abc123" {
+		t.Errorf("expected the deprecation warning with the context fields, got %v", records)
+	}
+}
+
+func TestNewSlogLogger_Default(t *testing.T) {
+	t.Parallel()
+
+	if logger := NewSlogLogger(nil); logger.logger != slog.Default() {
+		t.Error("expected the default logger")
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+// minSnippetThreshold is the smallest threshold accepted by
+// [WithSnippetConversion], which leaves room for the start of the text
+// next to the snippet link.
+const minSnippetThreshold = 200
+
+// convertSnippets replaces the text of each alert longer than the
+// [WithSnippetConversion] threshold with its start and a link to the full
+// text, uploaded as an [AssetSnippet]. The caller's alerts are not
+// changed; the returned slice holds modified copies. If an upload fails,
+// the alert is sent as it is and the API truncates its text.
+func (c *Client) convertSnippets(ctx context.Context, alerts []*types.Alert) []*types.Alert {
+	threshold := c.options.snippetThreshold
+
+	// Snippets are stored unencrypted, so text that must be encrypted is
+	// never converted.
+	if threshold == 0 || slices.Contains(c.options.encryptFields, "text") {
+		return alerts
+	}
+
+	copied := false
+
+	for i, alert := range alerts {
+		if utf8.RuneCountInString(alert.Text) <= threshold {
+			continue
+		}
+
+		asset, err := c.uploadSnippet(ctx, alert.Text)
+		if err != nil {
+			c.logger(ctx).Warnf("failed to upload the text of alert at index %d as a snippet, so it will be truncated: %s", i, err)
+			continue
+		}
+
+		if !copied {
+			alerts = slices.Clone(alerts)
+			copied = true
+		}
+
+		modified := *alert
+		modified.Text = snippetText(alert.Text, asset.URL, threshold)
+		alerts[i] = &modified
+	}
+
+	return alerts
+}
+
+// uploadSnippet uploads text as a snippet named after its hash, so the
+// same text is only stored once.
+func (c *Client) uploadSnippet(ctx context.Context, text string) (*Asset, error) {
+	sum := sha256.Sum256([]byte(text))
+	name := "snippet-" + hex.EncodeToString(sum[:8]) + ".txt"
+
+	asset, err := c.UploadAsset(ctx, AssetSnippet, name, "text/plain; charset=utf-8", []byte(text))
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.URL == "" {
+		return nil, fmt.Errorf("API returned no URL for snippet %s", name)
+	}
+
+	return asset, nil
+}
+
+// snippetText returns the start of text followed by a link to url, at
+// most threshold characters long in total. A code block cut short is
+// closed, so the link is not rendered as code.
+func snippetText(text, url string, threshold int) string {
+	link := fmt.Sprintf("\n… <%s|View the full text (%d characters)>", url, utf8.RuneCountInString(text))
+	keep := max(threshold-utf8.RuneCountInString(link)-3, 0)
+
+	runes := []rune(text)
+	start := strings.TrimSpace(string(runes[:min(keep, len(runes))]))
+
+	if strings.Count(start, "```")%2 == 1 {
+		start += "```"
+	}
+
+	return start + link
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithSnippetConversion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		snippets = map[string]string{}
+		sent     []*types.Alert
+		failPuts bool
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/ping":
+		case r.URL.Path == "/assets":
+			_, _ = w.Write([]byte(`{"assets":[]}`))
+		case strings.HasPrefix(r.URL.Path, "/assets/snippet/") && r.Method == http.MethodPut:
+			if failPuts {
+				http.Error(w, `{"error":"storage full"}`, http.StatusBadRequest)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			name := strings.TrimPrefix(r.URL.Path, "/assets/snippet/")
+			snippets[name] = string(body)
+
+			_ = json.NewEncoder(w).Encode(Asset{Kind: AssetSnippet, Name: name, ContentType: r.Header.Get("Content-Type"), URL: "https://slackmgr/assets/snippet/" + name})
+		default:
+			var list alertsList
+			_ = json.NewDecoder(r.Body).Decode(&list)
+			sent = list.Alerts
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithSnippetConversion(300))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	long := types.NewAlert(types.AlertError)
+	long.Text = "```" + strings.Repeat("trace line ", 100) + "```"
+
+	short := types.NewAlert(types.AlertInfo)
+	short.Text = "all good"
+
+	if err := client.Send(context.Background(), long, short); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+
+	if len(snippets) != 1 {
+		t.Fatalf("expected one snippet upload, got %v", snippets)
+	}
+
+	var name string
+	for n, content := range snippets {
+		name = n
+		if content != long.Text {
+			t.Errorf("expected the snippet to hold the full text, got %q", content)
+		}
+	}
+
+	text := sent[0].Text
+	if utf8.RuneCountInString(text) > 300 || !strings.HasPrefix(text, "```trace line") || !strings.HasSuffix(text, "/assets/snippet/"+name+"|View the full text (1106 characters)>") {
+		t.Errorf("unexpected converted text %q", text)
+	}
+
+	if strings.Count(text, "```") != 2 {
+		t.Errorf("expected the cut code block to be closed, got %q", text)
+	}
+
+	if sent[1].Text != "all good" {
+		t.Errorf("expected short text to be sent as is, got %q", sent[1].Text)
+	}
+
+	if utf8.RuneCountInString(long.Text) != 1106 {
+		t.Error("expected the caller's alert to be left unchanged")
+	}
+
+	failPuts = true
+	mu.Unlock()
+
+	long.Text = strings.Repeat("x", 400)
+
+	if err := client.Send(context.Background(), long); err != nil {
+		t.Fatalf("expected a failed upload not to fail the send, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if sent[0].Text != long.Text {
+		t.Errorf("expected the full text to be sent when the upload fails, got %d characters", len(sent[0].Text))
+	}
+}
+
+func TestWithSnippetConversion_Options(t *testing.T) {
+	t.Parallel()
+
+	for _, threshold := range []int{0, 199, types.MaxTextLength + 1} {
+		client := New("http://localhost", WithSnippetConversion(threshold))
+		if client.options.snippetThreshold != 0 || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected threshold %d to be ignored with a warning, got %v", threshold, client.ConfigWarnings())
+		}
+	}
+
+	if got := New("http://localhost", WithSnippetConversion(500)).EffectiveConfig().SnippetThreshold; got != 500 {
+		t.Errorf("expected the threshold in the snapshot, got %d", got)
+	}
+
+	client := New("http://localhost", WithSnippetConversion(200), WithFieldEncryption(staticKeys{key: make([]byte, 32)}, "text"))
+	alerts := []*types.Alert{{Text: strings.Repeat("x", 300)}}
+
+	if got := client.convertSnippets(context.Background(), alerts); got[0] != alerts[0] {
+		t.Error("expected encrypted text not to be converted")
+	}
+}
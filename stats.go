@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Stats is a point-in-time snapshot of a client's runtime state, as
+// returned by [Client.Stats].
+type Stats struct {
+	// Deprecations lists the endpoints for which the API has announced
+	// deprecation, in the order they were first seen.
+	Deprecations []DeprecationNotice
+
+	// AdaptiveBackoff reports the state of [WithAdaptiveBackoff].
+	AdaptiveBackoff AdaptiveBackoffStats
+
+	// LookupCache reports the state of [WithLookupCache].
+	LookupCache LookupCacheStats
+
+	// SlowRequests reports requests slower than [WithSlowRequestThreshold].
+	SlowRequests SlowRequestStats
+
+	// Timing accumulates the timing breakdown of every request attempt.
+	Timing TimingStats
+
+	// Reresolves counts the times [WithReresolveOnFailure] closed the idle
+	// connections after repeated connection failures.
+	Reresolves int64
+
+	// Async reports the state of [WithAsyncMode].
+	Async AsyncStats
+
+	// RateLimit reports the state of [WithRateLimit].
+	RateLimit RateLimitStats
+
+	// Quarantine reports the state of [WithQuarantine].
+	Quarantine QuarantineStats
+}
+
+// AdaptiveBackoffStats reports the state of adaptive retry backoff.
+type AdaptiveBackoffStats struct {
+	// Enabled reports whether [WithAdaptiveBackoff] is in effect.
+	Enabled bool
+
+	// Multiplier is the factor currently applied to retry wait times.
+	Multiplier float64
+
+	// FailureRate is the fraction of recent request attempts that failed
+	// with an overload response or a connection error.
+	FailureRate float64
+}
+
+// DeprecationNotice records the Deprecation and Sunset response headers
+// (RFC 9745 and RFC 8594) returned for an endpoint.
+type DeprecationNotice struct {
+	// Endpoint is the request method and path, e.g. "POST alerts".
+	Endpoint string
+
+	// Deprecation is the raw Deprecation header value, if any.
+	Deprecation string
+
+	// Sunset is the time after which the endpoint may stop responding, or
+	// the zero time if no valid Sunset header was returned.
+	Sunset time.Time
+
+	// Link is the raw Link header value, which usually points to migration
+	// documentation.
+	Link string
+
+	// FirstSeen is when the notice was first observed.
+	FirstSeen time.Time
+}
+
+// clientStats accumulates the state reported by [Client.Stats]. It is
+// shared between a client and the clients derived from it.
+type clientStats struct {
+	mu             sync.Mutex
+	deprecations   []DeprecationNotice
+	slowRequests   int64
+	slowestRequest time.Duration
+	timing         TimingStats
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{}
+}
+
+// Stats returns a snapshot of the client's runtime state.
+func (c *Client) Stats() Stats {
+	if c == nil || c.stats == nil {
+		return Stats{}
+	}
+
+	c.stats.mu.Lock()
+	stats := Stats{
+		Deprecations: slices.Clone(c.stats.deprecations),
+		SlowRequests: SlowRequestStats{
+			Enabled: c.options.slowThreshold > 0,
+			Count:   c.stats.slowRequests,
+			Max:     c.stats.slowestRequest,
+		},
+		Timing: c.stats.timing,
+	}
+	c.stats.mu.Unlock()
+
+	if c.adaptive != nil {
+		stats.AdaptiveBackoff.Enabled = true
+		stats.AdaptiveBackoff.Multiplier, stats.AdaptiveBackoff.FailureRate = c.adaptive.state()
+	}
+
+	if c.lookups != nil {
+		stats.LookupCache = c.lookups.snapshot()
+	}
+
+	if c.reresolve != nil {
+		stats.Reresolves = c.reresolve.resets.Load()
+	}
+
+	if c.async != nil {
+		stats.Async = c.async.stats()
+	}
+
+	if c.rateLimit != nil {
+		stats.RateLimit = c.rateLimit.stats()
+	}
+
+	if c.quarantine != nil {
+		stats.Quarantine = c.quarantine.stats()
+	}
+
+	return stats
+}
+
+// observeDeprecation records Deprecation and Sunset headers returned for
+// endpoint, logging a warning the first time each endpoint reports them.
+func (c *Client) observeDeprecation(ctx context.Context, endpoint string, response *resty.Response) {
+	header := response.Header()
+
+	deprecation := header.Get("Deprecation")
+	sunset := header.Get("Sunset")
+
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	notice := DeprecationNotice{
+		Endpoint:    endpoint,
+		Deprecation: deprecation,
+		Link:        header.Get("Link"),
+		FirstSeen:   time.Now(),
+	}
+
+	if t, err := http.ParseTime(sunset); err == nil {
+		notice.Sunset = t
+	}
+
+	c.stats.mu.Lock()
+
+	if slices.ContainsFunc(c.stats.deprecations, func(n DeprecationNotice) bool { return n.Endpoint == endpoint }) {
+		c.stats.mu.Unlock()
+		return
+	}
+
+	c.stats.deprecations = append(c.stats.deprecations, notice)
+	c.stats.mu.Unlock()
+
+	c.logger(ctx).Warnf("API endpoint %s is deprecated (Deprecation: %q, Sunset: %q, Link: %q)", endpoint, deprecation, sunset, notice.Link)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestStats_Deprecations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.Header().Set("Deprecation", "@1735689600")
+			w.Header().Set("Sunset", "Wed, 31 Dec 2026 23:59:59 GMT")
+			w.Header().Set("Link", `<https://example.com/migrate>; rel="deprecation"`)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+
+	client := New(server.URL, WithRequestLogger(logger))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats := client.Stats(); len(stats.Deprecations) != 0 {
+		t.Fatalf("expected no deprecations after ping, got %+v", stats.Deprecations)
+	}
+
+	for range 3 {
+		if err := client.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats := client.Stats()
+	if len(stats.Deprecations) != 1 {
+		t.Fatalf("expected 1 deprecation, got %+v", stats.Deprecations)
+	}
+
+	notice := stats.Deprecations[0]
+
+	if notice.Endpoint != "POST alerts" {
+		t.Errorf("expected endpoint 'POST alerts', got %q", notice.Endpoint)
+	}
+
+	if notice.Deprecation != "@1735689600" {
+		t.Errorf("unexpected Deprecation value %q", notice.Deprecation)
+	}
+
+	if want := time.Date(2026, time.December, 31, 23, 59, 59, 0, time.UTC); !notice.Sunset.Equal(want) {
+		t.Errorf("expected Sunset=%v, got %v", want, notice.Sunset)
+	}
+
+	if !strings.Contains(notice.Link, "migrate") {
+		t.Errorf("unexpected Link value %q", notice.Link)
+	}
+
+	var logged int
+	for _, w := range logger.warnings() {
+		if strings.Contains(w, "API endpoint POST alerts is deprecated") {
+			logged++
+		}
+	}
+
+	if logged != 1 {
+		t.Errorf("expected the deprecation to be logged once, got %d", logged)
+	}
+
+	if derived, err := client.With(context.Background()); err != nil || len(derived.Stats().Deprecations) != 1 {
+		t.Error("expected derived clients to share stats")
+	}
+}
+
+func TestStats_NilClient(t *testing.T) {
+	t.Parallel()
+
+	var client *Client
+
+	if stats := client.Stats(); stats.Deprecations != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	jsonContentType = "application/json"
+
+	// maxSchemaErrorBody is the number of bytes of the response body quoted
+	// in the message of a [ResponseSchemaError].
+	maxSchemaErrorBody = 1 << 10
+)
+
+// ResponseSchemaError reports a successful response that does not match
+// the schema the client expects, with [WithStrictResponses].
+type ResponseSchemaError struct {
+	// ContentType is the Content-Type header of the response, if known.
+	ContentType string
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Err describes the mismatch, such as a field the client does not
+	// know.
+	Err error
+}
+
+func (e *ResponseSchemaError) Error() string {
+	body := e.Body
+	if len(body) > maxSchemaErrorBody {
+		body = body[:maxSchemaErrorBody]
+		for len(body) > 0 && !utf8.Valid(body) {
+			body = body[:len(body)-1]
+		}
+
+		body = append(bytes.Clone(body), "..."...)
+	}
+
+	return fmt.Sprintf("response does not match the expected schema: %v (body: %s)", e.Err, body)
+}
+
+func (e *ResponseSchemaError) Unwrap() error { return e.Err }
+
+// decodeResponse decodes the JSON response body into out. With
+// [WithStrictResponses], fields out does not have, data after the JSON
+// value and a Content-Type other than JSON are errors too, returned as a
+// [*ResponseSchemaError]. An empty contentType is not checked.
+func (c *Client) decodeResponse(contentType string, body []byte, out any) error {
+	if !c.options.strictResponses {
+		return json.Unmarshal(body, out)
+	}
+
+	if err := strictDecode(contentType, body, out); err != nil {
+		return &ResponseSchemaError{ContentType: contentType, Body: body, Err: err}
+	}
+
+	return nil
+}
+
+func strictDecode(contentType string, body []byte, out any) error {
+	if contentType != "" {
+		if media, _, err := mime.ParseMediaType(contentType); err != nil || (media != jsonContentType && !strings.HasSuffix(media, "+json")) {
+			return fmt.Errorf("unexpected content type %q", contentType)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+
+	if decoder.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+
+	return nil
+}
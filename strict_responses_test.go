@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newSilencesServer answers GET /silences with body as contentType.
+func newSilencesServer(t *testing.T, contentType, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/silences" {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestWithStrictResponses(t *testing.T) {
+	t.Parallel()
+
+	for body, want := range map[string]string{
+		`{"silences": [{"id": "s1", "owner": "ops"}]}`: `unknown field "owner"`,
+		`{"silences": [{"id": 1}]}`:                    "cannot unmarshal number",
+		`{"silences": []} {"silences": []}`:            "unexpected data after the JSON value",
+	} {
+		url := newSilencesServer(t, "application/json; charset=utf-8", body)
+
+		client := New(url, WithStrictResponses())
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+		t.Cleanup(client.Close)
+
+		_, err := client.ListSilences(context.Background())
+
+		var schemaErr *ResponseSchemaError
+		if !errors.As(err, &schemaErr) || !strings.Contains(err.Error(), want) || string(schemaErr.Body) != body {
+			t.Errorf("expected a schema error containing %q with the body, got %v", want, err)
+		}
+	}
+}
+
+func TestWithStrictResponses_Off(t *testing.T) {
+	t.Parallel()
+
+	client := connectTo(t, newSilencesServer(t, "text/plain", `{"silences": [{"id": "s1", "owner": "ops"}]}`))
+
+	silences, err := client.ListSilences(context.Background())
+	if err != nil || len(silences) != 1 || silences[0].ID != "s1" {
+		t.Errorf("expected unknown fields to be ignored without strict mode, got %v, %v", silences, err)
+	}
+}
+
+func TestWithStrictResponses_ContentType(t *testing.T) {
+	t.Parallel()
+
+	client := New(newSilencesServer(t, "text/html", `{"silences": []}`), WithStrictResponses())
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	_, err := client.ListSilences(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `unexpected content type "text/html"`) {
+		t.Errorf("expected the content type to be rejected, got %v", err)
+	}
+
+	if !client.EffectiveConfig().StrictResponses {
+		t.Error("expected strict responses in the config snapshot")
+	}
+}
+
+func TestResponseSchemaError_LongBody(t *testing.T) {
+	t.Parallel()
+
+	err := &ResponseSchemaError{Body: []byte(strings.Repeat("é", maxSchemaErrorBody)), Err: errors.New("bad")}
+	if msg := err.Error(); !strings.HasSuffix(msg, "é...)") || len(msg) > maxSchemaErrorBody+100 {
+		t.Errorf("expected the body to be cut at a character boundary, got %d bytes ending %q", len(msg), msg[len(msg)-10:])
+	}
+}
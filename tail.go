@@ -0,0 +1,351 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// FeatureAlertStream is the [Capabilities] feature of APIs that stream
+	// alerts as server-sent events, which [Client.Tail] uses when
+	// supported.
+	FeatureAlertStream = "alertStream"
+
+	streamPath = "stream"
+	tailPath   = "tail"
+
+	maxTailWait      = 30 * time.Second
+	minTailPoll      = time.Second
+	maxTailEventSize = 1 << 20
+)
+
+// errTailStopped reports that the consumer of a tail stopped iterating.
+var errTailStopped = errors.New("tail stopped")
+
+// tailResponseError is an error response from the API, which ends a tail
+// instead of reconnecting.
+type tailResponseError struct {
+	err error
+}
+
+func (e *tailResponseError) Error() string { return e.err.Error() }
+func (e *tailResponseError) Unwrap() error { return e.err }
+
+// Tail returns an iterator over the alerts matching filter as the API
+// receives them, for live dashboards and terminal tools. It streams
+// server-sent events from GET <alerts endpoint>/stream if the API
+// advertises [FeatureAlertStream] in its [Capabilities], and otherwise
+// long-polls GET <alerts endpoint>/tail. Since, if set, replays the alerts
+// received after it first; filter must not set Until.
+//
+// A dropped connection is re-established, resuming after the last alert
+// received, with the backoff of [WithRetryWaitTime] and
+// [WithRetryMaxWaitTime]. Iteration stops, yielding the error with a nil
+// alert, on an error response or once reconnecting has failed more often
+// in a row than [WithRetryCount] allows. It ends without an error when ctx
+// is done. [Client.Connect] must be called first.
+func (c *Client) Tail(ctx context.Context, filter AlertFilter) iter.Seq2[*types.Alert, error] {
+	return func(yield func(*types.Alert, error) bool) {
+		if err := c.checkListRequest(filter.PageSize); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		if !filter.Until.IsZero() {
+			yield(nil, errors.New("tail filter must not set Until"))
+			return
+		}
+
+		t := &tail{c: c, query: filter.query()}
+		next := t.poll
+
+		if capabilities, err := c.Capabilities(ctx); err == nil && capabilities.Supports(FeatureAlertStream) {
+			next = t.stream
+		}
+
+		t.run(ctx, next, yield)
+	}
+}
+
+// tail follows the alerts feed for [Client.Tail].
+type tail struct {
+	c     *Client
+	query url.Values
+
+	// cursor is the ID of the last event or the cursor of the last page
+	// received, which a new connection resumes after.
+	cursor string
+
+	// retry overrides the reconnect backoff, as requested by the retry
+	// field of an event stream.
+	retry time.Duration
+
+	// pause is the wait before the next poll.
+	pause time.Duration
+}
+
+// tailConnection reads alerts from one connection to the feed until it
+// ends. It reports whether anything was received.
+type tailConnection func(ctx context.Context, yield func(*types.Alert, error) bool) (bool, error)
+
+// run reads from connections made with next until the consumer stops,
+// ctx is done or the feed fails.
+func (t *tail) run(ctx context.Context, next tailConnection, yield func(*types.Alert, error) bool) {
+	failures := 0
+
+	for {
+		received, err := next(ctx, yield)
+
+		switch {
+		case ctx.Err() != nil, errors.Is(err, errTailStopped):
+			return
+		case received:
+			failures = 0
+		case err != nil:
+			failures++
+		}
+
+		var response *tailResponseError
+		if errors.As(err, &response) || failures > t.c.options.retryCount {
+			yield(nil, err)
+			return
+		}
+
+		if err != nil {
+			t.c.logger(ctx).Warnf("alert tail disconnected, reconnecting: %v", err)
+		}
+
+		if err := sleepFor(ctx, t.backoff(failures)); err != nil {
+			return
+		}
+	}
+}
+
+// backoff returns the wait before reconnecting after the given number of
+// failures in a row, unless the stream asked for another.
+func (t *tail) backoff(failures int) time.Duration {
+	delay := t.pause
+
+	switch {
+	case failures == 0:
+	case t.retry > 0:
+		delay = t.retry
+	default:
+		delay = min(t.c.options.retryWaitTime<<min(failures-1, 16), t.c.options.retryMaxWaitTime)
+	}
+
+	if retryDelay := t.c.retryDelayFunc(); retryDelay != nil {
+		delay = retryDelay(delay)
+	}
+
+	return delay
+}
+
+// poll long-polls for the next page of alerts.
+func (t *tail) poll(ctx context.Context, yield func(*types.Alert, error) bool) (bool, error) {
+	query := maps.Clone(t.query)
+	query.Set("wait", strconv.Itoa(int(min(t.c.options.timeout/2, maxTailWait).Seconds()))+"s")
+
+	if t.cursor != "" {
+		query.Set("cursor", t.cursor)
+	}
+
+	path := t.c.options.alertsEndpoint + "/" + tailPath
+
+	var page alertsPage
+
+	status, err := t.c.doJSONRequest(ctx, http.MethodGet, path, query, nil, &page)
+	if err != nil && status != 0 {
+		return false, &tailResponseError{err: err}
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if page.NextCursor != "" {
+		t.cursor = page.NextCursor
+	}
+
+	for _, alert := range page.Alerts {
+		if !yield(alert, nil) {
+			return true, errTailStopped
+		}
+	}
+
+	// An API that answers at once, rather than holding the request until
+	// alerts arrive, is not polled in a busy loop.
+	t.pause = 0
+	if len(page.Alerts) == 0 {
+		t.pause = minTailPoll
+	}
+
+	return len(page.Alerts) > 0 || page.NextCursor != "", nil
+}
+
+// stream reads server-sent events from one connection to the stream
+// endpoint.
+func (t *tail) stream(ctx context.Context, yield func(*types.Alert, error) bool) (bool, error) {
+	response, err := t.openStream(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	received := false
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTailEventSize)
+
+	var event sseEvent
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line != "" {
+			if retry, ok := event.parse(line); ok {
+				t.retry = retry
+			}
+
+			continue
+		}
+
+		if event.id != "" {
+			t.cursor = event.id
+		}
+
+		alert, err := event.alert(t.c)
+		event = sseEvent{}
+
+		if err != nil {
+			return received, &tailResponseError{err: err}
+		}
+
+		if alert == nil {
+			continue
+		}
+
+		received = true
+
+		if !yield(alert, nil) {
+			return true, errTailStopped
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return received, fmt.Errorf("failed to read alert stream: %w", err)
+	}
+
+	return received, io.ErrUnexpectedEOF
+}
+
+// openStream connects to the stream endpoint. The request bypasses the
+// request timeout, which would end the stream, but otherwise carries the
+// headers of the client's other requests.
+func (t *tail) openStream(ctx context.Context) (*http.Response, error) {
+	path := t.c.options.alertsEndpoint + "/" + streamPath
+
+	target, err := url.JoinPath(t.c.client.BaseURL, path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream URL: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", path, err)
+	}
+
+	request.URL.RawQuery = t.query.Encode()
+	request.Header = t.c.client.Header.Clone()
+	request.Header.Set("Accept", "text/event-stream")
+
+	if t.cursor != "" {
+		request.Header.Set("Last-Event-ID", t.cursor)
+	}
+
+	t.c.headers.apply(request)
+
+	if t.c.options.tracer != nil {
+		t.c.options.tracer.Inject(ctx, request.Header)
+	}
+
+	client := *t.c.client.GetClient()
+	client.Timeout = 0
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", path, err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxTailEventSize))
+		err := fmt.Errorf("GET %s failed with status code %d: %s", sanitizeURL(request.URL.String()), response.StatusCode, bytes.TrimSpace(body))
+
+		return nil, &tailResponseError{err: err}
+	}
+
+	return response, nil
+}
+
+// sseEvent is a server-sent event being read.
+type sseEvent struct {
+	id    string
+	event string
+	data  strings.Builder
+}
+
+// parse adds a line of the event stream to the event. It returns the
+// reconnect delay of a retry field.
+func (e *sseEvent) parse(line string) (time.Duration, bool) {
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+
+	switch field {
+	case "id":
+		e.id = value
+	case "event":
+		e.event = value
+	case "data":
+		if e.data.Len() > 0 {
+			e.data.WriteByte('\n')
+		}
+
+		e.data.WriteString(value)
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+			return time.Duration(ms) * time.Millisecond, true
+		}
+	}
+
+	return 0, false
+}
+
+// alert decodes the alert of an alert event for c. Comments, such as
+// keep-alives, and events of other types return nil.
+func (e *sseEvent) alert(c *Client) (*types.Alert, error) {
+	if e.data.Len() == 0 || (e.event != "" && e.event != "alert") {
+		return nil, nil //nolint:nilnil // not an alert event
+	}
+
+	alert := &types.Alert{}
+	if err := c.decodeResponse("", []byte(e.data.String()), alert); err != nil {
+		return nil, fmt.Errorf("failed to decode alert event %q: %w", e.id, err)
+	}
+
+	return alert, nil
+}
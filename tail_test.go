@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newTailClient connects to handler, reconnecting tails without waiting.
+func newTailClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(server.URL)
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// collectTail returns the headers of the first n alerts of a tail.
+func collectTail(t *testing.T, client *Client, filter AlertFilter, n int) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var headers []string
+
+	for alert, err := range client.Tail(ctx, filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if headers = append(headers, alert.Header); len(headers) == n {
+			break
+		}
+	}
+
+	return headers
+}
+
+func writeAlertEvent(w http.ResponseWriter, id, header string) {
+	_, _ = fmt.Fprintf(w, "id: %s\nevent: alert\ndata: {\"header\": %q}\n\n", id, header)
+}
+
+func TestTail_Stream(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+
+	client := newTailClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capabilities":
+			_, _ = w.Write([]byte(`{"features": ["alertStream"]}`))
+		case "/alerts/stream":
+			if r.Header.Get("Accept") != "text/event-stream" || r.URL.Query().Get("routeKey") != "payments" {
+				t.Errorf("unexpected stream request %s %v", r.URL, r.Header)
+			}
+
+			w.Header().Set("Content-Type", "text/event-stream")
+
+			if connections.Add(1) == 1 {
+				_, _ = w.Write([]byte(": keep-alive\nretry: 0\n\nevent: heartbeat\ndata: {}\n\n"))
+				writeAlertEvent(w, "1", "disk full")
+
+				return
+			}
+
+			if id := r.Header.Get("Last-Event-ID"); id != "1" {
+				t.Errorf("expected the stream to resume after event 1, got %q", id)
+			}
+
+			writeAlertEvent(w, "2", "disk still full")
+			w.(http.Flusher).Flush() //nolint:forcetypeassert // httptest writers flush
+			<-r.Context().Done()
+		}
+	})
+
+	got := collectTail(t, client, AlertFilter{RouteKey: "payments"}, 2)
+	if len(got) != 2 || got[0] != "disk full" || got[1] != "disk still full" {
+		t.Errorf("unexpected alerts %q", got)
+	}
+}
+
+func TestTail_LongPoll(t *testing.T) {
+	t.Parallel()
+
+	client := newTailClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capabilities":
+			w.WriteHeader(http.StatusNotFound)
+		case "/alerts/tail":
+			if wait := r.URL.Query().Get("wait"); wait != "15s" {
+				t.Errorf("expected to wait 15s, got %q", wait)
+			}
+
+			page := alertsPage{Alerts: []*types.Alert{{Header: "disk full"}}, NextCursor: "c1"}
+			if r.URL.Query().Get("cursor") == "c1" {
+				page = alertsPage{Alerts: []*types.Alert{{Header: "disk still full"}}, NextCursor: "c2"}
+			}
+
+			_ = json.NewEncoder(w).Encode(page)
+		}
+	})
+
+	got := collectTail(t, client, AlertFilter{}, 2)
+	if len(got) != 2 || got[0] != "disk full" || got[1] != "disk still full" {
+		t.Errorf("unexpected alerts %q", got)
+	}
+}
+
+func TestTail_Errors(t *testing.T) {
+	t.Parallel()
+
+	var streams atomic.Int32
+
+	client := newTailClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capabilities":
+			_, _ = w.Write([]byte(`{"features": ["alertStream"]}`))
+		case "/alerts/stream":
+			streams.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("not allowed"))
+		}
+	})
+
+	for filter, want := range map[*AlertFilter]string{
+		{}:                              "status code 403: not allowed",
+		{Until: time.Now()}:             "must not set Until",
+		{PageSize: 1 + maxListPageSize}: "page size",
+	} {
+		var errs []error
+
+		for alert, err := range client.Tail(context.Background(), *filter) {
+			if alert != nil {
+				t.Fatalf("unexpected alert %+v", alert)
+			}
+
+			errs = append(errs, err)
+		}
+
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), want) {
+			t.Errorf("expected one error containing %q, got %v", want, errs)
+		}
+	}
+
+	if n := streams.Load(); n != 1 {
+		t.Errorf("expected an error response not to be retried, got %d requests", n)
+	}
+}
+
+func TestTail_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	var streams atomic.Int32
+
+	client := newTailClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capabilities":
+			_, _ = w.Write([]byte(`{"features": ["alertStream"]}`))
+		case "/alerts/stream":
+			// Each connection ends before sending anything.
+			streams.Add(1)
+		}
+	})
+
+	var errs []error
+	for _, err := range client.Tail(context.Background(), AlertFilter{}) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || streams.Load() != int32(client.options.retryCount)+1 {
+		t.Errorf("expected to give up after %d reconnects, got %v after %d connections", client.options.retryCount, errs, streams.Load())
+	}
+}
+
+func TestTail_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	client := newTailClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts/stream" {
+			w.(http.Flusher).Flush() //nolint:forcetypeassert // httptest writers flush
+			<-r.Context().Done()
+
+			return
+		}
+
+		if r.URL.Path == "/capabilities" {
+			_, _ = w.Write([]byte(`{"features": ["alertStream"]}`))
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	for alert, err := range client.Tail(ctx, AlertFilter{}) {
+		t.Errorf("expected the tail to end quietly, got %v, %v", alert, err)
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const templatesEndpoint = "templates"
+
+// templateNameRegex matches valid server-side template names.
+var templateNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]{1,100}$`)
+
+// Template is a server-side alert template. Text fields of Alert may
+// contain placeholders that the API fills in from the variables passed to
+// [Client.SendServerTemplate], so formatting can be changed centrally
+// without redeploying producers.
+type Template struct {
+	// Name identifies the template. It may contain letters, digits, '_',
+	// '-' and '.', up to 100 characters.
+	Name string `json:"name"`
+
+	Description string `json:"description,omitempty"`
+
+	// Alert is the alert produced by the template.
+	Alert *types.Alert `json:"alert"`
+
+	// Variables lists the variables the template requires.
+	Variables []string `json:"variables,omitempty"`
+
+	// Version is incremented by the API on every update.
+	Version   int       `json:"version,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+}
+
+type templatesList struct {
+	Templates []*Template `json:"templates"`
+}
+
+// ListTemplates returns all server-side templates. [Client.Connect] must be
+// called first.
+func (c *Client) ListTemplates(ctx context.Context) ([]*Template, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	var list templatesList
+	if err := c.doJSON(ctx, http.MethodGet, templatesEndpoint, nil, nil, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Templates, nil
+}
+
+// PutTemplate creates or replaces the template with tmpl.Name and returns
+// the stored template.
+func (c *Client) PutTemplate(ctx context.Context, tmpl *Template) (*Template, error) {
+	if tmpl == nil {
+		return nil, errors.New("template is nil")
+	}
+
+	path, err := templatePath(tmpl.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.Alert == nil {
+		return nil, fmt.Errorf("template %q has no alert", tmpl.Name)
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	stored := &Template{}
+	if err := c.doJSON(ctx, http.MethodPut, path, nil, tmpl, stored); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// DeleteTemplate deletes the template with the given name.
+func (c *Client) DeleteTemplate(ctx context.Context, name string) error {
+	path, err := templatePath(name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// SendServerTemplate asks the API to render the named template with vars
+// and send the resulting alert. The returned *ResponseMetadata follows the
+// same rules as [Client.SendWithResponse].
+func (c *Client) SendServerTemplate(ctx context.Context, name string, vars map[string]any) (*ResponseMetadata, error) {
+	path, err := templatePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if vars == nil {
+		vars = map[string]any{}
+	}
+
+	request := map[string]any{"variables": vars}
+	if len(c.options.theme) > 0 {
+		request["theme"] = c.options.theme
+	}
+
+	if incidentID := IncidentFromContext(ctx); incidentID != "" {
+		request[IncidentIDKey] = incidentID
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template variables: %w", err)
+	}
+
+	if body, err = c.transformBody(ctx, body); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	meta, err := c.postWithResponse(ctx, path+"/send", body, nil)
+	c.audit(ctx, AuditSend, path+"/send", 1, meta.status(), start, err)
+
+	return meta, err
+}
+
+func templatePath(name string) (string, error) {
+	if !templateNameRegex.MatchString(name) {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+
+	return templatesEndpoint + "/" + url.PathEscape(name), nil
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method, path string
+		body         map[string]any
+	}
+
+	calls := make(chan call, 4)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls <- call{r.Method, r.URL.Path, body}
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"templates":[{"name":"disk-full","version":3,"alert":{"header":"Disk full on {{host}}"}}]}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"name":"disk-full","version":4,"alert":{"header":"Disk full on {{host}}"}}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	templates, err := client.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(templates) != 1 || templates[0].Version != 3 || templates[0].Alert.Header != "Disk full on {{host}}" {
+		t.Errorf("unexpected templates %+v", templates)
+	}
+
+	<-calls
+
+	stored, err := client.PutTemplate(ctx, &Template{Name: "disk-full", Alert: &types.Alert{Header: "Disk full on {{host}}"}, Variables: []string{"host"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stored.Version != 4 {
+		t.Errorf("expected version 4, got %d", stored.Version)
+	}
+
+	if got := <-calls; got.method != http.MethodPut || got.path != "/templates/disk-full" {
+		t.Errorf("unexpected put request %+v", got)
+	}
+
+	meta, err := client.SendServerTemplate(ctx, "disk-full", map[string]any{"host": "db1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", meta.StatusCode)
+	}
+
+	got := <-calls
+	if got.method != http.MethodPost || got.path != "/templates/disk-full/send" {
+		t.Errorf("unexpected send request %+v", got)
+	}
+
+	if vars, _ := got.body["variables"].(map[string]any); vars["host"] != "db1" {
+		t.Errorf("unexpected send body %v", got.body)
+	}
+
+	if err := client.DeleteTemplate(ctx, "disk-full"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodDelete || got.path != "/templates/disk-full" {
+		t.Errorf("unexpected delete request %+v", got)
+	}
+}
+
+func TestTemplates_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := New("http://example.com")
+	ctx := context.Background()
+
+	if _, err := client.PutTemplate(ctx, &Template{Name: "bad name", Alert: &types.Alert{}}); err == nil || !strings.Contains(err.Error(), "invalid template name") {
+		t.Errorf("expected invalid name error, got %v", err)
+	}
+
+	if _, err := client.PutTemplate(ctx, &Template{Name: "ok"}); err == nil || !strings.Contains(err.Error(), "has no alert") {
+		t.Errorf("expected missing alert error, got %v", err)
+	}
+
+	if err := client.DeleteTemplate(ctx, ""); err == nil {
+		t.Error("expected error for empty name")
+	}
+
+	if _, err := client.SendServerTemplate(ctx, "ok", nil); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
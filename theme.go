@@ -0,0 +1,119 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// ThemeColorKey is the Metadata key under which [WithTheme] stores the
+// colour of an alert's severity, for the API and webhooks to use for the
+// Slack attachment.
+const ThemeColorKey = "color"
+
+// themeColorRegex matches the hex colours accepted in a [SeverityStyle].
+var themeColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// SeverityStyle is how alerts of one severity look, in a [Theme].
+type SeverityStyle struct {
+	// Color is a hex colour such as "#E01E5A", stored in the alert's
+	// Metadata under [ThemeColorKey].
+	Color string `json:"color,omitempty"`
+
+	// Emoji, such as ":rotating_light:", is put in front of the header.
+	Emoji string `json:"emoji,omitempty"`
+
+	// IconEmoji is used as the alert's icon, in the ":name:" form of
+	// [types.Alert.IconEmoji].
+	IconEmoji string `json:"iconEmoji,omitempty"`
+}
+
+// Theme maps severities to the style of their alerts, for [WithTheme].
+type Theme map[types.AlertSeverity]SeverityStyle
+
+// DefaultTheme returns a theme with Slack's brand colours and a
+// distinct emoji for each severity. It sets no icons.
+func DefaultTheme() Theme {
+	return Theme{
+		types.AlertPanic:    {Color: "#E01E5A", Emoji: ":rotating_light:"},
+		types.AlertError:    {Color: "#E01E5A", Emoji: ":red_circle:"},
+		types.AlertWarning:  {Color: "#ECB22E", Emoji: ":warning:"},
+		types.AlertResolved: {Color: "#2EB67D", Emoji: ":white_check_mark:"},
+		types.AlertInfo:     {Color: "#36C5F0", Emoji: ":information_source:"},
+	}
+}
+
+// validate returns an error for the first invalid style in t.
+func (t Theme) validate() error {
+	for severity, style := range t {
+		if !types.SeverityIsValid(severity) {
+			return fmt.Errorf("unknown severity %q", severity)
+		}
+
+		if style.Color != "" && !themeColorRegex.MatchString(style.Color) {
+			return fmt.Errorf("%s color %q is not a hex colour such as #E01E5A", severity, style.Color)
+		}
+
+		if style.IconEmoji != "" && !types.IconRegex.MatchString(style.IconEmoji) {
+			return fmt.Errorf("%s icon emoji %q must be in the :name: form", severity, style.IconEmoji)
+		}
+	}
+
+	return nil
+}
+
+// apply returns alert styled for its severity. Values set by the producer
+// are kept: the icon and colour are only set if empty, and a header that
+// already starts with the emoji is left as it is. The header shown once
+// the issue is resolved gets the emoji of [types.AlertResolved]. alert is
+// not changed; a styled copy is returned instead.
+func (t Theme) apply(alert *types.Alert) *types.Alert {
+	style, ok := t[alert.Severity]
+	resolved, resolvedOK := t[types.AlertResolved]
+
+	if !ok && !resolvedOK {
+		return alert
+	}
+
+	styled := *alert
+	styled.Header = prefixEmoji(style.Emoji, styled.Header)
+	styled.HeaderWhenResolved = prefixEmoji(resolved.Emoji, styled.HeaderWhenResolved)
+
+	if styled.IconEmoji == "" {
+		styled.IconEmoji = style.IconEmoji
+	}
+
+	if _, set := styled.Metadata[ThemeColorKey]; !set && style.Color != "" {
+		styled.Metadata = maps.Clone(styled.Metadata)
+		if styled.Metadata == nil {
+			styled.Metadata = make(map[string]any, 1)
+		}
+
+		styled.Metadata[ThemeColorKey] = style.Color
+	}
+
+	return &styled
+}
+
+// prefixEmoji puts emoji in front of a non-empty header that does not
+// already start with it.
+func prefixEmoji(emoji, header string) string {
+	if emoji == "" || header == "" || strings.HasPrefix(header, emoji) {
+		return header
+	}
+
+	return emoji + " " + header
+}
+
+// applyTheme returns alerts styled with the theme set with [WithTheme].
+func (c *Client) applyTheme(alerts []*types.Alert) []*types.Alert {
+	styled := make([]*types.Alert, len(alerts))
+	for i, alert := range alerts {
+		styled[i] = c.options.theme.apply(alert)
+	}
+
+	return styled
+}
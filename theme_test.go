@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithTheme(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+
+	theme := DefaultTheme()
+	theme[types.AlertPanic] = SeverityStyle{Color: "#8B0000", Emoji: ":fire:", IconEmoji: ":pager:"}
+
+	client := New(server.URL, WithTheme(theme))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	panicAlert := types.NewAlert(types.AlertPanic)
+	panicAlert.Header = "Database down"
+	panicAlert.HeaderWhenResolved = "Database back"
+
+	own := types.NewAlert(types.AlertError)
+	own.Header = ":red_circle: Disk full"
+	own.IconEmoji = ":disk:"
+	own.Metadata = map[string]any{ThemeColorKey: "#000000"}
+
+	if err := client.Send(context.Background(), panicAlert, own); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if panicAlert.Header != "Database down" || panicAlert.IconEmoji != "" || len(panicAlert.Metadata) != 0 {
+		t.Errorf("expected the caller's alert to be left unchanged, got %+v", panicAlert)
+	}
+
+	got := server.received()
+	if len(got) != 1 {
+		t.Fatalf("expected one batch, got %d", len(got))
+	}
+
+	styled := got[0][0]
+	if styled.Header != ":fire: Database down" || styled.HeaderWhenResolved != ":white_check_mark: Database back" || styled.IconEmoji != ":pager:" || styled.Metadata[ThemeColorKey] != "#8B0000" {
+		t.Errorf("unexpected styled alert %+v", styled)
+	}
+
+	kept := got[0][1]
+	if kept.Header != ":red_circle: Disk full" || kept.IconEmoji != ":disk:" || kept.Metadata[ThemeColorKey] != "#000000" {
+		t.Errorf("expected the producer's values to be kept, got %+v", kept)
+	}
+}
+
+func TestWithTheme_ServerTemplate(t *testing.T) {
+	t.Parallel()
+
+	bodies := make(chan []byte, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/templates/disk-full/send" {
+			body, _ := io.ReadAll(r.Body)
+			bodies <- body
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithTheme(Theme{types.AlertWarning: {Emoji: ":warning:"}}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.SendServerTemplate(context.Background(), "disk-full", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var request struct {
+		Theme Theme `json:"theme"`
+	}
+	if err := json.Unmarshal(<-bodies, &request); err != nil || request.Theme[types.AlertWarning].Emoji != ":warning:" {
+		t.Errorf("expected the theme to be sent with the template, got %+v (%v)", request, err)
+	}
+}
+
+func TestWithTheme_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, theme := range map[string]Theme{
+		"unknown severity": {"critical": {Emoji: ":x:"}},
+		"invalid color":    {types.AlertInfo: {Color: "blue"}},
+		"invalid icon":     {types.AlertInfo: {IconEmoji: "pager"}},
+	} {
+		client := New("http://localhost", WithTheme(theme))
+		if client.options.theme != nil || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("%s: expected the theme to be ignored with a warning, got %v", name, client.ConfigWarnings())
+		}
+	}
+
+	if theme := New("http://localhost", WithTheme(DefaultTheme())).EffectiveConfig().Theme; len(theme) != 5 {
+		t.Errorf("expected the theme in the snapshot, got %v", theme)
+	}
+
+	info := types.NewAlert(types.AlertInfo)
+	if styled := (Theme{types.AlertError: {Emoji: ":x:"}}).apply(info); styled != info {
+		t.Error("expected an alert without a style to be returned as is")
+	}
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultTimestampLayout is the layout [Timestamp] placeholders are
+	// rendered with, unless [WithChannelTimeZones] sets another.
+	DefaultTimestampLayout = "2006-01-02 15:04 MST"
+
+	// timestampPrefix starts a [Timestamp] placeholder.
+	timestampPrefix = "{{ts:"
+
+	// zoneTTL and zoneErrorTTL are how long the time zone of a channel,
+	// or a failure to look it up, is remembered.
+	zoneTTL      = time.Hour
+	zoneErrorTTL = time.Minute
+
+	// maxZoneEntries is the size above which expired zones are dropped.
+	maxZoneEntries = 1000
+)
+
+// timestampRegex matches [Timestamp] placeholders.
+var timestampRegex = regexp.MustCompile(`\{\{ts:([0-9T:.+\-Z]+)\}\}`)
+
+// Timestamp returns a placeholder for t to put in an alert's header, text,
+// footer, fallback text or field values. When the alert is sent, the
+// placeholder is replaced by t in the time zone of the alert's Slack
+// channel if [WithChannelTimeZones] is set, and in UTC otherwise:
+//
+//	alert.Text = "Backup failed at " + client.Timestamp(failedAt)
+//	// Sent to a channel in Europe/Oslo: "Backup failed at 2026-10-14 13:05 CEST"
+func Timestamp(t time.Time) string {
+	return timestampPrefix + t.UTC().Format(time.RFC3339Nano) + "}}"
+}
+
+// FormatTimestamp formats t in the IANA time zone tz, such as
+// "America/New_York", with layout, or [DefaultTimestampLayout] if layout
+// is empty. An empty tz means UTC.
+func FormatTimestamp(t time.Time, tz, layout string) (string, error) {
+	location, err := time.LoadLocation(tz)
+	if err != nil {
+		return "", err
+	}
+
+	if layout == "" {
+		layout = DefaultTimestampLayout
+	}
+
+	return t.In(location).Format(layout), nil
+}
+
+// ChannelLocation returns the time zone configured for a Slack channel,
+// from the channels API. Zones are cached for an hour when
+// [WithChannelTimeZones] is set. A channel without a configured time zone
+// is in UTC.
+func (c *Client) ChannelLocation(ctx context.Context, idOrName string) (*time.Location, error) {
+	if c.zones != nil {
+		if entry, ok := c.zones.get(idOrName); ok {
+			return entry.location, entry.err
+		}
+	}
+
+	location, err := c.lookupLocation(ctx, idOrName)
+
+	if c.zones != nil && ctx.Err() == nil {
+		c.zones.put(idOrName, location, err)
+	}
+
+	return location, err
+}
+
+// lookupLocation implements [Client.ChannelLocation] without the cache.
+func (c *Client) lookupLocation(ctx context.Context, idOrName string) (*time.Location, error) {
+	channel, err := c.GetChannel(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	return time.LoadLocation(channel.TimeZone)
+}
+
+// renderTimestamps replaces the [Timestamp] placeholders in alerts. The
+// caller's alerts are not changed; alerts with placeholders are copied.
+func (c *Client) renderTimestamps(ctx context.Context, alerts []*types.Alert) []*types.Alert {
+	var result []*types.Alert
+
+	for i, alert := range alerts {
+		if !hasTimestamps(alert) {
+			continue
+		}
+
+		if result == nil {
+			result = make([]*types.Alert, len(alerts))
+			copy(result, alerts)
+		}
+
+		location := time.UTC
+		if c.options.channelZones {
+			location = c.alertLocation(ctx, alert)
+		}
+
+		result[i] = renderAlertTimestamps(alert, location, cmp.Or(c.options.timestampLayout, DefaultTimestampLayout))
+	}
+
+	if result == nil {
+		return alerts
+	}
+
+	return result
+}
+
+// alertLocation returns the time zone of the channel of alert, or the
+// fallback zone set with [WithChannelTimeZones] if the alert has no
+// channel or its zone cannot be found.
+func (c *Client) alertLocation(ctx context.Context, alert *types.Alert) *time.Location {
+	if alert.SlackChannelID == "" {
+		return c.options.zoneFallback
+	}
+
+	location, err := c.ChannelLocation(ctx, alert.SlackChannelID)
+	if err != nil {
+		c.logger(ctx).Warnf("failed to find the time zone of channel %s, so timestamps are shown in %s: %s", alert.SlackChannelID, c.options.zoneFallback, err)
+		return c.options.zoneFallback
+	}
+
+	return location
+}
+
+// hasTimestamps reports whether any text field of alert holds a
+// [Timestamp] placeholder.
+func hasTimestamps(alert *types.Alert) bool {
+	for _, s := range []string{alert.Header, alert.HeaderWhenResolved, alert.Text, alert.TextWhenResolved, alert.FallbackText, alert.Footer} {
+		if strings.Contains(s, timestampPrefix) {
+			return true
+		}
+	}
+
+	for _, field := range alert.Fields {
+		if field != nil && strings.Contains(field.Value, timestampPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderAlertTimestamps returns a copy of alert with its placeholders
+// replaced by times in location, formatted with layout.
+func renderAlertTimestamps(alert *types.Alert, location *time.Location, layout string) *types.Alert {
+	render := func(s string) string {
+		if !strings.Contains(s, timestampPrefix) {
+			return s
+		}
+
+		return timestampRegex.ReplaceAllStringFunc(s, func(placeholder string) string {
+			t, err := time.Parse(time.RFC3339Nano, timestampRegex.FindStringSubmatch(placeholder)[1])
+			if err != nil {
+				return placeholder
+			}
+
+			return t.In(location).Format(layout)
+		})
+	}
+
+	rendered := *alert
+	rendered.Header = render(alert.Header)
+	rendered.HeaderWhenResolved = render(alert.HeaderWhenResolved)
+	rendered.Text = render(alert.Text)
+	rendered.TextWhenResolved = render(alert.TextWhenResolved)
+	rendered.FallbackText = render(alert.FallbackText)
+	rendered.Footer = render(alert.Footer)
+
+	if len(alert.Fields) > 0 {
+		rendered.Fields = make([]*types.Field, len(alert.Fields))
+		for i, field := range alert.Fields {
+			if field != nil {
+				copied := *field
+				copied.Value = render(field.Value)
+				field = &copied
+			}
+
+			rendered.Fields[i] = field
+		}
+	}
+
+	return &rendered
+}
+
+// zoneCache remembers the time zones of channels for
+// [Client.ChannelLocation].
+type zoneCache struct {
+	mu      sync.Mutex
+	entries map[string]zoneEntry
+}
+
+type zoneEntry struct {
+	location *time.Location
+	err      error
+	expires  time.Time
+}
+
+func newZoneCache() *zoneCache {
+	return &zoneCache{entries: make(map[string]zoneEntry)}
+}
+
+func (z *zoneCache) get(channel string) (zoneEntry, bool) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	entry, ok := z.entries[normalizeChannel(channel)]
+	if !ok || time.Now().After(entry.expires) {
+		return zoneEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (z *zoneCache) put(channel string, location *time.Location, err error) {
+	ttl := zoneTTL
+	if err != nil {
+		ttl = zoneErrorTTL
+	}
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	now := time.Now()
+
+	// Drop expired entries, so channels that are no longer alerted on do
+	// not pile up.
+	if len(z.entries) >= maxZoneEntries {
+		maps.DeleteFunc(z.entries, func(_ string, entry zoneEntry) bool {
+			return now.After(entry.expires)
+		})
+	}
+
+	z.entries[normalizeChannel(channel)] = zoneEntry{location: location, err: err, expires: now.Add(ttl)}
+}
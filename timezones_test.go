@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestTimestamp(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 14, 11, 5, 0, 0, time.UTC)
+
+	oslo, err := FormatTimestamp(at, "Europe/Oslo", "")
+	if err != nil || oslo != "2026-10-14 13:05 CEST" {
+		t.Errorf("unexpected time %q (%v)", oslo, err)
+	}
+
+	if _, err := FormatTimestamp(at, "Mars/Olympus", ""); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Text = "failed at " + Timestamp(at.In(time.FixedZone("X", 3600))) + " and " + Timestamp(at.Add(time.Hour))
+	alert.Fields = []*types.Field{nil, {Title: "Since", Value: Timestamp(at)}}
+
+	client := New("http://localhost")
+
+	got := client.renderTimestamps(context.Background(), []*types.Alert{alert})[0]
+	if got.Text != "failed at 2026-10-14 11:05 UTC and 2026-10-14 12:05 UTC" || got.Fields[1].Value != "2026-10-14 11:05 UTC" {
+		t.Errorf("expected the placeholders to be rendered in UTC, got %q and %q", got.Text, got.Fields[1].Value)
+	}
+
+	if !strings.Contains(alert.Text, timestampPrefix) || !strings.Contains(alert.Fields[1].Value, timestampPrefix) {
+		t.Error("expected the caller's alert to be left unchanged")
+	}
+
+	plain := []*types.Alert{types.NewAlert(types.AlertInfo)}
+	if got := client.renderTimestamps(context.Background(), plain); got[0] != plain[0] {
+		t.Error("expected alerts without placeholders to be left as they are")
+	}
+}
+
+func TestWithChannelTimeZones(t *testing.T) {
+	t.Parallel()
+
+	var lookups atomic.Int32
+
+	server := newAlertRecorder(t)
+	channels := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+		case "/channels/C0OSLO":
+			lookups.Add(1)
+			_, _ = w.Write([]byte(`{"id":"C0OSLO","timeZone":"Europe/Oslo"}`))
+		case "/channels/C0BAD":
+			_, _ = w.Write([]byte(`{"id":"C0BAD","timeZone":"Nowhere/Land"}`))
+		default:
+			server.Config.Handler.ServeHTTP(w, r)
+		}
+	}))
+	defer channels.Close()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	logger := &recordingLogger{}
+
+	client := New(channels.URL, WithChannelTimeZones(tokyo, "15:04 MST"), WithRequestLogger(logger))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	at := time.Date(2026, 10, 14, 11, 5, 0, 0, time.UTC)
+
+	newAlert := func(channel string) *types.Alert {
+		alert := types.NewAlert(types.AlertError)
+		alert.SlackChannelID = channel
+		alert.Header = "Backup failed at " + Timestamp(at)
+
+		return alert
+	}
+
+	for range 2 {
+		if err := client.Send(context.Background(), newAlert("C0OSLO"), newAlert(""), newAlert("C0BAD")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := server.received()
+	if len(got) != 2 {
+		t.Fatalf("expected two batches, got %d", len(got))
+	}
+
+	for i, want := range []string{"Backup failed at 13:05 CEST", "Backup failed at 20:05 JST", "Backup failed at 20:05 JST"} {
+		if got[1][i].Header != want {
+			t.Errorf("alert %d: expected %q, got %q", i, want, got[1][i].Header)
+		}
+	}
+
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("expected the channel zone to be looked up once, got %d", n)
+	}
+
+	if warnings := logger.warnings(); len(warnings) != 2 || !strings.Contains(warnings[0], "failed to find the time zone of channel C0BAD") {
+		t.Errorf("expected a warning per send for the unknown zone, got %v", warnings)
+	}
+
+	if config := client.EffectiveConfig(); !config.ChannelTimeZones || config.TimestampLayout != "15:04 MST" {
+		t.Errorf("unexpected snapshot %v and %q", config.ChannelTimeZones, config.TimestampLayout)
+	}
+}
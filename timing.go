@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	minSlowRequestThreshold = 1 * time.Millisecond
+	maxSlowRequestThreshold = 5 * time.Minute
+)
+
+// RequestTiming is the timing breakdown of one request attempt, captured
+// with [net/http/httptrace]. Comparing TTFB with the other phases tells
+// whether slowness comes from the network or from the server.
+type RequestTiming struct {
+	// Attempt is the attempt number, starting at 1.
+	Attempt int
+
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// DNSLookup, Connect and TLSHandshake are zero when an existing
+	// connection was reused.
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+
+	// TTFB is the time from when the connection was ready until the first
+	// response byte arrived, i.e. the server's processing time plus one
+	// round trip.
+	TTFB time.Duration
+
+	// Total is the duration of the attempt, including reading the body.
+	Total time.Duration
+
+	// ConnReused reports whether an idle keep-alive connection was used.
+	ConnReused bool
+}
+
+// TimingStats accumulates the [RequestTiming] of every request attempt.
+// Durations are totals; use [TimingStats.Mean] for averages.
+type TimingStats struct {
+	Samples      int64
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	TTFB         time.Duration
+	Total        time.Duration
+}
+
+// Mean returns the average timing per attempt, or the zero value if there
+// have been no attempts. Attempt, StatusCode and ConnReused are not set.
+func (s TimingStats) Mean() RequestTiming {
+	if s.Samples == 0 {
+		return RequestTiming{}
+	}
+
+	n := time.Duration(s.Samples)
+
+	return RequestTiming{
+		DNSLookup:    s.DNSLookup / n,
+		Connect:      s.Connect / n,
+		TLSHandshake: s.TLSHandshake / n,
+		TTFB:         s.TTFB / n,
+		Total:        s.Total / n,
+	}
+}
+
+// plus returns s with t added.
+func (s TimingStats) plus(t RequestTiming) TimingStats {
+	s.Samples++
+	s.DNSLookup += t.DNSLookup
+	s.Connect += t.Connect
+	s.TLSHandshake += t.TLSHandshake
+	s.TTFB += t.TTFB
+	s.Total += t.Total
+
+	return s
+}
+
+// SlowRequestStats reports requests that exceeded the threshold set with
+// [WithSlowRequestThreshold].
+type SlowRequestStats struct {
+	// Enabled reports whether [WithSlowRequestThreshold] is in effect.
+	Enabled bool
+
+	// Count is the number of slow requests.
+	Count int64
+
+	// Max is the duration of the slowest request.
+	Max time.Duration
+}
+
+// attemptTimings collects the timing of every attempt of one request. It is
+// carried in the request context so that retries append to the same list.
+type attemptTimings struct {
+	mu       sync.Mutex
+	attempts []RequestTiming
+}
+
+type attemptTimingsKey struct{}
+
+func withAttemptTimings(ctx context.Context) (context.Context, *attemptTimings) {
+	timings := &attemptTimings{}
+	return context.WithValue(ctx, attemptTimingsKey{}, timings), timings
+}
+
+func (a *attemptTimings) list() []RequestTiming {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]RequestTiming(nil), a.attempts...)
+}
+
+// observeResponse is a resty response middleware that records the timing
+// of every request attempt, adds the attempt to the span of [WithTracing],
+// and logs a warning with the breakdown for attempts slower than
+// [WithSlowRequestThreshold].
+func (c *Client) observeResponse(_ *resty.Client, response *resty.Response) error {
+	request := response.Request
+	trace := request.TraceInfo()
+
+	timing := RequestTiming{
+		Attempt:      request.Attempt,
+		StatusCode:   response.StatusCode(),
+		DNSLookup:    trace.DNSLookup,
+		Connect:      trace.TCPConnTime,
+		TLSHandshake: trace.TLSHandshake,
+		TTFB:         trace.ServerTime,
+		Total:        response.Time(),
+		ConnReused:   trace.IsConnReused,
+	}
+
+	traceAttempt(response)
+
+	if timings, ok := request.Context().Value(attemptTimingsKey{}).(*attemptTimings); ok {
+		timings.mu.Lock()
+		timings.attempts = append(timings.attempts, timing)
+		timings.mu.Unlock()
+	}
+
+	threshold := c.options.slowThreshold
+	slow := threshold > 0 && timing.Total >= threshold
+
+	c.stats.mu.Lock()
+	c.stats.timing = c.stats.timing.plus(timing)
+
+	if slow {
+		c.stats.slowRequests++
+		c.stats.slowestRequest = max(c.stats.slowestRequest, timing.Total)
+	}
+	c.stats.mu.Unlock()
+
+	if slow {
+		c.logger(request.Context()).Warnf(
+			"slow request: %s %s took %v (threshold %v): dns=%v connect=%v tls=%v ttfb=%v reused=%t attempt=%d status=%d",
+			request.Method, sanitizeURL(request.URL), timing.Total, threshold,
+			timing.DNSLookup, timing.Connect, timing.TLSHandshake, timing.TTFB, timing.ConnReused, timing.Attempt, timing.StatusCode,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestWithSlowRequestThreshold(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			time.Sleep(30 * time.Millisecond)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := New(server.URL, WithRequestLogger(logger), WithSlowRequestThreshold(20*time.Millisecond))
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := client.Stats().SlowRequests.Count; n != 0 {
+		t.Fatalf("expected the ping not to be slow, got %d slow requests", n)
+	}
+
+	if err := client.Send(context.Background(), types.NewAlert(types.AlertError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := client.Stats().SlowRequests
+	if !stats.Enabled || stats.Count != 1 || stats.Max < 30*time.Millisecond {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	warnings := logger.warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %q", warnings)
+	}
+
+	for _, want := range []string{"slow request: POST", "/alerts took", "(threshold 20ms)", "dns=", "connect=", "tls=", "ttfb=", "reused=true", "status=200"} {
+		if !strings.Contains(warnings[0], want) {
+			t.Errorf("expected warning to contain %q, got %q", want, warnings[0])
+		}
+	}
+}
+
+func TestSendWithResponse_AttemptTimings(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	meta, err := client.SendWithResponse(context.Background(), types.NewAlert(types.AlertError))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(meta.Attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %+v", meta.Attempts)
+	}
+
+	first, last := meta.Attempts[0], meta.Attempts[1]
+	if first.Attempt != 1 || first.StatusCode != http.StatusServiceUnavailable || last.Attempt != 2 || last.StatusCode != http.StatusOK {
+		t.Errorf("unexpected attempts %+v", meta.Attempts)
+	}
+
+	if last.Total <= 0 || last.TTFB <= 0 || !last.ConnReused {
+		t.Errorf("expected timing to be captured on a reused connection, got %+v", last)
+	}
+
+	// The ping and both attempts are recorded.
+	stats := client.Stats().Timing
+	if stats.Samples != 3 || stats.Mean().Total <= 0 {
+		t.Errorf("unexpected timing stats %+v", stats)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// clientCertificate holds the files of [WithClientCertificate].
+type clientCertificate struct {
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+// transportTLSConfig returns the TLS configuration of the connection pool:
+// the one set with [WithTLSConfig], with the files of
+// [WithClientCertificate] loaded into a copy of it. The caller's config is
+// not modified.
+func (o *Options) transportTLSConfig() (*tls.Config, error) {
+	if o.clientCert == nil {
+		return o.tlsConfig, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.tlsConfig != nil {
+		config = o.tlsConfig.Clone()
+	}
+
+	if o.clientCert.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.clientCert.certFile, o.clientCert.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.clientCert.caFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(o.clientCert.caFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to read CA file: no PEM certificates found")
+		}
+
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePEM writes a PEM block of the given type to a file in dir.
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+
+	return path
+}
+
+// mtlsFiles are the PEM files of a client certificate and of a CA.
+type mtlsFiles struct {
+	cert, key, ca string
+}
+
+// newMTLSServer returns a TLS server that requires a client certificate,
+// and the files of a client certificate it accepts and of its own CA.
+func newMTLSServer(t *testing.T) (*httptest.Server, mtlsFiles) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "alerts-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	clients := x509.NewCertPool()
+	clients.AddCert(cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clients, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+
+	return server, mtlsFiles{
+		cert: writePEM(t, dir, "client.crt", "CERTIFICATE", der),
+		key:  writePEM(t, dir, "client.key", "PRIVATE KEY", keyDER),
+		ca:   writePEM(t, dir, "ca.crt", "CERTIFICATE", server.Certificate().Raw),
+	}
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	server, files := newMTLSServer(t)
+
+	base := &tls.Config{MinVersion: tls.VersionTLS13}
+
+	client := New(server.URL, WithRetryCount(0), WithTLSConfig(base), WithClientCertificate(files.cert, files.key, files.ca))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if len(base.Certificates) != 0 || base.RootCAs != nil {
+		t.Error("expected the config of WithTLSConfig not to be modified")
+	}
+
+	if !client.EffectiveConfig().CustomTLSConfig {
+		t.Error("expected a custom TLS config in the config snapshot")
+	}
+
+	// Without the client certificate the server refuses the handshake.
+	if err := New(server.URL, WithRetryCount(0), WithClientCertificate("", "", files.ca)).Connect(context.Background()); err == nil {
+		t.Error("expected the connection to fail without a client certificate")
+	}
+}
+
+func TestWithClientCertificate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	server, files := newMTLSServer(t)
+
+	for invalid, want := range map[mtlsFiles]string{
+		{cert: files.cert, key: "missing.key"}: "failed to load client certificate",
+		{ca: "missing.crt"}:                    "failed to read CA file",
+		{ca: files.key}:                        "no PEM certificates found",
+	} {
+		err := New(server.URL, WithClientCertificate(invalid.cert, invalid.key, invalid.ca)).Connect(context.Background())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected an error containing %q for %+v, got %v", want, invalid, err)
+		}
+	}
+
+	for _, opt := range []Option{
+		WithClientCertificate(files.cert, "", ""),
+		WithClientCertificate("", "", ""),
+	} {
+		if client := New(server.URL, opt); client.options.clientCert != nil || len(client.ConfigWarnings()) != 1 {
+			t.Errorf("expected the option to be ignored with a warning, got %v", client.ConfigWarnings())
+		}
+	}
+}
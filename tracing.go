@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Span names used with [WithTracing].
+const (
+	SpanConnect = "slackmgr.Connect"
+	SpanSend    = "slackmgr.Send"
+)
+
+// Tracer creates the spans of [WithTracing] and propagates trace context
+// to the API. It is shaped after OpenTelemetry, so an adapter for an
+// OpenTelemetry TracerProvider is a few lines, without this module
+// depending on it. Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start starts a client span named name as a child of the span in
+	// ctx, if any, and returns a context holding it.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+
+	// Inject adds the trace context of ctx to the headers of a request to
+	// the API, such as the W3C traceparent and tracestate headers.
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a span started by a [Tracer].
+type Span interface {
+	// SetAttributes sets attributes of the span.
+	SetAttributes(attrs ...Attribute)
+
+	// AddEvent records an event, such as a request attempt or a retry.
+	AddEvent(name string, attrs ...Attribute)
+
+	// End ends the span, marking it as failed when err is not nil.
+	End(err error)
+}
+
+// Attribute is a span or event attribute. Value is a string, an int or a
+// bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attribute keys. The status code and error keys follow the OpenTelemetry
+// semantic conventions.
+const (
+	attrAlerts     = "slackmgr.alerts"
+	attrSkipped    = "slackmgr.skipped"
+	attrAttempt    = "slackmgr.attempt"
+	attrAttempts   = "slackmgr.attempts"
+	attrStatusCode = "http.response.status_code"
+	attrError      = "error.message"
+)
+
+type spanKey struct{}
+
+// noSpan is the span used without a tracer.
+type noSpan struct{}
+
+func (noSpan) SetAttributes(...Attribute)    {}
+func (noSpan) AddEvent(string, ...Attribute) {}
+func (noSpan) End(error)                     {}
+
+// startSpan starts a span with the tracer set with [WithTracing], and
+// keeps it in the returned context so that the attempts of its requests
+// are recorded as events.
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) { //nolint:ireturn // the span comes from the tracer
+	if c.options == nil || c.options.tracer == nil {
+		return ctx, noSpan{}
+	}
+
+	ctx, span := c.options.tracer.Start(ctx, name, attrs...)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// spanFromContext returns the span started by startSpan for ctx, if any.
+func spanFromContext(ctx context.Context) (Span, bool) { //nolint:ireturn // the span comes from the tracer
+	span, ok := ctx.Value(spanKey{}).(Span)
+	return span, ok
+}
+
+// traceAttempt records a request attempt that received a response as an
+// event of the span of its request.
+func traceAttempt(response *resty.Response) {
+	if span, ok := spanFromContext(response.Request.Context()); ok {
+		span.AddEvent("attempt",
+			Attribute{Key: attrAttempt, Value: response.Request.Attempt},
+			Attribute{Key: attrStatusCode, Value: response.StatusCode()},
+		)
+	}
+}
+
+// traceRetry is a resty retry hook that records a failed attempt that is
+// retried as an event of the span of its request.
+func traceRetry(response *resty.Response, err error) {
+	if response == nil || response.Request == nil {
+		return
+	}
+
+	span, ok := spanFromContext(response.Request.Context())
+	if !ok {
+		return
+	}
+
+	attrs := []Attribute{{Key: attrAttempt, Value: response.Request.Attempt}}
+
+	if response.RawResponse != nil {
+		attrs = append(attrs, Attribute{Key: attrStatusCode, Value: response.StatusCode()})
+	}
+
+	if err != nil {
+		attrs = append(attrs, Attribute{Key: attrError, Value: err.Error()})
+	}
+
+	span.AddEvent("retry", attrs...)
+}
+
+// sendAttributes returns the attributes describing the result of a send.
+func sendAttributes(meta *ResponseMetadata) []Attribute {
+	if meta == nil {
+		return nil
+	}
+
+	if meta.Skipped {
+		return []Attribute{{Key: attrSkipped, Value: true}}
+	}
+
+	return []Attribute{
+		{Key: attrStatusCode, Value: meta.StatusCode},
+		{Key: attrAttempts, Value: len(meta.Attempts)},
+	}
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// recordingTracer records the spans it starts and injects a fixed
+// traceparent header.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	mu     sync.Mutex
+	name   string
+	attrs  map[string]any
+	events []string
+	ended  bool
+	err    error
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) { //nolint:ireturn // implements Tracer
+	span := &recordingSpan{name: name, attrs: make(map[string]any)}
+	span.SetAttributes(attrs...)
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+
+	return ctx, span
+}
+
+func (t *recordingTracer) Inject(_ context.Context, header http.Header) {
+	header.Set("Traceparent", testTraceParent)
+}
+
+func (t *recordingTracer) started() []*recordingSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.spans)
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) AddEvent(name string, attrs ...Attribute) {
+	parts := make([]string, 0, 1+len(attrs))
+	parts = append(parts, name)
+
+	for _, attr := range attrs {
+		parts = append(parts, fmt.Sprintf("%s=%v", attr.Key, attr.Value))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, strings.Join(parts, " "))
+}
+
+func (s *recordingSpan) End(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ended, s.err = true, err
+}
+
+func TestWithTracing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		posts   atomic.Int32
+		headers sync.Map
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers.Store(r.URL.Path, r.Header.Get("Traceparent"))
+
+		if r.URL.Path == "/alerts" && posts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &recordingTracer{}
+
+	client := New(server.URL, WithTracing(tracer))
+	setRetryDelay(t, client, noRetryDelay)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "disk full"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := tracer.started()
+	if len(spans) != 2 || spans[0].name != SpanConnect || spans[1].name != SpanSend {
+		t.Fatalf("expected a connect and a send span, got %d", len(spans))
+	}
+
+	if connect := spans[0]; !connect.ended || connect.err != nil || len(connect.events) != 1 {
+		t.Errorf("unexpected connect span %+v", connect)
+	}
+
+	send := spans[1]
+
+	want := []string{
+		"attempt slackmgr.attempt=1 http.response.status_code=503",
+		"retry slackmgr.attempt=1 http.response.status_code=503",
+		"attempt slackmgr.attempt=2 http.response.status_code=202",
+	}
+	if !slices.Equal(send.events, want) {
+		t.Errorf("expected events %q, got %q", want, send.events)
+	}
+
+	if !send.ended || send.err != nil || send.attrs[attrAlerts] != 1 || send.attrs[attrStatusCode] != http.StatusAccepted || send.attrs[attrAttempts] != 2 {
+		t.Errorf("unexpected send span %+v", send)
+	}
+
+	for _, path := range []string{"/ping", "/alerts"} {
+		if header, _ := headers.Load(path); header != testTraceParent {
+			t.Errorf("expected the trace context to be propagated to %s, got %q", path, header)
+		}
+	}
+}
+
+func TestWithTracing_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &recordingTracer{}
+
+	client := New(server.URL, WithTracing(tracer))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "disk full"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	failing := New("http://127.0.0.1:1", WithTracing(tracer), WithRetryCount(0))
+	if err := failing.Connect(context.Background()); err == nil {
+		t.Fatal("expected a connect error")
+	}
+
+	spans := tracer.started()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	if send := spans[1]; send.err == nil || send.attrs[attrStatusCode] != http.StatusBadRequest {
+		t.Errorf("expected a failed send span, got %+v", send)
+	}
+
+	if connect := spans[2]; !connect.ended || connect.err == nil {
+		t.Errorf("expected a failed connect span, got %+v", connect)
+	}
+
+	if client := New(server.URL, WithTracing(nil)); client.options.tracer != nil || len(client.ConfigWarnings()) != 1 {
+		t.Errorf("expected a nil tracer to be ignored with a warning, got %v", client.ConfigWarnings())
+	}
+}
+
+func TestWithTracing_Skipped(t *testing.T) {
+	t.Parallel()
+
+	server := newAlertRecorder(t)
+	tracer := &recordingTracer{}
+
+	client := New(server.URL, WithTracing(tracer), WithSilences(Silence{ID: "quiet", SlackChannelID: "C-quiet", EndsAt: time.Now().Add(time.Hour)}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	if err := client.Send(context.Background(), &types.Alert{Header: "disk full", SlackChannelID: "C-quiet"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if spans := tracer.started(); len(spans) != 2 || spans[1].attrs[attrSkipped] != true || len(spans[1].events) != 0 {
+		t.Errorf("expected a skipped send span without attempts, got %+v", spans[len(spans)-1])
+	}
+}
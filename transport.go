@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/slackmgr/types"
+)
+
+// Transport delivers alert batches to the manager. The default
+// implementation posts to the alerts endpoint over HTTP; supply another
+// with [WithTransport] to use a different backend, such as gRPC, a
+// WebSocket or an in-memory fake in tests.
+//
+// A Transport carries alert sends and pings only. The client's other API
+// methods, such as [Client.ListAlerts] or [Client.CreateRoute], always use
+// HTTP. Implementations must be safe for concurrent use.
+type Transport interface {
+	// SendBatch delivers one batch of alerts. It returns nil metadata
+	// with a non-nil error when the manager could not be reached at all,
+	// which triggers [WithFallbackWebhook] and [WithEmailFallback].
+	SendBatch(ctx context.Context, batch *Batch) (*ResponseMetadata, error)
+
+	// Ping checks that the manager is reachable. It is called by
+	// [Client.Connect] and [Client.Ping].
+	Ping(ctx context.Context) error
+
+	// Close releases the transport's resources. It is called by
+	// [Client.Close] on the client that was given the transport.
+	Close() error
+}
+
+// Batch is a batch of alerts passed to [Transport.SendBatch].
+type Batch struct {
+	// Alerts are the alerts to deliver, after all client-side processing.
+	// They must not be modified.
+	Alerts []*types.Alert
+
+	// Body is the alerts list encoded in the schema selected with
+	// [WithAlertSchema] and rewritten by any [WithBodyTransformer]
+	// functions, ready to be sent as a JSON request body.
+	Body []byte
+
+	// Params are the per-request parameters set by [SendOption]s, such as
+	// [WithPreservedTimestamps]. The HTTP transport sends them as query
+	// parameters.
+	Params url.Values
+
+	// Header holds headers that describe Body, such as the
+	// [EncryptionKeyIDHeader] added by [WithFieldEncryption] and the
+	// [IdempotencyKeyHeader]. The HTTP transport sends them as request
+	// headers.
+	Header http.Header
+}
+
+// httpTransport is the default [Transport], which uses the client's resty
+// client.
+type httpTransport struct {
+	c *Client
+}
+
+func (t *httpTransport) SendBatch(ctx context.Context, batch *Batch) (*ResponseMetadata, error) {
+	var so *sendOptions
+	if len(batch.Params) > 0 || len(batch.Header) > 0 {
+		so = &sendOptions{query: batch.Params, header: batch.Header}
+	}
+
+	return t.c.postWithResponse(withEndpoint(ctx, EndpointSend), t.c.options.alertsEndpoint, batch.Body, so)
+}
+
+func (t *httpTransport) Ping(ctx context.Context) error {
+	return t.c.get(withEndpoint(ctx, EndpointPing), t.c.options.pingEndpoint)
+}
+
+func (t *httpTransport) Close() error {
+	if t.c.transport != nil {
+		t.c.transport.CloseIdleConnections()
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// fakeTransport is an in-memory [Transport] recording every batch.
+type fakeTransport struct {
+	mu      sync.Mutex
+	batches []*Batch
+	pings   int
+	closed  int
+	sendErr error
+}
+
+func (f *fakeTransport) SendBatch(_ context.Context, batch *Batch) (*ResponseMetadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.batches = append(f.batches, batch)
+
+	if f.sendErr != nil {
+		return nil, f.sendErr
+	}
+
+	return &ResponseMetadata{StatusCode: 202}, nil
+}
+
+func (f *fakeTransport) Ping(_ context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pings++
+
+	return nil
+}
+
+func (f *fakeTransport) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed++
+
+	return nil
+}
+
+func TestWithTransport(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeTransport{}
+
+	client := New("http://manager.invalid", WithTransport(fake))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	alert := types.NewAlert(types.AlertError)
+	alert.Header = "Disk full"
+
+	meta, err := client.SendWithOptions(context.Background(), []*types.Alert{alert}, WithPreservedTimestamps())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusAccepted {
+		t.Errorf("expected the transport's metadata, got %+v", meta)
+	}
+
+	if fake.pings != 1 || len(fake.batches) != 1 {
+		t.Fatalf("expected one ping and one batch, got %d and %d", fake.pings, len(fake.batches))
+	}
+
+	batch := fake.batches[0]
+	if len(batch.Alerts) != 1 || batch.Alerts[0] != alert || batch.Params.Get("preserveTimestamps") != "true" {
+		t.Errorf("unexpected batch: %+v", batch)
+	}
+
+	var list alertsList
+	if err := json.Unmarshal(batch.Body, &list); err != nil || len(list.Alerts) != 1 || list.Alerts[0].Header != "Disk full" {
+		t.Errorf("expected the encoded alerts list in the body, got %s (%v)", batch.Body, err)
+	}
+
+	derived, err := client.With(context.Background(), WithRequestHeader("X-Team", "payments"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := derived.Send(context.Background(), types.NewAlert(types.AlertInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	derived.Close()
+
+	if len(fake.batches) != 2 || fake.closed != 0 {
+		t.Errorf("expected the derived client to share the transport without closing it, got %d batches, %d closes", len(fake.batches), fake.closed)
+	}
+
+	client.Close()
+
+	if fake.closed != 1 {
+		t.Errorf("expected the transport to be closed once, got %d", fake.closed)
+	}
+
+	if got := client.EffectiveConfig().Transport; got != "*client.fakeTransport" {
+		t.Errorf("expected the transport type in the config snapshot, got %q", got)
+	}
+}
+
+func TestWithTransport_CloseReleasesHTTPPool(t *testing.T) {
+	t.Parallel()
+
+	closed := make(chan struct{}, 1)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	client := New(server.URL, WithTransport(&fakeTransport{}))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.Capabilities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.Close()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Close to close the idle connection of the API methods")
+	}
+}
+
+func TestWithTransport_FallbackOnUnreachable(t *testing.T) {
+	t.Parallel()
+
+	webhook, messages := newFallbackWebhook(t, 200)
+	fake := &fakeTransport{sendErr: errors.New("connection refused")}
+
+	client := New("http://manager.invalid", WithTransport(fake), WithFallbackWebhook(webhook.URL+"/hook"))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	client.webhookClient = webhook.Client()
+
+	err := client.Send(context.Background(), types.NewAlert(types.AlertPanic))
+
+	var fallbackErr *FallbackError
+	if !errors.As(err, &fallbackErr) || len(messages()) != 1 {
+		t.Errorf("expected the webhook fallback to deliver the alert, got %v", err)
+	}
+}
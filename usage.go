@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const usageEndpoint = "usage"
+
+// UsagePeriod is the half-open time range [Start, End) covered by a usage
+// report or by [Client.AlertStats].
+type UsagePeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// validate returns an error if the period does not have a start before
+// its end. what names the report the period is for.
+func (p UsagePeriod) validate(what string) error {
+	if p.Start.IsZero() || p.End.IsZero() || !p.End.After(p.Start) {
+		return fmt.Errorf("%s period must have a start before its end", what)
+	}
+
+	return nil
+}
+
+// query returns the period as the start and end query parameters.
+func (p UsagePeriod) query() url.Values {
+	query := url.Values{}
+	query.Set("start", p.Start.UTC().Format(time.RFC3339))
+	query.Set("end", p.End.UTC().Format(time.RFC3339))
+
+	return query
+}
+
+// MonthPeriod returns the [UsagePeriod] covering the given calendar month
+// in UTC.
+func MonthPeriod(year int, month time.Month) UsagePeriod {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return UsagePeriod{Start: start, End: start.AddDate(0, 1, 0)}
+}
+
+// UsageReport is the usage and quota consumption for a period, as returned
+// by [Client.UsageReport].
+type UsageReport struct {
+	Period UsagePeriod `json:"period"`
+	Teams  []TeamUsage `json:"teams"`
+	Total  UsageCounts `json:"total"`
+}
+
+// TeamUsage is the usage of a single team.
+type TeamUsage struct {
+	UsageCounts
+
+	Team string `json:"team"`
+
+	// Quota is the team's alert quota for the period, or 0 if unlimited.
+	Quota int64 `json:"quota"`
+}
+
+// QuotaUsed returns the fraction of the team's quota consumed, or 0 if the
+// team has no quota.
+func (u TeamUsage) QuotaUsed() float64 {
+	if u.Quota <= 0 {
+		return 0
+	}
+
+	return float64(u.Alerts) / float64(u.Quota)
+}
+
+// UsageCounts are the counters reported per team and in total.
+type UsageCounts struct {
+	// Alerts is the number of alerts received.
+	Alerts int64 `json:"alerts"`
+
+	// Messages is the number of Slack messages posted or updated.
+	Messages int64 `json:"messages"`
+
+	// Issues is the number of issues opened.
+	Issues int64 `json:"issues"`
+
+	// RateLimited is the number of alerts rejected by rate limits or
+	// quotas.
+	RateLimited int64 `json:"rateLimited"`
+}
+
+// UsageReport returns per-team message counts and quota consumption for
+// period from GET usage. [Client.Connect] must be called first.
+func (c *Client) UsageReport(ctx context.Context, period UsagePeriod) (*UsageReport, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if err := period.validate("usage"); err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{}
+	if err := c.doJSON(ctx, http.MethodGet, usageEndpoint, period.query(), nil, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsageReport(t *testing.T) {
+	t.Parallel()
+
+	queries := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/usage" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		queries <- r.URL.RawQuery
+		_, _ = w.Write([]byte(`{
+			"period": {"start": "2024-02-01T00:00:00Z", "end": "2024-03-01T00:00:00Z"},
+			"teams": [{"team": "payments", "alerts": 250, "messages": 400, "issues": 30, "quota": 1000}],
+			"total": {"alerts": 250, "messages": 400, "issues": 30, "rateLimited": 2}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := client.UsageReport(context.Background(), MonthPeriod(2024, time.February))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q := <-queries; q != "end=2024-03-01T00%3A00%3A00Z&start=2024-02-01T00%3A00%3A00Z" {
+		t.Errorf("unexpected query %q", q)
+	}
+
+	if len(report.Teams) != 1 || report.Teams[0].Messages != 400 || report.Total.RateLimited != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	if used := report.Teams[0].QuotaUsed(); used != 0.25 {
+		t.Errorf("expected QuotaUsed=0.25, got %v", used)
+	}
+
+	if used := (TeamUsage{UsageCounts: UsageCounts{Alerts: 5}}).QuotaUsed(); used != 0 {
+		t.Errorf("expected QuotaUsed=0 without quota, got %v", used)
+	}
+}
+
+func TestUsageReport_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+
+	if _, err := client.UsageReport(context.Background(), UsagePeriod{Start: now, End: now}); err == nil || !strings.Contains(err.Error(), "start before its end") {
+		t.Errorf("expected invalid period error, got %v", err)
+	}
+}
+
+func TestMonthPeriod(t *testing.T) {
+	t.Parallel()
+
+	period := MonthPeriod(2024, time.December)
+
+	if !period.Start.Equal(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)) || !period.End.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected period %+v", period)
+	}
+}
@@ -0,0 +1,26 @@
+// Package webhook decodes the callbacks that the Slack Manager sends to
+// URLs registered with [client.Client.RegisterCallback].
+//
+// Every callback is a JSON envelope with the event's ID, type and creation
+// time, and a type-specific data object:
+//
+//	{"id": "evt_1", "type": "alert.acked", "createdAt": "...", "data": {...}}
+//
+// [Decode] parses the envelope and decodes the data into the payload struct
+// registered for the type, such as [AlertAcked]:
+//
+//	event, err := webhook.Decode(body)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	switch payload := event.Payload.(type) {
+//	case *webhook.AlertAcked:
+//	    log.Printf("%s acknowledged issue %s", payload.AckedBy, payload.IssueID)
+//	case *webhook.Unknown:
+//	    // An event type added to the manager after this package was built.
+//	}
+//
+// Verify the signature of each callback with [client.VerifyCallbacks]
+// before decoding it.
+package webhook
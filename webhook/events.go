@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	client "github.com/slackmgr/go-client"
+	"github.com/slackmgr/types"
+)
+
+// Event is a decoded callback.
+type Event struct {
+	// ID identifies the event. Redeliveries of an event keep its ID.
+	ID string `json:"id"`
+
+	Type      client.CallbackEvent `json:"type"`
+	CreatedAt time.Time            `json:"createdAt"`
+
+	// Data is the undecoded payload.
+	Data json.RawMessage `json:"data"`
+
+	// Payload is Data decoded into a pointer to the struct registered for
+	// Type, or an [*Unknown] if no struct is registered.
+	Payload any `json:"-"`
+}
+
+// AlertCreated is the payload of [client.EventAlertCreated] events.
+type AlertCreated struct {
+	IssueID string       `json:"issueId"`
+	Alert   *types.Alert `json:"alert"`
+}
+
+// AlertAcked is the payload of [client.EventAlertAcked] events.
+type AlertAcked struct {
+	IssueID string    `json:"issueId"`
+	AckedBy string    `json:"ackedBy"`
+	AckedAt time.Time `json:"ackedAt"`
+}
+
+// AlertResolved is the payload of [client.EventAlertResolved] events.
+type AlertResolved struct {
+	IssueID    string    `json:"issueId"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// SilenceExpired is the payload of [client.EventSilenceExpired] events.
+type SilenceExpired struct {
+	Silence client.Silence `json:"silence"`
+}
+
+// Unknown is the payload of events whose type has no registered struct,
+// such as types added to the manager after this package was built. Handlers
+// should ignore them rather than fail, so that the manager does not
+// redeliver them.
+type Unknown struct {
+	Type client.CallbackEvent
+	Data json.RawMessage
+}
+
+// Registry maps event types to payload structs. It is safe for concurrent
+// use.
+type Registry struct {
+	mu    sync.RWMutex
+	kinds map[client.CallbackEvent]func() any
+}
+
+// NewRegistry returns a registry with the payload structs of all event
+// types in [client.ValidCallbackEvents].
+func NewRegistry() *Registry {
+	r := &Registry{kinds: make(map[client.CallbackEvent]func() any)}
+
+	Register[AlertCreated](r, client.EventAlertCreated)
+	Register[AlertAcked](r, client.EventAlertAcked)
+	Register[AlertResolved](r, client.EventAlertResolved)
+	Register[SilenceExpired](r, client.EventSilenceExpired)
+
+	return r
+}
+
+// Register makes r decode the payload of events of eventType into a *T,
+// replacing any struct registered before. Use it for event types that are
+// newer than this package, or to decode a built-in type into a struct of
+// your own.
+func Register[T any](r *Registry, eventType client.CallbackEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.kinds[eventType] = func() any { return new(T) }
+}
+
+// Types returns the registered event types, sorted.
+func (r *Registry) Types() []client.CallbackEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.kinds))
+}
+
+// Decode parses a callback body. Fields that r's structs do not know are
+// ignored, and events of unregistered types get an [*Unknown] payload, so
+// additions to the manager's events do not break receivers. It returns an
+// error if the envelope is invalid or lacks an ID or type, or if the data
+// does not match the registered struct.
+func (r *Registry) Decode(body []byte) (*Event, error) {
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	if event.ID == "" {
+		return nil, errors.New("webhook event has no ID")
+	}
+
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook event %q has no type", event.ID)
+	}
+
+	r.mu.RLock()
+	newPayload, ok := r.kinds[event.Type]
+	r.mu.RUnlock()
+
+	if !ok {
+		event.Payload = &Unknown{Type: event.Type, Data: event.Data}
+		return event, nil
+	}
+
+	payload := newPayload()
+
+	if len(event.Data) > 0 {
+		if err := json.Unmarshal(event.Data, payload); err != nil {
+			return nil, fmt.Errorf("failed to decode %s payload of webhook event %q: %w", event.Type, event.ID, err)
+		}
+	}
+
+	event.Payload = payload
+
+	return event, nil
+}
+
+// Decode parses a callback body with the built-in payload structs; see
+// [Registry.Decode].
+func Decode(body []byte) (*Event, error) {
+	return NewRegistry().Decode(body)
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/slackmgr/go-client"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want any
+	}{
+		{
+			name: "alert created",
+			body: `{"id":"e1","type":"alert.created","data":{"issueId":"i1","alert":{"header":"Disk full"}}}`,
+			want: "i1 Disk full",
+		},
+		{
+			name: "alert acked",
+			body: `{"id":"e2","type":"alert.acked","data":{"issueId":"i1","ackedBy":"U123","ackedAt":"2024-01-01T00:00:00Z","newField":true}}`,
+			want: &AlertAcked{IssueID: "i1", AckedBy: "U123", AckedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "alert resolved",
+			body: `{"id":"e3","type":"alert.resolved","data":{"issueId":"i1"}}`,
+			want: &AlertResolved{IssueID: "i1"},
+		},
+		{
+			name: "silence expired",
+			body: `{"id":"e4","type":"silence.expired","data":{"silence":{"id":"s1","routeKey":"db"}}}`,
+			want: &SilenceExpired{Silence: client.Silence{ID: "s1", RouteKey: "db"}},
+		},
+		{
+			name: "unknown type",
+			body: `{"id":"e5","type":"route.changed","data":{"name":"db"}}`,
+			want: &Unknown{Type: "route.changed", Data: []byte(`{"name":"db"}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := Decode([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := event.Payload
+			if created, ok := got.(*AlertCreated); ok {
+				got = created.IssueID + " " + created.Alert.Header
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected payload %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"invalid JSON", `{`, "failed to decode webhook event"},
+		{"no ID", `{"type":"alert.acked"}`, "has no ID"},
+		{"no type", `{"id":"e1"}`, `"e1" has no type`},
+		{"mismatched data", `{"id":"e1","type":"alert.acked","data":{"ackedAt":"yesterday"}}`, `failed to decode alert.acked payload of webhook event "e1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Decode([]byte(tt.body)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type routeChanged struct {
+		Name string `json:"name"`
+	}
+
+	registry := NewRegistry()
+	Register[routeChanged](registry, "route.changed")
+
+	event, err := registry.Decode([]byte(`{"id":"e1","type":"route.changed","data":{"name":"db"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payload, ok := event.Payload.(*routeChanged); !ok || payload.Name != "db" {
+		t.Errorf("expected the registered struct, got %#v", event.Payload)
+	}
+
+	want := []client.CallbackEvent{"alert.acked", "alert.created", "alert.resolved", "route.changed", "silence.expired"}
+	if got := registry.Types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected types %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	client "github.com/slackmgr/go-client"
+)
+
+const (
+	// DefaultDedupTTL is how long [ExactlyOnce] remembers a processed event
+	// when no TTL is given.
+	DefaultDedupTTL = 24 * time.Hour
+
+	// maxEventBody is the largest callback body [Handler] reads.
+	maxEventBody = 1 << 20
+
+	dedupKeyPrefix = "slackmgr-webhook:"
+)
+
+// HandlerFunc processes a decoded event. Returning an error makes [Handler]
+// respond with 500 Internal Server Error, so the manager redelivers the
+// event.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler returns an http.Handler that decodes callbacks with registry, or
+// the built-in payload structs if registry is nil, and passes them to fn.
+// It responds with 204 No Content when fn succeeds, 400 Bad Request when
+// the body cannot be decoded, 413 Request Entity Too Large for bodies over
+// 1 MiB, and 500 Internal Server Error when fn fails. Wrap it with
+// [client.VerifyCallbacks] to check signatures.
+func Handler(registry *Registry, fn HandlerFunc) http.Handler {
+	if registry == nil {
+		registry = NewRegistry()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventBody))
+		if err != nil {
+			status := http.StatusBadRequest
+
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+
+			http.Error(w, "failed to read webhook body", status)
+
+			return
+		}
+
+		event, err := registry.Decode(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := fn(r.Context(), event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// ExactlyOnce wraps fn so that each event ID is processed once, even when
+// the manager redelivers it. Before calling fn, it claims the event ID in
+// store for ttl, or [DefaultDedupTTL] if ttl is not positive; events whose
+// ID is already claimed are skipped without error, so they are acknowledged.
+// Use a store shared by all replicas, such as one backed by Redis, to dedup
+// across them.
+//
+// If fn fails, the claim is released, so the redelivery that the failure
+// triggers is processed. A redelivery that arrives while the first delivery
+// is still being processed is skipped; if the first then fails, the next
+// redelivery is processed.
+func ExactlyOnce(store client.LockStore, ttl time.Duration, fn HandlerFunc) HandlerFunc {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+
+	return func(ctx context.Context, event *Event) error {
+		key := dedupKeyPrefix + event.ID
+		owner := rand.Text()
+
+		claimed, err := store.TryLock(ctx, key, owner, ttl)
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook event %q: %w", event.ID, err)
+		}
+
+		if !claimed {
+			return nil
+		}
+
+		if err := fn(ctx, event); err != nil {
+			if unlockErr := store.Unlock(context.WithoutCancel(ctx), key, owner); unlockErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to release webhook event %q: %w", event.ID, unlockErr))
+			}
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// TrackAcks wraps fn so that the alert created, acked and resolved events
+// it receives are passed to c before fn is called, to track the alerts c
+// sent with [client.RequireAck] or repeats with [client.WithRepeat]. fn
+// may be nil.
+func TrackAcks(c *client.Client, fn HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, event *Event) error {
+		switch payload := event.Payload.(type) {
+		case *AlertCreated:
+			ack := client.AckEvent{Type: event.Type, IssueID: payload.IssueID, At: event.CreatedAt}
+			if payload.Alert != nil {
+				ack.CorrelationID = payload.Alert.CorrelationID
+			}
+
+			c.ObserveAckEvent(ack)
+		case *AlertAcked:
+			c.ObserveAckEvent(client.AckEvent{Type: event.Type, IssueID: payload.IssueID, By: payload.AckedBy, At: payload.AckedAt})
+		case *AlertResolved:
+			c.ObserveAckEvent(client.AckEvent{Type: event.Type, IssueID: payload.IssueID, At: payload.ResolvedAt})
+		}
+
+		if fn == nil {
+			return nil
+		}
+
+		return fn(ctx, event)
+	}
+}
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client "github.com/slackmgr/go-client"
+	"github.com/slackmgr/types"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	fail := errors.New("automation failed")
+
+	handler := Handler(nil, func(_ context.Context, event *Event) error {
+		if event.ID == "bad" {
+			return fail
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"handled", `{"id":"e1","type":"alert.acked","data":{}}`, http.StatusNoContent},
+		{"handler error", `{"id":"bad","type":"alert.acked","data":{}}`, http.StatusInternalServerError},
+		{"invalid event", `{"type":"alert.acked"}`, http.StatusBadRequest},
+		{"too large", `{"id":"` + strings.Repeat("x", maxEventBody) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestExactlyOnce(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	failNext := true
+
+	fn := ExactlyOnce(client.NewMemoryLockStore(), time.Minute, func(_ context.Context, _ *Event) error {
+		calls.Add(1)
+
+		if failNext {
+			failNext = false
+			return errors.New("try again")
+		}
+
+		return nil
+	})
+
+	event := &Event{ID: "e1", Type: client.EventAlertCreated}
+
+	if err := fn(context.Background(), event); err == nil {
+		t.Fatal("expected the first delivery to fail")
+	}
+
+	for range 3 {
+		if err := fn(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected a failed event to be retried once and then deduplicated, got %d calls", got)
+	}
+
+	if err := fn(context.Background(), &Event{ID: "e2"}); err != nil || calls.Load() != 3 {
+		t.Errorf("expected another event to be processed, got %v after %d calls", err, calls.Load())
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) TryLock(context.Context, string, string, time.Duration) (bool, error) {
+	return false, errors.New("store down")
+}
+
+func (failingStore) Unlock(context.Context, string, string) error {
+	return nil
+}
+
+func TestExactlyOnce_StoreError(t *testing.T) {
+	t.Parallel()
+
+	fn := ExactlyOnce(failingStore{}, 0, func(context.Context, *Event) error {
+		t.Error("expected the handler not to run")
+		return nil
+	})
+
+	if err := fn(context.Background(), &Event{ID: "e1"}); err == nil || !strings.Contains(err.Error(), `failed to claim webhook event "e1": store down`) {
+		t.Errorf("expected a claim error, got %v", err)
+	}
+}
+
+func TestTrackAcks(t *testing.T) {
+	t.Parallel()
+
+	escalations := make(chan client.AckReceipt, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	c := client.New(server.URL, client.WithAckEscalation(func(_ context.Context, receipt client.AckReceipt) {
+		escalations <- receipt
+	}))
+	if err := c.Connect(t.Context()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(c.Close)
+
+	var handled atomic.Int32
+
+	handler := Handler(nil, TrackAcks(c, func(context.Context, *Event) error {
+		handled.Add(1)
+		return nil
+	}))
+
+	alert := types.NewAlert(types.AlertPanic)
+	alert.Header = "Checkout down"
+	alert.CorrelationID = "checkout"
+
+	if _, err := c.SendWithOptions(t.Context(), []*types.Alert{alert}, client.RequireAck(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, body := range []string{
+		`{"id":"e1","type":"alert.created","data":{"issueId":"I1","alert":{"correlationId":"checkout"}}}`,
+		`{"id":"e2","type":"alert.acked","data":{"issueId":"I1","ackedBy":"alice","ackedAt":"2026-10-14T12:00:00Z"}}`,
+		`{"id":"e3","type":"silence.expired","data":{}}`,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+		}
+	}
+
+	if handled.Load() != 3 {
+		t.Errorf("expected every event to be passed on, got %d", handled.Load())
+	}
+
+	receipt, ok := c.AckReceipt("checkout")
+	if !ok || receipt.Status != client.AckAcked || receipt.IssueID != "I1" || receipt.AckedBy != "alice" {
+		t.Errorf("expected the alert to be acknowledged, got %+v", receipt)
+	}
+
+	if err := TrackAcks(c, nil)(t.Context(), &Event{ID: "e4", Payload: &AlertResolved{IssueID: "I1"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
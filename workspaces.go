@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const workspacesEndpoint = "admin/workspaces"
+
+// Workspace is a tenant of the manager, bound to one Slack workspace.
+type Workspace struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	SlackTeamID string `json:"slackTeamId"`
+
+	// DefaultChannel receives alerts whose route key has no mapping.
+	DefaultChannel string `json:"defaultChannel,omitempty"`
+
+	// ChannelMappings maps route keys to Slack channel IDs or names.
+	ChannelMappings map[string]string `json:"channelMappings,omitempty"`
+
+	Quota     WorkspaceQuota `json:"quota"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// WorkspaceQuota limits a workspace's usage. Zero fields mean unlimited.
+type WorkspaceQuota struct {
+	AlertsPerMinute int `json:"alertsPerMinute"`
+	AlertsPerDay    int `json:"alertsPerDay"`
+	MaxOpenIssues   int `json:"maxOpenIssues"`
+}
+
+// validate checks that no limit is negative.
+func (q WorkspaceQuota) validate() error {
+	if q.AlertsPerMinute < 0 || q.AlertsPerDay < 0 || q.MaxOpenIssues < 0 {
+		return errors.New("workspace quota limits must not be negative")
+	}
+
+	return nil
+}
+
+// CreateWorkspaceRequest holds the parameters of a new workspace.
+type CreateWorkspaceRequest struct {
+	// Name is a human-readable label for the workspace. Required.
+	Name string `json:"name"`
+
+	// SlackTeamID is the ID of the Slack workspace. Required.
+	SlackTeamID string `json:"slackTeamId"`
+
+	// DefaultChannel optionally sets [Workspace.DefaultChannel].
+	DefaultChannel string `json:"defaultChannel,omitempty"`
+
+	// Quota optionally sets the initial quota.
+	Quota *WorkspaceQuota `json:"quota,omitempty"`
+}
+
+// CreateWorkspace provisions a workspace. [Client.Connect] must be called
+// first, with credentials that have the [ScopeAdmin] scope.
+func (c *Client) CreateWorkspace(ctx context.Context, req CreateWorkspaceRequest) (*Workspace, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("workspace name must not be empty")
+	}
+
+	if strings.TrimSpace(req.SlackTeamID) == "" {
+		return nil, errors.New("workspace Slack team ID must not be empty")
+	}
+
+	if req.DefaultChannel != "" && !types.SlackChannelIDOrNameRegex.MatchString(req.DefaultChannel) {
+		return nil, fmt.Errorf("invalid default channel %q", req.DefaultChannel)
+	}
+
+	if req.Quota != nil {
+		if err := req.Quota.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	workspace := &Workspace{}
+	if err := c.doJSON(ctx, http.MethodPost, workspacesEndpoint, nil, &req, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// GetWorkspace returns the workspace with the given ID.
+func (c *Client) GetWorkspace(ctx context.Context, id string) (*Workspace, error) {
+	return c.workspaceRequest(ctx, http.MethodGet, id, "", nil)
+}
+
+// SetWorkspaceChannelMappings replaces the workspace's route key to channel
+// mappings and, if defaultChannel is not empty, its default channel.
+// Channels may be Slack channel IDs or names.
+func (c *Client) SetWorkspaceChannelMappings(ctx context.Context, id, defaultChannel string, mappings map[string]string) (*Workspace, error) {
+	if defaultChannel != "" && !types.SlackChannelIDOrNameRegex.MatchString(defaultChannel) {
+		return nil, fmt.Errorf("invalid default channel %q", defaultChannel)
+	}
+
+	for routeKey, channel := range mappings {
+		if strings.TrimSpace(routeKey) == "" {
+			return nil, errors.New("channel mapping route key must not be empty")
+		}
+
+		if !types.SlackChannelIDOrNameRegex.MatchString(channel) {
+			return nil, fmt.Errorf("invalid channel %q for route key %q", channel, routeKey)
+		}
+	}
+
+	body := struct {
+		DefaultChannel  string            `json:"defaultChannel,omitempty"`
+		ChannelMappings map[string]string `json:"channelMappings"`
+	}{defaultChannel, mappings}
+
+	if body.ChannelMappings == nil {
+		body.ChannelMappings = map[string]string{}
+	}
+
+	return c.workspaceRequest(ctx, http.MethodPut, id, "/channel-mappings", &body)
+}
+
+// SetWorkspaceQuota replaces the workspace's quota.
+func (c *Client) SetWorkspaceQuota(ctx context.Context, id string, quota WorkspaceQuota) (*Workspace, error) {
+	if err := quota.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.workspaceRequest(ctx, http.MethodPut, id, "/quota", &quota)
+}
+
+// workspaceRequest sends a request to a single workspace's endpoint and
+// decodes the returned workspace.
+func (c *Client) workspaceRequest(ctx context.Context, method, id, suffix string, body any) (*Workspace, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errors.New("workspace ID must not be empty")
+	}
+
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	workspace := &Workspace{}
+	if err := c.doJSON(ctx, method, workspacesEndpoint+"/"+url.PathEscape(id)+suffix, nil, body, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
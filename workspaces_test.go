@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method, path string
+		body         map[string]any
+	}
+
+	calls := make(chan call, 4)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls <- call{r.Method, r.URL.Path, body}
+
+		_, _ = w.Write([]byte(`{"id":"w1","name":"payments","slackTeamId":"T1","defaultChannel":"C1","channelMappings":{"db":"C2"},"quota":{"alertsPerMinute":60}}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	workspace, err := client.CreateWorkspace(ctx, CreateWorkspaceRequest{Name: "payments", SlackTeamID: "T1", DefaultChannel: "C1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if workspace.ID != "w1" || workspace.ChannelMappings["db"] != "C2" || workspace.Quota.AlertsPerMinute != 60 {
+		t.Errorf("unexpected workspace %+v", workspace)
+	}
+
+	if got := <-calls; got.method != http.MethodPost || got.path != "/admin/workspaces" || got.body["slackTeamId"] != "T1" {
+		t.Errorf("unexpected create request %+v", got)
+	}
+
+	if _, err := client.GetWorkspace(ctx, "w1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodGet || got.path != "/admin/workspaces/w1" {
+		t.Errorf("unexpected get request %+v", got)
+	}
+
+	if _, err := client.SetWorkspaceChannelMappings(ctx, "w1", "", map[string]string{"db": "C2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := <-calls
+	if got.method != http.MethodPut || got.path != "/admin/workspaces/w1/channel-mappings" {
+		t.Errorf("unexpected mappings request %+v", got)
+	}
+
+	if _, ok := got.body["defaultChannel"]; ok {
+		t.Error("expected empty default channel to be omitted")
+	}
+
+	if _, err := client.SetWorkspaceQuota(ctx, "w1", WorkspaceQuota{AlertsPerDay: 1000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-calls; got.method != http.MethodPut || got.path != "/admin/workspaces/w1/quota" || got.body["alertsPerDay"] != float64(1000) {
+		t.Errorf("unexpected quota request %+v", got)
+	}
+}
+
+func TestWorkspaces_Validation(t *testing.T) {
+	t.Parallel()
+
+	rec := newAlertRecorder(t)
+
+	client := New(rec.URL)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr string
+	}{
+		{"empty name", func() error {
+			_, err := client.CreateWorkspace(ctx, CreateWorkspaceRequest{SlackTeamID: "T1"})
+			return err
+		}, "name must not be empty"},
+		{"empty team", func() error {
+			_, err := client.CreateWorkspace(ctx, CreateWorkspaceRequest{Name: "a"})
+			return err
+		}, "team ID must not be empty"},
+		{"invalid default channel", func() error {
+			_, err := client.CreateWorkspace(ctx, CreateWorkspaceRequest{Name: "a", SlackTeamID: "T1", DefaultChannel: "#bad channel"})
+			return err
+		}, "invalid default channel"},
+		{"invalid mapping", func() error {
+			_, err := client.SetWorkspaceChannelMappings(ctx, "w1", "", map[string]string{"db": "bad channel"})
+			return err
+		}, `invalid channel "bad channel"`},
+		{"negative quota", func() error {
+			_, err := client.SetWorkspaceQuota(ctx, "w1", WorkspaceQuota{AlertsPerDay: -1})
+			return err
+		}, "must not be negative"},
+		{"empty ID", func() error {
+			_, err := client.GetWorkspace(ctx, "")
+			return err
+		}, "workspace ID must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.call(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
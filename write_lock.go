@@ -0,0 +1,240 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWriteLockTTL   = time.Minute
+	defaultWriteLockRetry = time.Second
+	minWriteLockTTL       = time.Second
+
+	memoryLockSweepInterval = time.Minute
+)
+
+// ErrWriteLockLost is the cause of the cancellation of the context passed
+// to the function run by [Client.RunExclusive] when the lock could not be
+// renewed, because its store failed or another owner took it over.
+var ErrWriteLockLost = errors.New("write lock lost")
+
+// LockStore is a shared store of leased locks, backed for example by Redis,
+// Consul sessions or a database row, that [Client.RunExclusive] uses to
+// keep writes made from different processes from interleaving.
+// Implementations must be safe for concurrent use.
+type LockStore interface {
+	// TryLock acquires the lock named key for owner until ttl has elapsed,
+	// or extends it if owner already holds it. It reports false, without
+	// an error, if another owner holds the lock.
+	TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+
+	// Unlock releases the lock named key if owner holds it.
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+// WriteLock configures [Client.RunExclusive].
+type WriteLock struct {
+	// Store holds the lock. Required.
+	Store LockStore
+
+	// Key names the lock. Defaults to "slackmgr-writes:" followed by the
+	// host of the base URL, so that runs against the same manager exclude
+	// each other.
+	Key string
+
+	// Owner identifies this holder in the store. Defaults to the host
+	// name, the process ID and a random suffix.
+	Owner string
+
+	// TTL is the lease duration, at least one second. The lease is renewed
+	// every third of it while the function runs, so a crashed holder only
+	// blocks others until its lease expires. Defaults to one minute.
+	TTL time.Duration
+
+	// RetryInterval is the wait between attempts to acquire a lock held by
+	// another owner. Defaults to one second.
+	RetryInterval time.Duration
+}
+
+// RunExclusive runs fn while holding lock, so that concurrent runs, such
+// as infrastructure-as-code pipelines applying routing rules or admin
+// changes, are serialized instead of interleaving their writes. It waits
+// for the lock until ctx is done.
+//
+// If the lease cannot be renewed, the context passed to fn is cancelled
+// with [ErrWriteLockLost] as its cause, and RunExclusive returns an error
+// wrapping it. The lock is released when fn returns, even if ctx is done.
+// The lock only excludes other callers of RunExclusive; it does not stop
+// writes made without it.
+func (c *Client) RunExclusive(ctx context.Context, lock WriteLock, fn func(ctx context.Context) error) error {
+	if lock.Store == nil {
+		return errors.New("write lock store must not be nil")
+	}
+
+	if lock.TTL != 0 && lock.TTL < minWriteLockTTL {
+		return fmt.Errorf("write lock TTL must be at least %v", minWriteLockTTL)
+	}
+
+	if lock.RetryInterval < 0 {
+		return errors.New("write lock retry interval must not be negative")
+	}
+
+	key := cmp.Or(lock.Key, "slackmgr-writes:"+baseURLHost(c.baseURL))
+	owner := cmp.Or(lock.Owner, defaultLockOwner())
+	ttl := cmp.Or(lock.TTL, defaultWriteLockTTL)
+	retry := cmp.Or(lock.RetryInterval, defaultWriteLockRetry)
+
+	if err := acquireLock(ctx, lock.Store, key, owner, ttl, retry); err != nil {
+		return err
+	}
+
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ttl)
+		defer cancel()
+
+		if err := lock.Store.Unlock(releaseCtx, key, owner); err != nil {
+			c.logger(ctx).Errorf("failed to release write lock %q: %v", key, err)
+		}
+	}()
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+
+	stop := make(chan struct{})
+
+	wg.Go(func() {
+		renewLock(runCtx, cancel, stop, lock.Store, key, owner, ttl)
+	})
+
+	err := fn(runCtx)
+
+	close(stop)
+	wg.Wait()
+
+	if cause := context.Cause(runCtx); errors.Is(cause, ErrWriteLockLost) {
+		return errors.Join(cause, err)
+	}
+
+	return err
+}
+
+// acquireLock tries to acquire the lock every retry until it succeeds or
+// ctx is done.
+func acquireLock(ctx context.Context, store LockStore, key, owner string, ttl, retry time.Duration) error {
+	for {
+		acquired, err := store.TryLock(ctx, key, owner, ttl)
+		if err != nil {
+			return fmt.Errorf("failed to acquire write lock %q: %w", key, err)
+		}
+
+		if acquired {
+			return nil
+		}
+
+		if err := sleepFor(ctx, retry); err != nil {
+			return fmt.Errorf("write lock %q is held by another owner: %w", key, err)
+		}
+	}
+}
+
+// renewLock extends the lease every third of ttl until stop is closed, and
+// cancels the run if it cannot.
+func renewLock(ctx context.Context, cancel context.CancelCauseFunc, stop <-chan struct{}, store LockStore, key, owner string, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := store.TryLock(ctx, key, owner, ttl)
+
+		switch {
+		case err != nil:
+			cancel(fmt.Errorf("%w: failed to renew %q: %w", ErrWriteLockLost, key, err))
+			return
+		case !renewed:
+			cancel(fmt.Errorf("%w: %q is held by another owner", ErrWriteLockLost, key))
+			return
+		}
+	}
+}
+
+// defaultLockOwner returns an owner ID unique to this call.
+func defaultLockOwner() string {
+	host, _ := os.Hostname()
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return cmp.Or(host, "unknown") + "-" + strconv.Itoa(os.Getpid()) + "-" + hex.EncodeToString(suffix)
+}
+
+// MemoryLockStore is a [LockStore] kept in memory. It only excludes runs
+// within one process, which is useful in tests and for tools that run
+// several syncs concurrently. Expired locks are dropped once a minute.
+type MemoryLockStore struct {
+	mu        sync.Mutex
+	locks     map[string]memoryLock
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+type memoryLock struct {
+	owner   string
+	expires time.Time
+}
+
+// NewMemoryLockStore returns an empty [MemoryLockStore].
+func NewMemoryLockStore() *MemoryLockStore {
+	return &MemoryLockStore{locks: make(map[string]memoryLock), now: time.Now}
+}
+
+// TryLock implements [LockStore].
+func (s *MemoryLockStore) TryLock(_ context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if now.After(s.nextSweep) {
+		maps.DeleteFunc(s.locks, func(_ string, held memoryLock) bool { return !now.Before(held.expires) })
+		s.nextSweep = now.Add(memoryLockSweepInterval)
+	}
+
+	if held, ok := s.locks[key]; ok && held.owner != owner && now.Before(held.expires) {
+		return false, nil
+	}
+
+	s.locks[key] = memoryLock{owner: owner, expires: now.Add(ttl)}
+
+	return true, nil
+}
+
+// Unlock implements [LockStore].
+func (s *MemoryLockStore) Unlock(_ context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if held, ok := s.locks[key]; ok && held.owner == owner {
+		delete(s.locks, key)
+	}
+
+	return nil
+}
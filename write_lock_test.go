@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunExclusive_SerializesRuns(t *testing.T) {
+	t.Parallel()
+
+	client := New("https://alerts.example.com")
+	store := NewMemoryLockStore()
+
+	var (
+		running atomic.Int32
+		overlap atomic.Bool
+		wg      sync.WaitGroup
+	)
+
+	for range 4 {
+		wg.Go(func() {
+			err := client.RunExclusive(context.Background(), WriteLock{Store: store, RetryInterval: time.Millisecond}, func(context.Context) error {
+				if running.Add(1) > 1 {
+					overlap.Store(true)
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+
+				return nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if overlap.Load() {
+		t.Error("expected the runs not to overlap")
+	}
+
+	if len(store.locks) != 0 {
+		t.Errorf("expected every lock to be released, got %v", store.locks)
+	}
+}
+
+func TestRunExclusive_WaitsUntilContextDone(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryLockStore()
+	if ok, _ := store.TryLock(context.Background(), "slackmgr-writes:alerts.example.com", "ci-run-1", time.Hour); !ok {
+		t.Fatal("expected to take the lock")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	ran := false
+
+	err := New("https://alerts.example.com").RunExclusive(ctx, WriteLock{Store: store, RetryInterval: 5 * time.Millisecond}, func(context.Context) error {
+		ran = true
+		return nil
+	})
+	if ran || !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "held by another owner") {
+		t.Fatalf("expected to give up waiting for the lock, got %v (ran: %t)", err, ran)
+	}
+}
+
+// stealingLockStore grants the first lock and refuses every renewal.
+type stealingLockStore struct {
+	*MemoryLockStore
+
+	calls atomic.Int32
+}
+
+func (s *stealingLockStore) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	if s.calls.Add(1) > 1 {
+		return false, nil
+	}
+
+	return s.MemoryLockStore.TryLock(ctx, key, owner, ttl)
+}
+
+func TestRunExclusive_LostLockCancelsRun(t *testing.T) {
+	t.Parallel()
+
+	store := &stealingLockStore{MemoryLockStore: NewMemoryLockStore()}
+
+	err := New("https://alerts.example.com").RunExclusive(context.Background(), WriteLock{Store: store, TTL: time.Second}, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrWriteLockLost) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to be cancelled by the lost lock, got %v", err)
+	}
+}
+
+func TestRunExclusive_InvalidLock(t *testing.T) {
+	t.Parallel()
+
+	client := New("https://alerts.example.com")
+	noop := func(context.Context) error { return nil }
+
+	for _, lock := range []WriteLock{{}, {Store: NewMemoryLockStore(), TTL: time.Millisecond}, {Store: NewMemoryLockStore(), RetryInterval: -1}} {
+		if err := client.RunExclusive(context.Background(), lock, noop); err == nil {
+			t.Errorf("expected an error for %+v", lock)
+		}
+	}
+}
+
+func TestMemoryLockStore_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := NewMemoryLockStore()
+	store.now = func() time.Time { return now }
+
+	if ok, _ := store.TryLock(context.Background(), "k", "a", time.Minute); !ok {
+		t.Fatal("expected a to take the lock")
+	}
+
+	if ok, _ := store.TryLock(context.Background(), "k", "b", time.Minute); ok {
+		t.Fatal("expected b to be refused while a holds the lock")
+	}
+
+	now = now.Add(time.Minute)
+
+	if ok, _ := store.TryLock(context.Background(), "k", "b", time.Minute); !ok {
+		t.Fatal("expected b to take the expired lock")
+	}
+
+	_ = store.Unlock(context.Background(), "k", "a")
+
+	if ok, _ := store.TryLock(context.Background(), "k", "a", time.Minute); ok {
+		t.Error("expected a stale owner's unlock to leave b's lock in place")
+	}
+
+	_, _ = store.TryLock(context.Background(), "other", "a", time.Second)
+	now = now.Add(2 * time.Minute)
+	_, _ = store.TryLock(context.Background(), "k", "a", time.Minute)
+
+	if _, ok := store.locks["other"]; ok || len(store.locks) != 1 {
+		t.Errorf("expected expired locks to be swept, got %v", store.locks)
+	}
+}